@@ -2,8 +2,12 @@ package main
 
 import (
 	"context"
+	"crypto"
+	"crypto/ed25519"
+	"encoding/json"
 	"errors"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -20,8 +24,12 @@ import (
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/gapi"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/mail"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/oidc"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/sso"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/worker"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/denylist"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/identity"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/proto/gen"
 )
@@ -52,10 +60,20 @@ func main() {
 
 	verifyEmailRepository := infra.NewVerifyEmailRepository(connPool)
 	userRepository := infra.NewUserRepository(connPool)
+	resetPasswordRepository := infra.NewResetPasswordRepository(connPool)
+	roleRepository := infra.NewRoleRepository(connPool)
+	authEventRepository := infra.NewAuthEventRepository(connPool)
+
+	tokenMaker := newTokenMaker(&config)
+	userApplication := newUserApplication(connPool, userRepository, resetPasswordRepository, roleRepository, authEventRepository, tokenMaker, &config)
+	oidcApplication := newOidcApplication(connPool, userApplication, tokenMaker, &config)
+	serviceAccountApplication := newServiceAccountApplication(connPool)
+	roleApplication := application.NewRoleApplication(userRepository, roleRepository)
 
 	waitGroup, ctx := errgroup.WithContext(ctx)
-	runTaskProcessor(ctx, waitGroup, userRepository, verifyEmailRepository, config)
-	runGrpcServer(ctx, connPool, waitGroup, userRepository, verifyEmailRepository, config)
+	runTaskProcessor(ctx, waitGroup, userRepository, verifyEmailRepository, resetPasswordRepository, userRepository, config)
+	runGrpcServer(ctx, waitGroup, userApplication, oidcApplication, serviceAccountApplication, roleApplication, verifyEmailRepository, authEventRepository, tokenMaker, config)
+	runHttpServer(ctx, waitGroup, tokenMaker, oidcApplication, config)
 
 	err = waitGroup.Wait()
 	if err != nil {
@@ -63,13 +81,16 @@ func main() {
 	}
 }
 
-func runGrpcServer(ctx context.Context, connPool *pgxpool.Pool, waitGroup *errgroup.Group, userRepository application.UserRepository, verifyEmailRepository application.VerifyEmailRepository, config util.Config) {
-	userApplication := newUserApplication(connPool, userRepository, &config)
-	verifyEmailApplication := newVerifyEmailApplication(verifyEmailRepository)
-	server := gapi.NewAuthServer(userApplication, verifyEmailApplication)
+func runGrpcServer(ctx context.Context, waitGroup *errgroup.Group, userApplication gapi.UserApplication, oidcApplication gapi.OidcApplication, serviceAccountApplication gapi.ServiceAccountApplication, roleApplication gapi.RoleApplication, verifyEmailRepository application.VerifyEmailRepository, authEventRepository application.AuthEventRepository, tokenMaker application.JwtTokenMaker, config util.Config) {
+	tokenVerifier := newTokenVerifier(tokenMaker, &config)
+	verifyEmailApplication := newVerifyEmailApplication(verifyEmailRepository, authEventRepository, &config)
+	server := gapi.NewAuthServer(userApplication, verifyEmailApplication, oidcApplication, serviceAccountApplication, roleApplication, tokenVerifier)
 
-	grpcLogger := grpc.UnaryInterceptor(gapi.GrpcLogger)
-	grpcServer := grpc.NewServer(grpcLogger)
+	authInterceptor := gapi.NewAuthInterceptor(tokenVerifier, newIdentityVerifier(&config), gapi.MethodPolicies)
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(gapi.GrpcLogger, authInterceptor.Unary),
+		grpc.StreamInterceptor(authInterceptor.Stream),
+	)
 	gen.RegisterAuthServiceServer(grpcServer, server)
 	reflection.Register(grpcServer)
 
@@ -104,13 +125,87 @@ func runGrpcServer(ctx context.Context, connPool *pgxpool.Pool, waitGroup *errgr
 	})
 }
 
-func runTaskProcessor(ctx context.Context, waitGroup *errgroup.Group, userRepository application.UserRepository, verifyEmailRepository application.VerifyEmailRepository, config util.Config) {
+// jwksProvider is implemented by token makers that sign with asymmetric keys
+// and can publish their public keys.
+type jwksProvider interface {
+	KeySet() (token.JSONWebKeySet, error)
+}
+
+// runHttpServer serves the JWKS when tokens are signed with asymmetric keys,
+// and the OpenID Connect provider endpoints when OIDC_ISSUER_URL is set.
+func runHttpServer(ctx context.Context, waitGroup *errgroup.Group, tokenMaker application.JwtTokenMaker, oidcApplication *application.OidcApplication, config util.Config) {
+	keySetProvider, hasKeySet := tokenMaker.(jwksProvider)
+	if !hasKeySet && config.OidcIssuerURL == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+
+	if hasKeySet {
+		mux.HandleFunc("GET "+oidc.JwksPath, func(w http.ResponseWriter, r *http.Request) {
+			keySet, err := keySetProvider.KeySet()
+			if err != nil {
+				log.Error().Err(err).Msg("failed to build JWKS")
+				http.Error(w, "failed to build JWKS", http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Cache-Control", "public, max-age=300")
+			json.NewEncoder(w).Encode(keySet)
+		})
+	}
+
+	if config.OidcIssuerURL != "" {
+		// clients verify the id tokens with the JWKS
+		if _, ok := tokenMaker.(application.ClaimsSigner); !ok || !hasKeySet {
+			log.Fatal().Msg("OIDC_ISSUER_URL needs a token keyring or private key to sign id tokens")
+		}
+
+		oidc.NewServer(oidcApplication, config.OidcIssuerURL).Register(mux)
+	}
+
+	srv := &http.Server{
+		Addr:    config.HttpServerAddress,
+		Handler: mux,
+	}
+
+	waitGroup.Go(func() error {
+		log.Info().Msgf("start HTTP server at %s", config.HttpServerAddress)
+		err := srv.ListenAndServe()
+		if err != nil {
+			if errors.Is(err, http.ErrServerClosed) {
+				return nil
+			}
+
+			log.Error().Err(err).Msg("HTTP server failed to serve")
+			return err
+		}
+
+		return nil
+	})
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		log.Info().Msg("graceful shutdown HTTP server")
+		err := srv.Shutdown(context.Background())
+		if err != nil {
+			log.Error().Err(err).Msg("failed to shutdown HTTP server")
+			return err
+		}
+
+		log.Info().Msg("HTTP server was stopped")
+		return nil
+	})
+}
+
+func runTaskProcessor(ctx context.Context, waitGroup *errgroup.Group, userRepository application.UserRepository, verifyEmailRepository application.VerifyEmailRepository, resetPasswordRepository application.ResetPasswordRepository, userPurger worker.UserPurger, config util.Config) {
 	redisOpt := asynq.RedisClientOpt{
 		Addr: config.RedisAddress,
 	}
 
 	mailer := mail.NewMailTrappSender(config.EmailSenderUsername, config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword)
-	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, userRepository, verifyEmailRepository, mailer)
+	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, userRepository, verifyEmailRepository, resetPasswordRepository, userPurger, mailer)
 	log.Info().Msg("start task processor")
 	err := taskProcessor.Start()
 	if err != nil {
@@ -126,12 +221,116 @@ func runTaskProcessor(ctx context.Context, waitGroup *errgroup.Group, userReposi
 	})
 }
 
-func newUserApplication(connPool *pgxpool.Pool, userRepository application.UserRepository, config *util.Config) gapi.UserApplication {
-	tokenMaker, err := token.NewJwtToken(config.TokenSecretKey)
+// newTokenMaker signs with the keyring at TOKEN_KEYRING_PATH or the private key
+// at TOKEN_PRIVATE_KEY_PATH when set, otherwise it falls back to HS256 with
+// TOKEN_SECRET_KEY. See docs/token_key_rotation.md for rotating keyring keys.
+func newTokenMaker(config *util.Config) application.JwtTokenMaker {
+	if config.TokenFormat == "paseto" {
+		return newPasetoMaker(config)
+	}
+
+	if config.TokenKeyringPath != "" {
+		keyring, err := token.LoadKeyring(config.TokenKeyringPath)
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot load token keyring")
+		}
+
+		return token.NewKeyringJwtToken(keyring)
+	}
+
+	if config.TokenPrivateKeyPath == "" {
+		tokenMaker, err := token.NewJwtToken(config.TokenSecretKey)
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot create token maker")
+		}
+
+		return tokenMaker
+	}
+
+	tokenMaker, err := token.NewAsymmetricJwtToken(config.TokenKeyID, readTokenPrivateKey(config))
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create token maker")
+	}
+
+	return tokenMaker
+}
+
+// newPasetoMaker creates v4.public tokens signed with the Ed25519 key at
+// TOKEN_PRIVATE_KEY_PATH when set, otherwise v4.local tokens encrypted with
+// TOKEN_SECRET_KEY.
+func newPasetoMaker(config *util.Config) application.JwtTokenMaker {
+	if config.TokenPrivateKeyPath == "" {
+		tokenMaker, err := token.NewPasetoLocalMaker(config.TokenSecretKey)
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot create token maker")
+		}
+
+		return tokenMaker
+	}
+
+	privateKey, ok := readTokenPrivateKey(config).(ed25519.PrivateKey)
+	if !ok {
+		log.Fatal().Msg("PASETO public tokens need an Ed25519 private key")
+	}
+
+	tokenMaker, err := token.NewPasetoPublicMaker(privateKey)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create token maker")
 	}
 
+	return tokenMaker
+}
+
+func readTokenPrivateKey(config *util.Config) crypto.Signer {
+	pemBytes, err := os.ReadFile(config.TokenPrivateKeyPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot read token private key")
+	}
+
+	privateKey, err := token.ParsePrivateKeyPEM(pemBytes)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot parse token private key")
+	}
+
+	return privateKey
+}
+
+// newIdentityVerifier verifies the identities the gateway forwards with
+// IDENTITY_SIGNING_KEY. Without it forwarded identities are rejected.
+func newIdentityVerifier(config *util.Config) gapi.IdentityVerifier {
+	if config.IdentitySigningKey == "" {
+		log.Warn().Msg("IDENTITY_SIGNING_KEY is not set, identities forwarded by the gateway are rejected")
+		return nil
+	}
+
+	signer, err := identity.NewSigner(config.IdentitySigningKey, identity.DefaultTTL)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot initiate identity verifier")
+	}
+
+	return signer
+}
+
+// newTokenVerifier only accepts tokens minted by this service for
+// TOKEN_SERVICE_AUDIENCE, which keeps tokens minted for OIDC clients out.
+func newTokenVerifier(tokenMaker application.JwtTokenMaker, config *util.Config) token.Verifier {
+	var opts []token.VerifyOption
+	if config.TokenIssuer != "" {
+		opts = append(opts, token.RequireIssuer(config.TokenIssuer))
+	}
+
+	if config.TokenServiceAudience != "" {
+		opts = append(opts, token.RequireAudience(config.TokenServiceAudience))
+	}
+
+	if len(opts) == 0 {
+		return tokenMaker
+	}
+
+	return token.WithVerifyOptions(tokenMaker, opts...)
+}
+
+func newUserApplication(connPool *pgxpool.Pool, userRepository application.UserRepository, resetPasswordRepository application.ResetPasswordRepository, permissionRepository application.PermissionRepository, authEventRepository application.AuthEventRepository, tokenMaker application.JwtTokenMaker, config *util.Config) *application.UserApplication {
 	redisOpt := asynq.RedisClientOpt{
 		Addr: config.RedisAddress,
 	}
@@ -139,10 +338,45 @@ func newUserApplication(connPool *pgxpool.Pool, userRepository application.UserR
 	taskDistributor := worker.NewRedisTaskDistributor(redisOpt)
 
 	sessionRepository := infra.NewSessionRepository(connPool)
+	loginFailureRepository := infra.NewLoginFailureRepository(connPool)
+	totpRepository := infra.NewTotpRepository(connPool)
+	webauthnRepository := infra.NewWebauthnRepository(connPool)
+	externalLoginRepository := infra.NewExternalLoginRepository(connPool)
+
+	tokenDenylist := denylist.New(config.RedisAddress)
+
+	return application.NewUserApplication(userRepository, sessionRepository, resetPasswordRepository, loginFailureRepository, totpRepository, webauthnRepository, externalLoginRepository, permissionRepository, authEventRepository, newIdentityProvider(config), taskDistributor, tokenMaker, tokenDenylist, config)
+}
+
+// newIdentityProvider loads the identity providers of OIDC_PROVIDERS_PATH.
+// Without it no provider is known and external logins are refused.
+func newIdentityProvider(config *util.Config) application.ExternalIdentityProvider {
+	var providerConfigs []sso.ProviderConfig
+	if config.OidcProvidersPath != "" {
+		var err error
+		providerConfigs, err = sso.LoadProviderConfigs(config.OidcProvidersPath)
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot load oidc providers")
+		}
+	}
+
+	return sso.NewClient(providerConfigs, nil)
+}
+
+// newOidcApplication signs id tokens with the token maker when it supports
+// it, see runHttpServer.
+func newOidcApplication(connPool *pgxpool.Pool, userApplication *application.UserApplication, tokenMaker application.JwtTokenMaker, config *util.Config) *application.OidcApplication {
+	signer, _ := tokenMaker.(application.ClaimsSigner)
+	oauthRepository := infra.NewOauthRepository(connPool)
+
+	return application.NewOidcApplication(userApplication, oauthRepository, signer, config)
+}
 
-	return application.NewUserApplication(userRepository, sessionRepository, taskDistributor, tokenMaker, config)
+func newServiceAccountApplication(connPool *pgxpool.Pool) gapi.ServiceAccountApplication {
+	serviceAccountRepository := infra.NewServiceAccountRepository(connPool)
+	return application.NewServiceAccountApplication(serviceAccountRepository)
 }
 
-func newVerifyEmailApplication(verifyEmailRepository application.VerifyEmailRepository) gapi.VerifyEmailApplication {
-	return application.NewVerifyEmailApplication(verifyEmailRepository)
+func newVerifyEmailApplication(verifyEmailRepository application.VerifyEmailRepository, authEventRepository application.AuthEventRepository, config *util.Config) gapi.VerifyEmailApplication {
+	return application.NewVerifyEmailApplication(verifyEmailRepository, authEventRepository, config)
 }
@@ -0,0 +1,82 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/worker"
+	"github.com/rs/zerolog/log"
+)
+
+type DeleteAccount struct {
+	Username string `json:"username"`
+}
+
+// DeleteAccount deletes the account of the logged user right away and
+// schedules the purge of their data once the grace period is over. Their
+// sessions are revoked first, so a failure leaves no session alive for the
+// retry. The purge task has an ID per user, so a retry does not schedule a
+// second one.
+func (u *UserApplication) DeleteAccount(ctx context.Context, arg DeleteAccount) error {
+	if errValidation := validateDeleteAccountParams(arg); errValidation != nil {
+		return errValidation
+	}
+
+	user, err := u.userRepository.SoftDeleteUser(ctx, arg.Username)
+	if err != nil {
+		return err
+	}
+
+	err = u.revokeUserSessions(ctx, user.Username, uuid.Nil)
+	if err != nil {
+		return err
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.ProcessIn(u.config.AccountDeletionGracePeriod),
+		asynq.Queue(worker.DefaultQueue),
+		asynq.TaskID(purgeAccountTaskID(user.Username)),
+	}
+
+	err = u.taskDistributor.DistributeTaskPurgeAccount(ctx, &worker.PayloadPurgeAccount{Username: user.Username}, opts...)
+	if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return fmt.Errorf("failed to distribute purge account task: %w", err)
+	}
+
+	log.Info().Str("username", user.Username).Time("purge_at", user.DeletedAt.Add(u.config.AccountDeletionGracePeriod)).Msg("account deleted by its user")
+
+	return nil
+}
+
+func purgeAccountTaskID(username string) string {
+	return "purge:" + username
+}
+
+type ExportMyData struct {
+	Username string `json:"username"`
+}
+
+// ExportMyData returns everything the auth service stores about the logged
+// user as a JSON archive.
+func (u *UserApplication) ExportMyData(ctx context.Context, arg ExportMyData) ([]byte, error) {
+	if errValidation := validateExportMyDataParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	export, err := u.userRepository.ExportUserData(ctx, arg.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := json.Marshal(export)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal data export: %w", err)
+	}
+
+	return archive, nil
+}
@@ -0,0 +1,202 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hibiken/asynq"
+	mock "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application/mock"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/worker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteAccountUseCase(t *testing.T) {
+	user, _ := randomUser(t)
+	config := util.Config{
+		AccessTokenDuration:        time.Minute,
+		AccountDeletionGracePeriod: 24 * time.Hour,
+	}
+
+	testCases := []struct {
+		name          string
+		buildMocks    func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, taskDistributor *mock.MockTaskDistributor, denylist *mock.MockDenylist)
+		checkResponse func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, taskDistributor *mock.MockTaskDistributor, denylist *mock.MockDenylist) {
+				deletedAt := time.Now()
+				deletedUser := *user
+				deletedUser.DeletedAt = &deletedAt
+
+				userRepository.EXPECT().
+					SoftDeleteUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(&deletedUser, nil)
+
+				gomock.InOrder(
+					sessionRepository.EXPECT().
+						BlockUserSessions(gomock.Any(), gomock.Eq(infra.BlockUserSessions{Username: user.Username})).
+						Times(1).
+						Return(nil),
+					denylist.EXPECT().
+						BlockUser(gomock.Any(), gomock.Eq(user.Username), gomock.Eq(config.AccessTokenDuration)).
+						Times(1).
+						Return(nil),
+					taskDistributor.EXPECT().
+						DistributeTaskPurgeAccount(gomock.Any(), gomock.Eq(&worker.PayloadPurgeAccount{Username: user.Username}), gomock.Any()).
+						Times(1).
+						DoAndReturn(func(_ context.Context, _ *worker.PayloadPurgeAccount, opts ...asynq.Option) error {
+							require.Contains(t, opts, asynq.ProcessIn(config.AccountDeletionGracePeriod))
+							require.Contains(t, opts, asynq.TaskID("purge:"+user.Username))
+							return nil
+						}),
+				)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "DistributeError",
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, taskDistributor *mock.MockTaskDistributor, denylist *mock.MockDenylist) {
+				deletedAt := time.Now()
+				deletedUser := *user
+				deletedUser.DeletedAt = &deletedAt
+
+				userRepository.EXPECT().
+					SoftDeleteUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(&deletedUser, nil)
+
+				sessionRepository.EXPECT().BlockUserSessions(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				denylist.EXPECT().BlockUser(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskPurgeAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("redis is down"))
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.Error(t, err)
+			},
+		},
+		{
+			name: "PurgeAlreadyScheduled",
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, taskDistributor *mock.MockTaskDistributor, denylist *mock.MockDenylist) {
+				deletedAt := time.Now()
+				deletedUser := *user
+				deletedUser.DeletedAt = &deletedAt
+
+				userRepository.EXPECT().
+					SoftDeleteUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(&deletedUser, nil)
+
+				sessionRepository.EXPECT().BlockUserSessions(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				denylist.EXPECT().BlockUser(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskPurgeAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(fmt.Errorf("failed to enqueue task: %w", asynq.ErrTaskIDConflict))
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "RevokeSessionsError",
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, taskDistributor *mock.MockTaskDistributor, denylist *mock.MockDenylist) {
+				deletedAt := time.Now()
+				deletedUser := *user
+				deletedUser.DeletedAt = &deletedAt
+
+				userRepository.EXPECT().
+					SoftDeleteUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(&deletedUser, nil)
+
+				sessionRepository.EXPECT().
+					BlockUserSessions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("database is down"))
+
+				taskDistributor.EXPECT().DistributeTaskPurgeAccount(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.Error(t, err)
+			},
+		},
+		{
+			name: "NotFound",
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, taskDistributor *mock.MockTaskDistributor, denylist *mock.MockDenylist) {
+				userRepository.EXPECT().
+					SoftDeleteUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(nil, domain.ErrUserNotFound)
+
+				taskDistributor.EXPECT().DistributeTaskPurgeAccount(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, domain.ErrUserNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			sessionRepository := mock.NewMockSessionRepository(ctrl)
+			taskDistributor := mock.NewMockTaskDistributor(ctrl)
+			denylist := mock.NewMockDenylist(ctrl)
+			tc.buildMocks(userRepository, sessionRepository, taskDistributor, denylist)
+
+			userApplication := NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, nil, nil, nil, taskDistributor, nil, denylist, &config)
+
+			err := userApplication.DeleteAccount(context.Background(), DeleteAccount{Username: user.Username})
+			tc.checkResponse(t, err)
+		})
+	}
+}
+
+func TestExportMyDataUseCase(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	userRepository := mock.NewMockUserRepository(ctrl)
+
+	userRepository.EXPECT().
+		ExportUserData(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(&domain.UserDataExport{
+			ExportedAt: time.Now(),
+			Profile: domain.ExportedProfile{
+				Username: user.Username,
+				FullName: user.FullName,
+				Email:    user.Email,
+				Role:     user.Role,
+			},
+			Roles: []string{"catalog_editor"},
+		}, nil)
+
+	userApplication := NewUserApplication(userRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	archive, err := userApplication.ExportMyData(context.Background(), ExportMyData{Username: user.Username})
+	require.NoError(t, err)
+
+	var export map[string]any
+	require.NoError(t, json.Unmarshal(archive, &export))
+	require.Equal(t, user.Email, export["profile"].(map[string]any)["email"])
+	require.Equal(t, []any{"catalog_editor"}, export["roles"])
+	require.NotContains(t, string(archive), user.HashedPassword)
+}
@@ -0,0 +1,107 @@
+package application
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+type AuthEventRepository interface {
+	CreateAuthEvent(ctx context.Context, arg infra.CreateAuthEvent) (*domain.AuthEvent, error)
+	ListAuthEvents(ctx context.Context, arg infra.ListAuthEvents) ([]*domain.AuthEvent, error)
+}
+
+// recordAuthEvent appends an event to the audit trail with the client of the
+// request. A failed write is logged but does not fail the request it records.
+func recordAuthEvent(ctx context.Context, authEventRepository AuthEventRepository, trustedProxies []string, eventType string, username string, outcome string) {
+	metadata := util.ExtractMetadata(ctx, trustedProxies)
+	_, err := authEventRepository.CreateAuthEvent(ctx, infra.CreateAuthEvent{
+		EventType: eventType,
+		Username:  username,
+		ClientIP:  loginClientIP(metadata.ClientIP),
+		UserAgent: metadata.UserAgent,
+		Outcome:   outcome,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("event_type", eventType).Str("username", username).Str("outcome", outcome).Msg("failed to record auth event")
+	}
+}
+
+func (u *UserApplication) recordAuthEvent(ctx context.Context, eventType string, username string, outcome string) {
+	recordAuthEvent(ctx, u.authEventRepository, u.config.TrustedProxies, eventType, username, outcome)
+}
+
+type ListAuthEvents struct {
+	EventType     *string    `json:"event_type"`
+	Username      *string    `json:"username"`
+	Outcome       *string    `json:"outcome"`
+	CreatedAfter  *time.Time `json:"created_after"`
+	CreatedBefore *time.Time `json:"created_before"`
+	// PageToken is the NextPageToken of the previous page, empty for the
+	// first one.
+	PageToken string `json:"page_token"`
+	PageSize  int32  `json:"page_size"`
+}
+
+type ListAuthEventsResult struct {
+	Events []*domain.AuthEvent `json:"events"`
+	// NextPageToken is empty on the last page.
+	NextPageToken string `json:"next_page_token"`
+}
+
+// ListAuthEvents returns a page of the audit trail, newest first, for the
+// admins. Pages are cursors, so events recorded while paging do not shift
+// them.
+func (u *UserApplication) ListAuthEvents(ctx context.Context, arg ListAuthEvents) (*ListAuthEventsResult, error) {
+	if errValidation := validateListAuthEventsParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	var beforeID *int64
+	if arg.PageToken != "" {
+		id, _ := decodeAuthEventPageToken(arg.PageToken)
+		beforeID = &id
+	}
+
+	// one more event than asked tells whether there is a next page
+	events, err := u.authEventRepository.ListAuthEvents(ctx, infra.ListAuthEvents{
+		EventType:     arg.EventType,
+		Username:      arg.Username,
+		Outcome:       arg.Outcome,
+		CreatedAfter:  arg.CreatedAfter,
+		CreatedBefore: arg.CreatedBefore,
+		BeforeID:      beforeID,
+		Limit:         arg.PageSize + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list auth events: %w", err)
+	}
+
+	result := &ListAuthEventsResult{Events: events}
+	if len(events) > int(arg.PageSize) {
+		result.Events = events[:arg.PageSize]
+		result.NextPageToken = encodeAuthEventPageToken(result.Events[len(result.Events)-1].ID)
+	}
+
+	return result, nil
+}
+
+func encodeAuthEventPageToken(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+func decodeAuthEventPageToken(pageToken string) (int64, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(string(decoded), 10, 64)
+}
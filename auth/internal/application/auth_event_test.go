@@ -0,0 +1,150 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/golang/mock/gomock"
+	mock "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application/mock"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginUserRecordsAuthEvent(t *testing.T) {
+	user, password := randomUser(t)
+	session := randomSession(t, user.Username)
+	clientIP := "203.0.113.7"
+	userAgent := "test-agent"
+
+	testCases := []struct {
+		name     string
+		password string
+		outcome  string
+		sessions int
+	}{
+		{
+			name:     "Success",
+			password: password,
+			outcome:  domain.AuthEventSuccess,
+			sessions: 1,
+		},
+		{
+			name:     "Failure",
+			password: util.RandomString(8),
+			outcome:  domain.AuthEventFailure,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			sessionRepository := mock.NewMockSessionRepository(ctrl)
+			authEventRepository := mock.NewMockAuthEventRepository(ctrl)
+
+			userRepository.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+
+			sessionRepository.EXPECT().
+				CreateSession(gomock.Any(), gomock.Any()).
+				Times(tc.sessions).
+				Return(session, nil)
+
+			authEventRepository.EXPECT().
+				CreateAuthEvent(gomock.Any(), gomock.Eq(infra.CreateAuthEvent{
+					EventType: domain.AuthEventLogin,
+					Username:  user.Username,
+					ClientIP:  clientIP,
+					UserAgent: userAgent,
+					Outcome:   tc.outcome,
+				})).
+				Times(1)
+
+			tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+			require.NoError(t, err)
+
+			config := util.Config{
+				AccessTokenDuration:  time.Minute,
+				RefreshTokenDuration: time.Minute,
+				TrustedProxies:       []string{testGatewayIP},
+			}
+
+			userApplication := NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, authEventRepository, nil, nil, tokenMaker, nil, &config)
+
+			_, err = userApplication.Login(newGatewayContext(clientIP, userAgent), LoginUser{Username: user.Username, Password: tc.password})
+			if tc.outcome == domain.AuthEventSuccess {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidLoginPassword)
+			}
+		})
+	}
+}
+
+func TestListAuthEventsUseCase(t *testing.T) {
+	username := util.RandomUsername()
+	outcome := domain.AuthEventFailure
+
+	events := make([]*domain.AuthEvent, 0, 6)
+	for id := int64(20); id > 14; id-- {
+		events = append(events, &domain.AuthEvent{ID: id, EventType: domain.AuthEventLogin, Username: username, Outcome: outcome})
+	}
+
+	ctrl := gomock.NewController(t)
+	authEventRepository := mock.NewMockAuthEventRepository(ctrl)
+
+	authEventRepository.EXPECT().
+		ListAuthEvents(gomock.Any(), gomock.Eq(infra.ListAuthEvents{
+			Username: &username,
+			Outcome:  &outcome,
+			Limit:    6,
+		})).
+		Times(1).
+		Return(events, nil)
+
+	beforeID := int64(16)
+	authEventRepository.EXPECT().
+		ListAuthEvents(gomock.Any(), gomock.Eq(infra.ListAuthEvents{
+			BeforeID: &beforeID,
+			Limit:    6,
+		})).
+		Times(1).
+		Return(events[5:], nil)
+
+	userApplication := NewUserApplication(nil, nil, nil, nil, nil, nil, nil, nil, authEventRepository, nil, nil, nil, nil, nil)
+
+	result, err := userApplication.ListAuthEvents(context.Background(), ListAuthEvents{
+		Username: &username,
+		Outcome:  &outcome,
+		PageSize: 5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, events[:5], result.Events)
+	require.NotEmpty(t, result.NextPageToken)
+
+	result, err = userApplication.ListAuthEvents(context.Background(), ListAuthEvents{
+		PageToken: result.NextPageToken,
+		PageSize:  5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, events[5:], result.Events)
+	require.Empty(t, result.NextPageToken)
+
+	eventType := "unknown"
+	_, err = userApplication.ListAuthEvents(context.Background(), ListAuthEvents{
+		EventType: &eventType,
+		PageToken: "not a token",
+		PageSize:  5,
+	})
+	var validationErrors validation.Errors
+	require.ErrorAs(t, err, &validationErrors)
+	require.Contains(t, validationErrors, "event_type")
+	require.Contains(t, validationErrors, "page_token")
+}
@@ -1,5 +1,76 @@
 package application
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
-var ErrInvalidLoginPassword = errors.New("invalid usarname or password")
+var (
+	ErrInvalidLoginPassword      = errors.New("invalid usarname or password")
+	ErrInvalidCurrentPassword    = errors.New("current password is wrong")
+	ErrInvalidRefreshToken       = errors.New("invalid refresh token")
+	ErrBlockedSession            = errors.New("blocked session")
+	ErrIncorrectSessionUser      = errors.New("incorrect session user")
+	ErrIncorrectSessionClient    = errors.New("incorrect session client")
+	ErrMismatchedSessionToken    = errors.New("mismatched session token")
+	ErrExpiredSession            = errors.New("expired session")
+	ErrRefreshTokenReused        = errors.New("refresh token reuse detected")
+	ErrEmailNotVerified          = errors.New("email address is not verified")
+	ErrAccountLocked             = errors.New("account is temporarily locked")
+	ErrTooManyLoginAttempts      = errors.New("too many failed login attempts")
+	ErrTotpNotEnabled            = errors.New("totp is not enabled")
+	ErrInvalidTotpCode           = errors.New("invalid totp or recovery code")
+	ErrInvalidMfaToken           = errors.New("invalid mfa token")
+	ErrInvalidWebauthnSession    = errors.New("invalid or expired webauthn session")
+	ErrInvalidWebauthnCredential = errors.New("invalid webauthn credential")
+	ErrWebauthnCloneDetected     = errors.New("webauthn credential may be cloned")
+	ErrInvalidExternalLoginState = errors.New("invalid or expired external login state")
+	ErrExternalEmailRequired     = errors.New("identity provider did not share an email address")
+	ErrExternalAccountConflict   = errors.New("email address belongs to an account that cannot be linked")
+	ErrInvalidOauthClient        = errors.New("unknown oauth client")
+	ErrInvalidOauthRedirectURI   = errors.New("redirect_uri is not registered for this client")
+	ErrInvalidApiKey             = errors.New("invalid, revoked or expired api key")
+	ErrInvalidRevokeToken        = errors.New("invalid or expired revoke session token")
+	ErrAccountDisabled           = errors.New("account is disabled")
+	ErrAccountDeleted            = errors.New("account is deleted")
+	ErrPasswordResetRequired     = errors.New("password must be reset before logging in")
+	ErrSelfManagement            = errors.New("admins cannot disable, delete or change the role of their own account")
+)
+
+// Error codes of OauthError, from RFC 6749 and RFC 6750.
+const (
+	OauthInvalidRequest          = "invalid_request"
+	OauthInvalidClient           = "invalid_client"
+	OauthInvalidGrant            = "invalid_grant"
+	OauthInvalidScope            = "invalid_scope"
+	OauthInvalidToken            = "invalid_token"
+	OauthUnsupportedGrantType    = "unsupported_grant_type"
+	OauthUnsupportedResponseType = "unsupported_response_type"
+)
+
+// OauthError is an error the OpenID Connect endpoints report to the client
+// as is, with one of the Oauth error codes.
+type OauthError struct {
+	Code        string
+	Description string
+}
+
+func (e *OauthError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Description)
+}
+
+// LoginThrottledError wraps ErrAccountLocked or ErrTooManyLoginAttempts with
+// the time left until the next login attempt is accepted.
+type LoginThrottledError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LoginThrottledError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,287 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/sso"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/oauth2"
+)
+
+type ExternalLoginRepository interface {
+	CreateExternalLoginState(ctx context.Context, arg infra.CreateExternalLoginState) (*domain.ExternalLoginState, error)
+	ConsumeExternalLoginState(ctx context.Context, arg infra.ConsumeExternalLoginState) (*domain.ExternalLoginState, error)
+	GetExternalIdentity(ctx context.Context, arg infra.GetExternalIdentity) (*domain.ExternalIdentity, error)
+	CreateExternalIdentity(ctx context.Context, arg infra.CreateExternalIdentity) (*domain.ExternalIdentity, error)
+}
+
+// ExternalIdentityProvider runs the authorization code flow against the
+// identity providers configured at OIDC_PROVIDERS_PATH.
+type ExternalIdentityProvider interface {
+	AuthCodeURL(ctx context.Context, providerName, state, nonce, codeVerifier string) (string, error)
+	Exchange(ctx context.Context, providerName, code, codeVerifier, nonce string) (*sso.Identity, error)
+}
+
+// usernameAttempts is how many usernames are tried for a new external user
+// before giving up, the first one derived from the identity and the next ones
+// with a random suffix.
+const usernameAttempts = 5
+
+type BeginExternalLogin struct {
+	Provider string `json:"provider"`
+}
+
+type BeginExternalLoginResult struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+// BeginExternalLogin returns the URL the user is redirected to in order to
+// login with arg.Provider. The state, nonce and PKCE verifier are kept until
+// CompleteExternalLogin, at most OIDC_LOGIN_DURATION.
+func (u *UserApplication) BeginExternalLogin(ctx context.Context, arg BeginExternalLogin) (*BeginExternalLoginResult, error) {
+	if errValidation := validateBeginExternalLoginParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	state, err := randomURLToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create external login state: %w", err)
+	}
+
+	nonce, err := randomURLToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create external login nonce: %w", err)
+	}
+
+	codeVerifier := oauth2.GenerateVerifier()
+
+	authorizationURL, err := u.identityProvider.AuthCodeURL(ctx, arg.Provider, state, nonce, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = u.externalLoginRepository.CreateExternalLoginState(ctx, infra.CreateExternalLoginState{
+		State:        state,
+		Provider:     arg.Provider,
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(u.config.OidcLoginDuration),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create external login state: %w", err)
+	}
+
+	return &BeginExternalLoginResult{
+		AuthorizationURL: authorizationURL,
+		State:            state,
+	}, nil
+}
+
+type CompleteExternalLogin struct {
+	Provider string `json:"provider"`
+	State    string `json:"state"`
+	Code     string `json:"code"`
+}
+
+// CompleteExternalLogin exchanges the code sent back by the provider and logs
+// in the user linked to the identity, linking or creating one on the first
+// login. The result is the same as Login, including the TOTP challenge.
+func (u *UserApplication) CompleteExternalLogin(ctx context.Context, arg CompleteExternalLogin) (*LoginUserResult, error) {
+	if errValidation := validateCompleteExternalLoginParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	loginState, err := u.externalLoginRepository.ConsumeExternalLoginState(ctx, infra.ConsumeExternalLoginState{
+		State:    arg.State,
+		Provider: arg.Provider,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrExternalLoginStateNotFound) {
+			return nil, ErrInvalidExternalLoginState
+		}
+		return nil, fmt.Errorf("failed to get external login state: %w", err)
+	}
+
+	identity, err := u.identityProvider.Exchange(ctx, arg.Provider, arg.Code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.externalLoginUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.checkLoginAllowed(ctx, user.Username, loginClientIP(util.ExtractMetadata(ctx, u.config.TrustedProxies).ClientIP))
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsTotpEnabled {
+		return u.createMfaChallenge(ctx, user)
+	}
+
+	return u.createLoginSession(ctx, user)
+}
+
+// externalLoginUser returns the user linked to identity. An identity seen for
+// the first time is linked to the user with the same email only when both the
+// provider and the user verified it, otherwise anyone able to register that
+// email with the provider would take over the account.
+func (u *UserApplication) externalLoginUser(ctx context.Context, identity *sso.Identity) (*domain.User, error) {
+	linked, err := u.externalLoginRepository.GetExternalIdentity(ctx, infra.GetExternalIdentity{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+	})
+	if err == nil {
+		return u.userRepository.GetUser(ctx, linked.Username)
+	}
+
+	if !errors.Is(err, domain.ErrExternalIdentityNotFound) {
+		return nil, fmt.Errorf("failed to get external identity: %w", err)
+	}
+
+	if identity.Email == "" {
+		return nil, ErrExternalEmailRequired
+	}
+
+	user, err := u.userRepository.GetUserByEmail(ctx, identity.Email)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return u.createExternalUser(ctx, identity)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if !identity.EmailVerified || !user.IsEmailVerified {
+		return nil, ErrExternalAccountConflict
+	}
+
+	_, err = u.externalLoginRepository.CreateExternalIdentity(ctx, infra.CreateExternalIdentity{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Username: user.Username,
+		Email:    identity.Email,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to link external identity: %w", err)
+	}
+
+	log.Info().Str("username", user.Username).Str("provider", identity.Provider).Msg("external identity linked to existing user")
+
+	return user, nil
+}
+
+// createExternalUser creates the user of an identity seen for the first time.
+// The user gets a random password, so it can only login through the provider
+// until a password is reset.
+func (u *UserApplication) createExternalUser(ctx context.Context, identity *sso.Identity) (*domain.User, error) {
+	password, err := randomURLToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create password: %w", err)
+	}
+
+	hashedPassword, err := util.HashPassword(password)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to hash password")
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	username := externalUsername(identity)
+	fullName := identity.Name
+	if fullName == "" {
+		fullName = username
+	}
+
+	for attempt := range usernameAttempts {
+		candidate := username
+		if attempt > 0 {
+			candidate = fmt.Sprintf("%s_%s", username, util.RandomString(6))
+		}
+
+		res, err := u.userRepository.CreateUserTx(ctx, infra.CreateUserTx{
+			CreateUser: infra.CreateUser{
+				Username:        candidate,
+				HashedPassword:  hashedPassword,
+				FullName:        fullName,
+				Email:           identity.Email,
+				IsEmailVerified: identity.EmailVerified,
+			},
+			ExternalIdentity: &infra.CreateExternalIdentity{
+				Provider: identity.Provider,
+				Subject:  identity.Subject,
+				Email:    identity.Email,
+			},
+			AfterCreate: func(user domain.User) error {
+				if user.IsEmailVerified {
+					return nil
+				}
+				return u.distributeVerifyEmail(ctx, user.Username, asynq.ProcessIn(10*time.Second))
+			},
+		})
+		if err == nil {
+			return &res.User, nil
+		}
+
+		switch {
+		case errors.Is(err, domain.ErrUsernameAlreadyExist):
+			continue
+		case errors.Is(err, domain.ErrEmailAlreadyExist):
+			return nil, ErrExternalAccountConflict
+		}
+
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return nil, fmt.Errorf("failed to create user: %w", domain.ErrUsernameAlreadyExist)
+}
+
+// externalUsername derives a valid username from the preferred username or
+// the email of the identity.
+func externalUsername(identity *sso.Identity) string {
+	name := identity.PreferredUsername
+	if name == "" {
+		name, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	username := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		}
+		return '_'
+	}, name)
+
+	// leaves room for the suffix added when the username is taken
+	if len(username) > 90 {
+		username = username[:90]
+	}
+
+	if len(username) < 3 {
+		username = "user_" + username
+	}
+
+	return username
+}
+
+func randomURLToken() (string, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(random), nil
+}
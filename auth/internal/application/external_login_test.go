@@ -0,0 +1,520 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/golang/mock/gomock"
+	mock "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application/mock"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/sso"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/sso/ssotest"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/stretchr/testify/require"
+)
+
+const testExternalProvider = "fake"
+
+func newExternalLoginConfig() *util.Config {
+	return &util.Config{
+		AccessTokenDuration:  time.Minute,
+		RefreshTokenDuration: time.Minute,
+		OidcLoginDuration:    time.Minute,
+	}
+}
+
+// beginTestExternalLogin runs BeginExternalLogin against the fake provider
+// and signs in there as identityUser, returning the stored state and the code
+// the provider sent back.
+func beginTestExternalLogin(t *testing.T, config *util.Config, identityProvider ExternalIdentityProvider, provider *ssotest.Provider, identityUser ssotest.User) (*domain.ExternalLoginState, string) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	externalLoginRepository := mock.NewMockExternalLoginRepository(ctrl)
+
+	var loginState domain.ExternalLoginState
+	externalLoginRepository.EXPECT().
+		CreateExternalLoginState(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg infra.CreateExternalLoginState) (*domain.ExternalLoginState, error) {
+			loginState = domain.ExternalLoginState{
+				State:        arg.State,
+				Provider:     arg.Provider,
+				CodeVerifier: arg.CodeVerifier,
+				Nonce:        arg.Nonce,
+				ExpiresAt:    arg.ExpiresAt,
+			}
+			return &loginState, nil
+		})
+
+	userApplication := NewUserApplication(nil, nil, nil, nil, nil, nil, externalLoginRepository, nil, nil, identityProvider, nil, nil, nil, config)
+
+	result, err := userApplication.BeginExternalLogin(context.Background(), BeginExternalLogin{Provider: testExternalProvider})
+	require.NoError(t, err)
+
+	code, state := provider.Authorize(t, result.AuthorizationURL, identityUser)
+	require.Equal(t, result.State, state)
+
+	return &loginState, code
+}
+
+func TestBeginExternalLoginUseCase(t *testing.T) {
+	config := newExternalLoginConfig()
+	provider := ssotest.NewProvider(t)
+	identityProvider := sso.NewClient([]sso.ProviderConfig{provider.Config(testExternalProvider)}, nil)
+
+	testCases := []struct {
+		name          string
+		arg           BeginExternalLogin
+		buildMocks    func(externalLoginRepository *mock.MockExternalLoginRepository)
+		checkResponse func(t *testing.T, result *BeginExternalLoginResult, err error)
+	}{
+		{
+			name: "OK",
+			arg:  BeginExternalLogin{Provider: testExternalProvider},
+			buildMocks: func(externalLoginRepository *mock.MockExternalLoginRepository) {
+				externalLoginRepository.EXPECT().
+					CreateExternalLoginState(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg infra.CreateExternalLoginState) (*domain.ExternalLoginState, error) {
+						require.Equal(t, testExternalProvider, arg.Provider)
+						require.NotEmpty(t, arg.State)
+						require.NotEmpty(t, arg.Nonce)
+						require.NotEmpty(t, arg.CodeVerifier)
+						require.WithinDuration(t, time.Now().Add(config.OidcLoginDuration), arg.ExpiresAt, time.Second)
+						return &domain.ExternalLoginState{State: arg.State, Provider: arg.Provider}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, result *BeginExternalLoginResult, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, result.State)
+				require.Contains(t, result.AuthorizationURL, provider.URL)
+				require.Contains(t, result.AuthorizationURL, "code_challenge_method=S256")
+			},
+		},
+		{
+			name: "UnknownProvider",
+			arg:  BeginExternalLogin{Provider: "unknown"},
+			buildMocks: func(externalLoginRepository *mock.MockExternalLoginRepository) {
+				externalLoginRepository.EXPECT().
+					CreateExternalLoginState(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *BeginExternalLoginResult, err error) {
+				require.ErrorIs(t, err, sso.ErrUnknownProvider)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name: "MissingProvider",
+			arg:  BeginExternalLogin{},
+			buildMocks: func(externalLoginRepository *mock.MockExternalLoginRepository) {
+				externalLoginRepository.EXPECT().
+					CreateExternalLoginState(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *BeginExternalLoginResult, err error) {
+				var valErr validation.Errors
+				require.ErrorAs(t, err, &valErr)
+				require.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			externalLoginRepository := mock.NewMockExternalLoginRepository(ctrl)
+
+			tc.buildMocks(externalLoginRepository)
+
+			userApplication := NewUserApplication(nil, nil, nil, nil, nil, nil, externalLoginRepository, nil, nil, identityProvider, nil, nil, nil, config)
+
+			result, err := userApplication.BeginExternalLogin(context.Background(), tc.arg)
+			tc.checkResponse(t, result, err)
+		})
+	}
+}
+
+func TestCompleteExternalLoginUseCase(t *testing.T) {
+	config := newExternalLoginConfig()
+	provider := ssotest.NewProvider(t)
+	identityProvider := sso.NewClient([]sso.ProviderConfig{provider.Config(testExternalProvider)}, nil)
+
+	user, _ := randomUser(t)
+	user.IsEmailVerified = true
+	session := randomSession(t, user.Username)
+
+	identityUser := ssotest.User{
+		Subject:           util.RandomString(12),
+		Email:             user.Email,
+		EmailVerified:     true,
+		Name:              user.FullName,
+		PreferredUsername: "New.User",
+	}
+
+	type mocks struct {
+		userRepository          *mock.MockUserRepository
+		sessionRepository       *mock.MockSessionRepository
+		externalLoginRepository *mock.MockExternalLoginRepository
+		taskDistributor         *mock.MockTaskDistributor
+	}
+
+	testCases := []struct {
+		name          string
+		identityUser  ssotest.User
+		buildMocks    func(m mocks, loginState *domain.ExternalLoginState)
+		checkResponse func(t *testing.T, result *LoginUserResult, err error)
+	}{
+		{
+			name:         "LinkedIdentity",
+			identityUser: identityUser,
+			buildMocks: func(m mocks, loginState *domain.ExternalLoginState) {
+				m.externalLoginRepository.EXPECT().
+					ConsumeExternalLoginState(gomock.Any(), gomock.Eq(infra.ConsumeExternalLoginState{State: loginState.State, Provider: testExternalProvider})).
+					Times(1).
+					Return(loginState, nil)
+
+				m.externalLoginRepository.EXPECT().
+					GetExternalIdentity(gomock.Any(), gomock.Eq(infra.GetExternalIdentity{Provider: testExternalProvider, Subject: identityUser.Subject})).
+					Times(1).
+					Return(&domain.ExternalIdentity{Provider: testExternalProvider, Subject: identityUser.Subject, Username: user.Username}, nil)
+
+				m.userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				m.sessionRepository.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(session, nil)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user, result.User)
+				require.Equal(t, session.ID, result.SessionId)
+				require.NotEmpty(t, result.AccessToken)
+				require.NotEmpty(t, result.RefreshToken)
+			},
+		},
+		{
+			name:         "LinkVerifiedEmail",
+			identityUser: identityUser,
+			buildMocks: func(m mocks, loginState *domain.ExternalLoginState) {
+				m.externalLoginRepository.EXPECT().
+					ConsumeExternalLoginState(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(loginState, nil)
+
+				m.externalLoginRepository.EXPECT().
+					GetExternalIdentity(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrExternalIdentityNotFound)
+
+				m.userRepository.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				m.externalLoginRepository.EXPECT().
+					CreateExternalIdentity(gomock.Any(), gomock.Eq(infra.CreateExternalIdentity{
+						Provider: testExternalProvider,
+						Subject:  identityUser.Subject,
+						Username: user.Username,
+						Email:    user.Email,
+					})).
+					Times(1).
+					Return(&domain.ExternalIdentity{}, nil)
+
+				m.sessionRepository.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(session, nil)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user, result.User)
+				require.NotEmpty(t, result.AccessToken)
+			},
+		},
+		{
+			name: "UnverifiedEmailConflict",
+			identityUser: ssotest.User{
+				Subject:       identityUser.Subject,
+				Email:         user.Email,
+				EmailVerified: false,
+			},
+			buildMocks: func(m mocks, loginState *domain.ExternalLoginState) {
+				m.externalLoginRepository.EXPECT().
+					ConsumeExternalLoginState(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(loginState, nil)
+
+				m.externalLoginRepository.EXPECT().
+					GetExternalIdentity(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrExternalIdentityNotFound)
+
+				m.userRepository.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				m.externalLoginRepository.EXPECT().
+					CreateExternalIdentity(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				m.sessionRepository.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.ErrorIs(t, err, ErrExternalAccountConflict)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name:         "CreateUser",
+			identityUser: identityUser,
+			buildMocks: func(m mocks, loginState *domain.ExternalLoginState) {
+				m.externalLoginRepository.EXPECT().
+					ConsumeExternalLoginState(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(loginState, nil)
+
+				m.externalLoginRepository.EXPECT().
+					GetExternalIdentity(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrExternalIdentityNotFound)
+
+				m.userRepository.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(nil, domain.ErrUserNotFound)
+
+				gomock.InOrder(
+					m.userRepository.EXPECT().
+						CreateUserTx(gomock.Any(), gomock.Any()).
+						Times(1).
+						DoAndReturn(func(_ context.Context, arg infra.CreateUserTx) (infra.CreateUserTxResult, error) {
+							require.Equal(t, "new_user", arg.Username)
+							return infra.CreateUserTxResult{}, domain.ErrUsernameAlreadyExist
+						}),
+					m.userRepository.EXPECT().
+						CreateUserTx(gomock.Any(), gomock.Any()).
+						Times(1).
+						DoAndReturn(func(_ context.Context, arg infra.CreateUserTx) (infra.CreateUserTxResult, error) {
+							require.Regexp(t, `^new_user_[a-z]{6}$`, arg.Username)
+							require.Equal(t, identityUser.Email, arg.Email)
+							require.Equal(t, identityUser.Name, arg.FullName)
+							require.True(t, arg.IsEmailVerified)
+							require.NotEmpty(t, arg.HashedPassword)
+							require.Equal(t, &infra.CreateExternalIdentity{Provider: testExternalProvider, Subject: identityUser.Subject, Email: identityUser.Email}, arg.ExternalIdentity)
+
+							created := domain.User{
+								Username:        arg.Username,
+								Role:            domain.UserRole,
+								FullName:        arg.FullName,
+								Email:           arg.Email,
+								IsEmailVerified: arg.IsEmailVerified,
+							}
+							return infra.CreateUserTxResult{User: created}, arg.AfterCreate(created)
+						}),
+				)
+
+				m.taskDistributor.EXPECT().
+					DistributeTaskSendVerifyEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+
+				m.sessionRepository.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(session, nil)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.NoError(t, err)
+				require.Regexp(t, `^new_user_[a-z]{6}$`, result.User.Username)
+				require.NotEmpty(t, result.AccessToken)
+			},
+		},
+		{
+			name: "CreateUserWithUnverifiedEmail",
+			identityUser: ssotest.User{
+				Subject: identityUser.Subject,
+				Email:   "Other.Name@example.com",
+			},
+			buildMocks: func(m mocks, loginState *domain.ExternalLoginState) {
+				m.externalLoginRepository.EXPECT().
+					ConsumeExternalLoginState(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(loginState, nil)
+
+				m.externalLoginRepository.EXPECT().
+					GetExternalIdentity(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrExternalIdentityNotFound)
+
+				m.userRepository.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrUserNotFound)
+
+				m.userRepository.EXPECT().
+					CreateUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg infra.CreateUserTx) (infra.CreateUserTxResult, error) {
+						require.Equal(t, "other_name", arg.Username)
+						require.Equal(t, "other_name", arg.FullName)
+						require.False(t, arg.IsEmailVerified)
+
+						created := domain.User{Username: arg.Username, Role: domain.UserRole, Email: arg.Email}
+						return infra.CreateUserTxResult{User: created}, arg.AfterCreate(created)
+					})
+
+				m.taskDistributor.EXPECT().
+					DistributeTaskSendVerifyEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+
+				m.sessionRepository.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(session, nil)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "other_name", result.User.Username)
+			},
+		},
+		{
+			name: "MissingEmail",
+			identityUser: ssotest.User{
+				Subject: identityUser.Subject,
+			},
+			buildMocks: func(m mocks, loginState *domain.ExternalLoginState) {
+				m.externalLoginRepository.EXPECT().
+					ConsumeExternalLoginState(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(loginState, nil)
+
+				m.externalLoginRepository.EXPECT().
+					GetExternalIdentity(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrExternalIdentityNotFound)
+
+				m.userRepository.EXPECT().
+					CreateUserTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.ErrorIs(t, err, ErrExternalEmailRequired)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name:         "MfaRequired",
+			identityUser: identityUser,
+			buildMocks: func(m mocks, loginState *domain.ExternalLoginState) {
+				totpUser := *user
+				totpUser.IsTotpEnabled = true
+
+				m.externalLoginRepository.EXPECT().
+					ConsumeExternalLoginState(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(loginState, nil)
+
+				m.externalLoginRepository.EXPECT().
+					GetExternalIdentity(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&domain.ExternalIdentity{Username: user.Username}, nil)
+
+				m.userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(&totpUser, nil)
+
+				m.sessionRepository.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.NoError(t, err)
+				require.True(t, result.MfaRequired)
+				require.NotEmpty(t, result.MfaToken)
+				require.Empty(t, result.AccessToken)
+			},
+		},
+		{
+			name:         "InvalidState",
+			identityUser: identityUser,
+			buildMocks: func(m mocks, loginState *domain.ExternalLoginState) {
+				m.externalLoginRepository.EXPECT().
+					ConsumeExternalLoginState(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrExternalLoginStateNotFound)
+
+				m.externalLoginRepository.EXPECT().
+					GetExternalIdentity(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.ErrorIs(t, err, ErrInvalidExternalLoginState)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name:         "NonceMismatch",
+			identityUser: identityUser,
+			buildMocks: func(m mocks, loginState *domain.ExternalLoginState) {
+				tampered := *loginState
+				tampered.Nonce = "tampered"
+
+				m.externalLoginRepository.EXPECT().
+					ConsumeExternalLoginState(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&tampered, nil)
+
+				m.externalLoginRepository.EXPECT().
+					GetExternalIdentity(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.ErrorIs(t, err, sso.ErrInvalidIDToken)
+				require.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			loginState, code := beginTestExternalLogin(t, config, identityProvider, provider, tc.identityUser)
+
+			ctrl := gomock.NewController(t)
+			m := mocks{
+				userRepository:          mock.NewMockUserRepository(ctrl),
+				sessionRepository:       mock.NewMockSessionRepository(ctrl),
+				externalLoginRepository: mock.NewMockExternalLoginRepository(ctrl),
+				taskDistributor:         mock.NewMockTaskDistributor(ctrl),
+			}
+
+			tc.buildMocks(m, loginState)
+
+			tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+			require.NoError(t, err)
+
+			userApplication := NewUserApplication(m.userRepository, m.sessionRepository, nil, nil, nil, nil, m.externalLoginRepository, staticPermissions{}, discardAuthEvents{}, identityProvider, m.taskDistributor, tokenMaker, nil, config)
+
+			result, err := userApplication.CompleteExternalLogin(context.Background(), CompleteExternalLogin{
+				Provider: testExternalProvider,
+				State:    loginState.State,
+				Code:     code,
+			})
+			tc.checkResponse(t, result, err)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/rs/zerolog/log"
+)
+
+type LoginFailureRepository interface {
+	GetLoginFailure(ctx context.Context, scope string, subject string) (*domain.LoginFailure, error)
+	RecordLoginFailureTx(ctx context.Context, arg infra.RecordLoginFailureTx) (infra.RecordLoginFailureTxResult, error)
+	DeleteLoginFailures(ctx context.Context, arg infra.DeleteLoginFailures) error
+	UnlockUserTx(ctx context.Context, arg infra.UnlockUserTx) (infra.UnlockUserTxResult, error)
+}
+
+// loginProtectionEnabled tells whether failed logins are tracked, which is the
+// case when LOGIN_MAX_FAILURES or LOGIN_MAX_IP_FAILURES is set.
+func (u *UserApplication) loginProtectionEnabled() bool {
+	return u.config.LoginMaxFailures > 0 || u.config.LoginMaxIPFailures > 0
+}
+
+// loginClientIP drops the port of peer addresses, so every connection of a
+// client counts against the same IP.
+func loginClientIP(address string) string {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		return host
+	}
+
+	return address
+}
+
+// checkLoginAllowed rejects a login while the username or client IP is locked
+// or still waiting for the backoff of its last failure.
+func (u *UserApplication) checkLoginAllowed(ctx context.Context, username string, clientIP string) error {
+	if !u.loginProtectionEnabled() {
+		return nil
+	}
+
+	subjects := []struct {
+		scope   string
+		subject string
+		locked  error
+	}{
+		{domain.LoginFailureScopeUsername, username, ErrAccountLocked},
+		{domain.LoginFailureScopeIP, clientIP, ErrTooManyLoginAttempts},
+	}
+
+	now := time.Now()
+	for _, s := range subjects {
+		if s.subject == "" {
+			continue
+		}
+
+		loginFailure, err := u.loginFailureRepository.GetLoginFailure(ctx, s.scope, s.subject)
+		if err != nil {
+			if errors.Is(err, domain.ErrLoginFailureNotFound) {
+				continue
+			}
+			return fmt.Errorf("failed to get login failures: %w", err)
+		}
+
+		if loginFailure.LockedUntil != nil && now.Before(*loginFailure.LockedUntil) {
+			return &LoginThrottledError{Err: s.locked, RetryAfter: loginFailure.LockedUntil.Sub(now)}
+		}
+
+		if loginFailure.LockedUntil != nil {
+			continue
+		}
+
+		nextAttempt := loginFailure.LastFailedAt.Add(u.loginBackoff(loginFailure.Failures))
+		if now.Before(nextAttempt) {
+			return &LoginThrottledError{Err: ErrTooManyLoginAttempts, RetryAfter: nextAttempt.Sub(now)}
+		}
+	}
+
+	return nil
+}
+
+// loginBackoff is how long to wait after the given number of consecutive
+// failures, doubling LOGIN_BACKOFF_BASE on each one up to LOGIN_BACKOFF_MAX.
+func (u *UserApplication) loginBackoff(failures int32) time.Duration {
+	if failures <= 0 || u.config.LoginBackoffBase <= 0 {
+		return 0
+	}
+
+	backoff := u.config.LoginBackoffBase
+	for i := int32(1); i < failures; i++ {
+		if u.config.LoginBackoffMax > 0 && backoff >= u.config.LoginBackoffMax {
+			break
+		}
+		backoff *= 2
+	}
+
+	if u.config.LoginBackoffMax > 0 && backoff > u.config.LoginBackoffMax {
+		return u.config.LoginBackoffMax
+	}
+
+	return backoff
+}
+
+// countLoginAttempt counts a login attempt as failed before its credentials
+// are checked, so parallel guesses can not all pass checkLoginAllowed before
+// any of them is recorded. The attempt is rejected when it locked the username
+// or client IP, and a successful login resets the counters.
+func (u *UserApplication) countLoginAttempt(ctx context.Context, username string, clientIP string) error {
+	if !u.loginProtectionEnabled() {
+		return nil
+	}
+
+	txResult, err := u.loginFailureRepository.RecordLoginFailureTx(ctx, infra.RecordLoginFailureTx{
+		Username:        username,
+		ClientIP:        clientIP,
+		MaxFailures:     u.config.LoginMaxFailures,
+		MaxIPFailures:   u.config.LoginMaxIPFailures,
+		LockoutDuration: u.config.LoginLockoutDuration,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	if txResult.Lockout != nil {
+		log.Warn().
+			Str("username", txResult.Lockout.Username).
+			Str("client_ip", txResult.Lockout.ClientIP).
+			Time("locked_until", txResult.Lockout.LockedUntil).
+			Msg("account locked after too many failed logins")
+	}
+
+	now := time.Now()
+	if retryAfter, locked := loginLocked(txResult.Username, now); locked {
+		return &LoginThrottledError{Err: ErrAccountLocked, RetryAfter: retryAfter}
+	}
+
+	if retryAfter, locked := loginLocked(txResult.ClientIP, now); locked {
+		return &LoginThrottledError{Err: ErrTooManyLoginAttempts, RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+func loginLocked(loginFailure *domain.LoginFailure, now time.Time) (time.Duration, bool) {
+	if loginFailure == nil || loginFailure.LockedUntil == nil || !now.Before(*loginFailure.LockedUntil) {
+		return 0, false
+	}
+
+	return loginFailure.LockedUntil.Sub(now), true
+}
+
+func (u *UserApplication) resetLoginFailures(ctx context.Context, username string, clientIP string) error {
+	if !u.loginProtectionEnabled() {
+		return nil
+	}
+
+	err := u.loginFailureRepository.DeleteLoginFailures(ctx, infra.DeleteLoginFailures{
+		Username: username,
+		ClientIP: clientIP,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset login failures: %w", err)
+	}
+
+	return nil
+}
+
+type UnlockUser struct {
+	Username   string `json:"username"`
+	UnlockedBy string `json:"unlocked_by"`
+}
+
+// UnlockUser lifts the lockout of an account locked by too many failed logins.
+func (u *UserApplication) UnlockUser(ctx context.Context, arg UnlockUser) (*domain.AccountLockout, error) {
+	if errValidation := validateUnlockUserParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	txResult, err := u.loginFailureRepository.UnlockUserTx(ctx, infra.UnlockUserTx{
+		Username:   arg.Username,
+		UnlockedBy: arg.UnlockedBy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to unlock user: %w", err)
+	}
+
+	log.Info().Str("username", arg.Username).Str("unlocked_by", arg.UnlockedBy).Msg("account unlocked")
+
+	return txResult.Lockout, nil
+}
@@ -0,0 +1,423 @@
+package application
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mock "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application/mock"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestLoginUserLoginProtection(t *testing.T) {
+	user, password := randomUser(t)
+	session := randomSession(t, user.Username)
+	clientIP := "10.0.0.1"
+
+	config := util.Config{
+		AccessTokenDuration:  time.Minute,
+		RefreshTokenDuration: time.Minute,
+		LoginMaxFailures:     3,
+		LoginMaxIPFailures:   10,
+		LoginBackoffBase:     time.Second,
+		LoginBackoffMax:      time.Minute,
+		LoginLockoutDuration: 15 * time.Minute,
+		TrustedProxies:       []string{testGatewayIP},
+	}
+
+	notFound := func(loginFailureRepository *mock.MockLoginFailureRepository, scope string, subject string) {
+		loginFailureRepository.EXPECT().
+			GetLoginFailure(gomock.Any(), gomock.Eq(scope), gomock.Eq(subject)).
+			Times(1).
+			Return(nil, domain.ErrLoginFailureNotFound)
+	}
+
+	testCases := []struct {
+		name          string
+		password      string
+		buildMocks    func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, loginFailureRepository *mock.MockLoginFailureRepository)
+		checkResponse func(t *testing.T, result *LoginUserResult, err error)
+	}{
+		{
+			name:     "OK",
+			password: password,
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, loginFailureRepository *mock.MockLoginFailureRepository) {
+				loginFailureRepository.EXPECT().
+					GetLoginFailure(gomock.Any(), gomock.Eq(domain.LoginFailureScopeUsername), gomock.Eq(user.Username)).
+					Times(1).
+					Return(&domain.LoginFailure{Failures: 2, LastFailedAt: time.Now().Add(-time.Minute)}, nil)
+
+				notFound(loginFailureRepository, domain.LoginFailureScopeIP, clientIP)
+
+				loginFailureRepository.EXPECT().
+					RecordLoginFailureTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(infra.RecordLoginFailureTxResult{
+						Username: &domain.LoginFailure{Failures: 3, LastFailedAt: time.Now()},
+						ClientIP: &domain.LoginFailure{Failures: 1, LastFailedAt: time.Now()},
+					}, nil)
+
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				loginFailureRepository.EXPECT().
+					DeleteLoginFailures(gomock.Any(), gomock.Eq(infra.DeleteLoginFailures{Username: user.Username, ClientIP: clientIP})).
+					Times(1).
+					Return(nil)
+
+				sessionRepository.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(session, nil)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+			},
+		},
+		{
+			name:     "WrongPasswordRecordsFailure",
+			password: "wrong-password",
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, loginFailureRepository *mock.MockLoginFailureRepository) {
+				notFound(loginFailureRepository, domain.LoginFailureScopeUsername, user.Username)
+				notFound(loginFailureRepository, domain.LoginFailureScopeIP, clientIP)
+
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				arg := infra.RecordLoginFailureTx{
+					Username:        user.Username,
+					ClientIP:        clientIP,
+					MaxFailures:     config.LoginMaxFailures,
+					MaxIPFailures:   config.LoginMaxIPFailures,
+					LockoutDuration: config.LoginLockoutDuration,
+				}
+
+				loginFailureRepository.EXPECT().
+					RecordLoginFailureTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(infra.RecordLoginFailureTxResult{}, nil)
+
+				loginFailureRepository.EXPECT().
+					DeleteLoginFailures(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				sessionRepository.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.ErrorIs(t, err, ErrInvalidLoginPassword)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name:     "UnknownUserRecordsFailure",
+			password: password,
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, loginFailureRepository *mock.MockLoginFailureRepository) {
+				notFound(loginFailureRepository, domain.LoginFailureScopeUsername, user.Username)
+				notFound(loginFailureRepository, domain.LoginFailureScopeIP, clientIP)
+
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(nil, domain.ErrUserNotFound)
+
+				loginFailureRepository.EXPECT().
+					RecordLoginFailureTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(infra.RecordLoginFailureTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.ErrorIs(t, err, domain.ErrUserNotFound)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name:     "AccountLocked",
+			password: password,
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, loginFailureRepository *mock.MockLoginFailureRepository) {
+				lockedUntil := time.Now().Add(10 * time.Minute)
+
+				loginFailureRepository.EXPECT().
+					GetLoginFailure(gomock.Any(), gomock.Eq(domain.LoginFailureScopeUsername), gomock.Eq(user.Username)).
+					Times(1).
+					Return(&domain.LoginFailure{Failures: 3, LastFailedAt: time.Now(), LockedUntil: &lockedUntil}, nil)
+
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				loginFailureRepository.EXPECT().
+					RecordLoginFailureTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.ErrorIs(t, err, ErrAccountLocked)
+				require.Nil(t, result)
+
+				throttledErr, ok := err.(*LoginThrottledError)
+				require.True(t, ok)
+				require.InDelta(t, 10*time.Minute, throttledErr.RetryAfter, float64(time.Second))
+			},
+		},
+		{
+			name:     "LockedByConcurrentAttempt",
+			password: password,
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, loginFailureRepository *mock.MockLoginFailureRepository) {
+				lockedUntil := time.Now().Add(config.LoginLockoutDuration)
+
+				loginFailureRepository.EXPECT().
+					GetLoginFailure(gomock.Any(), gomock.Eq(domain.LoginFailureScopeUsername), gomock.Eq(user.Username)).
+					Times(1).
+					Return(&domain.LoginFailure{Failures: 2, LastFailedAt: time.Now().Add(-time.Minute)}, nil)
+
+				notFound(loginFailureRepository, domain.LoginFailureScopeIP, clientIP)
+
+				loginFailureRepository.EXPECT().
+					RecordLoginFailureTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(infra.RecordLoginFailureTxResult{
+						Username: &domain.LoginFailure{Failures: 4, LastFailedAt: time.Now(), LockedUntil: &lockedUntil},
+						ClientIP: &domain.LoginFailure{Failures: 4, LastFailedAt: time.Now()},
+					}, nil)
+
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.ErrorIs(t, err, ErrAccountLocked)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name:     "BackoffPending",
+			password: password,
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, loginFailureRepository *mock.MockLoginFailureRepository) {
+				loginFailureRepository.EXPECT().
+					GetLoginFailure(gomock.Any(), gomock.Eq(domain.LoginFailureScopeUsername), gomock.Eq(user.Username)).
+					Times(1).
+					Return(&domain.LoginFailure{Failures: 2, LastFailedAt: time.Now()}, nil)
+
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.ErrorIs(t, err, ErrTooManyLoginAttempts)
+				require.Nil(t, result)
+
+				throttledErr, ok := err.(*LoginThrottledError)
+				require.True(t, ok)
+				require.InDelta(t, 2*time.Second, throttledErr.RetryAfter, float64(time.Second))
+			},
+		},
+		{
+			name:     "ClientIPLocked",
+			password: password,
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, loginFailureRepository *mock.MockLoginFailureRepository) {
+				lockedUntil := time.Now().Add(time.Minute)
+
+				notFound(loginFailureRepository, domain.LoginFailureScopeUsername, user.Username)
+
+				loginFailureRepository.EXPECT().
+					GetLoginFailure(gomock.Any(), gomock.Eq(domain.LoginFailureScopeIP), gomock.Eq(clientIP)).
+					Times(1).
+					Return(&domain.LoginFailure{Failures: 10, LastFailedAt: time.Now(), LockedUntil: &lockedUntil}, nil)
+
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.ErrorIs(t, err, ErrTooManyLoginAttempts)
+				require.NotErrorIs(t, err, ErrAccountLocked)
+				require.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			sessionRepository := mock.NewMockSessionRepository(ctrl)
+			loginFailureRepository := mock.NewMockLoginFailureRepository(ctrl)
+
+			tc.buildMocks(userRepository, sessionRepository, loginFailureRepository)
+
+			tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+			require.NoError(t, err)
+
+			userApplication := NewUserApplication(userRepository, sessionRepository, nil, loginFailureRepository, nil, nil, nil, staticPermissions{}, discardAuthEvents{}, nil, nil, tokenMaker, nil, &config)
+
+			result, err := userApplication.Login(newGatewayContext(clientIP, ""), LoginUser{Username: user.Username, Password: tc.password})
+			tc.checkResponse(t, result, err)
+		})
+	}
+}
+
+// TestLoginUserLoginProtectionUntrustedPeer calls the service directly instead
+// of through the gateway, with an x-forwarded-for naming another client. The IP
+// scope must count the peer, so callers can not pick the IP they are throttled by.
+func TestLoginUserLoginProtectionUntrustedPeer(t *testing.T) {
+	user, _ := randomUser(t)
+	peerIP := "198.51.100.4"
+
+	config := util.Config{
+		LoginMaxFailures:     3,
+		LoginMaxIPFailures:   10,
+		LoginLockoutDuration: 15 * time.Minute,
+		TrustedProxies:       []string{testGatewayIP},
+	}
+
+	ctrl := gomock.NewController(t)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	loginFailureRepository := mock.NewMockLoginFailureRepository(ctrl)
+
+	loginFailureRepository.EXPECT().
+		GetLoginFailure(gomock.Any(), gomock.Eq(domain.LoginFailureScopeUsername), gomock.Eq(user.Username)).
+		Times(1).
+		Return(nil, domain.ErrLoginFailureNotFound)
+
+	loginFailureRepository.EXPECT().
+		GetLoginFailure(gomock.Any(), gomock.Eq(domain.LoginFailureScopeIP), gomock.Eq(peerIP)).
+		Times(1).
+		Return(nil, domain.ErrLoginFailureNotFound)
+
+	userRepository.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+
+	loginFailureRepository.EXPECT().
+		RecordLoginFailureTx(gomock.Any(), gomock.Eq(infra.RecordLoginFailureTx{
+			Username:        user.Username,
+			ClientIP:        peerIP,
+			MaxFailures:     config.LoginMaxFailures,
+			MaxIPFailures:   config.LoginMaxIPFailures,
+			LockoutDuration: config.LoginLockoutDuration,
+		})).
+		Times(1).
+		Return(infra.RecordLoginFailureTxResult{}, nil)
+
+	userApplication := NewUserApplication(userRepository, nil, nil, loginFailureRepository, nil, nil, nil, staticPermissions{}, discardAuthEvents{}, nil, nil, nil, nil, &config)
+
+	md := metadata.Pairs("x-forwarded-for", "203.0.113.7")
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(peerIP), Port: 50051}})
+
+	result, err := userApplication.Login(ctx, LoginUser{Username: user.Username, Password: "wrong-password"})
+	require.ErrorIs(t, err, ErrInvalidLoginPassword)
+	require.Nil(t, result)
+}
+
+func TestLoginBackoff(t *testing.T) {
+	userApplication := NewUserApplication(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &util.Config{
+		LoginBackoffBase: time.Second,
+		LoginBackoffMax:  10 * time.Second,
+	})
+
+	require.Zero(t, userApplication.loginBackoff(0))
+	require.Equal(t, time.Second, userApplication.loginBackoff(1))
+	require.Equal(t, 2*time.Second, userApplication.loginBackoff(2))
+	require.Equal(t, 8*time.Second, userApplication.loginBackoff(4))
+	require.Equal(t, 10*time.Second, userApplication.loginBackoff(5))
+	require.Equal(t, 10*time.Second, userApplication.loginBackoff(1000))
+}
+
+func TestLoginClientIP(t *testing.T) {
+	require.Equal(t, "10.0.0.1", loginClientIP("10.0.0.1:53412"))
+	require.Equal(t, "::1", loginClientIP("[::1]:53412"))
+	require.Equal(t, "10.0.0.1", loginClientIP("10.0.0.1"))
+	require.Equal(t, "", loginClientIP(""))
+}
+
+func TestUnlockUserUseCase(t *testing.T) {
+	user, _ := randomUser(t)
+	admin, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		arg           UnlockUser
+		buildMocks    func(loginFailureRepository *mock.MockLoginFailureRepository)
+		checkResponse func(t *testing.T, lockout *domain.AccountLockout, err error)
+	}{
+		{
+			name: "OK",
+			arg:  UnlockUser{Username: user.Username, UnlockedBy: admin.Username},
+			buildMocks: func(loginFailureRepository *mock.MockLoginFailureRepository) {
+				unlockedAt := time.Now()
+				loginFailureRepository.EXPECT().
+					UnlockUserTx(gomock.Any(), gomock.Eq(infra.UnlockUserTx{Username: user.Username, UnlockedBy: admin.Username})).
+					Times(1).
+					Return(infra.UnlockUserTxResult{
+						Lockout: &domain.AccountLockout{
+							Username:   user.Username,
+							UnlockedAt: &unlockedAt,
+							UnlockedBy: &admin.Username,
+						},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, lockout *domain.AccountLockout, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user.Username, lockout.Username)
+				require.Equal(t, admin.Username, *lockout.UnlockedBy)
+			},
+		},
+		{
+			name: "NotLocked",
+			arg:  UnlockUser{Username: user.Username, UnlockedBy: admin.Username},
+			buildMocks: func(loginFailureRepository *mock.MockLoginFailureRepository) {
+				loginFailureRepository.EXPECT().
+					UnlockUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(infra.UnlockUserTxResult{}, domain.ErrAccountLockoutNotFound)
+			},
+			checkResponse: func(t *testing.T, lockout *domain.AccountLockout, err error) {
+				require.ErrorIs(t, err, domain.ErrAccountLockoutNotFound)
+				require.Nil(t, lockout)
+			},
+		},
+		{
+			name: "InvalidUsername",
+			arg:  UnlockUser{Username: "invalid user", UnlockedBy: admin.Username},
+			buildMocks: func(loginFailureRepository *mock.MockLoginFailureRepository) {
+				loginFailureRepository.EXPECT().
+					UnlockUserTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, lockout *domain.AccountLockout, err error) {
+				require.Error(t, err)
+				require.Nil(t, lockout)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			loginFailureRepository := mock.NewMockLoginFailureRepository(ctrl)
+
+			tc.buildMocks(loginFailureRepository)
+
+			userApplication := NewUserApplication(nil, nil, nil, loginFailureRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, &util.Config{})
+
+			lockout, err := userApplication.UnlockUser(context.Background(), tc.arg)
+			tc.checkResponse(t, lockout, err)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application (interfaces: AuthEventRepository)
+
+// Package application is a generated GoMock package.
+package application
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	infra "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+)
+
+// MockAuthEventRepository is a mock of AuthEventRepository interface.
+type MockAuthEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthEventRepositoryMockRecorder
+}
+
+// MockAuthEventRepositoryMockRecorder is the mock recorder for MockAuthEventRepository.
+type MockAuthEventRepositoryMockRecorder struct {
+	mock *MockAuthEventRepository
+}
+
+// NewMockAuthEventRepository creates a new mock instance.
+func NewMockAuthEventRepository(ctrl *gomock.Controller) *MockAuthEventRepository {
+	mock := &MockAuthEventRepository{ctrl: ctrl}
+	mock.recorder = &MockAuthEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthEventRepository) EXPECT() *MockAuthEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAuthEvent mocks base method.
+func (m *MockAuthEventRepository) CreateAuthEvent(arg0 context.Context, arg1 infra.CreateAuthEvent) (*domain.AuthEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuthEvent", arg0, arg1)
+	ret0, _ := ret[0].(*domain.AuthEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuthEvent indicates an expected call of CreateAuthEvent.
+func (mr *MockAuthEventRepositoryMockRecorder) CreateAuthEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuthEvent", reflect.TypeOf((*MockAuthEventRepository)(nil).CreateAuthEvent), arg0, arg1)
+}
+
+// ListAuthEvents mocks base method.
+func (m *MockAuthEventRepository) ListAuthEvents(arg0 context.Context, arg1 infra.ListAuthEvents) ([]*domain.AuthEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuthEvents", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.AuthEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuthEvents indicates an expected call of ListAuthEvents.
+func (mr *MockAuthEventRepositoryMockRecorder) ListAuthEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuthEvents", reflect.TypeOf((*MockAuthEventRepository)(nil).ListAuthEvents), arg0, arg1)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application (interfaces: ExternalLoginRepository)
+
+// Package application is a generated GoMock package.
+package application
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	infra "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+)
+
+// MockExternalLoginRepository is a mock of ExternalLoginRepository interface.
+type MockExternalLoginRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExternalLoginRepositoryMockRecorder
+}
+
+// MockExternalLoginRepositoryMockRecorder is the mock recorder for MockExternalLoginRepository.
+type MockExternalLoginRepositoryMockRecorder struct {
+	mock *MockExternalLoginRepository
+}
+
+// NewMockExternalLoginRepository creates a new mock instance.
+func NewMockExternalLoginRepository(ctrl *gomock.Controller) *MockExternalLoginRepository {
+	mock := &MockExternalLoginRepository{ctrl: ctrl}
+	mock.recorder = &MockExternalLoginRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExternalLoginRepository) EXPECT() *MockExternalLoginRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConsumeExternalLoginState mocks base method.
+func (m *MockExternalLoginRepository) ConsumeExternalLoginState(arg0 context.Context, arg1 infra.ConsumeExternalLoginState) (*domain.ExternalLoginState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeExternalLoginState", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ExternalLoginState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeExternalLoginState indicates an expected call of ConsumeExternalLoginState.
+func (mr *MockExternalLoginRepositoryMockRecorder) ConsumeExternalLoginState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeExternalLoginState", reflect.TypeOf((*MockExternalLoginRepository)(nil).ConsumeExternalLoginState), arg0, arg1)
+}
+
+// CreateExternalIdentity mocks base method.
+func (m *MockExternalLoginRepository) CreateExternalIdentity(arg0 context.Context, arg1 infra.CreateExternalIdentity) (*domain.ExternalIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExternalIdentity", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ExternalIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExternalIdentity indicates an expected call of CreateExternalIdentity.
+func (mr *MockExternalLoginRepositoryMockRecorder) CreateExternalIdentity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExternalIdentity", reflect.TypeOf((*MockExternalLoginRepository)(nil).CreateExternalIdentity), arg0, arg1)
+}
+
+// CreateExternalLoginState mocks base method.
+func (m *MockExternalLoginRepository) CreateExternalLoginState(arg0 context.Context, arg1 infra.CreateExternalLoginState) (*domain.ExternalLoginState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExternalLoginState", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ExternalLoginState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExternalLoginState indicates an expected call of CreateExternalLoginState.
+func (mr *MockExternalLoginRepositoryMockRecorder) CreateExternalLoginState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExternalLoginState", reflect.TypeOf((*MockExternalLoginRepository)(nil).CreateExternalLoginState), arg0, arg1)
+}
+
+// GetExternalIdentity mocks base method.
+func (m *MockExternalLoginRepository) GetExternalIdentity(arg0 context.Context, arg1 infra.GetExternalIdentity) (*domain.ExternalIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExternalIdentity", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ExternalIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExternalIdentity indicates an expected call of GetExternalIdentity.
+func (mr *MockExternalLoginRepositoryMockRecorder) GetExternalIdentity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalIdentity", reflect.TypeOf((*MockExternalLoginRepository)(nil).GetExternalIdentity), arg0, arg1)
+}
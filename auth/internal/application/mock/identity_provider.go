@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application (interfaces: ExternalIdentityProvider)
+
+// Package application is a generated GoMock package.
+package application
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	sso "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/sso"
+)
+
+// MockExternalIdentityProvider is a mock of ExternalIdentityProvider interface.
+type MockExternalIdentityProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockExternalIdentityProviderMockRecorder
+}
+
+// MockExternalIdentityProviderMockRecorder is the mock recorder for MockExternalIdentityProvider.
+type MockExternalIdentityProviderMockRecorder struct {
+	mock *MockExternalIdentityProvider
+}
+
+// NewMockExternalIdentityProvider creates a new mock instance.
+func NewMockExternalIdentityProvider(ctrl *gomock.Controller) *MockExternalIdentityProvider {
+	mock := &MockExternalIdentityProvider{ctrl: ctrl}
+	mock.recorder = &MockExternalIdentityProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExternalIdentityProvider) EXPECT() *MockExternalIdentityProviderMockRecorder {
+	return m.recorder
+}
+
+// AuthCodeURL mocks base method.
+func (m *MockExternalIdentityProvider) AuthCodeURL(arg0 context.Context, arg1, arg2, arg3, arg4 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthCodeURL", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthCodeURL indicates an expected call of AuthCodeURL.
+func (mr *MockExternalIdentityProviderMockRecorder) AuthCodeURL(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthCodeURL", reflect.TypeOf((*MockExternalIdentityProvider)(nil).AuthCodeURL), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Exchange mocks base method.
+func (m *MockExternalIdentityProvider) Exchange(arg0 context.Context, arg1, arg2, arg3, arg4 string) (*sso.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*sso.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockExternalIdentityProviderMockRecorder) Exchange(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockExternalIdentityProvider)(nil).Exchange), arg0, arg1, arg2, arg3, arg4)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application (interfaces: LoginFailureRepository)
+
+// Package application is a generated GoMock package.
+package application
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	infra "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+)
+
+// MockLoginFailureRepository is a mock of LoginFailureRepository interface.
+type MockLoginFailureRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginFailureRepositoryMockRecorder
+}
+
+// MockLoginFailureRepositoryMockRecorder is the mock recorder for MockLoginFailureRepository.
+type MockLoginFailureRepositoryMockRecorder struct {
+	mock *MockLoginFailureRepository
+}
+
+// NewMockLoginFailureRepository creates a new mock instance.
+func NewMockLoginFailureRepository(ctrl *gomock.Controller) *MockLoginFailureRepository {
+	mock := &MockLoginFailureRepository{ctrl: ctrl}
+	mock.recorder = &MockLoginFailureRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginFailureRepository) EXPECT() *MockLoginFailureRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteLoginFailures mocks base method.
+func (m *MockLoginFailureRepository) DeleteLoginFailures(arg0 context.Context, arg1 infra.DeleteLoginFailures) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoginFailures", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoginFailures indicates an expected call of DeleteLoginFailures.
+func (mr *MockLoginFailureRepositoryMockRecorder) DeleteLoginFailures(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoginFailures", reflect.TypeOf((*MockLoginFailureRepository)(nil).DeleteLoginFailures), arg0, arg1)
+}
+
+// GetLoginFailure mocks base method.
+func (m *MockLoginFailureRepository) GetLoginFailure(arg0 context.Context, arg1, arg2 string) (*domain.LoginFailure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginFailure", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.LoginFailure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginFailure indicates an expected call of GetLoginFailure.
+func (mr *MockLoginFailureRepositoryMockRecorder) GetLoginFailure(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginFailure", reflect.TypeOf((*MockLoginFailureRepository)(nil).GetLoginFailure), arg0, arg1, arg2)
+}
+
+// RecordLoginFailureTx mocks base method.
+func (m *MockLoginFailureRepository) RecordLoginFailureTx(arg0 context.Context, arg1 infra.RecordLoginFailureTx) (infra.RecordLoginFailureTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordLoginFailureTx", arg0, arg1)
+	ret0, _ := ret[0].(infra.RecordLoginFailureTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordLoginFailureTx indicates an expected call of RecordLoginFailureTx.
+func (mr *MockLoginFailureRepositoryMockRecorder) RecordLoginFailureTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLoginFailureTx", reflect.TypeOf((*MockLoginFailureRepository)(nil).RecordLoginFailureTx), arg0, arg1)
+}
+
+// UnlockUserTx mocks base method.
+func (m *MockLoginFailureRepository) UnlockUserTx(arg0 context.Context, arg1 infra.UnlockUserTx) (infra.UnlockUserTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUserTx", arg0, arg1)
+	ret0, _ := ret[0].(infra.UnlockUserTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockUserTx indicates an expected call of UnlockUserTx.
+func (mr *MockLoginFailureRepositoryMockRecorder) UnlockUserTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUserTx", reflect.TypeOf((*MockLoginFailureRepository)(nil).UnlockUserTx), arg0, arg1)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application (interfaces: OauthRepository)
+
+// Package application is a generated GoMock package.
+package application
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	infra "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+)
+
+// MockOauthRepository is a mock of OauthRepository interface.
+type MockOauthRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOauthRepositoryMockRecorder
+}
+
+// MockOauthRepositoryMockRecorder is the mock recorder for MockOauthRepository.
+type MockOauthRepositoryMockRecorder struct {
+	mock *MockOauthRepository
+}
+
+// NewMockOauthRepository creates a new mock instance.
+func NewMockOauthRepository(ctrl *gomock.Controller) *MockOauthRepository {
+	mock := &MockOauthRepository{ctrl: ctrl}
+	mock.recorder = &MockOauthRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOauthRepository) EXPECT() *MockOauthRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConsumeOauthAuthorizationCode mocks base method.
+func (m *MockOauthRepository) ConsumeOauthAuthorizationCode(arg0 context.Context, arg1 infra.ConsumeOauthAuthorizationCode) (*domain.OauthAuthorizationCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeOauthAuthorizationCode", arg0, arg1)
+	ret0, _ := ret[0].(*domain.OauthAuthorizationCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeOauthAuthorizationCode indicates an expected call of ConsumeOauthAuthorizationCode.
+func (mr *MockOauthRepositoryMockRecorder) ConsumeOauthAuthorizationCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeOauthAuthorizationCode", reflect.TypeOf((*MockOauthRepository)(nil).ConsumeOauthAuthorizationCode), arg0, arg1)
+}
+
+// CreateOauthAuthorizationCode mocks base method.
+func (m *MockOauthRepository) CreateOauthAuthorizationCode(arg0 context.Context, arg1 infra.CreateOauthAuthorizationCode) (*domain.OauthAuthorizationCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOauthAuthorizationCode", arg0, arg1)
+	ret0, _ := ret[0].(*domain.OauthAuthorizationCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOauthAuthorizationCode indicates an expected call of CreateOauthAuthorizationCode.
+func (mr *MockOauthRepositoryMockRecorder) CreateOauthAuthorizationCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOauthAuthorizationCode", reflect.TypeOf((*MockOauthRepository)(nil).CreateOauthAuthorizationCode), arg0, arg1)
+}
+
+// CreateOauthClient mocks base method.
+func (m *MockOauthRepository) CreateOauthClient(arg0 context.Context, arg1 infra.CreateOauthClient) (*domain.OauthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOauthClient", arg0, arg1)
+	ret0, _ := ret[0].(*domain.OauthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOauthClient indicates an expected call of CreateOauthClient.
+func (mr *MockOauthRepositoryMockRecorder) CreateOauthClient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOauthClient", reflect.TypeOf((*MockOauthRepository)(nil).CreateOauthClient), arg0, arg1)
+}
+
+// GetOauthClient mocks base method.
+func (m *MockOauthRepository) GetOauthClient(arg0 context.Context, arg1 string) (*domain.OauthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOauthClient", arg0, arg1)
+	ret0, _ := ret[0].(*domain.OauthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOauthClient indicates an expected call of GetOauthClient.
+func (mr *MockOauthRepositoryMockRecorder) GetOauthClient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOauthClient", reflect.TypeOf((*MockOauthRepository)(nil).GetOauthClient), arg0, arg1)
+}
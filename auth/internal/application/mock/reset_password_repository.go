@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application (interfaces: ResetPasswordRepository)
+
+// Package application is a generated GoMock package.
+package application
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	infra "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+)
+
+// MockResetPasswordRepository is a mock of ResetPasswordRepository interface.
+type MockResetPasswordRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockResetPasswordRepositoryMockRecorder
+}
+
+// MockResetPasswordRepositoryMockRecorder is the mock recorder for MockResetPasswordRepository.
+type MockResetPasswordRepositoryMockRecorder struct {
+	mock *MockResetPasswordRepository
+}
+
+// NewMockResetPasswordRepository creates a new mock instance.
+func NewMockResetPasswordRepository(ctrl *gomock.Controller) *MockResetPasswordRepository {
+	mock := &MockResetPasswordRepository{ctrl: ctrl}
+	mock.recorder = &MockResetPasswordRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResetPasswordRepository) EXPECT() *MockResetPasswordRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateResetPassword mocks base method.
+func (m *MockResetPasswordRepository) CreateResetPassword(arg0 context.Context, arg1 infra.CreateResetPassword) (*domain.ResetPassword, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateResetPassword", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ResetPassword)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateResetPassword indicates an expected call of CreateResetPassword.
+func (mr *MockResetPasswordRepositoryMockRecorder) CreateResetPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateResetPassword", reflect.TypeOf((*MockResetPasswordRepository)(nil).CreateResetPassword), arg0, arg1)
+}
+
+// ResetPasswordTx mocks base method.
+func (m *MockResetPasswordRepository) ResetPasswordTx(arg0 context.Context, arg1 infra.ResetPasswordTx) (infra.ResetPasswordTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPasswordTx", arg0, arg1)
+	ret0, _ := ret[0].(infra.ResetPasswordTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPasswordTx indicates an expected call of ResetPasswordTx.
+func (mr *MockResetPasswordRepositoryMockRecorder) ResetPasswordTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPasswordTx", reflect.TypeOf((*MockResetPasswordRepository)(nil).ResetPasswordTx), arg0, arg1)
+}
@@ -37,6 +37,34 @@ func (m *MockSessionRepository) EXPECT() *MockSessionRepositoryMockRecorder {
 	return m.recorder
 }
 
+// BlockSessionFamily mocks base method.
+func (m *MockSessionRepository) BlockSessionFamily(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockSessionFamily", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockSessionFamily indicates an expected call of BlockSessionFamily.
+func (mr *MockSessionRepositoryMockRecorder) BlockSessionFamily(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSessionFamily", reflect.TypeOf((*MockSessionRepository)(nil).BlockSessionFamily), arg0, arg1)
+}
+
+// BlockUserSessions mocks base method.
+func (m *MockSessionRepository) BlockUserSessions(arg0 context.Context, arg1 infra.BlockUserSessions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUserSessions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockUserSessions indicates an expected call of BlockUserSessions.
+func (mr *MockSessionRepositoryMockRecorder) BlockUserSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUserSessions", reflect.TypeOf((*MockSessionRepository)(nil).BlockUserSessions), arg0, arg1)
+}
+
 // CreateSession mocks base method.
 func (m *MockSessionRepository) CreateSession(arg0 context.Context, arg1 infra.CreateSession) (*domain.Session, error) {
 	m.ctrl.T.Helper()
@@ -66,3 +94,48 @@ func (mr *MockSessionRepositoryMockRecorder) GetSession(arg0, arg1 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockSessionRepository)(nil).GetSession), arg0, arg1)
 }
+
+// ListActiveSessions mocks base method.
+func (m *MockSessionRepository) ListActiveSessions(arg0 context.Context, arg1 string) ([]*domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveSessions", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveSessions indicates an expected call of ListActiveSessions.
+func (mr *MockSessionRepositoryMockRecorder) ListActiveSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveSessions", reflect.TypeOf((*MockSessionRepository)(nil).ListActiveSessions), arg0, arg1)
+}
+
+// ListSessionDevices mocks base method.
+func (m *MockSessionRepository) ListSessionDevices(arg0 context.Context, arg1 string) ([]*domain.SessionDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessionDevices", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.SessionDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessionDevices indicates an expected call of ListSessionDevices.
+func (mr *MockSessionRepositoryMockRecorder) ListSessionDevices(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessionDevices", reflect.TypeOf((*MockSessionRepository)(nil).ListSessionDevices), arg0, arg1)
+}
+
+// RotateSessionTx mocks base method.
+func (m *MockSessionRepository) RotateSessionTx(arg0 context.Context, arg1 infra.RotateSessionTx) (infra.RotateSessionTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSessionTx", arg0, arg1)
+	ret0, _ := ret[0].(infra.RotateSessionTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSessionTx indicates an expected call of RotateSessionTx.
+func (mr *MockSessionRepositoryMockRecorder) RotateSessionTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSessionTx", reflect.TypeOf((*MockSessionRepository)(nil).RotateSessionTx), arg0, arg1)
+}
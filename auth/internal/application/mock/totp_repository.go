@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application (interfaces: TotpRepository)
+
+// Package application is a generated GoMock package.
+package application
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	infra "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+)
+
+// MockTotpRepository is a mock of TotpRepository interface.
+type MockTotpRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTotpRepositoryMockRecorder
+}
+
+// MockTotpRepositoryMockRecorder is the mock recorder for MockTotpRepository.
+type MockTotpRepositoryMockRecorder struct {
+	mock *MockTotpRepository
+}
+
+// NewMockTotpRepository creates a new mock instance.
+func NewMockTotpRepository(ctrl *gomock.Controller) *MockTotpRepository {
+	mock := &MockTotpRepository{ctrl: ctrl}
+	mock.recorder = &MockTotpRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTotpRepository) EXPECT() *MockTotpRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DisableTotpTx mocks base method.
+func (m *MockTotpRepository) DisableTotpTx(arg0 context.Context, arg1 string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTotpTx", arg0, arg1)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableTotpTx indicates an expected call of DisableTotpTx.
+func (mr *MockTotpRepositoryMockRecorder) DisableTotpTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTotpTx", reflect.TypeOf((*MockTotpRepository)(nil).DisableTotpTx), arg0, arg1)
+}
+
+// EnableTotpTx mocks base method.
+func (m *MockTotpRepository) EnableTotpTx(arg0 context.Context, arg1 infra.EnableTotpTx) (infra.EnableTotpTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTotpTx", arg0, arg1)
+	ret0, _ := ret[0].(infra.EnableTotpTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableTotpTx indicates an expected call of EnableTotpTx.
+func (mr *MockTotpRepositoryMockRecorder) EnableTotpTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTotpTx", reflect.TypeOf((*MockTotpRepository)(nil).EnableTotpTx), arg0, arg1)
+}
+
+// EnrollTotp mocks base method.
+func (m *MockTotpRepository) EnrollTotp(arg0 context.Context, arg1 infra.EnrollTotp) (*domain.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTotp", arg0, arg1)
+	ret0, _ := ret[0].(*domain.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTotp indicates an expected call of EnrollTotp.
+func (mr *MockTotpRepositoryMockRecorder) EnrollTotp(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTotp", reflect.TypeOf((*MockTotpRepository)(nil).EnrollTotp), arg0, arg1)
+}
+
+// GetUserTotp mocks base method.
+func (m *MockTotpRepository) GetUserTotp(arg0 context.Context, arg1 string) (*domain.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTotp", arg0, arg1)
+	ret0, _ := ret[0].(*domain.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTotp indicates an expected call of GetUserTotp.
+func (mr *MockTotpRepositoryMockRecorder) GetUserTotp(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTotp", reflect.TypeOf((*MockTotpRepository)(nil).GetUserTotp), arg0, arg1)
+}
+
+// ReplaceRecoveryCodesTx mocks base method.
+func (m *MockTotpRepository) ReplaceRecoveryCodesTx(arg0 context.Context, arg1 infra.ReplaceRecoveryCodesTx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRecoveryCodesTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRecoveryCodesTx indicates an expected call of ReplaceRecoveryCodesTx.
+func (mr *MockTotpRepositoryMockRecorder) ReplaceRecoveryCodesTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecoveryCodesTx", reflect.TypeOf((*MockTotpRepository)(nil).ReplaceRecoveryCodesTx), arg0, arg1)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockTotpRepository) UseRecoveryCode(arg0 context.Context, arg1 infra.UseRecoveryCode) (*domain.RecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", arg0, arg1)
+	ret0, _ := ret[0].(*domain.RecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockTotpRepositoryMockRecorder) UseRecoveryCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockTotpRepository)(nil).UseRecoveryCode), arg0, arg1)
+}
+
+// UseTotpCounter mocks base method.
+func (m *MockTotpRepository) UseTotpCounter(arg0 context.Context, arg1 infra.UseTotpCounter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTotpCounter", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTotpCounter indicates an expected call of UseTotpCounter.
+func (mr *MockTotpRepositoryMockRecorder) UseTotpCounter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTotpCounter", reflect.TypeOf((*MockTotpRepository)(nil).UseTotpCounter), arg0, arg1)
+}
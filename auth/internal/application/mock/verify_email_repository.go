@@ -51,6 +51,21 @@ func (mr *MockVerifyEmailRepositoryMockRecorder) CreateVerifyEmail(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVerifyEmail", reflect.TypeOf((*MockVerifyEmailRepository)(nil).CreateVerifyEmail), arg0, arg1)
 }
 
+// GetVerifyEmail mocks base method.
+func (m *MockVerifyEmailRepository) GetVerifyEmail(arg0 context.Context, arg1 int64) (*domain.VerifyEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerifyEmail", arg0, arg1)
+	ret0, _ := ret[0].(*domain.VerifyEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerifyEmail indicates an expected call of GetVerifyEmail.
+func (mr *MockVerifyEmailRepositoryMockRecorder) GetVerifyEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerifyEmail", reflect.TypeOf((*MockVerifyEmailRepository)(nil).GetVerifyEmail), arg0, arg1)
+}
+
 // UpdateVerifyEmail mocks base method.
 func (m *MockVerifyEmailRepository) UpdateVerifyEmail(arg0 context.Context, arg1 infra.UpdateVerifyEmail) (*domain.VerifyEmail, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application (interfaces: WebauthnRepository)
+
+// Package application is a generated GoMock package.
+package application
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	infra "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+)
+
+// MockWebauthnRepository is a mock of WebauthnRepository interface.
+type MockWebauthnRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebauthnRepositoryMockRecorder
+}
+
+// MockWebauthnRepositoryMockRecorder is the mock recorder for MockWebauthnRepository.
+type MockWebauthnRepositoryMockRecorder struct {
+	mock *MockWebauthnRepository
+}
+
+// NewMockWebauthnRepository creates a new mock instance.
+func NewMockWebauthnRepository(ctrl *gomock.Controller) *MockWebauthnRepository {
+	mock := &MockWebauthnRepository{ctrl: ctrl}
+	mock.recorder = &MockWebauthnRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebauthnRepository) EXPECT() *MockWebauthnRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConsumeWebauthnSession mocks base method.
+func (m *MockWebauthnRepository) ConsumeWebauthnSession(arg0 context.Context, arg1 infra.ConsumeWebauthnSession) (*domain.WebauthnSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeWebauthnSession", arg0, arg1)
+	ret0, _ := ret[0].(*domain.WebauthnSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeWebauthnSession indicates an expected call of ConsumeWebauthnSession.
+func (mr *MockWebauthnRepositoryMockRecorder) ConsumeWebauthnSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeWebauthnSession", reflect.TypeOf((*MockWebauthnRepository)(nil).ConsumeWebauthnSession), arg0, arg1)
+}
+
+// CreateWebauthnCredential mocks base method.
+func (m *MockWebauthnRepository) CreateWebauthnCredential(arg0 context.Context, arg1 infra.CreateWebauthnCredential) (*domain.WebauthnCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebauthnCredential", arg0, arg1)
+	ret0, _ := ret[0].(*domain.WebauthnCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebauthnCredential indicates an expected call of CreateWebauthnCredential.
+func (mr *MockWebauthnRepositoryMockRecorder) CreateWebauthnCredential(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebauthnCredential", reflect.TypeOf((*MockWebauthnRepository)(nil).CreateWebauthnCredential), arg0, arg1)
+}
+
+// CreateWebauthnSession mocks base method.
+func (m *MockWebauthnRepository) CreateWebauthnSession(arg0 context.Context, arg1 infra.CreateWebauthnSession) (*domain.WebauthnSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebauthnSession", arg0, arg1)
+	ret0, _ := ret[0].(*domain.WebauthnSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebauthnSession indicates an expected call of CreateWebauthnSession.
+func (mr *MockWebauthnRepositoryMockRecorder) CreateWebauthnSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebauthnSession", reflect.TypeOf((*MockWebauthnRepository)(nil).CreateWebauthnSession), arg0, arg1)
+}
+
+// CreateWebauthnUserHandle mocks base method.
+func (m *MockWebauthnRepository) CreateWebauthnUserHandle(arg0 context.Context, arg1 infra.CreateWebauthnUserHandle) (*domain.WebauthnUserHandle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebauthnUserHandle", arg0, arg1)
+	ret0, _ := ret[0].(*domain.WebauthnUserHandle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebauthnUserHandle indicates an expected call of CreateWebauthnUserHandle.
+func (mr *MockWebauthnRepositoryMockRecorder) CreateWebauthnUserHandle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebauthnUserHandle", reflect.TypeOf((*MockWebauthnRepository)(nil).CreateWebauthnUserHandle), arg0, arg1)
+}
+
+// FlagWebauthnCloneWarning mocks base method.
+func (m *MockWebauthnRepository) FlagWebauthnCloneWarning(arg0 context.Context, arg1 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlagWebauthnCloneWarning", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FlagWebauthnCloneWarning indicates an expected call of FlagWebauthnCloneWarning.
+func (mr *MockWebauthnRepositoryMockRecorder) FlagWebauthnCloneWarning(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlagWebauthnCloneWarning", reflect.TypeOf((*MockWebauthnRepository)(nil).FlagWebauthnCloneWarning), arg0, arg1)
+}
+
+// GetWebauthnUserHandle mocks base method.
+func (m *MockWebauthnRepository) GetWebauthnUserHandle(arg0 context.Context, arg1 []byte) (*domain.WebauthnUserHandle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebauthnUserHandle", arg0, arg1)
+	ret0, _ := ret[0].(*domain.WebauthnUserHandle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebauthnUserHandle indicates an expected call of GetWebauthnUserHandle.
+func (mr *MockWebauthnRepositoryMockRecorder) GetWebauthnUserHandle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebauthnUserHandle", reflect.TypeOf((*MockWebauthnRepository)(nil).GetWebauthnUserHandle), arg0, arg1)
+}
+
+// ListWebauthnCredentials mocks base method.
+func (m *MockWebauthnRepository) ListWebauthnCredentials(arg0 context.Context, arg1 string) ([]*domain.WebauthnCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebauthnCredentials", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.WebauthnCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebauthnCredentials indicates an expected call of ListWebauthnCredentials.
+func (mr *MockWebauthnRepositoryMockRecorder) ListWebauthnCredentials(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebauthnCredentials", reflect.TypeOf((*MockWebauthnRepository)(nil).ListWebauthnCredentials), arg0, arg1)
+}
+
+// UseWebauthnCredential mocks base method.
+func (m *MockWebauthnRepository) UseWebauthnCredential(arg0 context.Context, arg1 infra.UseWebauthnCredential) (*domain.WebauthnCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseWebauthnCredential", arg0, arg1)
+	ret0, _ := ret[0].(*domain.WebauthnCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseWebauthnCredential indicates an expected call of UseWebauthnCredential.
+func (mr *MockWebauthnRepositoryMockRecorder) UseWebauthnCredential(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseWebauthnCredential", reflect.TypeOf((*MockWebauthnRepository)(nil).UseWebauthnCredential), arg0, arg1)
+}
@@ -0,0 +1,70 @@
+package application
+
+import (
+	"context"
+
+	"github.com/hibiken/asynq"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/worker"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/rs/zerolog/log"
+)
+
+// isNewLoginDevice reports whether the user never logged in before with the
+// user agent or from the client IP of the request. The first login of a user
+// is not new, there is no known device to compare it with.
+func (u *UserApplication) isNewLoginDevice(ctx context.Context, username string, metadata *util.Metadata) bool {
+	if !u.config.NewDeviceAlertEnabled {
+		return false
+	}
+
+	devices, err := u.sessionRespository.ListSessionDevices(ctx, username)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("failed to list session devices")
+		return false
+	}
+
+	if len(devices) == 0 {
+		return false
+	}
+
+	// client IPs of sessions may carry the port of the peer address
+	clientIP := loginClientIP(metadata.ClientIP)
+	knownUserAgent, knownClientIP := false, false
+	for _, device := range devices {
+		knownUserAgent = knownUserAgent || device.UserAgent == metadata.UserAgent
+		knownClientIP = knownClientIP || loginClientIP(device.ClientIp) == clientIP
+	}
+
+	return !knownUserAgent || !knownClientIP
+}
+
+// alertNewDeviceLogin sends the user an email about the session, with a link
+// that revokes it. A failed alert is logged, the login goes on.
+func (u *UserApplication) alertNewDeviceLogin(ctx context.Context, user *domain.User, session *domain.Session) {
+	revokeToken, _, err := u.tokenMaker.CreateToken(user.Username, user.Role, u.config.RefreshTokenDuration, u.tokenOptions(token.WithPurpose(token.PurposeRevokeSession), token.WithSessionID(session.FamilyID))...)
+	if err != nil {
+		log.Error().Err(err).Str("username", user.Username).Msg("failed to create revoke session token")
+		return
+	}
+
+	payload := &worker.PayloadSendNewDeviceLogin{
+		Username:    user.Username,
+		SessionID:   session.ID,
+		UserAgent:   session.UserAgent,
+		ClientIP:    loginClientIP(session.ClientIp),
+		LoggedInAt:  session.CreatedAt,
+		RevokeToken: revokeToken,
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.DefaultQueue),
+	}
+
+	err = u.taskDistributor.DistributeTaskSendNewDeviceLogin(ctx, payload, opts...)
+	if err != nil {
+		log.Error().Err(err).Str("username", user.Username).Msg("failed to distribute new device login task")
+	}
+}
@@ -0,0 +1,311 @@
+package application
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mock "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application/mock"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/worker"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestLoginAlertsNewDevice(t *testing.T) {
+	user, password := randomUser(t)
+	clientIP := "203.0.113.7"
+	userAgent := "test-agent"
+
+	session := randomSession(t, user.Username)
+	session.UserAgent = userAgent
+	session.ClientIp = clientIP + ":52100"
+	session.CreatedAt = time.Now()
+
+	testCases := []struct {
+		name    string
+		devices []*domain.SessionDevice
+		alerts  int
+	}{
+		{
+			name:    "NewUserAgent",
+			devices: []*domain.SessionDevice{{UserAgent: "other-agent", ClientIp: clientIP}},
+			alerts:  1,
+		},
+		{
+			name:    "NewClientIP",
+			devices: []*domain.SessionDevice{{UserAgent: userAgent, ClientIp: "198.51.100.4:41000"}},
+			alerts:  1,
+		},
+		{
+			name: "KnownDevice",
+			devices: []*domain.SessionDevice{
+				{UserAgent: "other-agent", ClientIp: "198.51.100.4"},
+				{UserAgent: userAgent, ClientIp: clientIP + ":40000"},
+			},
+		},
+		{
+			name: "FirstLogin",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			sessionRepository := mock.NewMockSessionRepository(ctrl)
+			taskDistributor := mock.NewMockTaskDistributor(ctrl)
+
+			userRepository.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+
+			sessionRepository.EXPECT().
+				ListSessionDevices(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(tc.devices, nil)
+
+			sessionRepository.EXPECT().
+				CreateSession(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(session, nil)
+
+			tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+			require.NoError(t, err)
+
+			taskDistributor.EXPECT().
+				DistributeTaskSendNewDeviceLogin(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(tc.alerts).
+				DoAndReturn(func(ctx context.Context, payload *worker.PayloadSendNewDeviceLogin, opts ...any) error {
+					require.Equal(t, user.Username, payload.Username)
+					require.Equal(t, session.ID, payload.SessionID)
+					require.Equal(t, userAgent, payload.UserAgent)
+					require.Equal(t, clientIP, payload.ClientIP)
+					require.Equal(t, session.CreatedAt, payload.LoggedInAt)
+
+					revokePayload, err := tokenMaker.VerifyToken(payload.RevokeToken, token.RequirePurpose(token.PurposeRevokeSession))
+					require.NoError(t, err)
+					require.Equal(t, user.Username, revokePayload.Username)
+					require.Equal(t, session.FamilyID, revokePayload.SessionID)
+					return nil
+				})
+
+			config := util.Config{
+				AccessTokenDuration:   time.Minute,
+				RefreshTokenDuration:  time.Minute,
+				NewDeviceAlertEnabled: true,
+				TrustedProxies:        []string{testGatewayIP},
+			}
+
+			userApplication := NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, discardAuthEvents{}, nil, taskDistributor, tokenMaker, nil, &config)
+
+			result, err := userApplication.Login(newGatewayContext(clientIP, userAgent), LoginUser{Username: user.Username, Password: password})
+			require.NoError(t, err)
+			require.Equal(t, session.ID, result.SessionId)
+		})
+	}
+}
+
+// TestLoginAlertsNewDeviceDirectClient logs in without a trusted proxy, so the
+// client IP is the peer address, port included, and x-forwarded-for is ignored.
+func TestLoginAlertsNewDeviceDirectClient(t *testing.T) {
+	user, password := randomUser(t)
+	clientIP := "203.0.113.7"
+	userAgent := "test-agent"
+
+	testCases := []struct {
+		name         string
+		forwardedFor string
+		devices      []*domain.SessionDevice
+		alerts       int
+	}{
+		{
+			name:    "KnownDevice",
+			devices: []*domain.SessionDevice{{UserAgent: userAgent, ClientIp: clientIP + ":40000"}},
+		},
+		{
+			name:    "NewClientIP",
+			devices: []*domain.SessionDevice{{UserAgent: userAgent, ClientIp: "198.51.100.4:41000"}},
+			alerts:  1,
+		},
+		{
+			name:         "SpoofedForwardedFor",
+			forwardedFor: "198.51.100.4",
+			devices:      []*domain.SessionDevice{{UserAgent: userAgent, ClientIp: "198.51.100.4:41000"}},
+			alerts:       1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			sessionRepository := mock.NewMockSessionRepository(ctrl)
+			taskDistributor := mock.NewMockTaskDistributor(ctrl)
+
+			userRepository.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+
+			sessionRepository.EXPECT().
+				ListSessionDevices(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(tc.devices, nil)
+
+			var session *domain.Session
+			sessionRepository.EXPECT().
+				CreateSession(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ context.Context, arg infra.CreateSession) (*domain.Session, error) {
+					session = randomSession(t, user.Username)
+					session.UserAgent = arg.UserAgent
+					session.ClientIp = arg.ClientIp
+					return session, nil
+				})
+
+			taskDistributor.EXPECT().
+				DistributeTaskSendNewDeviceLogin(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(tc.alerts).
+				DoAndReturn(func(ctx context.Context, payload *worker.PayloadSendNewDeviceLogin, opts ...any) error {
+					require.Equal(t, clientIP, payload.ClientIP)
+					return nil
+				})
+
+			tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+			require.NoError(t, err)
+
+			config := util.Config{
+				AccessTokenDuration:   time.Minute,
+				RefreshTokenDuration:  time.Minute,
+				NewDeviceAlertEnabled: true,
+				TrustedProxies:        []string{testGatewayIP},
+			}
+
+			userApplication := NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, discardAuthEvents{}, nil, taskDistributor, tokenMaker, nil, &config)
+
+			md := metadata.Pairs("user-agent", userAgent)
+			if tc.forwardedFor != "" {
+				md.Append("x-forwarded-for", tc.forwardedFor)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(clientIP), Port: 52100}})
+
+			_, err = userApplication.Login(ctx, LoginUser{Username: user.Username, Password: password})
+			require.NoError(t, err)
+			require.Equal(t, clientIP+":52100", session.ClientIp)
+		})
+	}
+}
+
+func TestRevokeNewDeviceSessionUseCase(t *testing.T) {
+	user, _ := randomUser(t)
+	session := randomSession(t, user.Username)
+	config := util.Config{AccessTokenDuration: time.Minute}
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	revokeToken, _, err := tokenMaker.CreateToken(user.Username, user.Role, time.Minute, token.WithPurpose(token.PurposeRevokeSession), token.WithSessionID(session.FamilyID))
+	require.NoError(t, err)
+
+	otherToken, _, err := tokenMaker.CreateToken(util.RandomUsername(), user.Role, time.Minute, token.WithPurpose(token.PurposeRevokeSession), token.WithSessionID(session.FamilyID))
+	require.NoError(t, err)
+
+	accessToken, _, err := tokenMaker.CreateToken(user.Username, user.Role, time.Minute, token.WithSessionID(session.FamilyID))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		arg           RevokeNewDeviceSession
+		buildMocks    func(sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist)
+		checkResponse func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			arg:  RevokeNewDeviceSession{Token: revokeToken},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.FamilyID)).
+					Times(1).
+					Return(session, nil)
+
+				sessionRepository.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Eq(session.FamilyID)).
+					Times(1).
+					Return(nil)
+
+				denylist.EXPECT().
+					RevokeSession(gomock.Any(), gomock.Eq(session.FamilyID), gomock.Eq(config.AccessTokenDuration)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "RequiredToken",
+			arg:  RevokeNewDeviceSession{},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.Error(t, err)
+			},
+		},
+		{
+			name: "AccessToken",
+			arg:  RevokeNewDeviceSession{Token: accessToken},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidRevokeToken)
+			},
+		},
+		{
+			name: "SessionOfAnotherUser",
+			arg:  RevokeNewDeviceSession{Token: otherToken},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.FamilyID)).
+					Times(1).
+					Return(session, nil)
+
+				sessionRepository.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, domain.ErrSessionNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			sessionRepository := mock.NewMockSessionRepository(ctrl)
+			denylist := mock.NewMockDenylist(ctrl)
+
+			tc.buildMocks(sessionRepository, denylist)
+
+			userApplication := NewUserApplication(nil, sessionRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, tokenMaker, denylist, &config)
+
+			err := userApplication.RevokeNewDeviceSession(context.Background(), tc.arg)
+			tc.checkResponse(t, err)
+		})
+	}
+}
@@ -0,0 +1,536 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+)
+
+type OauthRepository interface {
+	CreateOauthClient(ctx context.Context, arg infra.CreateOauthClient) (*domain.OauthClient, error)
+	GetOauthClient(ctx context.Context, id string) (*domain.OauthClient, error)
+	CreateOauthAuthorizationCode(ctx context.Context, arg infra.CreateOauthAuthorizationCode) (*domain.OauthAuthorizationCode, error)
+	ConsumeOauthAuthorizationCode(ctx context.Context, arg infra.ConsumeOauthAuthorizationCode) (*domain.OauthAuthorizationCode, error)
+}
+
+// ClaimsSigner signs id tokens with a key published in the JWKS.
+type ClaimsSigner interface {
+	SignClaims(claims jwt.Claims) (string, error)
+}
+
+// Scopes an OpenID Connect client can be registered for.
+const (
+	ScopeOpenID        = "openid"
+	ScopeProfile       = "profile"
+	ScopeEmail         = "email"
+	ScopeOfflineAccess = "offline_access"
+)
+
+var SupportedScopes = []string{ScopeOpenID, ScopeProfile, ScopeEmail, ScopeOfflineAccess}
+
+// Grant types accepted by the token endpoint.
+const (
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
+)
+
+const (
+	responseTypeCode        = "code"
+	codeChallengeMethodS256 = "S256"
+)
+
+// OidcApplication lets other applications delegate login to this service
+// through the OpenID Connect authorization code flow. Credentials are checked
+// by the UserApplication, and refresh tokens are sessions bound to the client.
+type OidcApplication struct {
+	userApplication *UserApplication
+	oauthRepository OauthRepository
+	signer          ClaimsSigner
+	config          *util.Config
+}
+
+func NewOidcApplication(userApplication *UserApplication, oauthRepository OauthRepository, signer ClaimsSigner, config *util.Config) *OidcApplication {
+	return &OidcApplication{
+		userApplication: userApplication,
+		oauthRepository: oauthRepository,
+		signer:          signer,
+		config:          config,
+	}
+}
+
+type CreateOauthClient struct {
+	Name         string   `json:"name"`
+	RedirectURIs []string `json:"redirect_uris"`
+	Scopes       []string `json:"scopes"`
+	Public       bool     `json:"public"`
+}
+
+type CreateOauthClientResult struct {
+	Client *domain.OauthClient `json:"client"`
+	// ClientSecret is only returned here, the client keeps its hash.
+	ClientSecret string `json:"client_secret"`
+}
+
+// CreateOauthClient registers an application allowed to login users through
+// OpenID Connect. Public clients get no secret and must use PKCE.
+func (o *OidcApplication) CreateOauthClient(ctx context.Context, arg CreateOauthClient) (*CreateOauthClientResult, error) {
+	if errValidation := validateCreateOauthClientParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	var clientSecret string
+	var hashedSecret *string
+	if !arg.Public {
+		var err error
+		clientSecret, err = randomURLToken()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client secret: %w", err)
+		}
+
+		hashed := hashOauthSecret(clientSecret)
+		hashedSecret = &hashed
+	}
+
+	client, err := o.oauthRepository.CreateOauthClient(ctx, infra.CreateOauthClient{
+		ID:           uuid.NewString(),
+		HashedSecret: hashedSecret,
+		Name:         arg.Name,
+		RedirectURIs: arg.RedirectURIs,
+		Scopes:       arg.Scopes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oauth client: %w", err)
+	}
+
+	return &CreateOauthClientResult{
+		Client:       client,
+		ClientSecret: clientSecret,
+	}, nil
+}
+
+type AuthorizationRequest struct {
+	ResponseType        string `json:"response_type"`
+	ClientID            string `json:"client_id"`
+	RedirectURI         string `json:"redirect_uri"`
+	Scope               string `json:"scope"`
+	State               string `json:"state"`
+	Nonce               string `json:"nonce"`
+	CodeChallenge       string `json:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method"`
+}
+
+// ValidateAuthorizationRequest checks a request of the authorize endpoint
+// before the user is asked to login. ErrInvalidOauthClient and
+// ErrInvalidOauthRedirectURI must be shown to the user, while an OauthError
+// can be sent back to the redirect_uri.
+func (o *OidcApplication) ValidateAuthorizationRequest(ctx context.Context, arg AuthorizationRequest) (*domain.OauthClient, error) {
+	client, err := o.oauthRepository.GetOauthClient(ctx, arg.ClientID)
+	if err != nil {
+		if errors.Is(err, domain.ErrOauthClientNotFound) {
+			return nil, ErrInvalidOauthClient
+		}
+		return nil, fmt.Errorf("failed to get oauth client: %w", err)
+	}
+
+	if !slices.Contains(client.RedirectURIs, arg.RedirectURI) {
+		return nil, ErrInvalidOauthRedirectURI
+	}
+
+	if arg.ResponseType != responseTypeCode {
+		return nil, &OauthError{Code: OauthUnsupportedResponseType, Description: "only the code response type is supported"}
+	}
+
+	scopes := strings.Fields(arg.Scope)
+	if !slices.Contains(scopes, ScopeOpenID) {
+		return nil, &OauthError{Code: OauthInvalidScope, Description: "the openid scope is required"}
+	}
+
+	for _, scope := range scopes {
+		if !slices.Contains(client.Scopes, scope) {
+			return nil, &OauthError{Code: OauthInvalidScope, Description: fmt.Sprintf("scope %q is not allowed for this client", scope)}
+		}
+	}
+
+	if arg.CodeChallenge == "" && client.IsPublic() {
+		return nil, &OauthError{Code: OauthInvalidRequest, Description: "public clients must use PKCE"}
+	}
+
+	if arg.CodeChallenge != "" && arg.CodeChallengeMethod != codeChallengeMethodS256 {
+		return nil, &OauthError{Code: OauthInvalidRequest, Description: "code_challenge_method must be S256"}
+	}
+
+	return client, nil
+}
+
+type Authorize struct {
+	AuthorizationRequest
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// MfaToken and Code finish a login that returned MfaRequired.
+	MfaToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+}
+
+type AuthorizeResult struct {
+	// RedirectURL sends the user back to the client with the authorization
+	// code. It is empty when MfaRequired is set.
+	RedirectURL string `json:"redirect_url"`
+	MfaRequired bool   `json:"mfa_required"`
+	MfaToken    string `json:"mfa_token"`
+}
+
+// Authorize logs in the user of an authorization request like Login and
+// VerifyLoginTotp do, then issues the authorization code the client exchanges
+// at the token endpoint.
+func (o *OidcApplication) Authorize(ctx context.Context, arg Authorize) (*AuthorizeResult, error) {
+	client, err := o.ValidateAuthorizationRequest(ctx, arg.AuthorizationRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *domain.User
+	if arg.MfaToken != "" {
+		user, err = o.userApplication.authenticateTotp(ctx, VerifyLoginTotp{MfaToken: arg.MfaToken, Code: arg.Code})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		user, err = o.userApplication.authenticate(ctx, LoginUser{Username: arg.Username, Password: arg.Password})
+		if err != nil {
+			return nil, err
+		}
+
+		if user.IsTotpEnabled {
+			challenge, err := o.userApplication.createMfaChallenge(ctx, user)
+			if err != nil {
+				return nil, err
+			}
+
+			return &AuthorizeResult{MfaRequired: true, MfaToken: challenge.MfaToken}, nil
+		}
+
+		err = o.userApplication.checkEmailVerified(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	code, err := randomURLToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorization code: %w", err)
+	}
+
+	_, err = o.oauthRepository.CreateOauthAuthorizationCode(ctx, infra.CreateOauthAuthorizationCode{
+		CodeHash:      hashOauthSecret(code),
+		ClientID:      client.ID,
+		Username:      user.Username,
+		RedirectURI:   arg.RedirectURI,
+		Scopes:        strings.Fields(arg.Scope),
+		Nonce:         arg.Nonce,
+		CodeChallenge: arg.CodeChallenge,
+		AuthTime:      time.Now(),
+		ExpiresAt:     time.Now().Add(o.config.OidcAuthorizationCodeDuration),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorization code: %w", err)
+	}
+
+	redirectURL, err := url.Parse(arg.RedirectURI)
+	if err != nil {
+		return nil, ErrInvalidOauthRedirectURI
+	}
+
+	query := redirectURL.Query()
+	query.Set("code", code)
+	if arg.State != "" {
+		query.Set("state", arg.State)
+	}
+	redirectURL.RawQuery = query.Encode()
+
+	return &AuthorizeResult{RedirectURL: redirectURL.String()}, nil
+}
+
+type TokenRequest struct {
+	GrantType    string `json:"grant_type"`
+	Code         string `json:"code"`
+	RedirectURI  string `json:"redirect_uri"`
+	CodeVerifier string `json:"code_verifier"`
+	RefreshToken string `json:"refresh_token"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+type TokenResult struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// Token implements the token endpoint for the authorization_code and
+// refresh_token grants. Refresh tokens are only issued for the offline_access
+// scope and can only be renewed by the client they were issued to.
+func (o *OidcApplication) Token(ctx context.Context, arg TokenRequest) (*TokenResult, error) {
+	client, err := o.authenticateClient(ctx, arg.ClientID, arg.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	switch arg.GrantType {
+	case GrantTypeAuthorizationCode:
+		return o.exchangeAuthorizationCode(ctx, client, arg)
+	case GrantTypeRefreshToken:
+		return o.refreshToken(ctx, client, arg)
+	}
+
+	return nil, &OauthError{Code: OauthUnsupportedGrantType, Description: "only the authorization_code and refresh_token grants are supported"}
+}
+
+func (o *OidcApplication) authenticateClient(ctx context.Context, clientID, clientSecret string) (*domain.OauthClient, error) {
+	invalidClient := &OauthError{Code: OauthInvalidClient, Description: "client authentication failed"}
+
+	if clientID == "" {
+		return nil, invalidClient
+	}
+
+	client, err := o.oauthRepository.GetOauthClient(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, domain.ErrOauthClientNotFound) {
+			return nil, invalidClient
+		}
+		return nil, fmt.Errorf("failed to get oauth client: %w", err)
+	}
+
+	if client.IsPublic() {
+		return client, nil
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashOauthSecret(clientSecret)), []byte(*client.HashedSecret)) != 1 {
+		return nil, invalidClient
+	}
+
+	return client, nil
+}
+
+func (o *OidcApplication) exchangeAuthorizationCode(ctx context.Context, client *domain.OauthClient, arg TokenRequest) (*TokenResult, error) {
+	invalidGrant := &OauthError{Code: OauthInvalidGrant, Description: "invalid or expired authorization code"}
+
+	if arg.Code == "" {
+		return nil, invalidGrant
+	}
+
+	code, err := o.oauthRepository.ConsumeOauthAuthorizationCode(ctx, infra.ConsumeOauthAuthorizationCode{
+		CodeHash: hashOauthSecret(arg.Code),
+		ClientID: client.ID,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrOauthAuthorizationCodeNotFound) {
+			return nil, invalidGrant
+		}
+		return nil, fmt.Errorf("failed to get authorization code: %w", err)
+	}
+
+	if code.RedirectURI != arg.RedirectURI {
+		return nil, &OauthError{Code: OauthInvalidGrant, Description: "redirect_uri does not match the authorization request"}
+	}
+
+	if code.CodeChallenge != "" && !verifyCodeChallenge(code.CodeChallenge, arg.CodeVerifier) {
+		return nil, &OauthError{Code: OauthInvalidGrant, Description: "invalid code_verifier"}
+	}
+
+	user, err := o.userApplication.userRepository.GetUser(ctx, code.Username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	session, err := o.userApplication.createClientSession(ctx, user, &client.ID, code.Scopes)
+	if err != nil {
+		if errors.Is(err, ErrAccountDeleted) || errors.Is(err, ErrAccountDisabled) || errors.Is(err, ErrPasswordResetRequired) {
+			return nil, &OauthError{Code: OauthInvalidGrant, Description: err.Error()}
+		}
+		return nil, err
+	}
+
+	idToken, err := o.signIDToken(user, client.ID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TokenResult{
+		AccessToken: session.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(time.Until(session.AccessTokenExpiresAt).Seconds()),
+		IDToken:     idToken,
+		Scope:       strings.Join(code.Scopes, " "),
+	}
+
+	if slices.Contains(code.Scopes, ScopeOfflineAccess) {
+		result.RefreshToken = session.RefreshToken
+	}
+
+	return result, nil
+}
+
+func (o *OidcApplication) refreshToken(ctx context.Context, client *domain.OauthClient, arg TokenRequest) (*TokenResult, error) {
+	renewed, err := o.userApplication.RenewAccessToken(ctx, RenewAccessToken{
+		RefreshToken: arg.RefreshToken,
+		ClientID:     &client.ID,
+	})
+	if err != nil {
+		var validationErrors validation.Errors
+		switch {
+		case errors.As(err, &validationErrors),
+			errors.Is(err, ErrInvalidRefreshToken),
+			errors.Is(err, ErrIncorrectSessionUser),
+			errors.Is(err, ErrIncorrectSessionClient),
+			errors.Is(err, ErrMismatchedSessionToken),
+			errors.Is(err, ErrExpiredSession),
+			errors.Is(err, ErrBlockedSession),
+			errors.Is(err, ErrRefreshTokenReused),
+			errors.Is(err, ErrEmailNotVerified),
+			errors.Is(err, domain.ErrSessionNotFound):
+			return nil, &OauthError{Code: OauthInvalidGrant, Description: err.Error()}
+		}
+		return nil, err
+	}
+
+	return &TokenResult{
+		AccessToken:  renewed.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(time.Until(renewed.AccessTokenExpiresAt).Seconds()),
+		RefreshToken: renewed.RefreshToken,
+	}, nil
+}
+
+// idTokenClaims are the claims of the id token, the profile and email claims
+// are only set for the matching scopes.
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	AuthTime          int64  `json:"auth_time"`
+	Nonce             string `json:"nonce,omitempty"`
+	Name              string `json:"name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     *bool  `json:"email_verified,omitempty"`
+}
+
+func (o *OidcApplication) signIDToken(user *domain.User, clientID string, code *domain.OauthAuthorizationCode) (string, error) {
+	now := time.Now()
+
+	claims := idTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    o.config.OidcIssuerURL,
+			Subject:   user.Username,
+			Audience:  jwt.ClaimStrings{clientID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(o.config.AccessTokenDuration)),
+		},
+		AuthTime: code.AuthTime.Unix(),
+		Nonce:    code.Nonce,
+	}
+
+	userInfo := newUserInfo(user, code.Scopes)
+	claims.Name = userInfo.Name
+	claims.PreferredUsername = userInfo.PreferredUsername
+	claims.Email = userInfo.Email
+	claims.EmailVerified = userInfo.EmailVerified
+
+	idToken, err := o.signer.SignClaims(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign id token: %w", err)
+	}
+
+	return idToken, nil
+}
+
+type UserInfo struct {
+	Subject           string `json:"sub"`
+	Name              string `json:"name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     *bool  `json:"email_verified,omitempty"`
+}
+
+// UserInfo returns the claims of the user an OpenID Connect access token was
+// issued for, limited to the scopes granted with the token. The client must
+// still exist, tokens of deleted clients are rejected.
+func (o *OidcApplication) UserInfo(ctx context.Context, accessToken string) (*UserInfo, error) {
+	invalidToken := &OauthError{Code: OauthInvalidToken, Description: "invalid access token"}
+
+	payload, err := o.userApplication.tokenMaker.VerifyToken(accessToken, append(o.userApplication.verifyOptions(), token.RequirePurpose(token.PurposeOidcAccess))...)
+	if err != nil || len(payload.Audience) == 0 {
+		return nil, invalidToken
+	}
+
+	_, err = o.oauthRepository.GetOauthClient(ctx, payload.Audience[0])
+	if err != nil {
+		if errors.Is(err, domain.ErrOauthClientNotFound) {
+			return nil, invalidToken
+		}
+		return nil, fmt.Errorf("failed to get oauth client: %w", err)
+	}
+
+	user, err := o.userApplication.userRepository.GetUser(ctx, payload.Username)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, invalidToken
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return newUserInfo(user, payload.Scopes), nil
+}
+
+func newUserInfo(user *domain.User, scopes []string) *UserInfo {
+	userInfo := &UserInfo{Subject: user.Username}
+
+	if slices.Contains(scopes, ScopeProfile) {
+		userInfo.Name = user.FullName
+		userInfo.PreferredUsername = user.Username
+	}
+
+	if slices.Contains(scopes, ScopeEmail) {
+		userInfo.Email = user.Email
+		userInfo.EmailVerified = &user.IsEmailVerified
+	}
+
+	return userInfo
+}
+
+// verifyCodeChallenge checks a PKCE code_verifier against the S256
+// code_challenge of the authorization request.
+func verifyCodeChallenge(challenge, verifier string) bool {
+	if verifier == "" {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+// hashOauthSecret hashes client secrets and authorization codes before they
+// are stored. Both are random, so a fast hash is enough.
+func hashOauthSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
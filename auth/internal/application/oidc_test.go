@@ -0,0 +1,684 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	mock "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application/mock"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/stretchr/testify/require"
+)
+
+const testRedirectURI = "https://app.example.com/callback"
+
+func newOidcConfig() *util.Config {
+	return &util.Config{
+		TokenIssuer:                   "auth-service",
+		AccessTokenDuration:           time.Minute,
+		RefreshTokenDuration:          time.Hour,
+		MfaChallengeDuration:          time.Minute,
+		OidcIssuerURL:                 "https://auth.example.com",
+		OidcAuthorizationCodeDuration: time.Minute,
+	}
+}
+
+func newOidcTokenMaker(t *testing.T) (*token.KeyringJwtToken, *rsa.PrivateKey) {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tokenMaker, err := token.NewAsymmetricJwtToken(util.RandomString(8), privateKey)
+	require.NoError(t, err)
+
+	return tokenMaker, privateKey
+}
+
+func randomOauthClient(t *testing.T, public bool) (*domain.OauthClient, string) {
+	t.Helper()
+
+	client := &domain.OauthClient{
+		ID:           uuid.NewString(),
+		Name:         "Example",
+		RedirectURIs: []string{testRedirectURI},
+		Scopes:       SupportedScopes,
+		CreatedAt:    time.Now(),
+	}
+
+	if public {
+		return client, ""
+	}
+
+	secret := util.RandomString(32)
+	hashedSecret := hashOauthSecret(secret)
+	client.HashedSecret = &hashedSecret
+
+	return client, secret
+}
+
+func testCodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func TestCreateOauthClientUseCase(t *testing.T) {
+	testCases := []struct {
+		name          string
+		arg           CreateOauthClient
+		buildMocks    func(oauthRepository *mock.MockOauthRepository)
+		checkResponse func(t *testing.T, result *CreateOauthClientResult, err error)
+	}{
+		{
+			name: "Confidential",
+			arg:  CreateOauthClient{Name: "Example", RedirectURIs: []string{testRedirectURI}, Scopes: []string{ScopeOpenID, ScopeEmail}},
+			buildMocks: func(oauthRepository *mock.MockOauthRepository) {
+				oauthRepository.EXPECT().
+					CreateOauthClient(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg infra.CreateOauthClient) (*domain.OauthClient, error) {
+						require.NotEmpty(t, arg.ID)
+						require.NotNil(t, arg.HashedSecret)
+						return &domain.OauthClient{ID: arg.ID, HashedSecret: arg.HashedSecret, Name: arg.Name, RedirectURIs: arg.RedirectURIs, Scopes: arg.Scopes}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, result *CreateOauthClientResult, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, result.ClientSecret)
+				require.Equal(t, hashOauthSecret(result.ClientSecret), *result.Client.HashedSecret)
+			},
+		},
+		{
+			name: "Public",
+			arg:  CreateOauthClient{Name: "Example", RedirectURIs: []string{testRedirectURI}, Scopes: []string{ScopeOpenID}, Public: true},
+			buildMocks: func(oauthRepository *mock.MockOauthRepository) {
+				oauthRepository.EXPECT().
+					CreateOauthClient(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg infra.CreateOauthClient) (*domain.OauthClient, error) {
+						require.Nil(t, arg.HashedSecret)
+						return &domain.OauthClient{ID: arg.ID, Name: arg.Name, RedirectURIs: arg.RedirectURIs, Scopes: arg.Scopes}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, result *CreateOauthClientResult, err error) {
+				require.NoError(t, err)
+				require.Empty(t, result.ClientSecret)
+				require.True(t, result.Client.IsPublic())
+			},
+		},
+		{
+			name: "MissingOpenIDScope",
+			arg:  CreateOauthClient{Name: "Example", RedirectURIs: []string{testRedirectURI}, Scopes: []string{ScopeEmail}},
+			buildMocks: func(oauthRepository *mock.MockOauthRepository) {
+				oauthRepository.EXPECT().CreateOauthClient(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, result *CreateOauthClientResult, err error) {
+				var validationErrors validation.Errors
+				require.ErrorAs(t, err, &validationErrors)
+				require.Contains(t, validationErrors, "scopes")
+			},
+		},
+		{
+			name: "UnknownScope",
+			arg:  CreateOauthClient{Name: "Example", RedirectURIs: []string{testRedirectURI}, Scopes: []string{ScopeOpenID, "admin"}},
+			buildMocks: func(oauthRepository *mock.MockOauthRepository) {
+				oauthRepository.EXPECT().CreateOauthClient(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, result *CreateOauthClientResult, err error) {
+				var validationErrors validation.Errors
+				require.ErrorAs(t, err, &validationErrors)
+				require.Contains(t, validationErrors, "scopes")
+			},
+		},
+		{
+			name: "InvalidRedirectURI",
+			arg:  CreateOauthClient{Name: "Example", RedirectURIs: []string{"not a url"}, Scopes: []string{ScopeOpenID}},
+			buildMocks: func(oauthRepository *mock.MockOauthRepository) {
+				oauthRepository.EXPECT().CreateOauthClient(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, result *CreateOauthClientResult, err error) {
+				var validationErrors validation.Errors
+				require.ErrorAs(t, err, &validationErrors)
+				require.Contains(t, validationErrors, "redirect_uris")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			oauthRepository := mock.NewMockOauthRepository(ctrl)
+			tc.buildMocks(oauthRepository)
+
+			oidcApplication := NewOidcApplication(nil, oauthRepository, nil, newOidcConfig())
+
+			result, err := oidcApplication.CreateOauthClient(context.Background(), tc.arg)
+			tc.checkResponse(t, result, err)
+		})
+	}
+}
+
+func TestValidateAuthorizationRequest(t *testing.T) {
+	confidential, _ := randomOauthClient(t, false)
+	public, _ := randomOauthClient(t, true)
+	public.Scopes = []string{ScopeOpenID}
+
+	request := func(client *domain.OauthClient) AuthorizationRequest {
+		return AuthorizationRequest{
+			ResponseType: responseTypeCode,
+			ClientID:     client.ID,
+			RedirectURI:  testRedirectURI,
+			Scope:        "openid email",
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		client  *domain.OauthClient
+		arg     func() AuthorizationRequest
+		wantErr error
+		code    string
+	}{
+		{
+			name:   "OK",
+			client: confidential,
+			arg:    func() AuthorizationRequest { return request(confidential) },
+		},
+		{
+			name:    "UnknownClient",
+			client:  nil,
+			arg:     func() AuthorizationRequest { return request(confidential) },
+			wantErr: ErrInvalidOauthClient,
+		},
+		{
+			name:   "UnregisteredRedirectURI",
+			client: confidential,
+			arg: func() AuthorizationRequest {
+				arg := request(confidential)
+				arg.RedirectURI = "https://evil.example.com/callback"
+				return arg
+			},
+			wantErr: ErrInvalidOauthRedirectURI,
+		},
+		{
+			name:   "UnsupportedResponseType",
+			client: confidential,
+			arg: func() AuthorizationRequest {
+				arg := request(confidential)
+				arg.ResponseType = "token"
+				return arg
+			},
+			code: OauthUnsupportedResponseType,
+		},
+		{
+			name:   "MissingOpenIDScope",
+			client: confidential,
+			arg: func() AuthorizationRequest {
+				arg := request(confidential)
+				arg.Scope = "email"
+				return arg
+			},
+			code: OauthInvalidScope,
+		},
+		{
+			name:   "ScopeNotAllowed",
+			client: public,
+			arg: func() AuthorizationRequest {
+				arg := request(public)
+				arg.CodeChallenge = testCodeChallenge("verifier")
+				arg.CodeChallengeMethod = codeChallengeMethodS256
+				return arg
+			},
+			code: OauthInvalidScope,
+		},
+		{
+			name:   "PublicClientWithoutPKCE",
+			client: public,
+			arg: func() AuthorizationRequest {
+				arg := request(public)
+				arg.Scope = ScopeOpenID
+				return arg
+			},
+			code: OauthInvalidRequest,
+		},
+		{
+			name:   "PlainCodeChallenge",
+			client: confidential,
+			arg: func() AuthorizationRequest {
+				arg := request(confidential)
+				arg.CodeChallenge = "verifier"
+				arg.CodeChallengeMethod = "plain"
+				return arg
+			},
+			code: OauthInvalidRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			oauthRepository := mock.NewMockOauthRepository(ctrl)
+
+			if tc.client == nil {
+				oauthRepository.EXPECT().GetOauthClient(gomock.Any(), gomock.Any()).Times(1).Return(nil, domain.ErrOauthClientNotFound)
+			} else {
+				oauthRepository.EXPECT().GetOauthClient(gomock.Any(), gomock.Eq(tc.client.ID)).Times(1).Return(tc.client, nil)
+			}
+
+			oidcApplication := NewOidcApplication(nil, oauthRepository, nil, newOidcConfig())
+
+			client, err := oidcApplication.ValidateAuthorizationRequest(context.Background(), tc.arg())
+			switch {
+			case tc.wantErr != nil:
+				require.ErrorIs(t, err, tc.wantErr)
+			case tc.code != "":
+				var oauthErr *OauthError
+				require.ErrorAs(t, err, &oauthErr)
+				require.Equal(t, tc.code, oauthErr.Code)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tc.client.ID, client.ID)
+			}
+		})
+	}
+}
+
+// oidcTestFlow holds the mocks of a full authorization code flow, the stored
+// authorization code and the sessions created for the client.
+type oidcTestFlow struct {
+	config            *util.Config
+	user              *domain.User
+	password          string
+	client            *domain.OauthClient
+	clientSecret      string
+	tokenMaker        *token.KeyringJwtToken
+	privateKey        *rsa.PrivateKey
+	userRepository    *mock.MockUserRepository
+	sessionRepository *mock.MockSessionRepository
+	oauthRepository   *mock.MockOauthRepository
+	oidcApplication   *OidcApplication
+	storedCode        *domain.OauthAuthorizationCode
+	sessions          map[uuid.UUID]*domain.Session
+}
+
+func newOidcTestFlow(t *testing.T, public bool) *oidcTestFlow {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	flow := &oidcTestFlow{
+		config:            newOidcConfig(),
+		userRepository:    mock.NewMockUserRepository(ctrl),
+		sessionRepository: mock.NewMockSessionRepository(ctrl),
+		oauthRepository:   mock.NewMockOauthRepository(ctrl),
+		sessions:          map[uuid.UUID]*domain.Session{},
+	}
+	flow.user, flow.password = randomUser(t)
+	flow.client, flow.clientSecret = randomOauthClient(t, public)
+	flow.tokenMaker, flow.privateKey = newOidcTokenMaker(t)
+
+	flow.userRepository.EXPECT().GetUser(gomock.Any(), gomock.Eq(flow.user.Username)).AnyTimes().Return(flow.user, nil)
+	flow.oauthRepository.EXPECT().GetOauthClient(gomock.Any(), gomock.Eq(flow.client.ID)).AnyTimes().Return(flow.client, nil)
+	flow.oauthRepository.EXPECT().GetOauthClient(gomock.Any(), gomock.Not(flow.client.ID)).AnyTimes().Return(nil, domain.ErrOauthClientNotFound)
+
+	flow.oauthRepository.EXPECT().
+		CreateOauthAuthorizationCode(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, arg infra.CreateOauthAuthorizationCode) (*domain.OauthAuthorizationCode, error) {
+			flow.storedCode = &domain.OauthAuthorizationCode{
+				CodeHash:      arg.CodeHash,
+				ClientID:      arg.ClientID,
+				Username:      arg.Username,
+				RedirectURI:   arg.RedirectURI,
+				Scopes:        arg.Scopes,
+				Nonce:         arg.Nonce,
+				CodeChallenge: arg.CodeChallenge,
+				AuthTime:      arg.AuthTime,
+				ExpiresAt:     arg.ExpiresAt,
+			}
+			return flow.storedCode, nil
+		})
+
+	flow.oauthRepository.EXPECT().
+		ConsumeOauthAuthorizationCode(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, arg infra.ConsumeOauthAuthorizationCode) (*domain.OauthAuthorizationCode, error) {
+			code := flow.storedCode
+			if code == nil || code.CodeHash != arg.CodeHash || code.ClientID != arg.ClientID {
+				return nil, domain.ErrOauthAuthorizationCodeNotFound
+			}
+			flow.storedCode = nil
+			return code, nil
+		})
+
+	flow.sessionRepository.EXPECT().
+		CreateSession(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, arg infra.CreateSession) (*domain.Session, error) {
+			session := &domain.Session{
+				ID:           arg.ID,
+				FamilyID:     arg.FamilyID,
+				Username:     arg.Username,
+				RefreshToken: arg.RefreshToken,
+				ExpiresAt:    arg.ExpiresAt,
+				ClientID:     arg.ClientID,
+			}
+			flow.sessions[session.ID] = session
+			return session, nil
+		})
+
+	flow.sessionRepository.EXPECT().
+		GetSession(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, id uuid.UUID) (*domain.Session, error) {
+			session, ok := flow.sessions[id]
+			if !ok {
+				return nil, domain.ErrSessionNotFound
+			}
+			return session, nil
+		})
+
+	flow.sessionRepository.EXPECT().
+		RotateSessionTx(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, arg infra.RotateSessionTx) (infra.RotateSessionTxResult, error) {
+			parent := flow.sessions[arg.ParentID]
+			rotatedAt := time.Now()
+			parent.RotatedAt = &rotatedAt
+
+			session := &domain.Session{
+				ID:           arg.Session.ID,
+				FamilyID:     parent.FamilyID,
+				Username:     arg.Session.Username,
+				RefreshToken: arg.Session.RefreshToken,
+				ExpiresAt:    arg.Session.ExpiresAt,
+				ClientID:     parent.ClientID,
+			}
+			flow.sessions[session.ID] = session
+			return infra.RotateSessionTxResult{Parent: parent, Session: session}, nil
+		})
+
+	userApplication := NewUserApplication(flow.userRepository, flow.sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, discardAuthEvents{}, nil, nil, flow.tokenMaker, nil, flow.config)
+	flow.oidcApplication = NewOidcApplication(userApplication, flow.oauthRepository, flow.tokenMaker, flow.config)
+
+	return flow
+}
+
+func (flow *oidcTestFlow) authorize(t *testing.T, scope, nonce, codeChallenge string) string {
+	t.Helper()
+
+	arg := Authorize{
+		AuthorizationRequest: AuthorizationRequest{
+			ResponseType: responseTypeCode,
+			ClientID:     flow.client.ID,
+			RedirectURI:  testRedirectURI,
+			Scope:        scope,
+			State:        "state",
+			Nonce:        nonce,
+		},
+		Username: flow.user.Username,
+		Password: flow.password,
+	}
+	if codeChallenge != "" {
+		arg.CodeChallenge = codeChallenge
+		arg.CodeChallengeMethod = codeChallengeMethodS256
+	}
+
+	result, err := flow.oidcApplication.Authorize(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, result.MfaRequired)
+
+	redirectURL, err := url.Parse(result.RedirectURL)
+	require.NoError(t, err)
+	require.Equal(t, "state", redirectURL.Query().Get("state"))
+
+	code := redirectURL.Query().Get("code")
+	require.NotEmpty(t, code)
+	require.Equal(t, hashOauthSecret(code), flow.storedCode.CodeHash)
+
+	return code
+}
+
+func TestOidcAuthorizationCodeFlow(t *testing.T) {
+	flow := newOidcTestFlow(t, false)
+
+	code := flow.authorize(t, "openid email offline_access", "nonce", "")
+
+	result, err := flow.oidcApplication.Token(context.Background(), TokenRequest{
+		GrantType:    GrantTypeAuthorizationCode,
+		Code:         code,
+		RedirectURI:  testRedirectURI,
+		ClientID:     flow.client.ID,
+		ClientSecret: flow.clientSecret,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Bearer", result.TokenType)
+	require.NotEmpty(t, result.AccessToken)
+	require.NotEmpty(t, result.RefreshToken)
+	require.Equal(t, "openid email offline_access", result.Scope)
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(result.IDToken, claims, func(*jwt.Token) (any, error) {
+		return flow.privateKey.Public(), nil
+	}, jwt.WithIssuer(flow.config.OidcIssuerURL), jwt.WithAudience(flow.client.ID))
+	require.NoError(t, err)
+	require.Equal(t, flow.user.Username, claims["sub"])
+	require.Equal(t, "nonce", claims["nonce"])
+	require.Equal(t, flow.user.Email, claims["email"])
+	require.NotContains(t, claims, "name")
+
+	// the access token is only meant for the client and the userinfo endpoint
+	_, err = flow.tokenMaker.VerifyToken(result.AccessToken)
+	require.ErrorIs(t, err, token.ErrInvalidToken)
+
+	userInfo, err := flow.oidcApplication.UserInfo(context.Background(), result.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, flow.user.Username, userInfo.Subject)
+	require.Equal(t, flow.user.Email, userInfo.Email)
+	// the client is registered for profile as well, but it was not granted
+	require.Empty(t, userInfo.Name)
+	require.Empty(t, userInfo.PreferredUsername)
+
+	// the code can only be exchanged once
+	_, err = flow.oidcApplication.Token(context.Background(), TokenRequest{
+		GrantType:    GrantTypeAuthorizationCode,
+		Code:         code,
+		RedirectURI:  testRedirectURI,
+		ClientID:     flow.client.ID,
+		ClientSecret: flow.clientSecret,
+	})
+	requireOauthError(t, err, OauthInvalidGrant)
+
+	refreshed, err := flow.oidcApplication.Token(context.Background(), TokenRequest{
+		GrantType:    GrantTypeRefreshToken,
+		RefreshToken: result.RefreshToken,
+		ClientID:     flow.client.ID,
+		ClientSecret: flow.clientSecret,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, refreshed.AccessToken)
+	require.NotEqual(t, result.RefreshToken, refreshed.RefreshToken)
+
+	// renewed access tokens keep the scopes granted with the code
+	userInfo, err = flow.oidcApplication.UserInfo(context.Background(), refreshed.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, flow.user.Email, userInfo.Email)
+	require.Empty(t, userInfo.Name)
+}
+
+func TestOidcAuthorizationCodeFlowPKCE(t *testing.T) {
+	flow := newOidcTestFlow(t, true)
+	verifier := util.RandomString(43)
+
+	code := flow.authorize(t, "openid", "", testCodeChallenge(verifier))
+
+	_, err := flow.oidcApplication.Token(context.Background(), TokenRequest{
+		GrantType:    GrantTypeAuthorizationCode,
+		Code:         code,
+		RedirectURI:  testRedirectURI,
+		CodeVerifier: util.RandomString(43),
+		ClientID:     flow.client.ID,
+	})
+	requireOauthError(t, err, OauthInvalidGrant)
+
+	code = flow.authorize(t, "openid", "", testCodeChallenge(verifier))
+
+	result, err := flow.oidcApplication.Token(context.Background(), TokenRequest{
+		GrantType:    GrantTypeAuthorizationCode,
+		Code:         code,
+		RedirectURI:  testRedirectURI,
+		CodeVerifier: verifier,
+		ClientID:     flow.client.ID,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.IDToken)
+	// offline_access was not requested
+	require.Empty(t, result.RefreshToken)
+}
+
+func TestOidcToken(t *testing.T) {
+	testCases := []struct {
+		name    string
+		request func(flow *oidcTestFlow, code string) TokenRequest
+		errCode string
+	}{
+		{
+			name: "WrongClientSecret",
+			request: func(flow *oidcTestFlow, code string) TokenRequest {
+				return TokenRequest{GrantType: GrantTypeAuthorizationCode, Code: code, RedirectURI: testRedirectURI, ClientID: flow.client.ID, ClientSecret: "wrong"}
+			},
+			errCode: OauthInvalidClient,
+		},
+		{
+			name: "UnknownClient",
+			request: func(flow *oidcTestFlow, code string) TokenRequest {
+				return TokenRequest{GrantType: GrantTypeAuthorizationCode, Code: code, RedirectURI: testRedirectURI, ClientID: uuid.NewString(), ClientSecret: flow.clientSecret}
+			},
+			errCode: OauthInvalidClient,
+		},
+		{
+			name: "RedirectURIMismatch",
+			request: func(flow *oidcTestFlow, code string) TokenRequest {
+				return TokenRequest{GrantType: GrantTypeAuthorizationCode, Code: code, RedirectURI: "https://app.example.com/other", ClientID: flow.client.ID, ClientSecret: flow.clientSecret}
+			},
+			errCode: OauthInvalidGrant,
+		},
+		{
+			name: "UnknownCode",
+			request: func(flow *oidcTestFlow, code string) TokenRequest {
+				return TokenRequest{GrantType: GrantTypeAuthorizationCode, Code: util.RandomString(43), RedirectURI: testRedirectURI, ClientID: flow.client.ID, ClientSecret: flow.clientSecret}
+			},
+			errCode: OauthInvalidGrant,
+		},
+		{
+			name: "UnsupportedGrantType",
+			request: func(flow *oidcTestFlow, code string) TokenRequest {
+				return TokenRequest{GrantType: "password", ClientID: flow.client.ID, ClientSecret: flow.clientSecret}
+			},
+			errCode: OauthUnsupportedGrantType,
+		},
+		{
+			name: "FirstPartyRefreshToken",
+			request: func(flow *oidcTestFlow, code string) TokenRequest {
+				login, err := flow.oidcApplication.userApplication.Login(context.Background(), LoginUser{Username: flow.user.Username, Password: flow.password})
+				require.NoError(t, err)
+				return TokenRequest{GrantType: GrantTypeRefreshToken, RefreshToken: login.RefreshToken, ClientID: flow.client.ID, ClientSecret: flow.clientSecret}
+			},
+			errCode: OauthInvalidGrant,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			flow := newOidcTestFlow(t, false)
+			code := flow.authorize(t, "openid", "", "")
+
+			_, err := flow.oidcApplication.Token(context.Background(), tc.request(flow, code))
+			requireOauthError(t, err, tc.errCode)
+		})
+	}
+}
+
+func TestOidcRefreshTokenOfOtherClient(t *testing.T) {
+	flow := newOidcTestFlow(t, false)
+	code := flow.authorize(t, "openid offline_access", "", "")
+
+	result, err := flow.oidcApplication.Token(context.Background(), TokenRequest{
+		GrantType:    GrantTypeAuthorizationCode,
+		Code:         code,
+		RedirectURI:  testRedirectURI,
+		ClientID:     flow.client.ID,
+		ClientSecret: flow.clientSecret,
+	})
+	require.NoError(t, err)
+
+	// the first party endpoint does not renew tokens issued to a client
+	_, err = flow.oidcApplication.userApplication.RenewAccessToken(context.Background(), RenewAccessToken{RefreshToken: result.RefreshToken})
+	require.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	otherClientID := uuid.NewString()
+	_, err = flow.oidcApplication.userApplication.RenewAccessToken(context.Background(), RenewAccessToken{RefreshToken: result.RefreshToken, ClientID: &otherClientID})
+	require.ErrorIs(t, err, ErrIncorrectSessionClient)
+}
+
+func TestOidcAuthorizeTotp(t *testing.T) {
+	flow := newOidcTestFlow(t, false)
+	flow.config.TotpIssuer = "Go Ecommerce"
+	flow.config.TotpEncryptionKey = util.RandomString(32)
+	flow.user.IsTotpEnabled = true
+
+	userTotp, secret := randomUserTotp(t, flow.config, flow.user.Username)
+
+	ctrl := gomock.NewController(t)
+	totpRepository := mock.NewMockTotpRepository(ctrl)
+	totpRepository.EXPECT().GetUserTotp(gomock.Any(), gomock.Eq(flow.user.Username)).Times(1).Return(userTotp, nil)
+	totpRepository.EXPECT().UseTotpCounter(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+	flow.oidcApplication.userApplication.totpRepository = totpRepository
+
+	request := AuthorizationRequest{
+		ResponseType: responseTypeCode,
+		ClientID:     flow.client.ID,
+		RedirectURI:  testRedirectURI,
+		Scope:        ScopeOpenID,
+	}
+
+	result, err := flow.oidcApplication.Authorize(context.Background(), Authorize{
+		AuthorizationRequest: request,
+		Username:             flow.user.Username,
+		Password:             flow.password,
+	})
+	require.NoError(t, err)
+	require.True(t, result.MfaRequired)
+	require.Empty(t, result.RedirectURL)
+	require.Nil(t, flow.storedCode)
+
+	result, err = flow.oidcApplication.Authorize(context.Background(), Authorize{
+		AuthorizationRequest: request,
+		MfaToken:             result.MfaToken,
+		Code:                 currentTotpCode(t, secret),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.RedirectURL)
+	require.NotNil(t, flow.storedCode)
+}
+
+func requireOauthError(t *testing.T, err error, code string) {
+	t.Helper()
+
+	var oauthErr *OauthError
+	require.True(t, errors.As(err, &oauthErr), "expected an OauthError, got %v", err)
+	require.Equal(t, code, oauthErr.Code)
+}
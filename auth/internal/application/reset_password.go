@@ -0,0 +1,97 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/worker"
+	"github.com/rs/zerolog/log"
+)
+
+type ResetPasswordRepository interface {
+	CreateResetPassword(ctx context.Context, arg infra.CreateResetPassword) (*domain.ResetPassword, error)
+	ResetPasswordTx(ctx context.Context, arg infra.ResetPasswordTx) (infra.ResetPasswordTxResult, error)
+}
+
+type RequestPasswordReset struct {
+	Email string `json:"email"`
+}
+
+// RequestPasswordReset emails a reset code to the owner of arg.Email. It
+// succeeds whether the account exists or not, so callers cannot use it to find
+// out which emails are registered.
+func (u *UserApplication) RequestPasswordReset(ctx context.Context, arg RequestPasswordReset) error {
+	if errValidation := validateRequestPasswordResetParams(arg); errValidation != nil {
+		return errValidation
+	}
+
+	user, err := u.userRepository.GetUserByEmail(ctx, arg.Email)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			log.Info().Msg("password reset requested for unknown email")
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	taskPayload := &worker.PayloadSendResetPassword{
+		Username: user.Username,
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.CriticalQueue),
+	}
+
+	err = u.taskDistributor.DistributeTaskSendResetPassword(ctx, taskPayload, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to distribute reset password task: %w", err)
+	}
+
+	return nil
+}
+
+type ResetPassword struct {
+	ResetID    int64  `json:"reset_id"`
+	SecretCode string `json:"secret_code"`
+	Password   string `json:"password"`
+}
+
+// ResetPassword sets a new password with a code sent by RequestPasswordReset
+// and revokes every session of the user, since one of them may belong to
+// whoever knew the old password.
+func (u *UserApplication) ResetPassword(ctx context.Context, arg ResetPassword) (*domain.User, error) {
+	if errValidation := validateResetPasswordParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	hashedPassword, err := util.HashPassword(arg.Password)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to hash password")
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	txResult, err := u.resetPasswordRepository.ResetPasswordTx(ctx, infra.ResetPasswordTx{
+		ResetPasswordID: arg.ResetID,
+		SecretCodeHash:  util.HashSecretCode(arg.SecretCode),
+		HashedPassword:  hashedPassword,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset password: %w", err)
+	}
+
+	u.recordAuthEvent(ctx, domain.AuthEventPasswordReset, txResult.User.Username, domain.AuthEventSuccess)
+
+	err = u.revokeUserSessions(ctx, txResult.User.Username, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return txResult.User, nil
+}
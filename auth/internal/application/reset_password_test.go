@@ -0,0 +1,210 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/golang/mock/gomock"
+	mock "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application/mock"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/worker"
+	"github.com/stretchr/testify/require"
+)
+
+func randomResetPassword(user *domain.User, secretCode string) *domain.ResetPassword {
+	return &domain.ResetPassword{
+		ID:             1,
+		Username:       user.Username,
+		Email:          user.Email,
+		SecretCodeHash: util.HashSecretCode(secretCode),
+		IsUsed:         true,
+		CreatedAt:      time.Now(),
+		ExpiredAt:      time.Now().Add(time.Minute),
+	}
+}
+
+func TestRequestPasswordResetUseCase(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		arg           RequestPasswordReset
+		buildMocks    func(userRepository *mock.MockUserRepository, taskDistributor *mock.MockTaskDistributor)
+		checkResponse func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			arg:  RequestPasswordReset{Email: user.Email},
+			buildMocks: func(userRepository *mock.MockUserRepository, taskDistributor *mock.MockTaskDistributor) {
+				userRepository.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendResetPassword(gomock.Any(), gomock.Eq(&worker.PayloadSendResetPassword{Username: user.Username}), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "UnknownEmail",
+			arg:  RequestPasswordReset{Email: util.RandomEmail()},
+			buildMocks: func(userRepository *mock.MockUserRepository, taskDistributor *mock.MockTaskDistributor) {
+				userRepository.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrUserNotFound)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendResetPassword(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "InvalidEmail",
+			arg:  RequestPasswordReset{Email: "invalid"},
+			buildMocks: func(userRepository *mock.MockUserRepository, taskDistributor *mock.MockTaskDistributor) {
+				userRepository.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				var valErr validation.Errors
+				require.ErrorAs(t, err, &valErr)
+				require.Contains(t, valErr, "email")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			taskDistributor := mock.NewMockTaskDistributor(ctrl)
+
+			tc.buildMocks(userRepository, taskDistributor)
+
+			userApplication := NewUserApplication(userRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, taskDistributor, nil, nil, nil)
+
+			err := userApplication.RequestPasswordReset(context.Background(), tc.arg)
+			tc.checkResponse(t, err)
+		})
+	}
+}
+
+func TestResetPasswordUseCase(t *testing.T) {
+	user, _ := randomUser(t)
+	secretCode := util.RandomString(32)
+	resetPassword := randomResetPassword(user, secretCode)
+	newPassword := util.RandomString(8)
+	config := util.Config{AccessTokenDuration: time.Minute}
+
+	testCases := []struct {
+		name          string
+		arg           ResetPassword
+		buildMocks    func(resetPasswordRepository *mock.MockResetPasswordRepository, sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist)
+		checkResponse func(t *testing.T, user *domain.User, err error)
+	}{
+		{
+			name: "OK",
+			arg: ResetPassword{
+				ResetID:    resetPassword.ID,
+				SecretCode: secretCode,
+				Password:   newPassword,
+			},
+			buildMocks: func(resetPasswordRepository *mock.MockResetPasswordRepository, sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist) {
+				resetPasswordRepository.EXPECT().
+					ResetPasswordTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg infra.ResetPasswordTx) (infra.ResetPasswordTxResult, error) {
+						require.Equal(t, resetPassword.ID, arg.ResetPasswordID)
+						require.Equal(t, resetPassword.SecretCodeHash, arg.SecretCodeHash)
+						require.NoError(t, util.CheckPassword(newPassword, arg.HashedPassword))
+						return infra.ResetPasswordTxResult{User: user, ResetPassword: resetPassword}, nil
+					})
+
+				sessionRepository.EXPECT().
+					BlockUserSessions(gomock.Any(), gomock.Eq(infra.BlockUserSessions{Username: user.Username})).
+					Times(1).
+					Return(nil)
+
+				denylist.EXPECT().
+					BlockUser(gomock.Any(), gomock.Eq(user.Username), gomock.Eq(config.AccessTokenDuration)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, result *domain.User, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user, result)
+			},
+		},
+		{
+			name: "InvalidCode",
+			arg: ResetPassword{
+				ResetID:    resetPassword.ID,
+				SecretCode: util.RandomString(32),
+				Password:   newPassword,
+			},
+			buildMocks: func(resetPasswordRepository *mock.MockResetPasswordRepository, sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist) {
+				resetPasswordRepository.EXPECT().
+					ResetPasswordTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(infra.ResetPasswordTxResult{}, domain.ErrResetPasswordNotFound)
+
+				sessionRepository.EXPECT().
+					BlockUserSessions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *domain.User, err error) {
+				require.ErrorIs(t, err, domain.ErrResetPasswordNotFound)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name: "ShortPassword",
+			arg: ResetPassword{
+				ResetID:    resetPassword.ID,
+				SecretCode: secretCode,
+				Password:   "abc",
+			},
+			buildMocks: func(resetPasswordRepository *mock.MockResetPasswordRepository, sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist) {
+				resetPasswordRepository.EXPECT().
+					ResetPasswordTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *domain.User, err error) {
+				var valErr validation.Errors
+				require.ErrorAs(t, err, &valErr)
+				require.Contains(t, valErr, "password")
+				require.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			resetPasswordRepository := mock.NewMockResetPasswordRepository(ctrl)
+			sessionRepository := mock.NewMockSessionRepository(ctrl)
+			denylist := mock.NewMockDenylist(ctrl)
+
+			tc.buildMocks(resetPasswordRepository, sessionRepository, denylist)
+
+			userApplication := NewUserApplication(nil, sessionRepository, resetPasswordRepository, nil, nil, nil, nil, nil, discardAuthEvents{}, nil, nil, nil, denylist, &config)
+
+			result, err := userApplication.ResetPassword(context.Background(), tc.arg)
+			tc.checkResponse(t, result, err)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+)
+
+type RoleRepository interface {
+	CreateRole(ctx context.Context, arg infra.CreateRole) (*domain.Role, error)
+	ListRoles(ctx context.Context) ([]*domain.Role, error)
+	DeleteRole(ctx context.Context, name string) error
+	CreatePermission(ctx context.Context, arg infra.CreatePermission) (*domain.Permission, error)
+	ListPermissions(ctx context.Context) ([]*domain.Permission, error)
+	SetRolePermissionsTx(ctx context.Context, arg infra.SetRolePermissionsTx) (*domain.Role, error)
+	AddUserRole(ctx context.Context, arg infra.UserRoleParams) (*domain.RoleAssignment, error)
+	RemoveUserRole(ctx context.Context, arg infra.UserRoleParams) error
+	ListUserRoles(ctx context.Context, username string) ([]string, error)
+	ListUserPermissions(ctx context.Context, username string) ([]string, error)
+}
+
+// builtinRoles are referenced by the code and cannot be deleted.
+var builtinRoles = []string{domain.UserRole, domain.AdminRole, domain.ServiceRole}
+
+// RoleApplication manages the roles, the permissions they grant and the
+// roles of each user. The access tokens carry the effective permissions of
+// their user, so changes apply from the next login or token renewal.
+type RoleApplication struct {
+	userRepository UserRepository
+	roleRepository RoleRepository
+}
+
+func NewRoleApplication(userRepository UserRepository, roleRepository RoleRepository) *RoleApplication {
+	return &RoleApplication{
+		userRepository: userRepository,
+		roleRepository: roleRepository,
+	}
+}
+
+type CreateRole struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (r *RoleApplication) CreateRole(ctx context.Context, arg CreateRole) (*domain.Role, error) {
+	if errValidation := validateCreateRoleParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	return r.roleRepository.CreateRole(ctx, infra.CreateRole{
+		Name:        arg.Name,
+		Description: arg.Description,
+	})
+}
+
+func (r *RoleApplication) ListRoles(ctx context.Context) ([]*domain.Role, error) {
+	return r.roleRepository.ListRoles(ctx)
+}
+
+type DeleteRole struct {
+	Name string `json:"name"`
+}
+
+func (r *RoleApplication) DeleteRole(ctx context.Context, arg DeleteRole) error {
+	if errValidation := validateDeleteRoleParams(arg); errValidation != nil {
+		return errValidation
+	}
+
+	return r.roleRepository.DeleteRole(ctx, arg.Name)
+}
+
+type CreatePermission struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (r *RoleApplication) CreatePermission(ctx context.Context, arg CreatePermission) (*domain.Permission, error) {
+	if errValidation := validateCreatePermissionParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	return r.roleRepository.CreatePermission(ctx, infra.CreatePermission{
+		Name:        arg.Name,
+		Description: arg.Description,
+	})
+}
+
+func (r *RoleApplication) ListPermissions(ctx context.Context) ([]*domain.Permission, error) {
+	return r.roleRepository.ListPermissions(ctx)
+}
+
+type SetRolePermissions struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+// SetRolePermissions replaces the permissions granted by a role, an empty list
+// removes them all.
+func (r *RoleApplication) SetRolePermissions(ctx context.Context, arg SetRolePermissions) (*domain.Role, error) {
+	if errValidation := validateSetRolePermissionsParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	role, err := r.roleRepository.SetRolePermissionsTx(ctx, infra.SetRolePermissionsTx{
+		Role:        arg.Role,
+		Permissions: arg.Permissions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set role permissions: %w", err)
+	}
+
+	return role, nil
+}
+
+type UserRoleParams struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// AssignUserRole gives a user a role besides their primary role.
+func (r *RoleApplication) AssignUserRole(ctx context.Context, arg UserRoleParams) (*UserRolesResult, error) {
+	if errValidation := validateUserRoleParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	_, err := r.roleRepository.AddUserRole(ctx, infra.UserRoleParams{
+		Username: arg.Username,
+		Role:     arg.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetUserRoles(ctx, GetUserRoles{Username: arg.Username})
+}
+
+func (r *RoleApplication) RemoveUserRole(ctx context.Context, arg UserRoleParams) (*UserRolesResult, error) {
+	if errValidation := validateUserRoleParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	err := r.roleRepository.RemoveUserRole(ctx, infra.UserRoleParams{
+		Username: arg.Username,
+		Role:     arg.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetUserRoles(ctx, GetUserRoles{Username: arg.Username})
+}
+
+type GetUserRoles struct {
+	Username string `json:"username"`
+}
+
+type UserRolesResult struct {
+	Username string `json:"username"`
+	// Role is the primary role of the user, Roles the ones assigned besides it.
+	Role        string   `json:"role"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+func (r *RoleApplication) GetUserRoles(ctx context.Context, arg GetUserRoles) (*UserRolesResult, error) {
+	if errValidation := validateGetUserRolesParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	user, err := r.userRepository.GetUser(ctx, arg.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := r.roleRepository.ListUserRoles(ctx, user.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := r.roleRepository.ListUserPermissions(ctx, user.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserRolesResult{
+		Username:    user.Username,
+		Role:        user.Role,
+		Roles:       roles,
+		Permissions: permissions,
+	}, nil
+}
@@ -0,0 +1,173 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/golang/mock/gomock"
+	mock "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application/mock"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteRoleUseCase(t *testing.T) {
+	testCases := []struct {
+		name          string
+		arg           DeleteRole
+		buildMocks    func(roleRepository *mock.MockRoleRepository)
+		checkResponse func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			arg:  DeleteRole{Name: "catalog_editor"},
+			buildMocks: func(roleRepository *mock.MockRoleRepository) {
+				roleRepository.EXPECT().
+					DeleteRole(gomock.Any(), gomock.Eq("catalog_editor")).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "InUse",
+			arg:  DeleteRole{Name: "catalog_editor"},
+			buildMocks: func(roleRepository *mock.MockRoleRepository) {
+				roleRepository.EXPECT().
+					DeleteRole(gomock.Any(), gomock.Eq("catalog_editor")).
+					Times(1).
+					Return(domain.ErrRoleInUse)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, domain.ErrRoleInUse)
+			},
+		},
+		{
+			name: "BuiltinRole",
+			arg:  DeleteRole{Name: domain.AdminRole},
+			buildMocks: func(roleRepository *mock.MockRoleRepository) {
+				roleRepository.EXPECT().DeleteRole(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				var validationErrors validation.Errors
+				require.ErrorAs(t, err, &validationErrors)
+				require.Contains(t, validationErrors, "name")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			roleRepository := mock.NewMockRoleRepository(ctrl)
+			tc.buildMocks(roleRepository)
+
+			roleApplication := NewRoleApplication(nil, roleRepository)
+
+			err := roleApplication.DeleteRole(context.Background(), tc.arg)
+			tc.checkResponse(t, err)
+		})
+	}
+}
+
+func TestSetRolePermissionsUseCase(t *testing.T) {
+	testCases := []struct {
+		name          string
+		arg           SetRolePermissions
+		buildMocks    func(roleRepository *mock.MockRoleRepository)
+		checkResponse func(t *testing.T, role *domain.Role, err error)
+	}{
+		{
+			name: "OK",
+			arg:  SetRolePermissions{Role: "catalog_editor", Permissions: []string{"product:write"}},
+			buildMocks: func(roleRepository *mock.MockRoleRepository) {
+				roleRepository.EXPECT().
+					SetRolePermissionsTx(gomock.Any(), gomock.Eq(infra.SetRolePermissionsTx{Role: "catalog_editor", Permissions: []string{"product:write"}})).
+					Times(1).
+					Return(&domain.Role{Name: "catalog_editor", Permissions: []string{"product:write"}}, nil)
+			},
+			checkResponse: func(t *testing.T, role *domain.Role, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"product:write"}, role.Permissions)
+			},
+		},
+		{
+			name: "UnknownPermission",
+			arg:  SetRolePermissions{Role: "catalog_editor", Permissions: []string{"product:delete"}},
+			buildMocks: func(roleRepository *mock.MockRoleRepository) {
+				roleRepository.EXPECT().
+					SetRolePermissionsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrPermissionNotFound)
+			},
+			checkResponse: func(t *testing.T, role *domain.Role, err error) {
+				require.ErrorIs(t, err, domain.ErrPermissionNotFound)
+			},
+		},
+		{
+			name: "InvalidPermission",
+			arg:  SetRolePermissions{Role: "catalog_editor", Permissions: []string{"Product Write"}},
+			buildMocks: func(roleRepository *mock.MockRoleRepository) {
+				roleRepository.EXPECT().SetRolePermissionsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, role *domain.Role, err error) {
+				var validationErrors validation.Errors
+				require.ErrorAs(t, err, &validationErrors)
+				require.Contains(t, validationErrors, "permissions")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			roleRepository := mock.NewMockRoleRepository(ctrl)
+			tc.buildMocks(roleRepository)
+
+			roleApplication := NewRoleApplication(nil, roleRepository)
+
+			role, err := roleApplication.SetRolePermissions(context.Background(), tc.arg)
+			tc.checkResponse(t, role, err)
+		})
+	}
+}
+
+func TestAssignUserRoleUseCase(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	roleRepository := mock.NewMockRoleRepository(ctrl)
+
+	roleRepository.EXPECT().
+		AddUserRole(gomock.Any(), gomock.Eq(infra.UserRoleParams{Username: user.Username, Role: "catalog_editor"})).
+		Times(1).
+		Return(&domain.RoleAssignment{Username: user.Username, Role: "catalog_editor"}, nil)
+
+	userRepository.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+
+	roleRepository.EXPECT().
+		ListUserRoles(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return([]string{"catalog_editor"}, nil)
+
+	roleRepository.EXPECT().
+		ListUserPermissions(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return([]string{"product:read", "product:write"}, nil)
+
+	roleApplication := NewRoleApplication(userRepository, roleRepository)
+
+	result, err := roleApplication.AssignUserRole(context.Background(), UserRoleParams{Username: user.Username, Role: "catalog_editor"})
+	require.NoError(t, err)
+	require.Equal(t, user.Username, result.Username)
+	require.Equal(t, domain.UserRole, result.Role)
+	require.Equal(t, []string{"catalog_editor"}, result.Roles)
+	require.Equal(t, []string{"product:read", "product:write"}, result.Permissions)
+}
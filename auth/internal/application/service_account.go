@@ -0,0 +1,229 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+)
+
+type ServiceAccountRepository interface {
+	CreateServiceAccountTx(ctx context.Context, arg infra.CreateServiceAccountTx) (*domain.ServiceAccount, error)
+	GetServiceAccount(ctx context.Context, name string) (*domain.ServiceAccount, error)
+	ListServiceAccounts(ctx context.Context) ([]*domain.ServiceAccount, error)
+	GetApiKey(ctx context.Context, id uuid.UUID) (*domain.ApiKey, error)
+	GetApiKeyByHash(ctx context.Context, hashedKey string) (*domain.ApiKey, error)
+	RevokeApiKey(ctx context.Context, id uuid.UUID) (*domain.ApiKey, error)
+	RotateApiKeyTx(ctx context.Context, arg infra.RotateApiKeyTx) (infra.RotateApiKeyTxResult, error)
+	TouchApiKey(ctx context.Context, id uuid.UUID) error
+}
+
+// apiKeyPrefix starts every API key, so leaked keys are easy to spot.
+// apiKeyDisplayLength characters of the key are stored in clear to tell keys
+// apart.
+const (
+	apiKeyPrefix        = "ak_"
+	apiKeyDisplayLength = len(apiKeyPrefix) + 8
+)
+
+// ServiceAccountApplication manages the service accounts used by batch jobs
+// and partner integrations, and verifies their API keys for the gateway.
+type ServiceAccountApplication struct {
+	serviceAccountRepository ServiceAccountRepository
+}
+
+func NewServiceAccountApplication(serviceAccountRepository ServiceAccountRepository) *ServiceAccountApplication {
+	return &ServiceAccountApplication{
+		serviceAccountRepository: serviceAccountRepository,
+	}
+}
+
+type CreateServiceAccount struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Role        string     `json:"role"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	CreatedBy   string     `json:"-"`
+}
+
+type CreateServiceAccountResult struct {
+	ServiceAccount *domain.ServiceAccount `json:"service_account"`
+	// Key is only returned here, the service keeps its hash.
+	Key string `json:"key"`
+}
+
+// CreateServiceAccount creates a service account with its first API key. The
+// role defaults to domain.ServiceRole.
+func (s *ServiceAccountApplication) CreateServiceAccount(ctx context.Context, arg CreateServiceAccount) (*CreateServiceAccountResult, error) {
+	if arg.Role == "" {
+		arg.Role = domain.ServiceRole
+	}
+
+	if errValidation := validateCreateServiceAccountParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	key, createApiKey, err := newApiKey(arg.Scopes, arg.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.serviceAccountRepository.CreateServiceAccountTx(ctx, infra.CreateServiceAccountTx{
+		ServiceAccount: infra.CreateServiceAccount{
+			Name:        arg.Name,
+			Description: arg.Description,
+			Role:        arg.Role,
+			CreatedBy:   arg.CreatedBy,
+		},
+		ApiKey: createApiKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service account: %w", err)
+	}
+
+	return &CreateServiceAccountResult{ServiceAccount: account, Key: key}, nil
+}
+
+// ListServiceAccounts returns every service account with all its API keys.
+func (s *ServiceAccountApplication) ListServiceAccounts(ctx context.Context) ([]*domain.ServiceAccount, error) {
+	accounts, err := s.serviceAccountRepository.ListServiceAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+type RotateApiKey struct {
+	ID        uuid.UUID  `json:"id"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type RotateApiKeyResult struct {
+	ApiKey *domain.ApiKey `json:"api_key"`
+	// Key is only returned here, the service keeps its hash.
+	Key string `json:"key"`
+}
+
+// RotateApiKey revokes a key and issues its replacement with the same scopes.
+// Without ExpiresAt the new key lives as long as the old one did.
+func (s *ServiceAccountApplication) RotateApiKey(ctx context.Context, arg RotateApiKey) (*RotateApiKeyResult, error) {
+	if errValidation := validateRotateApiKeyParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	apiKey, err := s.serviceAccountRepository.GetApiKey(ctx, arg.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	expiresAt := arg.ExpiresAt
+	if expiresAt == nil && apiKey.ExpiresAt != nil {
+		lifetime := apiKey.ExpiresAt.Sub(apiKey.CreatedAt)
+		renewed := time.Now().Add(lifetime)
+		expiresAt = &renewed
+	}
+
+	key, createApiKey, err := newApiKey(apiKey.Scopes, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.serviceAccountRepository.RotateApiKeyTx(ctx, infra.RotateApiKeyTx{
+		ID:     apiKey.ID,
+		ApiKey: createApiKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate api key: %w", err)
+	}
+
+	return &RotateApiKeyResult{ApiKey: result.ApiKey, Key: key}, nil
+}
+
+type RevokeApiKey struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// RevokeApiKey stops a key from authenticating right away.
+func (s *ServiceAccountApplication) RevokeApiKey(ctx context.Context, arg RevokeApiKey) (*domain.ApiKey, error) {
+	if errValidation := validateRevokeApiKeyParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	apiKey, err := s.serviceAccountRepository.RevokeApiKey(ctx, arg.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	return apiKey, nil
+}
+
+type VerifyApiKeyResult struct {
+	ApiKey         *domain.ApiKey         `json:"api_key"`
+	ServiceAccount *domain.ServiceAccount `json:"service_account"`
+}
+
+// VerifyApiKey returns the service account of a key that is neither revoked
+// nor expired, and records that the key was used. Any other key returns
+// ErrInvalidApiKey.
+func (s *ServiceAccountApplication) VerifyApiKey(ctx context.Context, key string) (*VerifyApiKeyResult, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, ErrInvalidApiKey
+	}
+
+	apiKey, err := s.serviceAccountRepository.GetApiKeyByHash(ctx, hashApiKey(key))
+	if err != nil {
+		if errors.Is(err, domain.ErrApiKeyNotFound) {
+			return nil, ErrInvalidApiKey
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	if !apiKey.IsActive(time.Now()) {
+		return nil, ErrInvalidApiKey
+	}
+
+	account, err := s.serviceAccountRepository.GetServiceAccount(ctx, apiKey.ServiceAccount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	// The last used time is informative, failing to record it must not
+	// reject the request.
+	_ = s.serviceAccountRepository.TouchApiKey(ctx, apiKey.ID)
+
+	return &VerifyApiKeyResult{ApiKey: apiKey, ServiceAccount: account}, nil
+}
+
+// newApiKey generates a key and the row that stores its hash.
+func newApiKey(scopes []string, expiresAt *time.Time) (string, infra.CreateApiKey, error) {
+	token, err := randomURLToken()
+	if err != nil {
+		return "", infra.CreateApiKey{}, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	key := apiKeyPrefix + token
+
+	return key, infra.CreateApiKey{
+		ID:        uuid.New(),
+		Prefix:    key[:apiKeyDisplayLength],
+		HashedKey: hashApiKey(key),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// hashApiKey hashes keys before they are stored and looked up. Keys are
+// random, so a fast hash is enough.
+func hashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,280 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	mock "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application/mock"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomApiKey(serviceAccount string) (*domain.ApiKey, string) {
+	key := apiKeyPrefix + util.RandomString(43)
+
+	return &domain.ApiKey{
+		ID:             uuid.New(),
+		ServiceAccount: serviceAccount,
+		Prefix:         key[:apiKeyDisplayLength],
+		HashedKey:      hashApiKey(key),
+		Scopes:         []string{"product:read"},
+		CreatedAt:      time.Now(),
+	}, key
+}
+
+func TestCreateServiceAccountUseCase(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	testCases := []struct {
+		name          string
+		arg           CreateServiceAccount
+		buildMocks    func(serviceAccountRepository *mock.MockServiceAccountRepository)
+		checkResponse func(t *testing.T, result *CreateServiceAccountResult, err error)
+	}{
+		{
+			name: "OK",
+			arg:  CreateServiceAccount{Name: "batch_job", Scopes: []string{"product:read"}, ExpiresAt: &future, CreatedBy: "admin"},
+			buildMocks: func(serviceAccountRepository *mock.MockServiceAccountRepository) {
+				serviceAccountRepository.EXPECT().
+					CreateServiceAccountTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg infra.CreateServiceAccountTx) (*domain.ServiceAccount, error) {
+						require.Equal(t, domain.ServiceRole, arg.ServiceAccount.Role)
+						require.Equal(t, "admin", arg.ServiceAccount.CreatedBy)
+						require.NotEqual(t, uuid.Nil, arg.ApiKey.ID)
+						require.True(t, strings.HasPrefix(arg.ApiKey.Prefix, apiKeyPrefix))
+						require.Equal(t, &future, arg.ApiKey.ExpiresAt)
+
+						apiKey := &domain.ApiKey{ID: arg.ApiKey.ID, Prefix: arg.ApiKey.Prefix, HashedKey: arg.ApiKey.HashedKey, Scopes: arg.ApiKey.Scopes}
+						return &domain.ServiceAccount{Name: arg.ServiceAccount.Name, Role: arg.ServiceAccount.Role, ApiKeys: []*domain.ApiKey{apiKey}}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, result *CreateServiceAccountResult, err error) {
+				require.NoError(t, err)
+				require.True(t, strings.HasPrefix(result.Key, apiKeyPrefix))
+				require.Equal(t, hashApiKey(result.Key), result.ServiceAccount.ApiKeys[0].HashedKey)
+				require.Equal(t, result.Key[:apiKeyDisplayLength], result.ServiceAccount.ApiKeys[0].Prefix)
+			},
+		},
+		{
+			name: "AlreadyExists",
+			arg:  CreateServiceAccount{Name: "batch_job", Scopes: []string{"product:read"}, CreatedBy: "admin"},
+			buildMocks: func(serviceAccountRepository *mock.MockServiceAccountRepository) {
+				serviceAccountRepository.EXPECT().
+					CreateServiceAccountTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrServiceAccountAlreadyExist)
+			},
+			checkResponse: func(t *testing.T, result *CreateServiceAccountResult, err error) {
+				require.ErrorIs(t, err, domain.ErrServiceAccountAlreadyExist)
+			},
+		},
+		{
+			name: "InvalidRole",
+			arg:  CreateServiceAccount{Name: "batch_job", Role: "Batch Jobs", Scopes: []string{"product:read"}, CreatedBy: "admin"},
+			buildMocks: func(serviceAccountRepository *mock.MockServiceAccountRepository) {
+				serviceAccountRepository.EXPECT().CreateServiceAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, result *CreateServiceAccountResult, err error) {
+				var validationErrors validation.Errors
+				require.ErrorAs(t, err, &validationErrors)
+				require.Contains(t, validationErrors, "role")
+			},
+		},
+		{
+			name: "InvalidScope",
+			arg:  CreateServiceAccount{Name: "batch_job", Scopes: []string{"Product Read"}, CreatedBy: "admin"},
+			buildMocks: func(serviceAccountRepository *mock.MockServiceAccountRepository) {
+				serviceAccountRepository.EXPECT().CreateServiceAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, result *CreateServiceAccountResult, err error) {
+				var validationErrors validation.Errors
+				require.ErrorAs(t, err, &validationErrors)
+				require.Contains(t, validationErrors, "scopes")
+			},
+		},
+		{
+			name: "ExpiresInThePast",
+			arg:  CreateServiceAccount{Name: "batch_job", Scopes: []string{"product:read"}, ExpiresAt: &past, CreatedBy: "admin"},
+			buildMocks: func(serviceAccountRepository *mock.MockServiceAccountRepository) {
+				serviceAccountRepository.EXPECT().CreateServiceAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, result *CreateServiceAccountResult, err error) {
+				var validationErrors validation.Errors
+				require.ErrorAs(t, err, &validationErrors)
+				require.Contains(t, validationErrors, "expires_at")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			serviceAccountRepository := mock.NewMockServiceAccountRepository(ctrl)
+			tc.buildMocks(serviceAccountRepository)
+
+			serviceAccountApplication := NewServiceAccountApplication(serviceAccountRepository)
+
+			result, err := serviceAccountApplication.CreateServiceAccount(context.Background(), tc.arg)
+			tc.checkResponse(t, result, err)
+		})
+	}
+}
+
+func TestRotateApiKeyUseCase(t *testing.T) {
+	apiKey, _ := randomApiKey("batch_job")
+	expiresAt := apiKey.CreatedAt.Add(24 * time.Hour)
+	apiKey.ExpiresAt = &expiresAt
+
+	ctrl := gomock.NewController(t)
+	serviceAccountRepository := mock.NewMockServiceAccountRepository(ctrl)
+
+	serviceAccountRepository.EXPECT().
+		GetApiKey(gomock.Any(), gomock.Eq(apiKey.ID)).
+		Times(1).
+		Return(apiKey, nil)
+
+	serviceAccountRepository.EXPECT().
+		RotateApiKeyTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg infra.RotateApiKeyTx) (infra.RotateApiKeyTxResult, error) {
+			require.Equal(t, apiKey.ID, arg.ID)
+			require.NotEqual(t, apiKey.ID, arg.ApiKey.ID)
+			require.Equal(t, apiKey.Scopes, arg.ApiKey.Scopes)
+			require.WithinDuration(t, time.Now().Add(24*time.Hour), *arg.ApiKey.ExpiresAt, time.Minute)
+
+			return infra.RotateApiKeyTxResult{
+				RevokedApiKey: apiKey,
+				ApiKey:        &domain.ApiKey{ID: arg.ApiKey.ID, HashedKey: arg.ApiKey.HashedKey, Scopes: arg.ApiKey.Scopes, ExpiresAt: arg.ApiKey.ExpiresAt},
+			}, nil
+		})
+
+	serviceAccountApplication := NewServiceAccountApplication(serviceAccountRepository)
+
+	result, err := serviceAccountApplication.RotateApiKey(context.Background(), RotateApiKey{ID: apiKey.ID})
+	require.NoError(t, err)
+	require.Equal(t, hashApiKey(result.Key), result.ApiKey.HashedKey)
+}
+
+func TestVerifyApiKeyUseCase(t *testing.T) {
+	account := &domain.ServiceAccount{Name: "batch_job", Role: domain.ServiceRole}
+
+	testCases := []struct {
+		name          string
+		buildApiKey   func(apiKey *domain.ApiKey)
+		key           func(key string) string
+		buildMocks    func(serviceAccountRepository *mock.MockServiceAccountRepository, apiKey *domain.ApiKey)
+		checkResponse func(t *testing.T, result *VerifyApiKeyResult, err error)
+	}{
+		{
+			name: "OK",
+			buildMocks: func(serviceAccountRepository *mock.MockServiceAccountRepository, apiKey *domain.ApiKey) {
+				serviceAccountRepository.EXPECT().
+					GetApiKeyByHash(gomock.Any(), gomock.Eq(apiKey.HashedKey)).
+					Times(1).
+					Return(apiKey, nil)
+				serviceAccountRepository.EXPECT().
+					GetServiceAccount(gomock.Any(), gomock.Eq(account.Name)).
+					Times(1).
+					Return(account, nil)
+				serviceAccountRepository.EXPECT().
+					TouchApiKey(gomock.Any(), gomock.Eq(apiKey.ID)).
+					Times(1).
+					Return(errors.New("db down"))
+			},
+			checkResponse: func(t *testing.T, result *VerifyApiKeyResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, account, result.ServiceAccount)
+			},
+		},
+		{
+			name: "WrongPrefix",
+			key: func(key string) string {
+				return strings.TrimPrefix(key, apiKeyPrefix)
+			},
+			buildMocks: func(serviceAccountRepository *mock.MockServiceAccountRepository, apiKey *domain.ApiKey) {
+				serviceAccountRepository.EXPECT().GetApiKeyByHash(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, result *VerifyApiKeyResult, err error) {
+				require.ErrorIs(t, err, ErrInvalidApiKey)
+			},
+		},
+		{
+			name: "Unknown",
+			buildMocks: func(serviceAccountRepository *mock.MockServiceAccountRepository, apiKey *domain.ApiKey) {
+				serviceAccountRepository.EXPECT().
+					GetApiKeyByHash(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrApiKeyNotFound)
+				serviceAccountRepository.EXPECT().GetServiceAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, result *VerifyApiKeyResult, err error) {
+				require.ErrorIs(t, err, ErrInvalidApiKey)
+			},
+		},
+		{
+			name: "Revoked",
+			buildApiKey: func(apiKey *domain.ApiKey) {
+				revokedAt := time.Now()
+				apiKey.RevokedAt = &revokedAt
+			},
+			buildMocks: func(serviceAccountRepository *mock.MockServiceAccountRepository, apiKey *domain.ApiKey) {
+				serviceAccountRepository.EXPECT().
+					GetApiKeyByHash(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(apiKey, nil)
+				serviceAccountRepository.EXPECT().TouchApiKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, result *VerifyApiKeyResult, err error) {
+				require.ErrorIs(t, err, ErrInvalidApiKey)
+			},
+		},
+		{
+			name: "Expired",
+			buildApiKey: func(apiKey *domain.ApiKey) {
+				expiresAt := time.Now().Add(-time.Second)
+				apiKey.ExpiresAt = &expiresAt
+			},
+			buildMocks: func(serviceAccountRepository *mock.MockServiceAccountRepository, apiKey *domain.ApiKey) {
+				serviceAccountRepository.EXPECT().
+					GetApiKeyByHash(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(apiKey, nil)
+				serviceAccountRepository.EXPECT().TouchApiKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, result *VerifyApiKeyResult, err error) {
+				require.ErrorIs(t, err, ErrInvalidApiKey)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			apiKey, key := randomApiKey(account.Name)
+			if tc.buildApiKey != nil {
+				tc.buildApiKey(apiKey)
+			}
+			if tc.key != nil {
+				key = tc.key(key)
+			}
+
+			ctrl := gomock.NewController(t)
+			serviceAccountRepository := mock.NewMockServiceAccountRepository(ctrl)
+			tc.buildMocks(serviceAccountRepository, apiKey)
+
+			serviceAccountApplication := NewServiceAccountApplication(serviceAccountRepository)
+
+			result, err := serviceAccountApplication.VerifyApiKey(context.Background(), key)
+			tc.checkResponse(t, result, err)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+)
+
+type ListSessions struct {
+	Username string `json:"username"`
+}
+
+func (u *UserApplication) ListSessions(ctx context.Context, arg ListSessions) ([]*domain.Session, error) {
+	sessions, err := u.sessionRespository.ListActiveSessions(ctx, arg.Username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+type RevokeSession struct {
+	Username         string    `json:"username"`
+	SessionID        uuid.UUID `json:"session_id"`
+	CurrentSessionID uuid.UUID `json:"current_session_id"`
+	AccessTokenID    uuid.UUID `json:"access_token_id"`
+	AccessExpiredAt  time.Time `json:"access_expired_at"`
+}
+
+func (u *UserApplication) RevokeSession(ctx context.Context, arg RevokeSession) error {
+	if errValidation := validateRevokeSessionParams(arg); errValidation != nil {
+		return errValidation
+	}
+
+	session, err := u.sessionRespository.GetSession(ctx, arg.SessionID)
+	if err != nil {
+		return err
+	}
+
+	// sessions of other users are reported as missing to not disclose they exist
+	if session.Username != arg.Username {
+		return domain.ErrSessionNotFound
+	}
+
+	err = u.revokeSessionFamily(ctx, session.FamilyID)
+	if err != nil {
+		return err
+	}
+
+	// revoking the current session logs out, so the access token of the
+	// request is denylisted by its own ID too, in case it carries no session
+	if session.FamilyID == arg.CurrentSessionID && arg.AccessTokenID != uuid.Nil {
+		err = u.denylist.RevokeToken(ctx, arg.AccessTokenID, arg.AccessExpiredAt)
+		if err != nil {
+			return fmt.Errorf("failed to denylist access token: %w", err)
+		}
+	}
+
+	return nil
+}
+
+type RevokeNewDeviceSession struct {
+	Token string `json:"token"`
+}
+
+// RevokeNewDeviceSession revokes the session of a new device login with the
+// token of the link in its alert email, without the user being logged in.
+func (u *UserApplication) RevokeNewDeviceSession(ctx context.Context, arg RevokeNewDeviceSession) error {
+	if errValidation := validateRevokeNewDeviceSessionParams(arg); errValidation != nil {
+		return errValidation
+	}
+
+	payload, err := u.tokenMaker.VerifyToken(arg.Token, append(u.verifyOptions(), token.RequirePurpose(token.PurposeRevokeSession))...)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRevokeToken, err)
+	}
+
+	session, err := u.sessionRespository.GetSession(ctx, payload.SessionID)
+	if err != nil {
+		return err
+	}
+
+	if session.Username != payload.Username {
+		return domain.ErrSessionNotFound
+	}
+
+	return u.revokeSessionFamily(ctx, session.FamilyID)
+}
+
+type RevokeAllSessions struct {
+	Username         string    `json:"username"`
+	CurrentSessionID uuid.UUID `json:"current_session_id"`
+	KeepCurrent      bool      `json:"keep_current"`
+}
+
+func (u *UserApplication) RevokeAllSessions(ctx context.Context, arg RevokeAllSessions) error {
+	var exceptFamilyID uuid.UUID
+	if arg.KeepCurrent {
+		exceptFamilyID = arg.CurrentSessionID
+	}
+
+	return u.revokeUserSessions(ctx, arg.Username, exceptFamilyID)
+}
+
+// revokeSessionFamily blocks the refresh tokens of a session family and
+// denylists its access tokens until they would have expired on their own.
+func (u *UserApplication) revokeSessionFamily(ctx context.Context, familyID uuid.UUID) error {
+	err := u.sessionRespository.BlockSessionFamily(ctx, familyID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	err = u.denylist.RevokeSession(ctx, familyID, u.config.AccessTokenDuration)
+	if err != nil {
+		return fmt.Errorf("failed to denylist session: %w", err)
+	}
+
+	return nil
+}
+
+// revokeUserSessions blocks every session of the user but exceptFamilyID. Without
+// an exception the user is denylisted as a whole, otherwise each revoked
+// session is, so the access token of the kept session stays valid.
+func (u *UserApplication) revokeUserSessions(ctx context.Context, username string, exceptFamilyID uuid.UUID) error {
+	var sessions []*domain.Session
+	if exceptFamilyID != uuid.Nil {
+		var err error
+		sessions, err = u.sessionRespository.ListActiveSessions(ctx, username)
+		if err != nil {
+			return fmt.Errorf("failed to list sessions: %w", err)
+		}
+	}
+
+	err := u.sessionRespository.BlockUserSessions(ctx, infra.BlockUserSessions{
+		Username:       username,
+		ExceptFamilyID: exceptFamilyID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	if exceptFamilyID == uuid.Nil {
+		err = u.denylist.BlockUser(ctx, username, u.config.AccessTokenDuration)
+		if err != nil {
+			return fmt.Errorf("failed to denylist user: %w", err)
+		}
+
+		return nil
+	}
+
+	for _, session := range sessions {
+		if session.FamilyID == exceptFamilyID {
+			continue
+		}
+
+		err = u.denylist.RevokeSession(ctx, session.FamilyID, u.config.AccessTokenDuration)
+		if err != nil {
+			return fmt.Errorf("failed to denylist session: %w", err)
+		}
+	}
+
+	return nil
+}
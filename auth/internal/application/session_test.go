@@ -0,0 +1,264 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	mock "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application/mock"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListSessionsUseCase(t *testing.T) {
+	user, _ := randomUser(t)
+
+	sessions := []*domain.Session{
+		randomSession(t, user.Username),
+		randomSession(t, user.Username),
+	}
+
+	sessionCtrl := gomock.NewController(t)
+	sessionRepository := mock.NewMockSessionRepository(sessionCtrl)
+
+	sessionRepository.EXPECT().
+		ListActiveSessions(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(sessions, nil)
+
+	userApplication := NewUserApplication(nil, sessionRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	result, err := userApplication.ListSessions(context.Background(), ListSessions{Username: user.Username})
+	require.NoError(t, err)
+	require.Equal(t, sessions, result)
+}
+
+func TestRevokeSessionUseCase(t *testing.T) {
+	user, _ := randomUser(t)
+	session := randomSession(t, user.Username)
+	config := util.Config{AccessTokenDuration: time.Minute}
+	accessTokenID := uuid.New()
+	accessExpiredAt := time.Now().Add(config.AccessTokenDuration)
+
+	testCases := []struct {
+		name          string
+		arg           RevokeSession
+		buildMocks    func(sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist)
+		checkResponse func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			arg: RevokeSession{
+				Username:  user.Username,
+				SessionID: session.ID,
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.ID)).
+					Times(1).
+					Return(session, nil)
+
+				sessionRepository.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Eq(session.FamilyID)).
+					Times(1).
+					Return(nil)
+
+				denylist.EXPECT().
+					RevokeSession(gomock.Any(), gomock.Eq(session.FamilyID), gomock.Eq(config.AccessTokenDuration)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "CurrentSession",
+			arg: RevokeSession{
+				Username:         user.Username,
+				SessionID:        session.ID,
+				CurrentSessionID: session.FamilyID,
+				AccessTokenID:    accessTokenID,
+				AccessExpiredAt:  accessExpiredAt,
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.ID)).
+					Times(1).
+					Return(session, nil)
+
+				sessionRepository.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Eq(session.FamilyID)).
+					Times(1).
+					Return(nil)
+
+				denylist.EXPECT().
+					RevokeSession(gomock.Any(), gomock.Eq(session.FamilyID), gomock.Eq(config.AccessTokenDuration)).
+					Times(1).
+					Return(nil)
+
+				denylist.EXPECT().
+					RevokeToken(gomock.Any(), gomock.Eq(accessTokenID), gomock.Eq(accessExpiredAt)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "RequiredSessionID",
+			arg: RevokeSession{
+				Username: user.Username,
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.Error(t, err)
+			},
+		},
+		{
+			name: "SessionNotFound",
+			arg: RevokeSession{
+				Username:  user.Username,
+				SessionID: session.ID,
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.ID)).
+					Times(1).
+					Return(nil, domain.ErrSessionNotFound)
+
+				sessionRepository.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, domain.ErrSessionNotFound)
+			},
+		},
+		{
+			name: "SessionOfAnotherUser",
+			arg: RevokeSession{
+				Username:  util.RandomUsername(),
+				SessionID: session.ID,
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.ID)).
+					Times(1).
+					Return(session, nil)
+
+				sessionRepository.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, domain.ErrSessionNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			sessionRepository := mock.NewMockSessionRepository(ctrl)
+			denylist := mock.NewMockDenylist(ctrl)
+
+			tc.buildMocks(sessionRepository, denylist)
+
+			userApplication := NewUserApplication(nil, sessionRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, denylist, &config)
+
+			err := userApplication.RevokeSession(context.Background(), tc.arg)
+			tc.checkResponse(t, err)
+		})
+	}
+}
+
+func TestRevokeAllSessionsUseCase(t *testing.T) {
+	user, _ := randomUser(t)
+	config := util.Config{AccessTokenDuration: time.Minute}
+
+	currentSession := randomSession(t, user.Username)
+	otherSession := randomSession(t, user.Username)
+
+	testCases := []struct {
+		name       string
+		arg        RevokeAllSessions
+		buildMocks func(sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist)
+	}{
+		{
+			name: "KeepCurrent",
+			arg: RevokeAllSessions{
+				Username:         user.Username,
+				CurrentSessionID: currentSession.FamilyID,
+				KeepCurrent:      true,
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist) {
+				sessionRepository.EXPECT().
+					ListActiveSessions(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]*domain.Session{currentSession, otherSession}, nil)
+
+				sessionRepository.EXPECT().
+					BlockUserSessions(gomock.Any(), gomock.Eq(infra.BlockUserSessions{
+						Username:       user.Username,
+						ExceptFamilyID: currentSession.FamilyID,
+					})).
+					Times(1).
+					Return(nil)
+
+				denylist.EXPECT().
+					RevokeSession(gomock.Any(), gomock.Eq(otherSession.FamilyID), gomock.Eq(config.AccessTokenDuration)).
+					Times(1).
+					Return(nil)
+
+				denylist.EXPECT().
+					BlockUser(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+		},
+		{
+			name: "RevokeCurrent",
+			arg: RevokeAllSessions{
+				Username:         user.Username,
+				CurrentSessionID: currentSession.FamilyID,
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist) {
+				sessionRepository.EXPECT().
+					BlockUserSessions(gomock.Any(), gomock.Eq(infra.BlockUserSessions{
+						Username: user.Username,
+					})).
+					Times(1).
+					Return(nil)
+
+				denylist.EXPECT().
+					BlockUser(gomock.Any(), gomock.Eq(user.Username), gomock.Eq(config.AccessTokenDuration)).
+					Times(1).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			sessionRepository := mock.NewMockSessionRepository(ctrl)
+			denylist := mock.NewMockDenylist(ctrl)
+
+			tc.buildMocks(sessionRepository, denylist)
+
+			userApplication := NewUserApplication(nil, sessionRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, denylist, &config)
+
+			err := userApplication.RevokeAllSessions(context.Background(), tc.arg)
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,412 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	totpPeriod        = 30
+	totpSkew          = 1
+	recoveryCodeCount = 10
+)
+
+type TotpRepository interface {
+	GetUserTotp(ctx context.Context, username string) (*domain.UserTotp, error)
+	EnrollTotp(ctx context.Context, arg infra.EnrollTotp) (*domain.UserTotp, error)
+	UseTotpCounter(ctx context.Context, arg infra.UseTotpCounter) error
+	EnableTotpTx(ctx context.Context, arg infra.EnableTotpTx) (infra.EnableTotpTxResult, error)
+	DisableTotpTx(ctx context.Context, username string) (*domain.User, error)
+	ReplaceRecoveryCodesTx(ctx context.Context, arg infra.ReplaceRecoveryCodesTx) error
+	UseRecoveryCode(ctx context.Context, arg infra.UseRecoveryCode) (*domain.RecoveryCode, error)
+}
+
+type EnrollTotp struct {
+	Username string `json:"username"`
+}
+
+type EnrollTotpResult struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// EnrollTotp creates a new TOTP secret for the user. It stays disabled until
+// ConfirmTotp receives a code generated with it.
+func (u *UserApplication) EnrollTotp(ctx context.Context, arg EnrollTotp) (*EnrollTotpResult, error) {
+	if errValidation := validateEnrollTotpParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	user, err := u.userRepository.GetUser(ctx, arg.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsTotpEnabled {
+		return nil, domain.ErrTotpAlreadyEnabled
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      u.config.TotpIssuer,
+		AccountName: user.Email,
+		Period:      totpPeriod,
+		Digits:      otp.DigitsSix,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+
+	encryptedSecret, err := util.EncryptSecret(u.config.TotpEncryptionKey, []byte(key.Secret()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt totp secret: %w", err)
+	}
+
+	_, err = u.totpRepository.EnrollTotp(ctx, infra.EnrollTotp{
+		Username: user.Username,
+		Secret:   encryptedSecret,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enroll totp: %w", err)
+	}
+
+	return &EnrollTotpResult{
+		Secret:          key.Secret(),
+		ProvisioningURI: key.URL(),
+	}, nil
+}
+
+type ConfirmTotp struct {
+	Username string `json:"username"`
+	Code     string `json:"code"`
+}
+
+// ConfirmTotp enables the enrolled secret once the user proves their
+// authenticator works, and returns the first set of recovery codes.
+func (u *UserApplication) ConfirmTotp(ctx context.Context, arg ConfirmTotp) ([]string, error) {
+	if errValidation := validateConfirmTotpParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	userTotp, err := u.totpRepository.GetUserTotp(ctx, arg.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if userTotp.IsTotpEnabled {
+		return nil, domain.ErrTotpAlreadyEnabled
+	}
+
+	if userTotp.Secret == nil {
+		return nil, domain.ErrTotpNotFound
+	}
+
+	counter, err := u.matchTotpCode(userTotp, arg.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, hashedRecoveryCodes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = u.totpRepository.EnableTotpTx(ctx, infra.EnableTotpTx{
+		Username:            arg.Username,
+		Counter:             counter,
+		HashedRecoveryCodes: hashedRecoveryCodes,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrTotpCodeReused) {
+			return nil, ErrInvalidTotpCode
+		}
+		return nil, fmt.Errorf("failed to enable totp: %w", err)
+	}
+
+	return recoveryCodes, nil
+}
+
+type DisableTotp struct {
+	Username string `json:"username"`
+	Code     string `json:"code"`
+}
+
+// DisableTotp turns off TOTP with a valid TOTP or recovery code and deletes the
+// remaining recovery codes.
+func (u *UserApplication) DisableTotp(ctx context.Context, arg DisableTotp) error {
+	if errValidation := validateDisableTotpParams(arg); errValidation != nil {
+		return errValidation
+	}
+
+	userTotp, err := u.enabledUserTotp(ctx, arg.Username)
+	if err != nil {
+		return err
+	}
+
+	err = u.verifySecondFactor(ctx, userTotp, arg.Code, true)
+	if err != nil {
+		return err
+	}
+
+	_, err = u.totpRepository.DisableTotpTx(ctx, arg.Username)
+	if err != nil {
+		return fmt.Errorf("failed to disable totp: %w", err)
+	}
+
+	return nil
+}
+
+type GenerateRecoveryCodes struct {
+	Username string `json:"username"`
+	Code     string `json:"code"`
+}
+
+// GenerateRecoveryCodes replaces every recovery code of the user. It needs a
+// TOTP code, so a leaked recovery code cannot be used to mint new ones.
+func (u *UserApplication) GenerateRecoveryCodes(ctx context.Context, arg GenerateRecoveryCodes) ([]string, error) {
+	if errValidation := validateGenerateRecoveryCodesParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	userTotp, err := u.enabledUserTotp(ctx, arg.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.verifySecondFactor(ctx, userTotp, arg.Code, false)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, hashedRecoveryCodes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.totpRepository.ReplaceRecoveryCodesTx(ctx, infra.ReplaceRecoveryCodesTx{
+		Username:    arg.Username,
+		HashedCodes: hashedRecoveryCodes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to replace recovery codes: %w", err)
+	}
+
+	return recoveryCodes, nil
+}
+
+type VerifyLoginTotp struct {
+	MfaToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+}
+
+// VerifyLoginTotp finishes a login that returned MfaRequired. Wrong codes count
+// as failed logins, so they are throttled like wrong passwords.
+func (u *UserApplication) VerifyLoginTotp(ctx context.Context, arg VerifyLoginTotp) (*LoginUserResult, error) {
+	user, err := u.authenticateTotp(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.createLoginSession(ctx, user)
+}
+
+// authenticateTotp checks the second factor of a login that returned
+// MfaRequired and returns the user it belongs to.
+func (u *UserApplication) authenticateTotp(ctx context.Context, arg VerifyLoginTotp) (*domain.User, error) {
+	if errValidation := validateVerifyLoginTotpParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	mfaPayload, err := u.tokenMaker.VerifyToken(arg.MfaToken, append(u.verifyOptions(), token.RequirePurpose(token.PurposeMFAChallenge))...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMfaToken, err)
+	}
+
+	user, err := u.checkLoginTotp(ctx, mfaPayload.Username, arg.Code)
+	if err != nil {
+		u.recordAuthEvent(ctx, domain.AuthEventLogin, mfaPayload.Username, domain.AuthEventFailure)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (u *UserApplication) checkLoginTotp(ctx context.Context, username string, code string) (*domain.User, error) {
+	clientIP := loginClientIP(util.ExtractMetadata(ctx, u.config.TrustedProxies).ClientIP)
+
+	err := u.checkLoginAllowed(ctx, username, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.countLoginAttempt(ctx, username, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepository.GetUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	userTotp, err := u.enabledUserTotp(ctx, user.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.verifySecondFactor(ctx, userTotp, code, true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.resetLoginFailures(ctx, user.Username, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// createMfaChallenge returns the short-lived token that stands in for the
+// session tokens until the second factor is verified. Users the
+// UNVERIFIED_EMAIL_POLICY would not give tokens to get no challenge either.
+func (u *UserApplication) createMfaChallenge(ctx context.Context, user *domain.User) (*LoginUserResult, error) {
+	err := u.checkEmailVerified(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	mfaToken, mfaPayload, err := u.tokenMaker.CreateToken(user.Username, user.Role, u.config.MfaChallengeDuration, u.tokenOptions(token.WithPurpose(token.PurposeMFAChallenge))...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mfa token: %w", err)
+	}
+
+	return &LoginUserResult{
+		User:              user,
+		MfaRequired:       true,
+		MfaToken:          mfaToken,
+		MfaTokenExpiresAt: mfaPayload.ExpiredAt,
+	}, nil
+}
+
+func (u *UserApplication) enabledUserTotp(ctx context.Context, username string) (*domain.UserTotp, error) {
+	userTotp, err := u.totpRepository.GetUserTotp(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if !userTotp.IsTotpEnabled || userTotp.Secret == nil {
+		return nil, ErrTotpNotEnabled
+	}
+
+	return userTotp, nil
+}
+
+// verifySecondFactor accepts a TOTP code that was not used before or, when
+// allowRecovery is set, an unused recovery code.
+func (u *UserApplication) verifySecondFactor(ctx context.Context, userTotp *domain.UserTotp, code string, allowRecovery bool) error {
+	counter, err := u.matchTotpCode(userTotp, code)
+	if err == nil {
+		err = u.totpRepository.UseTotpCounter(ctx, infra.UseTotpCounter{Username: userTotp.Username, Counter: counter})
+		if errors.Is(err, domain.ErrTotpCodeReused) {
+			return ErrInvalidTotpCode
+		}
+		return err
+	}
+
+	if !errors.Is(err, ErrInvalidTotpCode) || !allowRecovery {
+		return err
+	}
+
+	_, err = u.totpRepository.UseRecoveryCode(ctx, infra.UseRecoveryCode{
+		Username:   userTotp.Username,
+		HashedCode: hashRecoveryCode(code),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrRecoveryCodeNotFound) {
+			return ErrInvalidTotpCode
+		}
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	log.Info().Str("username", userTotp.Username).Msg("recovery code used")
+
+	return nil
+}
+
+// matchTotpCode returns the time step of code, allowing one step of clock
+// drift on each side.
+func (u *UserApplication) matchTotpCode(userTotp *domain.UserTotp, code string) (int64, error) {
+	secret, err := util.DecryptSecret(u.config.TotpEncryptionKey, userTotp.Secret)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decrypt totp secret: %w", err)
+	}
+
+	opts := totp.ValidateOpts{
+		Period:    totpPeriod,
+		Digits:    otp.DigitsSix,
+		Algorithm: otp.AlgorithmSHA1,
+	}
+
+	now := time.Now()
+	for step := -totpSkew; step <= totpSkew; step++ {
+		at := now.Add(time.Duration(step*totpPeriod) * time.Second)
+
+		expected, err := totp.GenerateCodeCustom(string(secret), at, opts)
+		if err != nil {
+			return 0, fmt.Errorf("failed to generate totp code: %w", err)
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return at.Unix() / totpPeriod, nil
+		}
+	}
+
+	return 0, ErrInvalidTotpCode
+}
+
+// generateRecoveryCodes returns the codes shown once to the user and the
+// hashes stored in their place.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for range recoveryCodeCount {
+		random := make([]byte, 10)
+		if _, err := rand.Read(random); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+
+		encoded := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(random))
+		code := fmt.Sprintf("%s-%s-%s-%s", encoded[0:4], encoded[4:8], encoded[8:12], encoded[12:16])
+
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode ignores case, spaces and dashes, so codes can be typed the
+// way they are easier to read.
+func hashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+	hash := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,522 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mock "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application/mock"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/require"
+)
+
+func newTotpConfig() *util.Config {
+	return &util.Config{
+		AccessTokenDuration:  time.Minute,
+		RefreshTokenDuration: time.Minute,
+		MfaChallengeDuration: time.Minute,
+		TotpIssuer:           "test",
+		TotpEncryptionKey:    util.RandomString(32),
+	}
+}
+
+// randomUserTotp returns the TOTP state of a user with TOTP enabled and its
+// plain secret.
+func randomUserTotp(t *testing.T, config *util.Config, username string) (*domain.UserTotp, string) {
+	t.Helper()
+
+	key, err := totp.Generate(totp.GenerateOpts{Issuer: config.TotpIssuer, AccountName: username})
+	require.NoError(t, err)
+
+	encryptedSecret, err := util.EncryptSecret(config.TotpEncryptionKey, []byte(key.Secret()))
+	require.NoError(t, err)
+
+	return &domain.UserTotp{
+		Username:      username,
+		Secret:        encryptedSecret,
+		IsTotpEnabled: true,
+	}, key.Secret()
+}
+
+func currentTotpCode(t *testing.T, secret string) string {
+	t.Helper()
+
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+
+	return code
+}
+
+func TestEnrollTotpUseCase(t *testing.T) {
+	config := newTotpConfig()
+
+	t.Run("OK", func(t *testing.T) {
+		user, _ := randomUser(t)
+
+		ctrl := gomock.NewController(t)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		totpRepository := mock.NewMockTotpRepository(ctrl)
+
+		userRepository.EXPECT().
+			GetUser(gomock.Any(), gomock.Eq(user.Username)).
+			Times(1).
+			Return(user, nil)
+
+		var storedSecret []byte
+		totpRepository.EXPECT().
+			EnrollTotp(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg infra.EnrollTotp) (*domain.UserTotp, error) {
+				require.Equal(t, user.Username, arg.Username)
+				storedSecret = arg.Secret
+				return &domain.UserTotp{Username: arg.Username, Secret: arg.Secret}, nil
+			})
+
+		userApplication := NewUserApplication(userRepository, nil, nil, nil, totpRepository, nil, nil, nil, nil, nil, nil, nil, nil, config)
+
+		result, err := userApplication.EnrollTotp(context.Background(), EnrollTotp{Username: user.Username})
+		require.NoError(t, err)
+		require.NotEmpty(t, result.Secret)
+		require.Contains(t, result.ProvisioningURI, "otpauth://totp/")
+
+		require.NotContains(t, string(storedSecret), result.Secret)
+		decrypted, err := util.DecryptSecret(config.TotpEncryptionKey, storedSecret)
+		require.NoError(t, err)
+		require.Equal(t, result.Secret, string(decrypted))
+	})
+
+	t.Run("AlreadyEnabled", func(t *testing.T) {
+		user, _ := randomUser(t)
+		user.IsTotpEnabled = true
+
+		ctrl := gomock.NewController(t)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		totpRepository := mock.NewMockTotpRepository(ctrl)
+
+		userRepository.EXPECT().
+			GetUser(gomock.Any(), gomock.Eq(user.Username)).
+			Times(1).
+			Return(user, nil)
+
+		totpRepository.EXPECT().
+			EnrollTotp(gomock.Any(), gomock.Any()).
+			Times(0)
+
+		userApplication := NewUserApplication(userRepository, nil, nil, nil, totpRepository, nil, nil, nil, nil, nil, nil, nil, nil, config)
+
+		result, err := userApplication.EnrollTotp(context.Background(), EnrollTotp{Username: user.Username})
+		require.ErrorIs(t, err, domain.ErrTotpAlreadyEnabled)
+		require.Nil(t, result)
+	})
+}
+
+func TestConfirmTotpUseCase(t *testing.T) {
+	config := newTotpConfig()
+	user, _ := randomUser(t)
+
+	pendingTotp := func(t *testing.T) (*domain.UserTotp, string) {
+		userTotp, secret := randomUserTotp(t, config, user.Username)
+		userTotp.IsTotpEnabled = false
+		return userTotp, secret
+	}
+
+	testCases := []struct {
+		name          string
+		code          func(secret string) string
+		userTotp      func(t *testing.T) (*domain.UserTotp, string)
+		buildMocks    func(totpRepository *mock.MockTotpRepository)
+		checkResponse func(t *testing.T, recoveryCodes []string, err error)
+	}{
+		{
+			name:     "OK",
+			code:     func(secret string) string { return currentTotpCode(t, secret) },
+			userTotp: pendingTotp,
+			buildMocks: func(totpRepository *mock.MockTotpRepository) {
+				totpRepository.EXPECT().
+					EnableTotpTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg infra.EnableTotpTx) (infra.EnableTotpTxResult, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.InDelta(t, time.Now().Unix()/totpPeriod, arg.Counter, 1)
+						require.Len(t, arg.HashedRecoveryCodes, recoveryCodeCount)
+						return infra.EnableTotpTxResult{User: user}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recoveryCodes []string, err error) {
+				require.NoError(t, err)
+				require.Len(t, recoveryCodes, recoveryCodeCount)
+			},
+		},
+		{
+			name:     "InvalidCode",
+			code:     func(secret string) string { return "000000" },
+			userTotp: pendingTotp,
+			buildMocks: func(totpRepository *mock.MockTotpRepository) {
+				totpRepository.EXPECT().
+					EnableTotpTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recoveryCodes []string, err error) {
+				require.ErrorIs(t, err, ErrInvalidTotpCode)
+				require.Nil(t, recoveryCodes)
+			},
+		},
+		{
+			name: "NotEnrolled",
+			code: func(secret string) string { return "123456" },
+			userTotp: func(t *testing.T) (*domain.UserTotp, string) {
+				return &domain.UserTotp{Username: user.Username}, ""
+			},
+			buildMocks: func(totpRepository *mock.MockTotpRepository) {
+				totpRepository.EXPECT().
+					EnableTotpTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recoveryCodes []string, err error) {
+				require.ErrorIs(t, err, domain.ErrTotpNotFound)
+				require.Nil(t, recoveryCodes)
+			},
+		},
+		{
+			name:     "AlreadyEnabled",
+			code:     func(secret string) string { return currentTotpCode(t, secret) },
+			userTotp: func(t *testing.T) (*domain.UserTotp, string) { return randomUserTotp(t, config, user.Username) },
+			buildMocks: func(totpRepository *mock.MockTotpRepository) {
+				totpRepository.EXPECT().
+					EnableTotpTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recoveryCodes []string, err error) {
+				require.ErrorIs(t, err, domain.ErrTotpAlreadyEnabled)
+				require.Nil(t, recoveryCodes)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			totpRepository := mock.NewMockTotpRepository(ctrl)
+
+			userTotp, secret := tc.userTotp(t)
+			totpRepository.EXPECT().
+				GetUserTotp(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(userTotp, nil)
+
+			tc.buildMocks(totpRepository)
+
+			userApplication := NewUserApplication(nil, nil, nil, nil, totpRepository, nil, nil, nil, nil, nil, nil, nil, nil, config)
+
+			recoveryCodes, err := userApplication.ConfirmTotp(context.Background(), ConfirmTotp{Username: user.Username, Code: tc.code(secret)})
+			tc.checkResponse(t, recoveryCodes, err)
+		})
+	}
+}
+
+func TestDisableTotpUseCase(t *testing.T) {
+	config := newTotpConfig()
+	user, _ := randomUser(t)
+	userTotp, secret := randomUserTotp(t, config, user.Username)
+	recoveryCode := "abcd-efgh-ijkl-mnop"
+
+	testCases := []struct {
+		name          string
+		code          string
+		buildMocks    func(totpRepository *mock.MockTotpRepository)
+		checkResponse func(t *testing.T, err error)
+	}{
+		{
+			name: "TotpCode",
+			code: currentTotpCode(t, secret),
+			buildMocks: func(totpRepository *mock.MockTotpRepository) {
+				totpRepository.EXPECT().
+					UseTotpCounter(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+
+				totpRepository.EXPECT().
+					DisableTotpTx(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "RecoveryCode",
+			code: "ABCD EFGH IJKL MNOP",
+			buildMocks: func(totpRepository *mock.MockTotpRepository) {
+				totpRepository.EXPECT().
+					UseRecoveryCode(gomock.Any(), gomock.Eq(infra.UseRecoveryCode{Username: user.Username, HashedCode: hashRecoveryCode(recoveryCode)})).
+					Times(1).
+					Return(&domain.RecoveryCode{Username: user.Username}, nil)
+
+				totpRepository.EXPECT().
+					DisableTotpTx(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "InvalidCode",
+			code: "000000",
+			buildMocks: func(totpRepository *mock.MockTotpRepository) {
+				totpRepository.EXPECT().
+					UseRecoveryCode(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrRecoveryCodeNotFound)
+
+				totpRepository.EXPECT().
+					DisableTotpTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidTotpCode)
+			},
+		},
+		{
+			name: "ReusedCode",
+			code: currentTotpCode(t, secret),
+			buildMocks: func(totpRepository *mock.MockTotpRepository) {
+				totpRepository.EXPECT().
+					UseTotpCounter(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ErrTotpCodeReused)
+
+				totpRepository.EXPECT().
+					DisableTotpTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidTotpCode)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			totpRepository := mock.NewMockTotpRepository(ctrl)
+
+			totpRepository.EXPECT().
+				GetUserTotp(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(userTotp, nil)
+
+			tc.buildMocks(totpRepository)
+
+			userApplication := NewUserApplication(nil, nil, nil, nil, totpRepository, nil, nil, nil, nil, nil, nil, nil, nil, config)
+
+			err := userApplication.DisableTotp(context.Background(), DisableTotp{Username: user.Username, Code: tc.code})
+			tc.checkResponse(t, err)
+		})
+	}
+}
+
+func TestGenerateRecoveryCodesUseCase(t *testing.T) {
+	config := newTotpConfig()
+	user, _ := randomUser(t)
+	userTotp, secret := randomUserTotp(t, config, user.Username)
+
+	ctrl := gomock.NewController(t)
+	totpRepository := mock.NewMockTotpRepository(ctrl)
+
+	totpRepository.EXPECT().
+		GetUserTotp(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(userTotp, nil)
+
+	totpRepository.EXPECT().
+		UseTotpCounter(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(nil)
+
+	var hashedCodes []string
+	totpRepository.EXPECT().
+		ReplaceRecoveryCodesTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg infra.ReplaceRecoveryCodesTx) error {
+			hashedCodes = arg.HashedCodes
+			return nil
+		})
+
+	userApplication := NewUserApplication(nil, nil, nil, nil, totpRepository, nil, nil, nil, nil, nil, nil, nil, nil, config)
+
+	recoveryCodes, err := userApplication.GenerateRecoveryCodes(context.Background(), GenerateRecoveryCodes{Username: user.Username, Code: currentTotpCode(t, secret)})
+	require.NoError(t, err)
+	require.Len(t, recoveryCodes, recoveryCodeCount)
+
+	for i, code := range recoveryCodes {
+		require.Regexp(t, `^[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}$`, code)
+		require.Equal(t, hashRecoveryCode(code), hashedCodes[i])
+	}
+
+	_, err = userApplication.GenerateRecoveryCodes(context.Background(), GenerateRecoveryCodes{Username: user.Username, Code: recoveryCodes[0]})
+	require.Error(t, err)
+}
+
+func TestLoginUserTotpChallenge(t *testing.T) {
+	config := newTotpConfig()
+	user, password := randomUser(t)
+	user.IsTotpEnabled = true
+
+	ctrl := gomock.NewController(t)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	sessionRepository := mock.NewMockSessionRepository(ctrl)
+
+	userRepository.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+
+	sessionRepository.EXPECT().
+		CreateSession(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	userApplication := NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, nil, nil, nil, tokenMaker, nil, config)
+
+	result, err := userApplication.Login(context.Background(), LoginUser{Username: user.Username, Password: password})
+	require.NoError(t, err)
+	require.True(t, result.MfaRequired)
+	require.Empty(t, result.AccessToken)
+	require.Empty(t, result.RefreshToken)
+	require.WithinDuration(t, time.Now().Add(config.MfaChallengeDuration), result.MfaTokenExpiresAt, time.Second)
+
+	_, err = tokenMaker.VerifyToken(result.MfaToken)
+	require.Error(t, err)
+
+	payload, err := tokenMaker.VerifyToken(result.MfaToken, token.RequirePurpose(token.PurposeMFAChallenge))
+	require.NoError(t, err)
+	require.Equal(t, user.Username, payload.Username)
+}
+
+func TestVerifyLoginTotpUseCase(t *testing.T) {
+	config := newTotpConfig()
+	user, _ := randomUser(t)
+	user.IsTotpEnabled = true
+	userTotp, secret := randomUserTotp(t, config, user.Username)
+	session := randomSession(t, user.Username)
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	mfaToken, _, err := tokenMaker.CreateToken(user.Username, user.Role, time.Minute, token.WithPurpose(token.PurposeMFAChallenge))
+	require.NoError(t, err)
+
+	accessToken, _, err := tokenMaker.CreateToken(user.Username, user.Role, time.Minute)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		arg           VerifyLoginTotp
+		buildMocks    func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, totpRepository *mock.MockTotpRepository)
+		checkResponse func(t *testing.T, result *LoginUserResult, err error)
+	}{
+		{
+			name: "OK",
+			arg:  VerifyLoginTotp{MfaToken: mfaToken, Code: currentTotpCode(t, secret)},
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, totpRepository *mock.MockTotpRepository) {
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				totpRepository.EXPECT().
+					GetUserTotp(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(userTotp, nil)
+
+				totpRepository.EXPECT().
+					UseTotpCounter(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+
+				sessionRepository.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(session, nil)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.NoError(t, err)
+				require.False(t, result.MfaRequired)
+				require.Equal(t, session.ID, result.SessionId)
+				require.NotEmpty(t, result.AccessToken)
+				require.NotEmpty(t, result.RefreshToken)
+			},
+		},
+		{
+			name: "ReusedCode",
+			arg:  VerifyLoginTotp{MfaToken: mfaToken, Code: currentTotpCode(t, secret)},
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, totpRepository *mock.MockTotpRepository) {
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				totpRepository.EXPECT().
+					GetUserTotp(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(userTotp, nil)
+
+				totpRepository.EXPECT().
+					UseTotpCounter(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ErrTotpCodeReused)
+
+				sessionRepository.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.ErrorIs(t, err, ErrInvalidTotpCode)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name: "AccessTokenInsteadOfMfaToken",
+			arg:  VerifyLoginTotp{MfaToken: accessToken, Code: currentTotpCode(t, secret)},
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, totpRepository *mock.MockTotpRepository) {
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				sessionRepository.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.ErrorIs(t, err, ErrInvalidMfaToken)
+				require.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			sessionRepository := mock.NewMockSessionRepository(ctrl)
+			totpRepository := mock.NewMockTotpRepository(ctrl)
+
+			tc.buildMocks(userRepository, sessionRepository, totpRepository)
+
+			userApplication := NewUserApplication(userRepository, sessionRepository, nil, nil, totpRepository, nil, nil, staticPermissions{}, discardAuthEvents{}, nil, nil, tokenMaker, nil, config)
+
+			result, err := userApplication.VerifyLoginTotp(context.Background(), tc.arg)
+			tc.checkResponse(t, result, err)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -19,37 +20,84 @@ type UserRepository interface {
 	CreateUser(ctx context.Context, arg infra.CreateUser) (*domain.User, error)
 	CreateUserTx(ctx context.Context, arg infra.CreateUserTx) (infra.CreateUserTxResult, error)
 	GetUser(ctx context.Context, username string) (*domain.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
 	UpdateUser(ctx context.Context, arg infra.UpdateUser) (*domain.User, error)
+	UpdateUserTx(ctx context.Context, arg infra.UpdateUserTx) (infra.UpdateUserTxResult, error)
+	ListUsers(ctx context.Context, arg infra.ListUsers) ([]*domain.User, error)
+	SetUserRole(ctx context.Context, username string, role string) (*domain.User, error)
+	SetUserDisabled(ctx context.Context, username string, disabled bool) (*domain.User, error)
+	RequirePasswordReset(ctx context.Context, username string) (*domain.User, error)
+	DeleteUser(ctx context.Context, username string) error
+	SoftDeleteUser(ctx context.Context, username string) (*domain.User, error)
+	ExportUserData(ctx context.Context, username string) (*domain.UserDataExport, error)
 }
 
 type SessionRepository interface {
 	CreateSession(ctx context.Context, arg infra.CreateSession) (*domain.Session, error)
 	GetSession(ctx context.Context, id uuid.UUID) (*domain.Session, error)
+	RotateSessionTx(ctx context.Context, arg infra.RotateSessionTx) (infra.RotateSessionTxResult, error)
+	BlockSessionFamily(ctx context.Context, familyID uuid.UUID) error
+	ListActiveSessions(ctx context.Context, username string) ([]*domain.Session, error)
+	BlockUserSessions(ctx context.Context, arg infra.BlockUserSessions) error
+	ListSessionDevices(ctx context.Context, username string) ([]*domain.SessionDevice, error)
+}
+
+// PermissionRepository reads the permissions granted to a user by their roles.
+type PermissionRepository interface {
+	ListUserPermissions(ctx context.Context, username string) ([]string, error)
 }
 
 type JwtTokenMaker interface {
-	CreateToken(username string, role string, duration time.Duration) (string, *token.Payload, error)
+	CreateToken(username string, role string, duration time.Duration, opts ...token.PayloadOption) (string, *token.Payload, error)
+	VerifyToken(token string, opts ...token.VerifyOption) (*token.Payload, error)
+}
+
+type Denylist interface {
+	RevokeToken(ctx context.Context, tokenID uuid.UUID, expiresAt time.Time) error
+	RevokeSession(ctx context.Context, sessionID uuid.UUID, ttl time.Duration) error
+	BlockUser(ctx context.Context, username string, ttl time.Duration) error
 }
 
 type TaskDistributor interface {
 	DistributeTaskSendVerifyEmail(ctx context.Context, payload *worker.PayloadSendVerifyEmail, opts ...asynq.Option) error
+	DistributeTaskSendResetPassword(ctx context.Context, payload *worker.PayloadSendResetPassword, opts ...asynq.Option) error
+	DistributeTaskPurgeAccount(ctx context.Context, payload *worker.PayloadPurgeAccount, opts ...asynq.Option) error
+	DistributeTaskSendNewDeviceLogin(ctx context.Context, payload *worker.PayloadSendNewDeviceLogin, opts ...asynq.Option) error
 }
 
 type UserApplication struct {
-	userRepository     UserRepository
-	sessionRespository SessionRepository
-	taskDistributor    TaskDistributor
-	tokenMaker         JwtTokenMaker
-	config             *util.Config
+	userRepository          UserRepository
+	sessionRespository      SessionRepository
+	resetPasswordRepository ResetPasswordRepository
+	loginFailureRepository  LoginFailureRepository
+	totpRepository          TotpRepository
+	webauthnRepository      WebauthnRepository
+	externalLoginRepository ExternalLoginRepository
+	permissionRepository    PermissionRepository
+	authEventRepository     AuthEventRepository
+	identityProvider        ExternalIdentityProvider
+	taskDistributor         TaskDistributor
+	tokenMaker              JwtTokenMaker
+	denylist                Denylist
+	config                  *util.Config
 }
 
-func NewUserApplication(userRepository UserRepository, sessionRepository SessionRepository, taskDistributor TaskDistributor, tokenMaker JwtTokenMaker, config *util.Config) *UserApplication {
+func NewUserApplication(userRepository UserRepository, sessionRepository SessionRepository, resetPasswordRepository ResetPasswordRepository, loginFailureRepository LoginFailureRepository, totpRepository TotpRepository, webauthnRepository WebauthnRepository, externalLoginRepository ExternalLoginRepository, permissionRepository PermissionRepository, authEventRepository AuthEventRepository, identityProvider ExternalIdentityProvider, taskDistributor TaskDistributor, tokenMaker JwtTokenMaker, denylist Denylist, config *util.Config) *UserApplication {
 	return &UserApplication{
-		userRepository:     userRepository,
-		sessionRespository: sessionRepository,
-		taskDistributor:    taskDistributor,
-		tokenMaker:         tokenMaker,
-		config:             config,
+		userRepository:          userRepository,
+		sessionRespository:      sessionRepository,
+		resetPasswordRepository: resetPasswordRepository,
+		loginFailureRepository:  loginFailureRepository,
+		totpRepository:          totpRepository,
+		webauthnRepository:      webauthnRepository,
+		externalLoginRepository: externalLoginRepository,
+		permissionRepository:    permissionRepository,
+		authEventRepository:     authEventRepository,
+		identityProvider:        identityProvider,
+		taskDistributor:         taskDistributor,
+		tokenMaker:              tokenMaker,
+		denylist:                denylist,
+		config:                  config,
 	}
 }
 
@@ -79,17 +127,7 @@ func (u *UserApplication) Create(ctx context.Context, arg CreateUser) (*domain.U
 			Email:          arg.Email,
 		},
 		AfterCreate: func(user domain.User) error {
-			taskPayload := &worker.PayloadSendVerifyEmail{
-				Username: user.Username,
-			}
-
-			opts := []asynq.Option{
-				asynq.MaxRetry(10),
-				asynq.ProcessIn(10 * time.Second),
-				asynq.Queue(worker.CriticalQueue),
-			}
-
-			return u.taskDistributor.DistributeTaskSendVerifyEmail(ctx, taskPayload, opts...)
+			return u.distributeVerifyEmail(ctx, user.Username, asynq.ProcessIn(10*time.Second))
 		},
 	}
 
@@ -102,10 +140,15 @@ func (u *UserApplication) Create(ctx context.Context, arg CreateUser) (*domain.U
 }
 
 type UpdateUser struct {
-	Username string  `json:"username"`
-	FullName *string `json:"full_name"`
-	Email    *string `json:"email"`
-	Password *string `json:"password"`
+	Username         string    `json:"username"`
+	FullName         *string   `json:"full_name"`
+	Email            *string   `json:"email"`
+	Password         *string   `json:"password"`
+	CurrentPassword  *string   `json:"current_password"`
+	CurrentSessionID uuid.UUID `json:"-"`
+	// RequireCurrentPassword is set when users update their own account, who
+	// must then prove they know the password they change.
+	RequireCurrentPassword bool `json:"-"`
 }
 
 func (u *UserApplication) Update(ctx context.Context, arg UpdateUser) (*domain.User, error) {
@@ -113,6 +156,13 @@ func (u *UserApplication) Update(ctx context.Context, arg UpdateUser) (*domain.U
 		return nil, errValidation
 	}
 
+	if arg.Password != nil && arg.RequireCurrentPassword {
+		err := u.checkCurrentPassword(ctx, arg.Username, *arg.CurrentPassword)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	updateUserParams := infra.UpdateUser{
 		FullName: arg.FullName,
 		Username: arg.Username,
@@ -132,14 +182,111 @@ func (u *UserApplication) Update(ctx context.Context, arg UpdateUser) (*domain.U
 		updateUserParams.PasswordChangedAt = &now
 	}
 
-	user, err := u.userRepository.UpdateUser(ctx, updateUserParams)
+	user, err := u.updateUser(ctx, updateUserParams)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
+	if arg.Password != nil {
+		u.recordAuthEvent(ctx, domain.AuthEventPasswordChange, user.Username, domain.AuthEventSuccess)
+
+		err = u.revokeUserSessions(ctx, user.Username, arg.CurrentSessionID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return user, nil
 }
 
+// updateUser goes through UpdateUserTx when the email may change, so a new
+// address is marked as not verified and gets a verification code.
+func (u *UserApplication) updateUser(ctx context.Context, arg infra.UpdateUser) (*domain.User, error) {
+	if arg.Email == nil {
+		return u.userRepository.UpdateUser(ctx, arg)
+	}
+
+	res, err := u.userRepository.UpdateUserTx(ctx, infra.UpdateUserTx{
+		UpdateUser: arg,
+		AfterEmailChange: func(user domain.User) error {
+			return u.distributeVerifyEmail(ctx, user.Username)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res.User, nil
+}
+
+type ResendVerifyEmail struct {
+	Email string `json:"email"`
+}
+
+// ResendVerifyEmail sends a new verification code to arg.Email. At most one
+// code is sent per VERIFY_EMAIL_RESEND_INTERVAL; like RequestPasswordReset it
+// answers the same way for unknown, verified and rate limited emails, so it
+// cannot be used to find out which emails are registered.
+func (u *UserApplication) ResendVerifyEmail(ctx context.Context, arg ResendVerifyEmail) error {
+	if errValidation := validateResendVerifyEmailParams(arg); errValidation != nil {
+		return errValidation
+	}
+
+	user, err := u.userRepository.GetUserByEmail(ctx, arg.Email)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			log.Info().Msg("verify email resend requested for unknown email")
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.IsEmailVerified {
+		return nil
+	}
+
+	err = u.distributeVerifyEmail(ctx, user.Username, asynq.Unique(u.config.VerifyEmailResendInterval))
+	if err != nil {
+		if errors.Is(err, asynq.ErrDuplicateTask) {
+			log.Info().Str("username", user.Username).Msg("verify email resend rate limited")
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (u *UserApplication) distributeVerifyEmail(ctx context.Context, username string, opts ...asynq.Option) error {
+	taskPayload := &worker.PayloadSendVerifyEmail{
+		Username: username,
+	}
+
+	opts = append([]asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.CriticalQueue),
+	}, opts...)
+
+	return u.taskDistributor.DistributeTaskSendVerifyEmail(ctx, taskPayload, opts...)
+}
+
+// checkCurrentPassword records a failed password change when the current
+// password is wrong.
+func (u *UserApplication) checkCurrentPassword(ctx context.Context, username string, currentPassword string) error {
+	user, err := u.userRepository.GetUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	err = util.CheckPassword(currentPassword, user.HashedPassword)
+	if err != nil {
+		u.recordAuthEvent(ctx, domain.AuthEventPasswordChange, user.Username, domain.AuthEventFailure)
+		return ErrInvalidCurrentPassword
+	}
+
+	return nil
+}
+
 type LoginUser struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
@@ -152,13 +299,57 @@ type LoginUserResult struct {
 	RefreshToken          string       `json:"refresh_token"`
 	AccessTokenExpiresAt  time.Time    `json:"access_token_expires_at"`
 	RefreshTokenExpiresAt time.Time    `json:"refresh_token_expires_at"`
+	// MfaRequired is set instead of the tokens when the user has TOTP enabled,
+	// MfaToken must then be sent to VerifyLoginTotp with a valid code.
+	MfaRequired       bool      `json:"mfa_required"`
+	MfaToken          string    `json:"mfa_token"`
+	MfaTokenExpiresAt time.Time `json:"mfa_token_expires_at"`
 }
 
 func (u *UserApplication) Login(ctx context.Context, arg LoginUser) (*LoginUserResult, error) {
+	user, err := u.authenticate(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsTotpEnabled {
+		return u.createMfaChallenge(ctx, user)
+	}
+
+	return u.createLoginSession(ctx, user)
+}
+
+// authenticate checks the password of a login, throttled by the failed
+// attempts of the user and of the client address. The second factor is left to
+// the caller.
+func (u *UserApplication) authenticate(ctx context.Context, arg LoginUser) (*domain.User, error) {
 	if errValidation := validateLoginUserParams(arg); errValidation != nil {
 		return nil, errValidation
 	}
 
+	user, err := u.checkLoginPassword(ctx, arg)
+	if err != nil {
+		u.recordAuthEvent(ctx, domain.AuthEventLogin, arg.Username, domain.AuthEventFailure)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (u *UserApplication) checkLoginPassword(ctx context.Context, arg LoginUser) (*domain.User, error) {
+	metadata := util.ExtractMetadata(ctx, u.config.TrustedProxies)
+	clientIP := loginClientIP(metadata.ClientIP)
+
+	err := u.checkLoginAllowed(ctx, arg.Username, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.countLoginAttempt(ctx, arg.Username, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := u.userRepository.GetUser(ctx, arg.Username)
 	if err != nil {
 		return nil, err
@@ -169,31 +360,81 @@ func (u *UserApplication) Login(ctx context.Context, arg LoginUser) (*LoginUserR
 		return nil, ErrInvalidLoginPassword
 	}
 
-	accessToken, accessPayload, err := u.tokenMaker.CreateToken(user.Username, user.Role, u.config.AccessTokenDuration)
+	err = u.resetLoginFailures(ctx, user.Username, clientIP)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to hash password")
-		return nil, fmt.Errorf("failed to create token: %w", err)
+		return nil, err
+	}
+
+	err = checkUserActive(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// createLoginSession issues the access and refresh tokens of a user that
+// passed every login check.
+func (u *UserApplication) createLoginSession(ctx context.Context, user *domain.User) (*LoginUserResult, error) {
+	return u.createClientSession(ctx, user, nil, nil)
+}
+
+// createClientSession is createLoginSession for the OpenID Connect client
+// clientID, or for this service itself when it is nil. The session remembers
+// the client, so only that client can renew it, and the tokens carry the
+// scopes granted to it.
+func (u *UserApplication) createClientSession(ctx context.Context, user *domain.User, clientID *string, scopes []string) (*LoginUserResult, error) {
+	err := checkUserActive(user)
+	if err != nil {
+		return nil, err
 	}
 
-	refreshToken, refreshPayload, err := u.tokenMaker.CreateToken(user.Username, user.Role, u.config.RefreshTokenDuration)
+	emailOptions, err := u.emailVerifiedOptions(user)
+	if err != nil {
+		u.recordAuthEvent(ctx, domain.AuthEventLogin, user.Username, domain.AuthEventFailure)
+		return nil, err
+	}
+
+	permissionOptions, err := u.permissionOptions(ctx, user.Username, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshPayload, err := u.tokenMaker.CreateToken(user.Username, user.Role, u.config.RefreshTokenDuration, u.refreshTokenOptions(clientID, scopes)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create refresh token: %w", err)
 	}
 
-	metadata := util.ExtractMetadata(ctx)
+	accessOptions := append(append(emailOptions, permissionOptions...), token.WithSessionID(refreshPayload.ID))
+	accessToken, accessPayload, err := u.tokenMaker.CreateToken(user.Username, user.Role, u.config.AccessTokenDuration, u.accessTokenOptions(clientID, scopes, accessOptions...)...)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create access token")
+		return nil, fmt.Errorf("failed to create token: %w", err)
+	}
+
+	metadata := util.ExtractMetadata(ctx, u.config.TrustedProxies)
+	newDevice := u.isNewLoginDevice(ctx, user.Username, metadata)
 	session, err := u.sessionRespository.CreateSession(ctx, infra.CreateSession{
 		ID:           refreshPayload.ID,
+		FamilyID:     refreshPayload.ID,
 		Username:     user.Username,
 		RefreshToken: refreshToken,
 		UserAgent:    metadata.UserAgent,
 		ClientIp:     metadata.ClientIP,
 		IsBlocked:    false,
 		ExpiresAt:    refreshPayload.ExpiredAt,
+		ClientID:     clientID,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	u.recordAuthEvent(ctx, domain.AuthEventLogin, user.Username, domain.AuthEventSuccess)
+
+	if newDevice {
+		u.alertNewDeviceLogin(ctx, user, session)
+	}
+
 	response := &LoginUserResult{
 		User:                  user,
 		SessionId:             session.ID,
@@ -205,3 +446,271 @@ func (u *UserApplication) Login(ctx context.Context, arg LoginUser) (*LoginUserR
 
 	return response, nil
 }
+
+type RenewAccessToken struct {
+	RefreshToken string `json:"refresh_token"`
+	// ClientID is set when an OpenID Connect client renews its tokens, the
+	// session must then belong to that client.
+	ClientID *string `json:"-"`
+}
+
+type RenewAccessTokenResult struct {
+	SessionId             uuid.UUID `json:"session_id"`
+	AccessToken           string    `json:"access_token"`
+	RefreshToken          string    `json:"refresh_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+func (u *UserApplication) RenewAccessToken(ctx context.Context, arg RenewAccessToken) (*RenewAccessTokenResult, error) {
+	if errValidation := validateRenewAccessTokenParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	purpose := token.PurposeRefresh
+	if arg.ClientID != nil {
+		purpose = token.PurposeOidcRefresh
+	}
+
+	refreshPayload, err := u.tokenMaker.VerifyToken(arg.RefreshToken, append(u.verifyOptions(), token.RequirePurpose(purpose))...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRefreshToken, err)
+	}
+
+	session, err := u.sessionRespository.GetSession(ctx, refreshPayload.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.RotatedAt != nil {
+		return nil, u.blockReusedSessionFamily(ctx, session)
+	}
+
+	if session.IsBlocked {
+		return nil, ErrBlockedSession
+	}
+
+	if session.Username != refreshPayload.Username {
+		return nil, ErrIncorrectSessionUser
+	}
+
+	if !equalClientID(session.ClientID, arg.ClientID) {
+		return nil, ErrIncorrectSessionClient
+	}
+
+	if session.RefreshToken != arg.RefreshToken {
+		return nil, ErrMismatchedSessionToken
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		return nil, ErrExpiredSession
+	}
+
+	// the verification status may have changed since login, so it is read again
+	var emailOptions []token.PayloadOption
+	if u.reportsEmailVerified() {
+		user, err := u.userRepository.GetUser(ctx, refreshPayload.Username)
+		if err != nil {
+			return nil, err
+		}
+
+		emailOptions, err = u.emailVerifiedOptions(user)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// roles may have changed since login as well
+	permissionOptions, err := u.permissionOptions(ctx, refreshPayload.Username, session.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the rotated refresh token keeps the family expiration, so renewing never extends the login lifetime
+	refreshToken, newRefreshPayload, err := u.tokenMaker.CreateToken(refreshPayload.Username, refreshPayload.Role, time.Until(session.ExpiresAt), u.refreshTokenOptions(session.ClientID, refreshPayload.Scopes)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	accessOptions := append(append(emailOptions, permissionOptions...), token.WithSessionID(session.FamilyID))
+	accessToken, accessPayload, err := u.tokenMaker.CreateToken(refreshPayload.Username, refreshPayload.Role, u.config.AccessTokenDuration, u.accessTokenOptions(session.ClientID, refreshPayload.Scopes, accessOptions...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token: %w", err)
+	}
+
+	metadata := util.ExtractMetadata(ctx, u.config.TrustedProxies)
+	txResult, err := u.sessionRespository.RotateSessionTx(ctx, infra.RotateSessionTx{
+		ParentID: session.ID,
+		Session: infra.CreateSession{
+			ID:           newRefreshPayload.ID,
+			Username:     session.Username,
+			RefreshToken: refreshToken,
+			UserAgent:    metadata.UserAgent,
+			ClientIp:     metadata.ClientIP,
+			IsBlocked:    false,
+			ExpiresAt:    newRefreshPayload.ExpiredAt,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionRotated) {
+			return nil, u.blockReusedSessionFamily(ctx, session)
+		}
+		return nil, fmt.Errorf("failed to rotate session: %w", err)
+	}
+
+	response := &RenewAccessTokenResult{
+		SessionId:             txResult.Session.ID,
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		AccessTokenExpiresAt:  accessPayload.ExpiredAt,
+		RefreshTokenExpiresAt: newRefreshPayload.ExpiredAt,
+	}
+
+	return response, nil
+}
+
+func (u *UserApplication) blockReusedSessionFamily(ctx context.Context, session *domain.Session) error {
+	log.Warn().
+		Str("username", session.Username).
+		Str("session_id", session.ID.String()).
+		Str("family_id", session.FamilyID.String()).
+		Msg("refresh token reuse detected, blocking session family")
+
+	err := u.revokeSessionFamily(ctx, session.FamilyID)
+	if err != nil {
+		return err
+	}
+
+	return ErrRefreshTokenReused
+}
+
+// checkUserActive refuses the logins of deleted accounts and of accounts an
+// admin disabled or asked to reset their password.
+func checkUserActive(user *domain.User) error {
+	if user.IsDeleted() {
+		return ErrAccountDeleted
+	}
+
+	if user.IsDisabled() {
+		return ErrAccountDisabled
+	}
+
+	if user.PasswordResetRequired {
+		return ErrPasswordResetRequired
+	}
+
+	return nil
+}
+
+// reportsEmailVerified tells whether access tokens carry the email_verified
+// claim, which is the case for every UNVERIFIED_EMAIL_POLICY but allow.
+func (u *UserApplication) reportsEmailVerified() bool {
+	switch u.config.UnverifiedEmailPolicy {
+	case util.UnverifiedEmailClaim, util.UnverifiedEmailDeny:
+		return true
+	}
+
+	return false
+}
+
+// emailVerifiedOptions applies UNVERIFIED_EMAIL_POLICY to a user about to get
+// an access token.
+func (u *UserApplication) emailVerifiedOptions(user *domain.User) ([]token.PayloadOption, error) {
+	if !u.reportsEmailVerified() {
+		return nil, nil
+	}
+
+	if !user.IsEmailVerified && u.config.UnverifiedEmailPolicy == util.UnverifiedEmailDeny {
+		return nil, ErrEmailNotVerified
+	}
+
+	return []token.PayloadOption{token.WithEmailVerified(user.IsEmailVerified)}, nil
+}
+
+// checkEmailVerified applies UNVERIFIED_EMAIL_POLICY to a login that does not
+// issue the access token right away, so the user is turned away before the
+// second factor or authorization code instead of after it.
+func (u *UserApplication) checkEmailVerified(ctx context.Context, user *domain.User) error {
+	_, err := u.emailVerifiedOptions(user)
+	if err != nil {
+		u.recordAuthEvent(ctx, domain.AuthEventLogin, user.Username, domain.AuthEventFailure)
+		return err
+	}
+
+	return nil
+}
+
+// tokenOptions stamps the configured issuer and audience on every issued token.
+func (u *UserApplication) tokenOptions(opts ...token.PayloadOption) []token.PayloadOption {
+	if u.config.TokenIssuer != "" {
+		opts = append(opts, token.WithIssuer(u.config.TokenIssuer))
+	}
+
+	if len(u.config.TokenAudience) > 0 {
+		opts = append(opts, token.WithAudience(u.config.TokenAudience...))
+	}
+
+	return opts
+}
+
+// permissionOptions puts the effective permissions of a user in the access
+// tokens of this service. Tokens issued to OpenID Connect clients are limited
+// to their scopes and never carry them.
+func (u *UserApplication) permissionOptions(ctx context.Context, username string, clientID *string) ([]token.PayloadOption, error) {
+	if clientID != nil {
+		return nil, nil
+	}
+
+	permissions, err := u.permissionRepository.ListUserPermissions(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user permissions: %w", err)
+	}
+
+	return []token.PayloadOption{token.WithPermissions(permissions...)}, nil
+}
+
+// accessTokenOptions are the tokenOptions of an access token. Tokens of an
+// OpenID Connect client are only meant for that client and the userinfo
+// endpoint, so they get their own purpose and audience.
+func (u *UserApplication) accessTokenOptions(clientID *string, scopes []string, opts ...token.PayloadOption) []token.PayloadOption {
+	if clientID == nil {
+		return u.tokenOptions(opts...)
+	}
+
+	return u.clientTokenOptions(*clientID, append(opts, token.WithPurpose(token.PurposeOidcAccess), token.WithScopes(scopes...))...)
+}
+
+// refreshTokenOptions are the tokenOptions of a refresh token. The purpose
+// keeps refresh tokens from being accepted as access tokens. Client refresh
+// tokens keep the granted scopes, so renewed access tokens get the same ones.
+func (u *UserApplication) refreshTokenOptions(clientID *string, scopes []string) []token.PayloadOption {
+	if clientID == nil {
+		return u.tokenOptions(token.WithPurpose(token.PurposeRefresh))
+	}
+
+	return u.clientTokenOptions(*clientID, token.WithPurpose(token.PurposeOidcRefresh), token.WithScopes(scopes...))
+}
+
+func (u *UserApplication) clientTokenOptions(clientID string, opts ...token.PayloadOption) []token.PayloadOption {
+	if u.config.TokenIssuer != "" {
+		opts = append(opts, token.WithIssuer(u.config.TokenIssuer))
+	}
+
+	return append(opts, token.WithAudience(clientID))
+}
+
+func equalClientID(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func (u *UserApplication) verifyOptions() []token.VerifyOption {
+	if u.config.TokenIssuer == "" {
+		return nil
+	}
+
+	return []token.VerifyOption{token.RequireIssuer(u.config.TokenIssuer)}
+}
@@ -0,0 +1,193 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/worker"
+	"github.com/rs/zerolog/log"
+)
+
+type ListUsers struct {
+	Role            *string    `json:"role"`
+	IsEmailVerified *bool      `json:"is_email_verified"`
+	CreatedAfter    *time.Time `json:"created_after"`
+	CreatedBefore   *time.Time `json:"created_before"`
+	PageID          int32      `json:"page_id"`
+	PageSize        int32      `json:"page_size"`
+}
+
+// ListUsers returns a page of users, newest first, for the admins.
+func (u *UserApplication) ListUsers(ctx context.Context, arg ListUsers) ([]*domain.User, error) {
+	if errValidation := validateListUsersParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	users, err := u.userRepository.ListUsers(ctx, infra.ListUsers{
+		Role:            arg.Role,
+		IsEmailVerified: arg.IsEmailVerified,
+		CreatedAfter:    arg.CreatedAfter,
+		CreatedBefore:   arg.CreatedBefore,
+		Limit:           arg.PageSize,
+		Offset:          (arg.PageID - 1) * arg.PageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return users, nil
+}
+
+type GetUser struct {
+	Username string `json:"username"`
+}
+
+func (u *UserApplication) GetUser(ctx context.Context, arg GetUser) (*domain.User, error) {
+	if errValidation := validateGetUserParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	return u.userRepository.GetUser(ctx, arg.Username)
+}
+
+// ManageUser is an admin action on the account Username. Admins cannot act on
+// their own account, so they cannot lock themselves out by mistake.
+type ManageUser struct {
+	Username  string `json:"username"`
+	ManagedBy string `json:"managed_by"`
+}
+
+type SetUserRole struct {
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	ManagedBy string `json:"managed_by"`
+}
+
+// SetUserRole changes the primary role of a user. Their sessions are revoked,
+// since their tokens carry the previous role.
+func (u *UserApplication) SetUserRole(ctx context.Context, arg SetUserRole) (*domain.User, error) {
+	if errValidation := validateSetUserRoleParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	if arg.Username == arg.ManagedBy {
+		return nil, ErrSelfManagement
+	}
+
+	user, err := u.userRepository.SetUserRole(ctx, arg.Username, arg.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.revokeUserSessions(ctx, user.Username, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Str("username", user.Username).Str("role", user.Role).Str("managed_by", arg.ManagedBy).Msg("user role changed")
+
+	return user, nil
+}
+
+// DisableUser refuses the logins of a user and revokes their sessions.
+func (u *UserApplication) DisableUser(ctx context.Context, arg ManageUser) (*domain.User, error) {
+	if errValidation := validateManageUserParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	if arg.Username == arg.ManagedBy {
+		return nil, ErrSelfManagement
+	}
+
+	user, err := u.userRepository.SetUserDisabled(ctx, arg.Username, true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.revokeUserSessions(ctx, user.Username, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Str("username", user.Username).Str("managed_by", arg.ManagedBy).Msg("account disabled")
+
+	return user, nil
+}
+
+func (u *UserApplication) EnableUser(ctx context.Context, arg ManageUser) (*domain.User, error) {
+	if errValidation := validateManageUserParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	user, err := u.userRepository.SetUserDisabled(ctx, arg.Username, false)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Str("username", user.Username).Str("managed_by", arg.ManagedBy).Msg("account enabled")
+
+	return user, nil
+}
+
+// ForcePasswordReset refuses the logins of a user until they reset their
+// password, revokes their sessions and emails them a reset code.
+func (u *UserApplication) ForcePasswordReset(ctx context.Context, arg ManageUser) (*domain.User, error) {
+	if errValidation := validateManageUserParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	user, err := u.userRepository.RequirePasswordReset(ctx, arg.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.revokeUserSessions(ctx, user.Username, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.CriticalQueue),
+	}
+
+	err = u.taskDistributor.DistributeTaskSendResetPassword(ctx, &worker.PayloadSendResetPassword{Username: user.Username}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to distribute reset password task: %w", err)
+	}
+
+	log.Info().Str("username", user.Username).Str("managed_by", arg.ManagedBy).Msg("password reset forced")
+
+	return user, nil
+}
+
+// DeleteUser deletes an account and everything stored about it. The access
+// tokens still in flight are denylisted.
+func (u *UserApplication) DeleteUser(ctx context.Context, arg ManageUser) error {
+	if errValidation := validateManageUserParams(arg); errValidation != nil {
+		return errValidation
+	}
+
+	if arg.Username == arg.ManagedBy {
+		return ErrSelfManagement
+	}
+
+	err := u.userRepository.DeleteUser(ctx, arg.Username)
+	if err != nil {
+		return err
+	}
+
+	err = u.denylist.BlockUser(ctx, arg.Username, u.config.AccessTokenDuration)
+	if err != nil {
+		return fmt.Errorf("failed to denylist user: %w", err)
+	}
+
+	log.Info().Str("username", arg.Username).Str("managed_by", arg.ManagedBy).Msg("account deleted")
+
+	return nil
+}
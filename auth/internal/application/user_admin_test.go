@@ -0,0 +1,274 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/golang/mock/gomock"
+	mock "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application/mock"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListUsersUseCase(t *testing.T) {
+	user, _ := randomUser(t)
+	role := domain.AdminRole
+	verified := true
+
+	ctrl := gomock.NewController(t)
+	userRepository := mock.NewMockUserRepository(ctrl)
+
+	userRepository.EXPECT().
+		ListUsers(gomock.Any(), gomock.Eq(infra.ListUsers{
+			Role:            &role,
+			IsEmailVerified: &verified,
+			Limit:           10,
+			Offset:          20,
+		})).
+		Times(1).
+		Return([]*domain.User{user}, nil)
+
+	userApplication := NewUserApplication(userRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	users, err := userApplication.ListUsers(context.Background(), ListUsers{
+		Role:            &role,
+		IsEmailVerified: &verified,
+		PageID:          3,
+		PageSize:        10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*domain.User{user}, users)
+
+	createdAfter := time.Now()
+	createdBefore := createdAfter.Add(-time.Hour)
+	_, err = userApplication.ListUsers(context.Background(), ListUsers{
+		CreatedAfter:  &createdAfter,
+		CreatedBefore: &createdBefore,
+		PageID:        1,
+		PageSize:      1000,
+	})
+	var validationErrors validation.Errors
+	require.ErrorAs(t, err, &validationErrors)
+	require.Contains(t, validationErrors, "created_before")
+	require.Contains(t, validationErrors, "page_size")
+}
+
+func TestDisableUserUseCase(t *testing.T) {
+	user, _ := randomUser(t)
+	admin, _ := randomUser(t)
+	config := util.Config{AccessTokenDuration: time.Minute}
+
+	testCases := []struct {
+		name          string
+		arg           ManageUser
+		buildMocks    func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist)
+		checkResponse func(t *testing.T, disabled *domain.User, err error)
+	}{
+		{
+			name: "OK",
+			arg:  ManageUser{Username: user.Username, ManagedBy: admin.Username},
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist) {
+				disabledAt := time.Now()
+				disabledUser := *user
+				disabledUser.DisabledAt = &disabledAt
+
+				userRepository.EXPECT().
+					SetUserDisabled(gomock.Any(), gomock.Eq(user.Username), gomock.Eq(true)).
+					Times(1).
+					Return(&disabledUser, nil)
+
+				sessionRepository.EXPECT().
+					BlockUserSessions(gomock.Any(), gomock.Eq(infra.BlockUserSessions{Username: user.Username})).
+					Times(1).
+					Return(nil)
+
+				denylist.EXPECT().
+					BlockUser(gomock.Any(), gomock.Eq(user.Username), gomock.Eq(config.AccessTokenDuration)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, disabled *domain.User, err error) {
+				require.NoError(t, err)
+				require.True(t, disabled.IsDisabled())
+			},
+		},
+		{
+			name: "SelfManagement",
+			arg:  ManageUser{Username: admin.Username, ManagedBy: admin.Username},
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist) {
+				userRepository.EXPECT().SetUserDisabled(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				sessionRepository.EXPECT().BlockUserSessions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, disabled *domain.User, err error) {
+				require.ErrorIs(t, err, ErrSelfManagement)
+			},
+		},
+		{
+			name: "NotFound",
+			arg:  ManageUser{Username: user.Username, ManagedBy: admin.Username},
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, denylist *mock.MockDenylist) {
+				userRepository.EXPECT().
+					SetUserDisabled(gomock.Any(), gomock.Eq(user.Username), gomock.Eq(true)).
+					Times(1).
+					Return(nil, domain.ErrUserNotFound)
+
+				sessionRepository.EXPECT().BlockUserSessions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, disabled *domain.User, err error) {
+				require.ErrorIs(t, err, domain.ErrUserNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			sessionRepository := mock.NewMockSessionRepository(ctrl)
+			denylist := mock.NewMockDenylist(ctrl)
+			tc.buildMocks(userRepository, sessionRepository, denylist)
+
+			userApplication := NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, denylist, &config)
+
+			disabled, err := userApplication.DisableUser(context.Background(), tc.arg)
+			tc.checkResponse(t, disabled, err)
+		})
+	}
+}
+
+func TestForcePasswordResetUseCase(t *testing.T) {
+	user, _ := randomUser(t)
+	admin, _ := randomUser(t)
+	config := util.Config{AccessTokenDuration: time.Minute}
+
+	ctrl := gomock.NewController(t)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	sessionRepository := mock.NewMockSessionRepository(ctrl)
+	denylist := mock.NewMockDenylist(ctrl)
+	taskDistributor := mock.NewMockTaskDistributor(ctrl)
+
+	resetUser := *user
+	resetUser.PasswordResetRequired = true
+
+	userRepository.EXPECT().
+		RequirePasswordReset(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(&resetUser, nil)
+
+	sessionRepository.EXPECT().
+		BlockUserSessions(gomock.Any(), gomock.Eq(infra.BlockUserSessions{Username: user.Username})).
+		Times(1).
+		Return(nil)
+
+	denylist.EXPECT().
+		BlockUser(gomock.Any(), gomock.Eq(user.Username), gomock.Eq(config.AccessTokenDuration)).
+		Times(1).
+		Return(nil)
+
+	taskDistributor.EXPECT().
+		DistributeTaskSendResetPassword(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(nil)
+
+	userApplication := NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, nil, nil, nil, taskDistributor, nil, denylist, &config)
+
+	result, err := userApplication.ForcePasswordReset(context.Background(), ManageUser{Username: user.Username, ManagedBy: admin.Username})
+	require.NoError(t, err)
+	require.True(t, result.PasswordResetRequired)
+}
+
+func TestDeleteUserUseCase(t *testing.T) {
+	user, _ := randomUser(t)
+	admin, _ := randomUser(t)
+	config := util.Config{AccessTokenDuration: time.Minute}
+
+	ctrl := gomock.NewController(t)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	denylist := mock.NewMockDenylist(ctrl)
+
+	userRepository.EXPECT().
+		DeleteUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(nil)
+
+	denylist.EXPECT().
+		BlockUser(gomock.Any(), gomock.Eq(user.Username), gomock.Eq(config.AccessTokenDuration)).
+		Times(1).
+		Return(nil)
+
+	userApplication := NewUserApplication(userRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, denylist, &config)
+
+	err := userApplication.DeleteUser(context.Background(), ManageUser{Username: user.Username, ManagedBy: admin.Username})
+	require.NoError(t, err)
+
+	err = userApplication.DeleteUser(context.Background(), ManageUser{Username: admin.Username, ManagedBy: admin.Username})
+	require.ErrorIs(t, err, ErrSelfManagement)
+}
+
+func TestLoginUserInactiveAccount(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name      string
+		setupUser func(user *domain.User)
+		err       error
+	}{
+		{
+			name: "Deleted",
+			setupUser: func(user *domain.User) {
+				user.DeletedAt = &now
+			},
+			err: ErrAccountDeleted,
+		},
+		{
+			name: "Disabled",
+			setupUser: func(user *domain.User) {
+				user.DisabledAt = &now
+			},
+			err: ErrAccountDisabled,
+		},
+		{
+			name: "PasswordResetRequired",
+			setupUser: func(user *domain.User) {
+				user.PasswordResetRequired = true
+			},
+			err: ErrPasswordResetRequired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			user, password := randomUser(t)
+			tc.setupUser(user)
+
+			ctrl := gomock.NewController(t)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			sessionRepository := mock.NewMockSessionRepository(ctrl)
+
+			userRepository.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+
+			sessionRepository.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+
+			tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+			require.NoError(t, err)
+
+			config := util.Config{
+				AccessTokenDuration:  time.Minute,
+				RefreshTokenDuration: time.Minute,
+			}
+
+			userApplication := NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, discardAuthEvents{}, nil, nil, tokenMaker, nil, &config)
+
+			_, err = userApplication.Login(context.Background(), LoginUser{Username: user.Username, Password: password})
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
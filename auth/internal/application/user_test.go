@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net"
 	"reflect"
 	"testing"
 	"time"
@@ -11,13 +12,17 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
 	mock "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application/mock"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/worker"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/denylist"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 func TestCreateUserUseCase(t *testing.T) {
@@ -264,7 +269,7 @@ func TestCreateUserUseCase(t *testing.T) {
 
 			tc.buildMocks(userRespository, taskDistrubutor)
 
-			userApplication := NewUserApplication(userRespository, nil, taskDistrubutor, nil, nil)
+			userApplication := NewUserApplication(userRespository, nil, nil, nil, nil, nil, nil, nil, nil, nil, taskDistrubutor, nil, nil, nil)
 			res, err := userApplication.Create(context.Background(), tc.arg)
 			tc.checkResponse(t, res, err)
 		})
@@ -311,9 +316,12 @@ func TestUpdateUserUseCase(t *testing.T) {
 				}
 
 				store.EXPECT().
-					UpdateUser(gomock.Any(), gomock.Eq(arg)).
+					UpdateUserTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(updatedUser, nil)
+					DoAndReturn(func(_ context.Context, argTx infra.UpdateUserTx) (infra.UpdateUserTxResult, error) {
+						require.Equal(t, arg, argTx.UpdateUser)
+						return infra.UpdateUserTxResult{User: *updatedUser}, nil
+					})
 			},
 			checkResponse: func(t *testing.T, updatedUser *domain.User, err error) {
 				require.NoError(t, err)
@@ -466,9 +474,9 @@ func TestUpdateUserUseCase(t *testing.T) {
 			},
 			buildMocks: func(userRepository *mock.MockUserRepository) {
 				userRepository.EXPECT().
-					UpdateUser(gomock.Any(), gomock.Any()).
+					UpdateUserTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(&domain.User{}, sql.ErrConnDone)
+					Return(infra.UpdateUserTxResult{}, sql.ErrConnDone)
 			},
 			checkResponse: func(t *testing.T, res *domain.User, err error) {
 				require.Error(t, err)
@@ -483,13 +491,337 @@ func TestUpdateUserUseCase(t *testing.T) {
 
 			tc.buildMocks(userRespository)
 
-			userApplication := NewUserApplication(userRespository, nil, nil, nil, nil)
+			userApplication := NewUserApplication(userRespository, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 			res, err := userApplication.Update(context.Background(), tc.arg)
 			tc.checkResponse(t, res, err)
 		})
 	}
 }
 
+func TestUpdateUserEmailChangeSendsVerifyEmail(t *testing.T) {
+	user, _ := randomUser(t)
+	user.IsEmailVerified = true
+	newEmail := util.RandomEmail()
+
+	ctrl := gomock.NewController(t)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	taskDistributor := mock.NewMockTaskDistributor(ctrl)
+
+	userRepository.EXPECT().
+		UpdateUserTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg infra.UpdateUserTx) (infra.UpdateUserTxResult, error) {
+			require.Equal(t, newEmail, *arg.Email)
+
+			updatedUser := *user
+			updatedUser.Email = newEmail
+			updatedUser.IsEmailVerified = false
+
+			err := arg.AfterEmailChange(updatedUser)
+			return infra.UpdateUserTxResult{User: updatedUser, EmailChanged: true}, err
+		})
+
+	taskDistributor.EXPECT().
+		DistributeTaskSendVerifyEmail(gomock.Any(), gomock.Eq(&worker.PayloadSendVerifyEmail{Username: user.Username}), gomock.Any()).
+		Times(1).
+		Return(nil)
+
+	userApplication := NewUserApplication(userRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, taskDistributor, nil, nil, nil)
+
+	updatedUser, err := userApplication.Update(context.Background(), UpdateUser{
+		Username: user.Username,
+		Email:    &newEmail,
+	})
+	require.NoError(t, err)
+	require.Equal(t, newEmail, updatedUser.Email)
+	require.False(t, updatedUser.IsEmailVerified)
+}
+
+func TestResendVerifyEmailUseCase(t *testing.T) {
+	user, _ := randomUser(t)
+	verifiedUser, _ := randomUser(t)
+	verifiedUser.IsEmailVerified = true
+
+	config := util.Config{VerifyEmailResendInterval: time.Minute}
+
+	testCases := []struct {
+		name          string
+		arg           ResendVerifyEmail
+		buildMocks    func(userRepository *mock.MockUserRepository, taskDistributor *mock.MockTaskDistributor)
+		checkResponse func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			arg:  ResendVerifyEmail{Email: user.Email},
+			buildMocks: func(userRepository *mock.MockUserRepository, taskDistributor *mock.MockTaskDistributor) {
+				userRepository.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendVerifyEmail(gomock.Any(), gomock.Eq(&worker.PayloadSendVerifyEmail{Username: user.Username}), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "RateLimited",
+			arg:  ResendVerifyEmail{Email: user.Email},
+			buildMocks: func(userRepository *mock.MockUserRepository, taskDistributor *mock.MockTaskDistributor) {
+				userRepository.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendVerifyEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(fmt.Errorf("failed to enqueue task: %w", asynq.ErrDuplicateTask))
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "AlreadyVerified",
+			arg:  ResendVerifyEmail{Email: verifiedUser.Email},
+			buildMocks: func(userRepository *mock.MockUserRepository, taskDistributor *mock.MockTaskDistributor) {
+				userRepository.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(verifiedUser.Email)).
+					Times(1).
+					Return(verifiedUser, nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendVerifyEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "UnknownEmail",
+			arg:  ResendVerifyEmail{Email: util.RandomEmail()},
+			buildMocks: func(userRepository *mock.MockUserRepository, taskDistributor *mock.MockTaskDistributor) {
+				userRepository.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrUserNotFound)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendVerifyEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "EnqueueError",
+			arg:  ResendVerifyEmail{Email: user.Email},
+			buildMocks: func(userRepository *mock.MockUserRepository, taskDistributor *mock.MockTaskDistributor) {
+				userRepository.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendVerifyEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			taskDistributor := mock.NewMockTaskDistributor(ctrl)
+
+			tc.buildMocks(userRepository, taskDistributor)
+
+			userApplication := NewUserApplication(userRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, taskDistributor, nil, nil, &config)
+
+			err := userApplication.ResendVerifyEmail(context.Background(), tc.arg)
+			tc.checkResponse(t, err)
+		})
+	}
+}
+
+func TestUpdateUserPasswordRevokesOtherSessions(t *testing.T) {
+	user, password := randomUser(t)
+	newPassword := util.RandomString(8)
+	currentSessionID := uuid.New()
+
+	userCtrl := gomock.NewController(t)
+	userRepository := mock.NewMockUserRepository(userCtrl)
+
+	sessionCtrl := gomock.NewController(t)
+	sessionRepository := mock.NewMockSessionRepository(sessionCtrl)
+
+	userRepository.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+
+	userRepository.EXPECT().
+		UpdateUser(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg infra.UpdateUser) (*domain.User, error) {
+			require.NotNil(t, arg.HashedPassword)
+			require.NoError(t, util.CheckPassword(newPassword, *arg.HashedPassword))
+			require.NotNil(t, arg.PasswordChangedAt)
+			return user, nil
+		})
+
+	otherSession := randomSession(t, user.Username)
+	sessionRepository.EXPECT().
+		ListActiveSessions(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return([]*domain.Session{otherSession}, nil)
+
+	sessionRepository.EXPECT().
+		BlockUserSessions(gomock.Any(), gomock.Eq(infra.BlockUserSessions{
+			Username:       user.Username,
+			ExceptFamilyID: currentSessionID,
+		})).
+		Times(1).
+		Return(nil)
+
+	config := util.Config{AccessTokenDuration: time.Minute}
+	denylist := mock.NewMockDenylist(sessionCtrl)
+	denylist.EXPECT().
+		RevokeSession(gomock.Any(), gomock.Eq(otherSession.FamilyID), gomock.Eq(config.AccessTokenDuration)).
+		Times(1).
+		Return(nil)
+
+	userApplication := NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, nil, discardAuthEvents{}, nil, nil, nil, denylist, &config)
+
+	updatedUser, err := userApplication.Update(context.Background(), UpdateUser{
+		Username:               user.Username,
+		Password:               &newPassword,
+		CurrentPassword:        &password,
+		CurrentSessionID:       currentSessionID,
+		RequireCurrentPassword: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, user, updatedUser)
+}
+
+func TestUpdateUserCurrentPassword(t *testing.T) {
+	user, _ := randomUser(t)
+	newPassword := util.RandomString(8)
+	wrongPassword := util.RandomString(8)
+
+	testCases := []struct {
+		name          string
+		arg           UpdateUser
+		buildMocks    func(userRepository *mock.MockUserRepository, authEventRepository *mock.MockAuthEventRepository)
+		checkResponse func(t *testing.T, err error)
+	}{
+		{
+			name: "RequiredCurrentPassword",
+			arg: UpdateUser{
+				Username:               user.Username,
+				Password:               &newPassword,
+				RequireCurrentPassword: true,
+			},
+			buildMocks: func(userRepository *mock.MockUserRepository, authEventRepository *mock.MockAuthEventRepository) {
+				userRepository.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				userRepository.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				var valErr validation.Errors
+				require.ErrorAs(t, err, &valErr)
+				require.Contains(t, valErr, "current_password")
+			},
+		},
+		{
+			name: "WrongCurrentPassword",
+			arg: UpdateUser{
+				Username:               user.Username,
+				Password:               &newPassword,
+				CurrentPassword:        &wrongPassword,
+				RequireCurrentPassword: true,
+			},
+			buildMocks: func(userRepository *mock.MockUserRepository, authEventRepository *mock.MockAuthEventRepository) {
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				authEventRepository.EXPECT().
+					CreateAuthEvent(gomock.Any(), gomock.Eq(infra.CreateAuthEvent{
+						EventType: domain.AuthEventPasswordChange,
+						Username:  user.Username,
+						Outcome:   domain.AuthEventFailure,
+					})).
+					Times(1)
+
+				userRepository.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidCurrentPassword)
+			},
+		},
+		{
+			name: "NotRequiredForAdmins",
+			arg: UpdateUser{
+				Username: user.Username,
+				Password: &newPassword,
+			},
+			buildMocks: func(userRepository *mock.MockUserRepository, authEventRepository *mock.MockAuthEventRepository) {
+				userRepository.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+
+				userRepository.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(user, nil)
+
+				authEventRepository.EXPECT().
+					CreateAuthEvent(gomock.Any(), gomock.Eq(infra.CreateAuthEvent{
+						EventType: domain.AuthEventPasswordChange,
+						Username:  user.Username,
+						Outcome:   domain.AuthEventSuccess,
+					})).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			sessionRepository := mock.NewMockSessionRepository(ctrl)
+			authEventRepository := mock.NewMockAuthEventRepository(ctrl)
+			denylist := mock.NewMockDenylist(ctrl)
+			tc.buildMocks(userRepository, authEventRepository)
+
+			sessionRepository.EXPECT().ListActiveSessions(gomock.Any(), gomock.Any()).AnyTimes()
+			sessionRepository.EXPECT().BlockUserSessions(gomock.Any(), gomock.Any()).AnyTimes()
+			denylist.EXPECT().BlockUser(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+			config := util.Config{AccessTokenDuration: time.Minute}
+			userApplication := NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, nil, authEventRepository, nil, nil, nil, denylist, &config)
+
+			_, err := userApplication.Update(context.Background(), tc.arg)
+			tc.checkResponse(t, err)
+		})
+	}
+}
+
 func TestLoginUserUseCase(t *testing.T) {
 	user, password := randomUser(t)
 	session := randomSession(t, user.Username)
@@ -610,7 +942,7 @@ func TestLoginUserUseCase(t *testing.T) {
 				RefreshTokenDuration: time.Minute,
 			}
 
-			userApplication := NewUserApplication(userRepository, sessionRepository, nil, tokenMaker, &config)
+			userApplication := NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, discardAuthEvents{}, nil, nil, tokenMaker, nil, &config)
 
 			result, err := userApplication.Login(context.Background(), tc.arg)
 			tc.checkResponse(t, result, err)
@@ -618,6 +950,287 @@ func TestLoginUserUseCase(t *testing.T) {
 	}
 }
 
+func TestLoginUserRegisteredClaims(t *testing.T) {
+	user, password := randomUser(t)
+	session := randomSession(t, user.Username)
+
+	ctrl := gomock.NewController(t)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	sessionRepository := mock.NewMockSessionRepository(ctrl)
+
+	userRepository.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+
+	sessionRepository.EXPECT().
+		CreateSession(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(session, nil)
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	config := util.Config{
+		AccessTokenDuration:  time.Minute,
+		RefreshTokenDuration: time.Minute,
+		TokenIssuer:          "auth-service",
+		TokenAudience:        []string{"gateway", "auth-service"},
+	}
+
+	userApplication := NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, discardAuthEvents{}, nil, nil, tokenMaker, nil, &config)
+
+	result, err := userApplication.Login(context.Background(), LoginUser{Username: user.Username, Password: password})
+	require.NoError(t, err)
+
+	accessPayload, err := tokenMaker.VerifyToken(result.AccessToken, token.RequireIssuer("auth-service"), token.RequireAudience("gateway"))
+	require.NoError(t, err)
+	require.Equal(t, user.Username, accessPayload.Subject)
+
+	refreshPayload, err := tokenMaker.VerifyToken(result.RefreshToken, token.RequireIssuer("auth-service"), token.RequireAudience("gateway"), token.RequirePurpose(token.PurposeRefresh))
+	require.NoError(t, err)
+	require.Equal(t, user.Username, refreshPayload.Subject)
+
+	_, err = tokenMaker.VerifyToken(result.RefreshToken, token.RequireIssuer("auth-service"), token.RequireAudience("gateway"))
+	require.ErrorIs(t, err, token.ErrInvalidToken)
+}
+
+func TestLoginUserPermissions(t *testing.T) {
+	user, password := randomUser(t)
+	session := randomSession(t, user.Username)
+
+	ctrl := gomock.NewController(t)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	sessionRepository := mock.NewMockSessionRepository(ctrl)
+	permissionRepository := mock.NewMockPermissionRepository(ctrl)
+
+	userRepository.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+
+	permissionRepository.EXPECT().
+		ListUserPermissions(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return([]string{"product:read", "product:write"}, nil)
+
+	sessionRepository.EXPECT().
+		CreateSession(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(session, nil)
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	config := util.Config{
+		AccessTokenDuration:  time.Minute,
+		RefreshTokenDuration: time.Minute,
+	}
+
+	userApplication := NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, permissionRepository, discardAuthEvents{}, nil, nil, tokenMaker, nil, &config)
+
+	result, err := userApplication.Login(context.Background(), LoginUser{Username: user.Username, Password: password})
+	require.NoError(t, err)
+
+	accessPayload, err := tokenMaker.VerifyToken(result.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, []string{"product:read", "product:write"}, accessPayload.Permissions)
+	require.True(t, accessPayload.HasPermission("product:write"))
+
+	refreshPayload, err := tokenMaker.VerifyToken(result.RefreshToken, token.RequirePurpose(token.PurposeRefresh))
+	require.NoError(t, err)
+	require.Empty(t, refreshPayload.Permissions)
+}
+
+func TestLoginUserUnverifiedEmailPolicy(t *testing.T) {
+	testCases := []struct {
+		name          string
+		policy        string
+		verified      bool
+		checkResponse func(t *testing.T, payload *token.Payload, err error)
+	}{
+		{
+			name:     "AllowOmitsClaim",
+			policy:   util.UnverifiedEmailAllow,
+			verified: false,
+			checkResponse: func(t *testing.T, payload *token.Payload, err error) {
+				require.NoError(t, err)
+				require.Nil(t, payload.EmailVerified)
+				require.False(t, payload.HasVerifiedEmail())
+			},
+		},
+		{
+			name:     "ClaimUnverified",
+			policy:   util.UnverifiedEmailClaim,
+			verified: false,
+			checkResponse: func(t *testing.T, payload *token.Payload, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, payload.EmailVerified)
+				require.False(t, payload.HasVerifiedEmail())
+			},
+		},
+		{
+			name:     "ClaimVerified",
+			policy:   util.UnverifiedEmailClaim,
+			verified: true,
+			checkResponse: func(t *testing.T, payload *token.Payload, err error) {
+				require.NoError(t, err)
+				require.True(t, payload.HasVerifiedEmail())
+			},
+		},
+		{
+			name:     "DenyVerified",
+			policy:   util.UnverifiedEmailDeny,
+			verified: true,
+			checkResponse: func(t *testing.T, payload *token.Payload, err error) {
+				require.NoError(t, err)
+				require.True(t, payload.HasVerifiedEmail())
+			},
+		},
+		{
+			name:     "DenyUnverified",
+			policy:   util.UnverifiedEmailDeny,
+			verified: false,
+			checkResponse: func(t *testing.T, payload *token.Payload, err error) {
+				require.ErrorIs(t, err, ErrEmailNotVerified)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			user, password := randomUser(t)
+			user.IsEmailVerified = tc.verified
+			session := randomSession(t, user.Username)
+
+			ctrl := gomock.NewController(t)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			sessionRepository := mock.NewMockSessionRepository(ctrl)
+
+			userRepository.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+
+			sessionRepository.EXPECT().
+				CreateSession(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(session, nil)
+
+			tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+			require.NoError(t, err)
+
+			config := util.Config{
+				AccessTokenDuration:   time.Minute,
+				RefreshTokenDuration:  time.Minute,
+				UnverifiedEmailPolicy: tc.policy,
+			}
+
+			userApplication := NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, discardAuthEvents{}, nil, nil, tokenMaker, nil, &config)
+
+			result, err := userApplication.Login(context.Background(), LoginUser{Username: user.Username, Password: password})
+			if err != nil {
+				tc.checkResponse(t, nil, err)
+				return
+			}
+
+			payload, err := tokenMaker.VerifyToken(result.AccessToken)
+			tc.checkResponse(t, payload, err)
+		})
+	}
+}
+
+func TestRenewAccessTokenRefreshesEmailVerified(t *testing.T) {
+	user, _ := randomUser(t)
+	user.IsEmailVerified = true
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	refreshToken, refreshPayload, err := tokenMaker.CreateToken(user.Username, user.Role, time.Minute, token.WithPurpose(token.PurposeRefresh))
+	require.NoError(t, err)
+
+	session := &domain.Session{
+		ID:           refreshPayload.ID,
+		FamilyID:     refreshPayload.ID,
+		Username:     user.Username,
+		RefreshToken: refreshToken,
+		ExpiresAt:    refreshPayload.ExpiredAt,
+		CreatedAt:    refreshPayload.IssuedAt,
+	}
+
+	ctrl := gomock.NewController(t)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	sessionRepository := mock.NewMockSessionRepository(ctrl)
+
+	sessionRepository.EXPECT().
+		GetSession(gomock.Any(), gomock.Eq(refreshPayload.ID)).
+		Times(1).
+		Return(session, nil)
+
+	userRepository.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+
+	sessionRepository.EXPECT().
+		RotateSessionTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg infra.RotateSessionTx) (infra.RotateSessionTxResult, error) {
+			return infra.RotateSessionTxResult{
+				Parent: session,
+				Session: &domain.Session{
+					ID:           arg.Session.ID,
+					FamilyID:     session.FamilyID,
+					ParentID:     &session.ID,
+					Username:     arg.Session.Username,
+					RefreshToken: arg.Session.RefreshToken,
+					ExpiresAt:    arg.Session.ExpiresAt,
+				},
+			}, nil
+		})
+
+	config := util.Config{
+		AccessTokenDuration:   time.Minute,
+		RefreshTokenDuration:  time.Minute,
+		UnverifiedEmailPolicy: util.UnverifiedEmailClaim,
+	}
+
+	userApplication := NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, nil, nil, nil, tokenMaker, nil, &config)
+
+	result, err := userApplication.RenewAccessToken(context.Background(), RenewAccessToken{RefreshToken: refreshToken})
+	require.NoError(t, err)
+
+	payload, err := tokenMaker.VerifyToken(result.AccessToken)
+	require.NoError(t, err)
+	require.True(t, payload.HasVerifiedEmail())
+}
+
+func TestRenewAccessTokenForeignIssuer(t *testing.T) {
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	refreshToken, _, err := tokenMaker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute, token.WithPurpose(token.PurposeRefresh), token.WithIssuer("other-service"))
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	sessionRepository := mock.NewMockSessionRepository(ctrl)
+	sessionRepository.EXPECT().
+		GetSession(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	config := util.Config{
+		AccessTokenDuration: time.Minute,
+		TokenIssuer:         "auth-service",
+	}
+
+	userApplication := NewUserApplication(nil, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, nil, nil, nil, tokenMaker, nil, &config)
+
+	result, err := userApplication.RenewAccessToken(context.Background(), RenewAccessToken{RefreshToken: refreshToken})
+	require.ErrorIs(t, err, ErrInvalidRefreshToken)
+	require.Nil(t, result)
+}
+
 type eqCreateUserParamsTxMatcher struct {
 	arg      infra.CreateUserTx
 	password string
@@ -653,6 +1266,25 @@ func EqCreateUserTxParams(arg infra.CreateUserTx, password string, user domain.U
 	return eqCreateUserParamsTxMatcher{arg, password, user}
 }
 
+// staticPermissions grants the same permissions to every user.
+type staticPermissions []string
+
+func (p staticPermissions) ListUserPermissions(ctx context.Context, username string) ([]string, error) {
+	return p, nil
+}
+
+// discardAuthEvents stands in for the audit trail in the tests that do not
+// check it.
+type discardAuthEvents struct{}
+
+func (discardAuthEvents) CreateAuthEvent(ctx context.Context, arg infra.CreateAuthEvent) (*domain.AuthEvent, error) {
+	return &domain.AuthEvent{EventType: arg.EventType, Username: arg.Username, Outcome: arg.Outcome}, nil
+}
+
+func (discardAuthEvents) ListAuthEvents(ctx context.Context, arg infra.ListAuthEvents) ([]*domain.AuthEvent, error) {
+	return nil, nil
+}
+
 func randomUser(t *testing.T) (*domain.User, string) {
 	t.Helper()
 
@@ -674,8 +1306,10 @@ func randomUser(t *testing.T) (*domain.User, string) {
 func randomSession(t *testing.T, username string) *domain.Session {
 	t.Helper()
 
+	id := uuid.New()
 	session := domain.Session{
-		ID:           uuid.New(),
+		ID:           id,
+		FamilyID:     id,
 		Username:     username,
 		RefreshToken: "",
 		UserAgent:    "",
@@ -687,3 +1321,317 @@ func randomSession(t *testing.T, username string) *domain.Session {
 
 	return &session
 }
+
+// testGatewayIP is the peer of the requests the gateway forwards in tests. It
+// must be among the TrustedProxies of the config for x-forwarded-for to count.
+const testGatewayIP = "192.168.0.10"
+
+// newGatewayContext returns the context of a request the gateway forwarded for
+// a client at clientIP.
+func newGatewayContext(clientIP string, userAgent string) context.Context {
+	md := metadata.Pairs("x-forwarded-for", clientIP, "user-agent", userAgent)
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	return peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(testGatewayIP), Port: 50051}})
+}
+
+func TestRenewAccessTokenUseCase(t *testing.T) {
+	user, _ := randomUser(t)
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	refreshToken, refreshPayload, err := tokenMaker.CreateToken(user.Username, user.Role, time.Minute, token.WithPurpose(token.PurposeRefresh))
+	require.NoError(t, err)
+
+	accessToken, _, err := tokenMaker.CreateToken(user.Username, user.Role, time.Minute, token.WithSessionID(refreshPayload.ID))
+	require.NoError(t, err)
+
+	newSession := func() *domain.Session {
+		return &domain.Session{
+			ID:           refreshPayload.ID,
+			FamilyID:     refreshPayload.ID,
+			Username:     user.Username,
+			RefreshToken: refreshToken,
+			ExpiresAt:    refreshPayload.ExpiredAt,
+			CreatedAt:    refreshPayload.IssuedAt,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		arg           RenewAccessToken
+		buildMocks    func(sessionRepository *mock.MockSessionRepository)
+		checkResponse func(t *testing.T, result *RenewAccessTokenResult, err error)
+	}{
+		{
+			name: "OK",
+			arg: RenewAccessToken{
+				RefreshToken: refreshToken,
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(refreshPayload.ID)).
+					Times(1).
+					Return(newSession(), nil)
+
+				sessionRepository.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg infra.RotateSessionTx) (infra.RotateSessionTxResult, error) {
+						require.Equal(t, refreshPayload.ID, arg.ParentID)
+						require.Equal(t, user.Username, arg.Session.Username)
+						require.NotEqual(t, refreshToken, arg.Session.RefreshToken)
+						require.WithinDuration(t, refreshPayload.ExpiredAt, arg.Session.ExpiresAt, time.Second)
+
+						parent := newSession()
+						return infra.RotateSessionTxResult{
+							Parent: parent,
+							Session: &domain.Session{
+								ID:           arg.Session.ID,
+								FamilyID:     parent.FamilyID,
+								ParentID:     &parent.ID,
+								Username:     arg.Session.Username,
+								RefreshToken: arg.Session.RefreshToken,
+								ExpiresAt:    arg.Session.ExpiresAt,
+							},
+						}, nil
+					})
+
+				sessionRepository.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *RenewAccessTokenResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				require.NotEmpty(t, result.AccessToken)
+				require.NotEmpty(t, result.RefreshToken)
+				require.NotEqual(t, refreshToken, result.RefreshToken)
+				require.NotEqual(t, refreshPayload.ID, result.SessionId)
+				require.True(t, result.AccessTokenExpiresAt.After(time.Now()))
+				require.WithinDuration(t, refreshPayload.ExpiredAt, result.RefreshTokenExpiresAt, time.Second)
+
+				payload, err := tokenMaker.VerifyToken(result.AccessToken)
+				require.NoError(t, err)
+				require.Equal(t, user.Username, payload.Username)
+				require.Equal(t, user.Role, payload.Role)
+
+				newRefreshPayload, err := tokenMaker.VerifyToken(result.RefreshToken, token.RequirePurpose(token.PurposeRefresh))
+				require.NoError(t, err)
+				require.Equal(t, result.SessionId, newRefreshPayload.ID)
+			},
+		},
+		{
+			name: "ReusedRefreshToken",
+			arg: RenewAccessToken{
+				RefreshToken: refreshToken,
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository) {
+				session := newSession()
+				rotatedAt := time.Now()
+				session.RotatedAt = &rotatedAt
+
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(refreshPayload.ID)).
+					Times(1).
+					Return(session, nil)
+
+				sessionRepository.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Eq(session.FamilyID)).
+					Times(1).
+					Return(nil)
+
+				sessionRepository.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *RenewAccessTokenResult, err error) {
+				require.ErrorIs(t, err, ErrRefreshTokenReused)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name: "ConcurrentRotation",
+			arg: RenewAccessToken{
+				RefreshToken: refreshToken,
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(refreshPayload.ID)).
+					Times(1).
+					Return(newSession(), nil)
+
+				sessionRepository.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(infra.RotateSessionTxResult{}, domain.ErrSessionRotated)
+
+				sessionRepository.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Eq(refreshPayload.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, result *RenewAccessTokenResult, err error) {
+				require.ErrorIs(t, err, ErrRefreshTokenReused)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name: "RequiredRefreshToken",
+			arg: RenewAccessToken{
+				RefreshToken: "",
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *RenewAccessTokenResult, err error) {
+				require.Error(t, err)
+				_, ok := err.(validation.Errors)
+				require.True(t, ok)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name: "InvalidRefreshToken",
+			arg: RenewAccessToken{
+				RefreshToken: "invalid",
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *RenewAccessTokenResult, err error) {
+				require.ErrorIs(t, err, ErrInvalidRefreshToken)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name: "AccessTokenAsRefreshToken",
+			arg: RenewAccessToken{
+				RefreshToken: accessToken,
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *RenewAccessTokenResult, err error) {
+				require.ErrorIs(t, err, ErrInvalidRefreshToken)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name: "SessionNotFound",
+			arg: RenewAccessToken{
+				RefreshToken: refreshToken,
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(refreshPayload.ID)).
+					Times(1).
+					Return(nil, domain.ErrSessionNotFound)
+			},
+			checkResponse: func(t *testing.T, result *RenewAccessTokenResult, err error) {
+				require.ErrorIs(t, err, domain.ErrSessionNotFound)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name: "BlockedSession",
+			arg: RenewAccessToken{
+				RefreshToken: refreshToken,
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository) {
+				session := newSession()
+				session.IsBlocked = true
+
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(refreshPayload.ID)).
+					Times(1).
+					Return(session, nil)
+			},
+			checkResponse: func(t *testing.T, result *RenewAccessTokenResult, err error) {
+				require.ErrorIs(t, err, ErrBlockedSession)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name: "IncorrectSessionUser",
+			arg: RenewAccessToken{
+				RefreshToken: refreshToken,
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository) {
+				session := newSession()
+				session.Username = util.RandomUsername()
+
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(refreshPayload.ID)).
+					Times(1).
+					Return(session, nil)
+			},
+			checkResponse: func(t *testing.T, result *RenewAccessTokenResult, err error) {
+				require.ErrorIs(t, err, ErrIncorrectSessionUser)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name: "MismatchedSessionToken",
+			arg: RenewAccessToken{
+				RefreshToken: refreshToken,
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository) {
+				session := newSession()
+				session.RefreshToken = util.RandomString(32)
+
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(refreshPayload.ID)).
+					Times(1).
+					Return(session, nil)
+			},
+			checkResponse: func(t *testing.T, result *RenewAccessTokenResult, err error) {
+				require.ErrorIs(t, err, ErrMismatchedSessionToken)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name: "ExpiredSession",
+			arg: RenewAccessToken{
+				RefreshToken: refreshToken,
+			},
+			buildMocks: func(sessionRepository *mock.MockSessionRepository) {
+				session := newSession()
+				session.ExpiresAt = time.Now().Add(-time.Minute)
+
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(refreshPayload.ID)).
+					Times(1).
+					Return(session, nil)
+			},
+			checkResponse: func(t *testing.T, result *RenewAccessTokenResult, err error) {
+				require.ErrorIs(t, err, ErrExpiredSession)
+				require.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sessionCtrl := gomock.NewController(t)
+			sessionRepository := mock.NewMockSessionRepository(sessionCtrl)
+
+			tc.buildMocks(sessionRepository)
+
+			config := util.Config{
+				AccessTokenDuration: time.Minute,
+			}
+
+			userApplication := NewUserApplication(nil, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, nil, nil, nil, tokenMaker, denylist.NewMemoryDenylist(), &config)
+
+			result, err := userApplication.RenewAccessToken(context.Background(), tc.arg)
+			tc.checkResponse(t, result, err)
+		})
+	}
+}
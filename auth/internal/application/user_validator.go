@@ -1,15 +1,23 @@
 package application
 
 import (
+	"errors"
+	"fmt"
 	"regexp"
+	"slices"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/google/uuid"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
 )
 
 var (
-	isValidUsername = regexp.MustCompile(`^[a-z0-9_]+$`)
-	isValidFullName = regexp.MustCompile(`^[A-Za-z ]+$`)
+	isValidUsername   = regexp.MustCompile(`^[a-z0-9_]+$`)
+	isValidFullName   = regexp.MustCompile(`^[A-Za-z ]+$`)
+	isValidTotpCode   = regexp.MustCompile(`^[0-9]{6}$`)
+	isValidPermission = regexp.MustCompile(`^[a-z0-9_.:-]+$`)
 )
 
 func validateCreateUserParams(arg CreateUser) error {
@@ -25,15 +33,269 @@ func validateUpdateUserParams(arg UpdateUser) error {
 		validation.Field(&arg.Username, validateUsername()...),
 		validation.Field(&arg.FullName, validation.Length(3, 100), validation.Match(isValidFullName).Error("must contain only letter and spaces"), validation.NilOrNotEmpty),
 		validation.Field(&arg.Password, validation.Length(6, 200), validation.NilOrNotEmpty),
+		validation.Field(&arg.CurrentPassword, validateCurrentPassword(arg)...),
 		validation.Field(&arg.Email, validation.Length(3, 200), is.Email, validation.NilOrNotEmpty))
 }
 
+func validateCurrentPassword(arg UpdateUser) []validation.Rule {
+	rules := []validation.Rule{}
+	if arg.Password != nil && arg.RequireCurrentPassword {
+		rules = append(rules, validation.Required)
+	}
+	rules = append(rules, validation.NilOrNotEmpty)
+	return rules
+}
+
 func validateLoginUserParams(arg LoginUser) error {
 	return validation.ValidateStruct(&arg,
 		validation.Field(&arg.Username, validateUsername()...),
 		validation.Field(&arg.Password, validatePassword()...))
 }
 
+func validateRenewAccessTokenParams(arg RenewAccessToken) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.RefreshToken, validation.Required))
+}
+
+func validateRevokeSessionParams(arg RevokeSession) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.SessionID, validation.NotIn(uuid.Nil.String()).Error("cannot be blank")))
+}
+
+func validateRevokeNewDeviceSessionParams(arg RevokeNewDeviceSession) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Token, validation.Required))
+}
+
+func validateResendVerifyEmailParams(arg ResendVerifyEmail) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Email, validateEmail()...))
+}
+
+func validateRequestPasswordResetParams(arg RequestPasswordReset) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Email, validateEmail()...))
+}
+
+func validateResetPasswordParams(arg ResetPassword) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.ResetID, validation.Required),
+		validation.Field(&arg.SecretCode, validation.Required, validation.Length(32, 128)),
+		validation.Field(&arg.Password, validatePassword()...))
+}
+
+func validateUnlockUserParams(arg UnlockUser) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Username, validateUsername()...))
+}
+
+func validateEnrollTotpParams(arg EnrollTotp) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Username, validateUsername()...))
+}
+
+func validateConfirmTotpParams(arg ConfirmTotp) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Username, validateUsername()...),
+		validation.Field(&arg.Code, validateTotpCode()...))
+}
+
+func validateDisableTotpParams(arg DisableTotp) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Username, validateUsername()...),
+		validation.Field(&arg.Code, validateSecondFactorCode()...))
+}
+
+func validateGenerateRecoveryCodesParams(arg GenerateRecoveryCodes) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Username, validateUsername()...),
+		validation.Field(&arg.Code, validateTotpCode()...))
+}
+
+func validateVerifyLoginTotpParams(arg VerifyLoginTotp) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.MfaToken, validation.Required),
+		validation.Field(&arg.Code, validateSecondFactorCode()...))
+}
+
+func validateBeginWebauthnRegistrationParams(arg BeginWebauthnRegistration) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Username, validateUsername()...))
+}
+
+func validateFinishWebauthnRegistrationParams(arg FinishWebauthnRegistration) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Username, validateUsername()...),
+		validation.Field(&arg.SessionID, validation.NotIn(uuid.Nil).Error("cannot be blank")),
+		validation.Field(&arg.Credential, validation.Required))
+}
+
+func validateFinishWebauthnLoginParams(arg FinishWebauthnLogin) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.SessionID, validation.NotIn(uuid.Nil).Error("cannot be blank")),
+		validation.Field(&arg.Credential, validation.Required))
+}
+
+func validateBeginExternalLoginParams(arg BeginExternalLogin) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Provider, validation.Required, validation.Length(1, 100)))
+}
+
+func validateCompleteExternalLoginParams(arg CompleteExternalLogin) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Provider, validation.Required, validation.Length(1, 100)),
+		validation.Field(&arg.State, validation.Required),
+		validation.Field(&arg.Code, validation.Required))
+}
+
+func validateCreateOauthClientParams(arg CreateOauthClient) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Name, validation.Required, validation.Length(3, 100)),
+		validation.Field(&arg.RedirectURIs, validation.Required, validation.Each(validation.Required, is.RequestURL)),
+		validation.Field(&arg.Scopes, validation.Required, validation.Each(validation.In(stringsToAny(SupportedScopes)...)), validation.By(containsScope(ScopeOpenID))))
+}
+
+func validateCreateServiceAccountParams(arg CreateServiceAccount) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Name, validateUsername()...),
+		validation.Field(&arg.Description, validation.Length(0, 200)),
+		validation.Field(&arg.Role, validateRole()...),
+		validation.Field(&arg.Scopes, validateApiScopes()...),
+		validation.Field(&arg.ExpiresAt, validateApiKeyExpiresAt()...))
+}
+
+func validateRotateApiKeyParams(arg RotateApiKey) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.ID, validation.NotIn(uuid.Nil).Error("cannot be blank")),
+		validation.Field(&arg.ExpiresAt, validateApiKeyExpiresAt()...))
+}
+
+func validateRevokeApiKeyParams(arg RevokeApiKey) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.ID, validation.NotIn(uuid.Nil).Error("cannot be blank")))
+}
+
+func validateCreateRoleParams(arg CreateRole) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Name, validateRole()...),
+		validation.Field(&arg.Description, validation.Length(0, 200)))
+}
+
+func validateDeleteRoleParams(arg DeleteRole) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Name, append(validateRole(), validation.NotIn(stringsToAny(builtinRoles)...).Error("cannot delete a built-in role"))...))
+}
+
+func validateCreatePermissionParams(arg CreatePermission) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Name, validatePermission()...),
+		validation.Field(&arg.Description, validation.Length(0, 200)))
+}
+
+func validateSetRolePermissionsParams(arg SetRolePermissions) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Role, validateRole()...),
+		validation.Field(&arg.Permissions, validation.Each(validatePermission()...)))
+}
+
+func validateUserRoleParams(arg UserRoleParams) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Username, validateUsername()...),
+		validation.Field(&arg.Role, validateRole()...))
+}
+
+func validateGetUserRolesParams(arg GetUserRoles) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Username, validateUsername()...))
+}
+
+func validateListUsersParams(arg ListUsers) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Role, validation.NilOrNotEmpty, validation.Length(3, 50), validation.Match(isValidUsername).Error("must contain only letter, digits or underscores")),
+		validation.Field(&arg.CreatedBefore, validation.By(func(value any) error {
+			createdBefore, _ := value.(*time.Time)
+			if createdBefore != nil && arg.CreatedAfter != nil && !createdBefore.After(*arg.CreatedAfter) {
+				return errors.New("must be after created_after")
+			}
+			return nil
+		})),
+		validation.Field(&arg.PageID, validation.Required, validation.Min(int32(1))),
+		validation.Field(&arg.PageSize, validation.Required, validation.Min(int32(5)), validation.Max(int32(100))))
+}
+
+func validateGetUserParams(arg GetUser) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Username, validateUsername()...))
+}
+
+func validateManageUserParams(arg ManageUser) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Username, validateUsername()...),
+		validation.Field(&arg.ManagedBy, validateUsername()...))
+}
+
+func validateSetUserRoleParams(arg SetUserRole) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Username, validateUsername()...),
+		validation.Field(&arg.Role, validateRole()...),
+		validation.Field(&arg.ManagedBy, validateUsername()...))
+}
+
+func validateDeleteAccountParams(arg DeleteAccount) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Username, validateUsername()...))
+}
+
+func validateExportMyDataParams(arg ExportMyData) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.Username, validateUsername()...))
+}
+
+func validateListAuthEventsParams(arg ListAuthEvents) error {
+	return validation.ValidateStruct(&arg,
+		validation.Field(&arg.EventType, validation.In(domain.AuthEventLogin, domain.AuthEventPasswordChange, domain.AuthEventPasswordReset, domain.AuthEventEmailVerification)),
+		validation.Field(&arg.Username, validation.NilOrNotEmpty, validation.Length(3, 100), validation.Match(isValidUsername).Error("must contain only letter, digits or underscores")),
+		validation.Field(&arg.Outcome, validation.In(domain.AuthEventSuccess, domain.AuthEventFailure)),
+		validation.Field(&arg.CreatedBefore, validation.By(func(value any) error {
+			createdBefore, _ := value.(*time.Time)
+			if createdBefore != nil && arg.CreatedAfter != nil && !createdBefore.After(*arg.CreatedAfter) {
+				return errors.New("must be after created_after")
+			}
+			return nil
+		})),
+		validation.Field(&arg.PageToken, validation.By(func(value any) error {
+			pageToken, _ := value.(string)
+			if pageToken == "" {
+				return nil
+			}
+			if _, err := decodeAuthEventPageToken(pageToken); err != nil {
+				return errors.New("must be the next_page_token of a previous page")
+			}
+			return nil
+		})),
+		validation.Field(&arg.PageSize, validation.Required, validation.Min(int32(5)), validation.Max(int32(100))))
+}
+
+func stringsToAny(values []string) []any {
+	result := make([]any, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+
+	return result
+}
+
+func containsScope(scope string) validation.RuleFunc {
+	return func(value any) error {
+		scopes, _ := value.([]string)
+		if !slices.Contains(scopes, scope) {
+			return fmt.Errorf("must contain %s", scope)
+		}
+
+		return nil
+	}
+}
+
 func validateUsername() []validation.Rule {
 	rules := []validation.Rule{}
 	rules = append(rules, validation.Required)
@@ -57,6 +319,56 @@ func validatePassword() []validation.Rule {
 	return rules
 }
 
+func validateTotpCode() []validation.Rule {
+	rules := []validation.Rule{}
+	rules = append(rules, validation.Required)
+	rules = append(rules, validation.Match(isValidTotpCode).Error("must contain exactly 6 digits"))
+	return rules
+}
+
+// validateSecondFactorCode accepts TOTP codes and recovery codes.
+func validateSecondFactorCode() []validation.Rule {
+	rules := []validation.Rule{}
+	rules = append(rules, validation.Required)
+	rules = append(rules, validation.Length(6, 32))
+	return rules
+}
+
+func validateApiScopes() []validation.Rule {
+	rules := []validation.Rule{}
+	rules = append(rules, validation.Required)
+	rules = append(rules, validation.Each(validatePermission()...))
+	return rules
+}
+
+func validatePermission() []validation.Rule {
+	rules := []validation.Rule{}
+	rules = append(rules, validation.Required)
+	rules = append(rules, validation.Length(1, 100))
+	rules = append(rules, validation.Match(isValidPermission).Error("must contain only lowercase letters, digits, '_', '.', ':' or '-'"))
+	return rules
+}
+
+func validateRole() []validation.Rule {
+	rules := []validation.Rule{}
+	rules = append(rules, validation.Required)
+	rules = append(rules, validation.Length(3, 50))
+	rules = append(rules, validation.Match(isValidUsername).Error("must contain only letter, digits or underscores"))
+	return rules
+}
+
+func validateApiKeyExpiresAt() []validation.Rule {
+	rules := []validation.Rule{}
+	rules = append(rules, validation.By(func(value any) error {
+		expiresAt, _ := value.(*time.Time)
+		if expiresAt != nil && !expiresAt.After(time.Now()) {
+			return errors.New("must be in the future")
+		}
+		return nil
+	}))
+	return rules
+}
+
 func validateEmail() []validation.Rule {
 	rules := []validation.Rule{}
 	rules = append(rules, validation.Required)
@@ -2,26 +2,34 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/rs/zerolog/log"
 )
 
 type VerifyEmailRepository interface {
 	CreateVerifyEmail(ctx context.Context, arg infra.CreateVerifyEmail) (*domain.VerifyEmail, error)
+	GetVerifyEmail(ctx context.Context, id int64) (*domain.VerifyEmail, error)
 	UpdateVerifyEmail(ctx context.Context, arg infra.UpdateVerifyEmail) (*domain.VerifyEmail, error)
 	VerifyEmailTx(ctx context.Context, arg infra.VerifyEmailTx) (infra.VerifyEmailTxResult, error)
 }
 
 type VerifyEmailApplication struct {
 	verifyEmailRepository VerifyEmailRepository
+	authEventRepository   AuthEventRepository
+	config                *util.Config
 }
 
-func NewVerifyEmailApplication(verifyEmailRepository VerifyEmailRepository) *VerifyEmailApplication {
+func NewVerifyEmailApplication(verifyEmailRepository VerifyEmailRepository, authEventRepository AuthEventRepository, config *util.Config) *VerifyEmailApplication {
 	return &VerifyEmailApplication{
 		verifyEmailRepository: verifyEmailRepository,
+		authEventRepository:   authEventRepository,
+		config:                config,
 	}
 }
 
@@ -45,9 +53,14 @@ func (v *VerifyEmailApplication) VerifyEmail(ctx context.Context, arg VerifyEmai
 		SecreteCode: arg.SecretCode,
 	})
 	if err != nil {
+		if errors.Is(err, domain.ErrVerifyEmailNotFound) || errors.Is(err, domain.ErrVerifyEmailMismatch) {
+			v.recordVerifyEmailFailure(ctx, arg.EmailId)
+		}
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
+	recordAuthEvent(ctx, v.authEventRepository, v.config.TrustedProxies, domain.AuthEventEmailVerification, txResult.User.Username, domain.AuthEventSuccess)
+
 	response := &VerifyEmailResult{
 		User:        *txResult.User,
 		VerifyEmail: *txResult.VerifyEmail,
@@ -56,6 +69,20 @@ func (v *VerifyEmailApplication) VerifyEmail(ctx context.Context, arg VerifyEmai
 	return response, nil
 }
 
+// recordVerifyEmailFailure records a failed verification for the user the
+// email was sent to. Unknown email ids belong to no one and are not recorded.
+func (v *VerifyEmailApplication) recordVerifyEmailFailure(ctx context.Context, emailID int64) {
+	verifyEmail, err := v.verifyEmailRepository.GetVerifyEmail(ctx, emailID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrVerifyEmailNotFound) {
+			log.Error().Err(err).Int64("email_id", emailID).Msg("failed to get verify email")
+		}
+		return
+	}
+
+	recordAuthEvent(ctx, v.authEventRepository, v.config.TrustedProxies, domain.AuthEventEmailVerification, verifyEmail.Username, domain.AuthEventFailure)
+}
+
 func validateVerifyEmailRequest(arg VerifyEmail) error {
 	return validation.ValidateStruct(&arg,
 		validation.Field(&arg.EmailId, validation.Required),
@@ -33,7 +33,7 @@ func TestVerifyEmailUseCase(t *testing.T) {
 	testCases := []struct {
 		name          string
 		arg           VerifyEmail
-		buildMocks    func(arg VerifyEmail, verifyEmailRepository *mock.MockVerifyEmailRepository)
+		buildMocks    func(arg VerifyEmail, verifyEmailRepository *mock.MockVerifyEmailRepository, authEventRepository *mock.MockAuthEventRepository)
 		checkResponse func(t *testing.T, res *VerifyEmailResult, err error)
 	}{
 		{
@@ -42,7 +42,7 @@ func TestVerifyEmailUseCase(t *testing.T) {
 				EmailId:    verifyEmail.ID,
 				SecretCode: verifyEmail.SecretCode,
 			},
-			buildMocks: func(arg VerifyEmail, verifyEmailRepository *mock.MockVerifyEmailRepository) {
+			buildMocks: func(arg VerifyEmail, verifyEmailRepository *mock.MockVerifyEmailRepository, authEventRepository *mock.MockAuthEventRepository) {
 				txArg := infra.VerifyEmailTx{
 					EmailId:     arg.EmailId,
 					SecreteCode: arg.SecretCode,
@@ -57,6 +57,14 @@ func TestVerifyEmailUseCase(t *testing.T) {
 					VerifyEmailTx(gomock.Any(), txArg).
 					Times(1).
 					Return(txRes, nil)
+
+				authEventRepository.EXPECT().
+					CreateAuthEvent(gomock.Any(), gomock.Eq(infra.CreateAuthEvent{
+						EventType: domain.AuthEventEmailVerification,
+						Username:  user.Username,
+						Outcome:   domain.AuthEventSuccess,
+					})).
+					Times(1)
 			},
 			checkResponse: func(t *testing.T, res *VerifyEmailResult, err error) {
 				require.NoError(t, err)
@@ -71,7 +79,7 @@ func TestVerifyEmailUseCase(t *testing.T) {
 				EmailId:    0,
 				SecretCode: verifyEmail.SecretCode,
 			},
-			buildMocks: func(arg VerifyEmail, verifyEmailRepository *mock.MockVerifyEmailRepository) {
+			buildMocks: func(arg VerifyEmail, verifyEmailRepository *mock.MockVerifyEmailRepository, authEventRepository *mock.MockAuthEventRepository) {
 				verifyEmailRepository.EXPECT().
 					VerifyEmailTx(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -89,7 +97,7 @@ func TestVerifyEmailUseCase(t *testing.T) {
 				EmailId:    verifyEmail.ID,
 				SecretCode: "invalid",
 			},
-			buildMocks: func(arg VerifyEmail, verifyEmailRepository *mock.MockVerifyEmailRepository) {
+			buildMocks: func(arg VerifyEmail, verifyEmailRepository *mock.MockVerifyEmailRepository, authEventRepository *mock.MockAuthEventRepository) {
 				verifyEmailRepository.EXPECT().
 					VerifyEmailTx(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -101,15 +109,70 @@ func TestVerifyEmailUseCase(t *testing.T) {
 				require.True(t, ok)
 			},
 		},
+		{
+			name: "WrongSecretCode",
+			arg: VerifyEmail{
+				EmailId:    verifyEmail.ID,
+				SecretCode: util.RandomString(32),
+			},
+			buildMocks: func(arg VerifyEmail, verifyEmailRepository *mock.MockVerifyEmailRepository, authEventRepository *mock.MockAuthEventRepository) {
+				verifyEmailRepository.EXPECT().
+					VerifyEmailTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(infra.VerifyEmailTxResult{}, domain.ErrVerifyEmailNotFound)
+
+				verifyEmailRepository.EXPECT().
+					GetVerifyEmail(gomock.Any(), gomock.Eq(verifyEmail.ID)).
+					Times(1).
+					Return(verifyEmail, nil)
+
+				authEventRepository.EXPECT().
+					CreateAuthEvent(gomock.Any(), gomock.Eq(infra.CreateAuthEvent{
+						EventType: domain.AuthEventEmailVerification,
+						Username:  user.Username,
+						Outcome:   domain.AuthEventFailure,
+					})).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, res *VerifyEmailResult, err error) {
+				require.ErrorIs(t, err, domain.ErrVerifyEmailNotFound)
+				require.Nil(t, res)
+			},
+		},
+		{
+			name: "UnknownEmailId",
+			arg: VerifyEmail{
+				EmailId:    verifyEmail.ID + 1,
+				SecretCode: util.RandomString(32),
+			},
+			buildMocks: func(arg VerifyEmail, verifyEmailRepository *mock.MockVerifyEmailRepository, authEventRepository *mock.MockAuthEventRepository) {
+				verifyEmailRepository.EXPECT().
+					VerifyEmailTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(infra.VerifyEmailTxResult{}, domain.ErrVerifyEmailNotFound)
+
+				verifyEmailRepository.EXPECT().
+					GetVerifyEmail(gomock.Any(), gomock.Eq(arg.EmailId)).
+					Times(1).
+					Return(nil, domain.ErrVerifyEmailNotFound)
+
+				authEventRepository.EXPECT().CreateAuthEvent(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *VerifyEmailResult, err error) {
+				require.ErrorIs(t, err, domain.ErrVerifyEmailNotFound)
+				require.Nil(t, res)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			repositoryCtrl := gomock.NewController(t)
 			verifyEmailRespository := mock.NewMockVerifyEmailRepository(repositoryCtrl)
-			tc.buildMocks(tc.arg, verifyEmailRespository)
+			authEventRepository := mock.NewMockAuthEventRepository(repositoryCtrl)
+			tc.buildMocks(tc.arg, verifyEmailRespository, authEventRepository)
 
-			verifyEmailApplication := NewVerifyEmailApplication(verifyEmailRespository)
+			verifyEmailApplication := NewVerifyEmailApplication(verifyEmailRespository, authEventRepository, &util.Config{})
 			res, err := verifyEmailApplication.VerifyEmail(context.Background(), tc.arg)
 			tc.checkResponse(t, res, err)
 		})
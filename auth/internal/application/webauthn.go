@@ -0,0 +1,448 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+type WebauthnRepository interface {
+	CreateWebauthnSession(ctx context.Context, arg infra.CreateWebauthnSession) (*domain.WebauthnSession, error)
+	ConsumeWebauthnSession(ctx context.Context, arg infra.ConsumeWebauthnSession) (*domain.WebauthnSession, error)
+	CreateWebauthnCredential(ctx context.Context, arg infra.CreateWebauthnCredential) (*domain.WebauthnCredential, error)
+	ListWebauthnCredentials(ctx context.Context, username string) ([]*domain.WebauthnCredential, error)
+	UseWebauthnCredential(ctx context.Context, arg infra.UseWebauthnCredential) (*domain.WebauthnCredential, error)
+	FlagWebauthnCloneWarning(ctx context.Context, id []byte) error
+	CreateWebauthnUserHandle(ctx context.Context, arg infra.CreateWebauthnUserHandle) (*domain.WebauthnUserHandle, error)
+	GetWebauthnUserHandle(ctx context.Context, handle []byte) (*domain.WebauthnUserHandle, error)
+}
+
+// WebauthnCeremony is returned by the begin calls. Options are passed as is to
+// navigator.credentials.create or get, and SessionID must be sent back with
+// the authenticator response to the finish call.
+type WebauthnCeremony struct {
+	SessionID uuid.UUID       `json:"session_id"`
+	Options   json.RawMessage `json:"options"`
+}
+
+type BeginWebauthnRegistration struct {
+	Username string `json:"username"`
+}
+
+// BeginWebauthnRegistration creates the challenge to register a passkey for
+// the user. Passkeys the user already has are excluded, so the same
+// authenticator is not registered twice.
+func (u *UserApplication) BeginWebauthnRegistration(ctx context.Context, arg BeginWebauthnRegistration) (*WebauthnCeremony, error) {
+	if errValidation := validateBeginWebauthnRegistrationParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	passkeyUser, err := u.getWebauthnUser(ctx, arg.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	relyingParty, err := u.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+
+	creation, sessionData, err := relyingParty.BeginRegistration(passkeyUser,
+		webauthn.WithExclusions(webauthn.Credentials(passkeyUser.credentials).CredentialDescriptors()),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin webauthn registration: %w", err)
+	}
+
+	return u.createWebauthnCeremony(ctx, &passkeyUser.user.Username, domain.WebauthnRegistration, creation, sessionData)
+}
+
+type FinishWebauthnRegistration struct {
+	Username   string          `json:"username"`
+	SessionID  uuid.UUID       `json:"session_id"`
+	Credential json.RawMessage `json:"credential"`
+}
+
+// FinishWebauthnRegistration verifies the attestation of the authenticator and
+// stores the new passkey.
+func (u *UserApplication) FinishWebauthnRegistration(ctx context.Context, arg FinishWebauthnRegistration) (*domain.WebauthnCredential, error) {
+	if errValidation := validateFinishWebauthnRegistrationParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	sessionData, err := u.consumeWebauthnSession(ctx, arg.SessionID, domain.WebauthnRegistration, &arg.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedResponse, err := protocol.ParseCredentialCreationResponseBytes(arg.Credential)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidWebauthnCredential, err)
+	}
+
+	passkeyUser, err := u.getWebauthnUser(ctx, arg.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	relyingParty, err := u.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := relyingParty.CreateCredential(passkeyUser, *sessionData, parsedResponse)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidWebauthnCredential, err)
+	}
+
+	transports := make([]string, 0, len(credential.Transport))
+	for _, transport := range credential.Transport {
+		transports = append(transports, string(transport))
+	}
+
+	return u.webauthnRepository.CreateWebauthnCredential(ctx, infra.CreateWebauthnCredential{
+		ID:              credential.ID,
+		Username:        arg.Username,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		Transports:      transports,
+		Aaguid:          credential.Authenticator.AAGUID,
+		SignCount:       int64(credential.Authenticator.SignCount),
+		BackupEligible:  credential.Flags.BackupEligible,
+		BackupState:     credential.Flags.BackupState,
+	})
+}
+
+// BeginWebauthnLogin creates the challenge of a passkey login. No username is
+// needed, the authenticator picks the passkey and tells which user it belongs
+// to, so the call cannot be used to find out which usernames exist.
+func (u *UserApplication) BeginWebauthnLogin(ctx context.Context) (*WebauthnCeremony, error) {
+	relyingParty, err := u.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+
+	assertion, sessionData, err := relyingParty.BeginDiscoverableLogin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin webauthn login: %w", err)
+	}
+
+	return u.createWebauthnCeremony(ctx, nil, domain.WebauthnLogin, assertion, sessionData)
+}
+
+type FinishWebauthnLogin struct {
+	SessionID  uuid.UUID       `json:"session_id"`
+	Credential json.RawMessage `json:"credential"`
+}
+
+// FinishWebauthnLogin verifies the assertion of the authenticator and issues
+// the same session as Login. The passkey already requires user verification,
+// so users with TOTP enabled are not asked for a code.
+func (u *UserApplication) FinishWebauthnLogin(ctx context.Context, arg FinishWebauthnLogin) (*LoginUserResult, error) {
+	if errValidation := validateFinishWebauthnLoginParams(arg); errValidation != nil {
+		return nil, errValidation
+	}
+
+	sessionData, err := u.consumeWebauthnSession(ctx, arg.SessionID, domain.WebauthnLogin, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedResponse, err := protocol.ParseCredentialRequestResponseBytes(arg.Credential)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidWebauthnCredential, err)
+	}
+
+	userHandle, err := u.webauthnRepository.GetWebauthnUserHandle(ctx, parsedResponse.Response.UserHandle)
+	if err != nil {
+		if errors.Is(err, domain.ErrWebauthnUserHandleNotFound) {
+			return nil, ErrInvalidWebauthnCredential
+		}
+		return nil, fmt.Errorf("failed to get webauthn user handle: %w", err)
+	}
+
+	user, err := u.checkLoginPasskey(ctx, sessionData, parsedResponse, userHandle)
+	if err != nil {
+		u.recordAuthEvent(ctx, domain.AuthEventLogin, userHandle.Username, domain.AuthEventFailure)
+		return nil, err
+	}
+
+	return u.createLoginSession(ctx, user)
+}
+
+func (u *UserApplication) checkLoginPasskey(ctx context.Context, sessionData *webauthn.SessionData, parsedResponse *protocol.ParsedCredentialAssertionData, userHandle *domain.WebauthnUserHandle) (*domain.User, error) {
+	clientIP := loginClientIP(util.ExtractMetadata(ctx, u.config.TrustedProxies).ClientIP)
+
+	err := u.checkLoginAllowed(ctx, userHandle.Username, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.countLoginAttempt(ctx, userHandle.Username, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	relyingParty, err := u.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+
+	var user *domain.User
+	_, credential, err := relyingParty.ValidatePasskeyLogin(func(rawID, handle []byte) (webauthn.User, error) {
+		passkeyUser, err := u.loadWebauthnUser(ctx, userHandle)
+		if err != nil {
+			return nil, err
+		}
+
+		user = passkeyUser.user
+		return passkeyUser, nil
+	}, *sessionData, parsedResponse)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidWebauthnCredential, err)
+	}
+
+	err = u.useWebauthnCredential(ctx, user.Username, credential)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.resetLoginFailures(ctx, user.Username, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// useWebauthnCredential stores the new sign count of the credential. A counter
+// that did not increase means the passkey may have been cloned, so the
+// credential is flagged and refused from then on.
+func (u *UserApplication) useWebauthnCredential(ctx context.Context, username string, credential *webauthn.Credential) error {
+	if !credential.Authenticator.CloneWarning {
+		_, err := u.webauthnRepository.UseWebauthnCredential(ctx, infra.UseWebauthnCredential{
+			ID:          credential.ID,
+			SignCount:   int64(credential.Authenticator.SignCount),
+			BackupState: credential.Flags.BackupState,
+		})
+		if err == nil {
+			return nil
+		}
+
+		if !errors.Is(err, domain.ErrWebauthnSignCountNotIncreased) {
+			return fmt.Errorf("failed to use webauthn credential: %w", err)
+		}
+	}
+
+	log.Warn().Str("username", username).Msg("webauthn sign count did not increase, credential may be cloned")
+
+	err := u.webauthnRepository.FlagWebauthnCloneWarning(ctx, credential.ID)
+	if err != nil {
+		return fmt.Errorf("failed to flag webauthn credential: %w", err)
+	}
+
+	return ErrWebauthnCloneDetected
+}
+
+func (u *UserApplication) createWebauthnCeremony(ctx context.Context, username *string, ceremony string, options any, sessionData *webauthn.SessionData) (*WebauthnCeremony, error) {
+	data, err := json.Marshal(sessionData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webauthn session: %w", err)
+	}
+
+	rawOptions, err := json.Marshal(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webauthn options: %w", err)
+	}
+
+	sessionID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webauthn session id: %w", err)
+	}
+
+	session, err := u.webauthnRepository.CreateWebauthnSession(ctx, infra.CreateWebauthnSession{
+		ID:        sessionID,
+		Username:  username,
+		Ceremony:  ceremony,
+		Data:      data,
+		ExpiresAt: time.Now().Add(u.config.WebauthnChallengeDuration),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webauthn session: %w", err)
+	}
+
+	return &WebauthnCeremony{
+		SessionID: session.ID,
+		Options:   rawOptions,
+	}, nil
+}
+
+// consumeWebauthnSession returns the challenge of a ceremony that was begun
+// by username, which is nil for logins.
+func (u *UserApplication) consumeWebauthnSession(ctx context.Context, sessionID uuid.UUID, ceremony string, username *string) (*webauthn.SessionData, error) {
+	session, err := u.webauthnRepository.ConsumeWebauthnSession(ctx, infra.ConsumeWebauthnSession{
+		ID:       sessionID,
+		Ceremony: ceremony,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrWebauthnSessionNotFound) {
+			return nil, ErrInvalidWebauthnSession
+		}
+		return nil, fmt.Errorf("failed to get webauthn session: %w", err)
+	}
+
+	if username != nil && (session.Username == nil || *session.Username != *username) {
+		return nil, ErrInvalidWebauthnSession
+	}
+
+	var sessionData webauthn.SessionData
+	err = json.Unmarshal(session.Data, &sessionData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal webauthn session: %w", err)
+	}
+
+	return &sessionData, nil
+}
+
+// getWebauthnUser loads the user registering a passkey. Their user handle is
+// created on the first registration and reused by the next ones.
+func (u *UserApplication) getWebauthnUser(ctx context.Context, username string) (*webauthnUser, error) {
+	user, err := u.userRepository.GetUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	handle := make([]byte, webauthnUserHandleSize)
+	_, err = rand.Read(handle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webauthn user handle: %w", err)
+	}
+
+	userHandle, err := u.webauthnRepository.CreateWebauthnUserHandle(ctx, infra.CreateWebauthnUserHandle{
+		Handle:   handle,
+		Username: user.Username,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webauthn user handle: %w", err)
+	}
+
+	return u.withWebauthnCredentials(ctx, user, userHandle.Handle)
+}
+
+// loadWebauthnUser loads the user a passkey login returned the handle of.
+func (u *UserApplication) loadWebauthnUser(ctx context.Context, userHandle *domain.WebauthnUserHandle) (*webauthnUser, error) {
+	user, err := u.userRepository.GetUser(ctx, userHandle.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.withWebauthnCredentials(ctx, user, userHandle.Handle)
+}
+
+func (u *UserApplication) withWebauthnCredentials(ctx context.Context, user *domain.User, handle []byte) (*webauthnUser, error) {
+	storedCredentials, err := u.webauthnRepository.ListWebauthnCredentials(ctx, user.Username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webauthn credentials: %w", err)
+	}
+
+	return newWebauthnUser(user, handle, storedCredentials), nil
+}
+
+func (u *UserApplication) relyingParty() (*webauthn.WebAuthn, error) {
+	timeout := webauthn.TimeoutConfig{
+		Enforce: true,
+		Timeout: u.config.WebauthnChallengeDuration,
+	}
+
+	relyingParty, err := webauthn.New(&webauthn.Config{
+		RPID:          u.config.WebauthnRPID,
+		RPDisplayName: u.config.WebauthnRPDisplayName,
+		RPOrigins:     u.config.WebauthnRPOrigins,
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			ResidentKey:      protocol.ResidentKeyRequirementRequired,
+			UserVerification: protocol.VerificationRequired,
+		},
+		Timeouts: webauthn.TimeoutsConfig{
+			Login:        timeout,
+			Registration: timeout,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid webauthn config: %w", err)
+	}
+
+	return relyingParty, nil
+}
+
+// webauthnUserHandleSize is the size of the random user handles, the maximum
+// the WebAuthn specification allows.
+const webauthnUserHandleSize = 64
+
+// webauthnUser adapts a user and their passkeys to webauthn.User. The user
+// handle is random, it is how a discoverable login finds the user without the
+// authenticator storing who they are.
+type webauthnUser struct {
+	user        *domain.User
+	handle      []byte
+	credentials []webauthn.Credential
+}
+
+func newWebauthnUser(user *domain.User, handle []byte, storedCredentials []*domain.WebauthnCredential) *webauthnUser {
+	credentials := make([]webauthn.Credential, 0, len(storedCredentials))
+	for _, stored := range storedCredentials {
+		transports := make([]protocol.AuthenticatorTransport, 0, len(stored.Transports))
+		for _, transport := range stored.Transports {
+			transports = append(transports, protocol.AuthenticatorTransport(transport))
+		}
+
+		credentials = append(credentials, webauthn.Credential{
+			ID:              stored.ID,
+			PublicKey:       stored.PublicKey,
+			AttestationType: stored.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				BackupEligible: stored.BackupEligible,
+				BackupState:    stored.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:       stored.Aaguid,
+				SignCount:    uint32(stored.SignCount),
+				CloneWarning: stored.CloneWarning,
+			},
+		})
+	}
+
+	return &webauthnUser{
+		user:        user,
+		handle:      handle,
+		credentials: credentials,
+	}
+}
+
+func (w *webauthnUser) WebAuthnID() []byte {
+	return w.handle
+}
+
+func (w *webauthnUser) WebAuthnName() string {
+	return w.user.Username
+}
+
+func (w *webauthnUser) WebAuthnDisplayName() string {
+	return w.user.FullName
+}
+
+func (w *webauthnUser) WebAuthnCredentials() []webauthn.Credential {
+	return w.credentials
+}
@@ -0,0 +1,831 @@
+package application
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	mock "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application/mock"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebauthnOrigin = "http://localhost:3000"
+
+func newWebauthnConfig() *util.Config {
+	return &util.Config{
+		AccessTokenDuration:       time.Minute,
+		RefreshTokenDuration:      time.Minute,
+		WebauthnRPID:              "localhost",
+		WebauthnRPDisplayName:     "test",
+		WebauthnRPOrigins:         []string{testWebauthnOrigin},
+		WebauthnChallengeDuration: time.Minute,
+	}
+}
+
+// testAuthenticator is a software passkey that answers the ceremonies the
+// way a browser would, with the "none" attestation format.
+type testAuthenticator struct {
+	privateKey   *ecdsa.PrivateKey
+	credentialID []byte
+	signCount    uint32
+}
+
+func newTestAuthenticator(t *testing.T) *testAuthenticator {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return &testAuthenticator{
+		privateKey:   privateKey,
+		credentialID: []byte(util.RandomString(16)),
+	}
+}
+
+func (a *testAuthenticator) publicKey(t *testing.T) []byte {
+	t.Helper()
+
+	publicKey, err := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{
+			KeyType:   int64(webauthncose.EllipticKey),
+			Algorithm: int64(webauthncose.AlgES256),
+		},
+		Curve:  1,
+		XCoord: a.privateKey.X.FillBytes(make([]byte, 32)),
+		YCoord: a.privateKey.Y.FillBytes(make([]byte, 32)),
+	})
+	require.NoError(t, err)
+
+	return publicKey
+}
+
+// credential is the record stored once this authenticator is registered.
+func (a *testAuthenticator) credential(t *testing.T, username string) *domain.WebauthnCredential {
+	t.Helper()
+
+	return &domain.WebauthnCredential{
+		ID:              a.credentialID,
+		Username:        username,
+		PublicKey:       a.publicKey(t),
+		AttestationType: "none",
+		Aaguid:          make([]byte, 16),
+		SignCount:       int64(a.signCount),
+		CreatedAt:       time.Now(),
+	}
+}
+
+func (a *testAuthenticator) authenticatorData(t *testing.T, rpID string, attested bool) []byte {
+	t.Helper()
+
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	flags := byte(0x01 | 0x04) // user present and verified
+
+	data := append([]byte{}, rpIDHash[:]...)
+	if attested {
+		flags |= 0x40
+	}
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+
+	if attested {
+		data = append(data, make([]byte, 16)...)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(a.credentialID)))
+		data = append(data, a.credentialID...)
+		data = append(data, a.publicKey(t)...)
+	}
+
+	return data
+}
+
+func clientDataJSON(t *testing.T, ceremonyType string, options json.RawMessage) []byte {
+	t.Helper()
+
+	var parsed struct {
+		PublicKey struct {
+			Challenge string `json:"challenge"`
+		} `json:"publicKey"`
+	}
+	require.NoError(t, json.Unmarshal(options, &parsed))
+
+	data, err := json.Marshal(map[string]string{
+		"type":      ceremonyType,
+		"challenge": parsed.PublicKey.Challenge,
+		"origin":    testWebauthnOrigin,
+	})
+	require.NoError(t, err)
+
+	return data
+}
+
+// register returns the response of navigator.credentials.create.
+func (a *testAuthenticator) register(t *testing.T, options json.RawMessage) json.RawMessage {
+	t.Helper()
+
+	attestationObject, err := webauthncbor.Marshal(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": a.authenticatorData(t, "localhost", true),
+	})
+	require.NoError(t, err)
+
+	encode := base64.RawURLEncoding.EncodeToString
+	response, err := json.Marshal(map[string]any{
+		"id":    encode(a.credentialID),
+		"rawId": encode(a.credentialID),
+		"type":  "public-key",
+		"response": map[string]any{
+			"clientDataJSON":    encode(clientDataJSON(t, "webauthn.create", options)),
+			"attestationObject": encode(attestationObject),
+			"transports":        []string{"internal"},
+		},
+	})
+	require.NoError(t, err)
+
+	return response
+}
+
+// login returns the response of navigator.credentials.get, signed after
+// increasing the sign count.
+func (a *testAuthenticator) login(t *testing.T, options json.RawMessage, userHandle []byte) json.RawMessage {
+	t.Helper()
+
+	a.signCount++
+
+	authenticatorData := a.authenticatorData(t, "localhost", false)
+	clientData := clientDataJSON(t, "webauthn.get", options)
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authenticatorData...), clientDataHash[:]...))
+
+	signature, err := ecdsa.SignASN1(rand.Reader, a.privateKey, digest[:])
+	require.NoError(t, err)
+
+	encode := base64.RawURLEncoding.EncodeToString
+	response, err := json.Marshal(map[string]any{
+		"id":    encode(a.credentialID),
+		"rawId": encode(a.credentialID),
+		"type":  "public-key",
+		"response": map[string]any{
+			"clientDataJSON":    encode(clientData),
+			"authenticatorData": encode(authenticatorData),
+			"signature":         encode(signature),
+			"userHandle":        encode(userHandle),
+		},
+	})
+	require.NoError(t, err)
+
+	return response
+}
+
+// expectCreateWebauthnSession stores the created session in session, so it
+// can be returned when the ceremony is finished.
+func expectCreateWebauthnSession(webauthnRepository *mock.MockWebauthnRepository, session *domain.WebauthnSession) {
+	webauthnRepository.EXPECT().
+		CreateWebauthnSession(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg infra.CreateWebauthnSession) (*domain.WebauthnSession, error) {
+			*session = domain.WebauthnSession{
+				ID:        arg.ID,
+				Username:  arg.Username,
+				Ceremony:  arg.Ceremony,
+				Data:      arg.Data,
+				ExpiresAt: arg.ExpiresAt,
+				CreatedAt: time.Now(),
+			}
+			return session, nil
+		})
+}
+
+// testWebauthnUserHandle is the user handle of user in the tests, the same on
+// every call so a ceremony can be begun and finished by different mocks.
+func testWebauthnUserHandle(user *domain.User) []byte {
+	handle := sha512.Sum512([]byte(user.Username))
+	return handle[:]
+}
+
+// expectCreateWebauthnUserHandle returns handle as the user handle of username,
+// whichever handle the registration generated.
+func expectCreateWebauthnUserHandle(t *testing.T, webauthnRepository *mock.MockWebauthnRepository, username string, handle []byte) {
+	webauthnRepository.EXPECT().
+		CreateWebauthnUserHandle(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg infra.CreateWebauthnUserHandle) (*domain.WebauthnUserHandle, error) {
+			require.Equal(t, username, arg.Username)
+			require.Len(t, arg.Handle, webauthnUserHandleSize)
+			return &domain.WebauthnUserHandle{Handle: handle, Username: arg.Username, CreatedAt: time.Now()}, nil
+		})
+}
+
+func expectGetWebauthnUserHandle(webauthnRepository *mock.MockWebauthnRepository, user *domain.User) {
+	webauthnRepository.EXPECT().
+		GetWebauthnUserHandle(gomock.Any(), gomock.Eq(testWebauthnUserHandle(user))).
+		Times(1).
+		Return(&domain.WebauthnUserHandle{Handle: testWebauthnUserHandle(user), Username: user.Username}, nil)
+}
+
+func beginTestWebauthnCeremony(t *testing.T, config *util.Config, user *domain.User, ceremony string) (*domain.WebauthnSession, json.RawMessage) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	webauthnRepository := mock.NewMockWebauthnRepository(ctrl)
+
+	userRepository.EXPECT().
+		GetUser(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(user, nil)
+
+	webauthnRepository.EXPECT().
+		ListWebauthnCredentials(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(nil, nil)
+
+	webauthnRepository.EXPECT().
+		CreateWebauthnUserHandle(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(&domain.WebauthnUserHandle{Handle: testWebauthnUserHandle(user), Username: user.Username}, nil)
+
+	var session domain.WebauthnSession
+	expectCreateWebauthnSession(webauthnRepository, &session)
+
+	userApplication := NewUserApplication(userRepository, nil, nil, nil, nil, webauthnRepository, nil, nil, nil, nil, nil, nil, nil, config)
+
+	var ceremonyResult *WebauthnCeremony
+	var err error
+	if ceremony == domain.WebauthnRegistration {
+		ceremonyResult, err = userApplication.BeginWebauthnRegistration(context.Background(), BeginWebauthnRegistration{Username: user.Username})
+	} else {
+		ceremonyResult, err = userApplication.BeginWebauthnLogin(context.Background())
+	}
+	require.NoError(t, err)
+
+	return &session, ceremonyResult.Options
+}
+
+func TestBeginWebauthnRegistrationUseCase(t *testing.T) {
+	config := newWebauthnConfig()
+	user, _ := randomUser(t)
+	registered := newTestAuthenticator(t).credential(t, user.Username)
+	handle := []byte(util.RandomString(webauthnUserHandleSize))
+
+	testCases := []struct {
+		name          string
+		arg           BeginWebauthnRegistration
+		buildMocks    func(userRepository *mock.MockUserRepository, webauthnRepository *mock.MockWebauthnRepository)
+		checkResponse func(t *testing.T, result *WebauthnCeremony, err error)
+	}{
+		{
+			name: "OK",
+			arg:  BeginWebauthnRegistration{Username: user.Username},
+			buildMocks: func(userRepository *mock.MockUserRepository, webauthnRepository *mock.MockWebauthnRepository) {
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				expectCreateWebauthnUserHandle(t, webauthnRepository, user.Username, handle)
+
+				webauthnRepository.EXPECT().
+					ListWebauthnCredentials(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]*domain.WebauthnCredential{registered}, nil)
+
+				webauthnRepository.EXPECT().
+					CreateWebauthnSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg infra.CreateWebauthnSession) (*domain.WebauthnSession, error) {
+						require.Equal(t, user.Username, *arg.Username)
+						require.Equal(t, domain.WebauthnRegistration, arg.Ceremony)
+						require.WithinDuration(t, time.Now().Add(config.WebauthnChallengeDuration), arg.ExpiresAt, time.Second)
+						return &domain.WebauthnSession{ID: arg.ID, Username: arg.Username, Ceremony: arg.Ceremony, Data: arg.Data, ExpiresAt: arg.ExpiresAt}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, result *WebauthnCeremony, err error) {
+				require.NoError(t, err)
+				require.NotEqual(t, uuid.Nil, result.SessionID)
+
+				var options struct {
+					PublicKey struct {
+						Challenge string `json:"challenge"`
+						RP        struct {
+							ID string `json:"id"`
+						} `json:"rp"`
+						User struct {
+							ID   string `json:"id"`
+							Name string `json:"name"`
+						} `json:"user"`
+						ExcludeCredentials []struct {
+							ID string `json:"id"`
+						} `json:"excludeCredentials"`
+					} `json:"publicKey"`
+				}
+				require.NoError(t, json.Unmarshal(result.Options, &options))
+				require.NotEmpty(t, options.PublicKey.Challenge)
+				require.Equal(t, config.WebauthnRPID, options.PublicKey.RP.ID)
+				require.Equal(t, user.Username, options.PublicKey.User.Name)
+				require.Equal(t, base64.RawURLEncoding.EncodeToString(handle), options.PublicKey.User.ID)
+				require.Len(t, options.PublicKey.ExcludeCredentials, 1)
+				require.Equal(t, base64.RawURLEncoding.EncodeToString(registered.ID), options.PublicKey.ExcludeCredentials[0].ID)
+			},
+		},
+		{
+			name: "UserNotFound",
+			arg:  BeginWebauthnRegistration{Username: user.Username},
+			buildMocks: func(userRepository *mock.MockUserRepository, webauthnRepository *mock.MockWebauthnRepository) {
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(nil, domain.ErrUserNotFound)
+
+				webauthnRepository.EXPECT().
+					CreateWebauthnSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *WebauthnCeremony, err error) {
+				require.ErrorIs(t, err, domain.ErrUserNotFound)
+				require.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			webauthnRepository := mock.NewMockWebauthnRepository(ctrl)
+
+			tc.buildMocks(userRepository, webauthnRepository)
+
+			userApplication := NewUserApplication(userRepository, nil, nil, nil, nil, webauthnRepository, nil, nil, nil, nil, nil, nil, nil, config)
+
+			result, err := userApplication.BeginWebauthnRegistration(context.Background(), tc.arg)
+			tc.checkResponse(t, result, err)
+		})
+	}
+}
+
+func TestFinishWebauthnRegistrationUseCase(t *testing.T) {
+	config := newWebauthnConfig()
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		buildArg      func(t *testing.T, session *domain.WebauthnSession, options json.RawMessage) FinishWebauthnRegistration
+		buildMocks    func(userRepository *mock.MockUserRepository, webauthnRepository *mock.MockWebauthnRepository, session *domain.WebauthnSession)
+		checkResponse func(t *testing.T, credential *domain.WebauthnCredential, err error)
+	}{
+		{
+			name: "OK",
+			buildArg: func(t *testing.T, session *domain.WebauthnSession, options json.RawMessage) FinishWebauthnRegistration {
+				return FinishWebauthnRegistration{Username: user.Username, SessionID: session.ID, Credential: newTestAuthenticator(t).register(t, options)}
+			},
+			buildMocks: func(userRepository *mock.MockUserRepository, webauthnRepository *mock.MockWebauthnRepository, session *domain.WebauthnSession) {
+				webauthnRepository.EXPECT().
+					ConsumeWebauthnSession(gomock.Any(), gomock.Eq(infra.ConsumeWebauthnSession{ID: session.ID, Ceremony: domain.WebauthnRegistration})).
+					Times(1).
+					Return(session, nil)
+
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				expectCreateWebauthnUserHandle(t, webauthnRepository, user.Username, testWebauthnUserHandle(user))
+
+				webauthnRepository.EXPECT().
+					ListWebauthnCredentials(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(nil, nil)
+
+				webauthnRepository.EXPECT().
+					CreateWebauthnCredential(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg infra.CreateWebauthnCredential) (*domain.WebauthnCredential, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.Equal(t, "none", arg.AttestationType)
+						require.Equal(t, []string{"internal"}, arg.Transports)
+						require.NotEmpty(t, arg.ID)
+						require.NotEmpty(t, arg.PublicKey)
+						return &domain.WebauthnCredential{ID: arg.ID, Username: arg.Username, PublicKey: arg.PublicKey, AttestationType: arg.AttestationType, Transports: arg.Transports, CreatedAt: time.Now()}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, credential *domain.WebauthnCredential, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user.Username, credential.Username)
+			},
+		},
+		{
+			name: "SessionNotFound",
+			buildArg: func(t *testing.T, session *domain.WebauthnSession, options json.RawMessage) FinishWebauthnRegistration {
+				return FinishWebauthnRegistration{Username: user.Username, SessionID: session.ID, Credential: newTestAuthenticator(t).register(t, options)}
+			},
+			buildMocks: func(userRepository *mock.MockUserRepository, webauthnRepository *mock.MockWebauthnRepository, session *domain.WebauthnSession) {
+				webauthnRepository.EXPECT().
+					ConsumeWebauthnSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrWebauthnSessionNotFound)
+
+				webauthnRepository.EXPECT().
+					CreateWebauthnCredential(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, credential *domain.WebauthnCredential, err error) {
+				require.ErrorIs(t, err, ErrInvalidWebauthnSession)
+				require.Nil(t, credential)
+			},
+		},
+		{
+			name: "SessionOfAnotherUser",
+			buildArg: func(t *testing.T, session *domain.WebauthnSession, options json.RawMessage) FinishWebauthnRegistration {
+				return FinishWebauthnRegistration{Username: other.Username, SessionID: session.ID, Credential: newTestAuthenticator(t).register(t, options)}
+			},
+			buildMocks: func(userRepository *mock.MockUserRepository, webauthnRepository *mock.MockWebauthnRepository, session *domain.WebauthnSession) {
+				webauthnRepository.EXPECT().
+					ConsumeWebauthnSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(session, nil)
+
+				webauthnRepository.EXPECT().
+					CreateWebauthnCredential(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, credential *domain.WebauthnCredential, err error) {
+				require.ErrorIs(t, err, ErrInvalidWebauthnSession)
+				require.Nil(t, credential)
+			},
+		},
+		{
+			name: "WrongChallenge",
+			buildArg: func(t *testing.T, session *domain.WebauthnSession, options json.RawMessage) FinishWebauthnRegistration {
+				_, otherOptions := beginTestWebauthnCeremony(t, config, user, domain.WebauthnRegistration)
+				return FinishWebauthnRegistration{Username: user.Username, SessionID: session.ID, Credential: newTestAuthenticator(t).register(t, otherOptions)}
+			},
+			buildMocks: func(userRepository *mock.MockUserRepository, webauthnRepository *mock.MockWebauthnRepository, session *domain.WebauthnSession) {
+				webauthnRepository.EXPECT().
+					ConsumeWebauthnSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(session, nil)
+
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				expectCreateWebauthnUserHandle(t, webauthnRepository, user.Username, testWebauthnUserHandle(user))
+
+				webauthnRepository.EXPECT().
+					ListWebauthnCredentials(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(nil, nil)
+
+				webauthnRepository.EXPECT().
+					CreateWebauthnCredential(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, credential *domain.WebauthnCredential, err error) {
+				require.ErrorIs(t, err, ErrInvalidWebauthnCredential)
+				require.Nil(t, credential)
+			},
+		},
+		{
+			name: "MissingCredential",
+			buildArg: func(t *testing.T, session *domain.WebauthnSession, options json.RawMessage) FinishWebauthnRegistration {
+				return FinishWebauthnRegistration{Username: user.Username, SessionID: session.ID}
+			},
+			buildMocks: func(userRepository *mock.MockUserRepository, webauthnRepository *mock.MockWebauthnRepository, session *domain.WebauthnSession) {
+				webauthnRepository.EXPECT().
+					ConsumeWebauthnSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, credential *domain.WebauthnCredential, err error) {
+				require.Error(t, err)
+				require.Nil(t, credential)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			session, options := beginTestWebauthnCeremony(t, config, user, domain.WebauthnRegistration)
+
+			ctrl := gomock.NewController(t)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			webauthnRepository := mock.NewMockWebauthnRepository(ctrl)
+
+			tc.buildMocks(userRepository, webauthnRepository, session)
+
+			userApplication := NewUserApplication(userRepository, nil, nil, nil, nil, webauthnRepository, nil, nil, nil, nil, nil, nil, nil, config)
+
+			credential, err := userApplication.FinishWebauthnRegistration(context.Background(), tc.buildArg(t, session, options))
+			tc.checkResponse(t, credential, err)
+		})
+	}
+}
+
+func TestFinishWebauthnLoginUseCase(t *testing.T) {
+	config := newWebauthnConfig()
+	user, _ := randomUser(t)
+	user.IsTotpEnabled = true
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		userHandle    []byte
+		buildMocks    func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, webauthnRepository *mock.MockWebauthnRepository, credential *domain.WebauthnCredential)
+		checkResponse func(t *testing.T, result *LoginUserResult, err error)
+	}{
+		{
+			name:       "OK",
+			userHandle: testWebauthnUserHandle(user),
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, webauthnRepository *mock.MockWebauthnRepository, credential *domain.WebauthnCredential) {
+				expectGetWebauthnUserHandle(webauthnRepository, user)
+
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				webauthnRepository.EXPECT().
+					ListWebauthnCredentials(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]*domain.WebauthnCredential{credential}, nil)
+
+				webauthnRepository.EXPECT().
+					UseWebauthnCredential(gomock.Any(), gomock.Eq(infra.UseWebauthnCredential{ID: credential.ID, SignCount: credential.SignCount + 1})).
+					Times(1).
+					Return(credential, nil)
+
+				sessionRepository.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(randomSession(t, user.Username), nil)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.NoError(t, err)
+				require.False(t, result.MfaRequired)
+				require.Equal(t, user.Username, result.User.Username)
+				require.NotEmpty(t, result.AccessToken)
+				require.NotEmpty(t, result.RefreshToken)
+			},
+		},
+		{
+			name:       "SignCountNotIncreased",
+			userHandle: testWebauthnUserHandle(user),
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, webauthnRepository *mock.MockWebauthnRepository, credential *domain.WebauthnCredential) {
+				credential.SignCount = 100
+
+				expectGetWebauthnUserHandle(webauthnRepository, user)
+
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				webauthnRepository.EXPECT().
+					ListWebauthnCredentials(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]*domain.WebauthnCredential{credential}, nil)
+
+				webauthnRepository.EXPECT().
+					UseWebauthnCredential(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				webauthnRepository.EXPECT().
+					FlagWebauthnCloneWarning(gomock.Any(), gomock.Eq(credential.ID)).
+					Times(1).
+					Return(nil)
+
+				sessionRepository.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.ErrorIs(t, err, ErrWebauthnCloneDetected)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name:       "ConcurrentSignCount",
+			userHandle: testWebauthnUserHandle(user),
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, webauthnRepository *mock.MockWebauthnRepository, credential *domain.WebauthnCredential) {
+				expectGetWebauthnUserHandle(webauthnRepository, user)
+
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				webauthnRepository.EXPECT().
+					ListWebauthnCredentials(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]*domain.WebauthnCredential{credential}, nil)
+
+				webauthnRepository.EXPECT().
+					UseWebauthnCredential(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrWebauthnSignCountNotIncreased)
+
+				webauthnRepository.EXPECT().
+					FlagWebauthnCloneWarning(gomock.Any(), gomock.Eq(credential.ID)).
+					Times(1).
+					Return(nil)
+
+				sessionRepository.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.ErrorIs(t, err, ErrWebauthnCloneDetected)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name:       "CredentialOfAnotherUser",
+			userHandle: testWebauthnUserHandle(&domain.User{Username: "other"}),
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, webauthnRepository *mock.MockWebauthnRepository, credential *domain.WebauthnCredential) {
+				other, _ := randomUser(t)
+				other.Username = "other"
+
+				expectGetWebauthnUserHandle(webauthnRepository, other)
+
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(other.Username)).
+					Times(1).
+					Return(other, nil)
+
+				webauthnRepository.EXPECT().
+					ListWebauthnCredentials(gomock.Any(), gomock.Eq(other.Username)).
+					Times(1).
+					Return(nil, nil)
+
+				webauthnRepository.EXPECT().
+					UseWebauthnCredential(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				sessionRepository.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.ErrorIs(t, err, ErrInvalidWebauthnCredential)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name:       "UnknownUser",
+			userHandle: testWebauthnUserHandle(user),
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, webauthnRepository *mock.MockWebauthnRepository, credential *domain.WebauthnCredential) {
+				expectGetWebauthnUserHandle(webauthnRepository, user)
+
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(nil, domain.ErrUserNotFound)
+
+				sessionRepository.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.ErrorIs(t, err, ErrInvalidWebauthnCredential)
+				require.Nil(t, result)
+			},
+		},
+		{
+			name:       "UnknownUserHandle",
+			userHandle: []byte(user.Username),
+			buildMocks: func(userRepository *mock.MockUserRepository, sessionRepository *mock.MockSessionRepository, webauthnRepository *mock.MockWebauthnRepository, credential *domain.WebauthnCredential) {
+				webauthnRepository.EXPECT().
+					GetWebauthnUserHandle(gomock.Any(), gomock.Eq([]byte(user.Username))).
+					Times(1).
+					Return(nil, domain.ErrWebauthnUserHandleNotFound)
+
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				sessionRepository.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, result *LoginUserResult, err error) {
+				require.ErrorIs(t, err, ErrInvalidWebauthnCredential)
+				require.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			session, options := beginTestWebauthnCeremony(t, config, user, domain.WebauthnLogin)
+			require.Nil(t, session.Username)
+
+			authenticator := newTestAuthenticator(t)
+			credential := authenticator.credential(t, user.Username)
+
+			ctrl := gomock.NewController(t)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			sessionRepository := mock.NewMockSessionRepository(ctrl)
+			webauthnRepository := mock.NewMockWebauthnRepository(ctrl)
+
+			webauthnRepository.EXPECT().
+				ConsumeWebauthnSession(gomock.Any(), gomock.Eq(infra.ConsumeWebauthnSession{ID: session.ID, Ceremony: domain.WebauthnLogin})).
+				Times(1).
+				Return(session, nil)
+
+			tc.buildMocks(userRepository, sessionRepository, webauthnRepository, credential)
+
+			userApplication := NewUserApplication(userRepository, sessionRepository, nil, nil, nil, webauthnRepository, nil, staticPermissions{}, discardAuthEvents{}, nil, nil, tokenMaker, nil, config)
+
+			result, err := userApplication.FinishWebauthnLogin(context.Background(), FinishWebauthnLogin{
+				SessionID:  session.ID,
+				Credential: authenticator.login(t, options, tc.userHandle),
+			})
+			tc.checkResponse(t, result, err)
+		})
+	}
+}
+
+// TestFinishWebauthnLoginRecordsFailure makes sure a passkey that does not
+// belong to the user counts against the login protection like a wrong password.
+func TestFinishWebauthnLoginRecordsFailure(t *testing.T) {
+	config := newWebauthnConfig()
+	config.LoginMaxFailures = 3
+	config.LoginMaxIPFailures = 10
+	config.LoginLockoutDuration = 15 * time.Minute
+	config.TrustedProxies = []string{testGatewayIP}
+
+	user, _ := randomUser(t)
+	clientIP := "10.0.0.1"
+
+	session, options := beginTestWebauthnCeremony(t, config, user, domain.WebauthnLogin)
+
+	ctrl := gomock.NewController(t)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	webauthnRepository := mock.NewMockWebauthnRepository(ctrl)
+	loginFailureRepository := mock.NewMockLoginFailureRepository(ctrl)
+
+	webauthnRepository.EXPECT().
+		ConsumeWebauthnSession(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(session, nil)
+
+	expectGetWebauthnUserHandle(webauthnRepository, user)
+
+	loginFailureRepository.EXPECT().
+		GetLoginFailure(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(2).
+		Return(nil, domain.ErrLoginFailureNotFound)
+
+	loginFailureRepository.EXPECT().
+		RecordLoginFailureTx(gomock.Any(), gomock.Eq(infra.RecordLoginFailureTx{
+			Username:        user.Username,
+			ClientIP:        clientIP,
+			MaxFailures:     config.LoginMaxFailures,
+			MaxIPFailures:   config.LoginMaxIPFailures,
+			LockoutDuration: config.LoginLockoutDuration,
+		})).
+		Times(1).
+		Return(infra.RecordLoginFailureTxResult{}, nil)
+
+	userRepository.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+
+	webauthnRepository.EXPECT().
+		ListWebauthnCredentials(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(nil, nil)
+
+	loginFailureRepository.EXPECT().
+		DeleteLoginFailures(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	userApplication := NewUserApplication(userRepository, nil, nil, loginFailureRepository, nil, webauthnRepository, nil, staticPermissions{}, discardAuthEvents{}, nil, nil, nil, nil, config)
+
+	result, err := userApplication.FinishWebauthnLogin(newGatewayContext(clientIP, ""), FinishWebauthnLogin{
+		SessionID:  session.ID,
+		Credential: newTestAuthenticator(t).login(t, options, testWebauthnUserHandle(user)),
+	})
+	require.ErrorIs(t, err, ErrInvalidWebauthnCredential)
+	require.Nil(t, result)
+}
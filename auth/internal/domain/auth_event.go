@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// Types of an AuthEvent.
+const (
+	AuthEventLogin             = "login"
+	AuthEventPasswordChange    = "password_change"
+	AuthEventPasswordReset     = "password_reset"
+	AuthEventEmailVerification = "email_verification"
+)
+
+// Outcomes of an AuthEvent.
+const (
+	AuthEventSuccess = "success"
+	AuthEventFailure = "failure"
+)
+
+// AuthEvent is an entry of the audit trail of logins and credential changes.
+// Events are never updated, and only deleted when their account is purged.
+// Failed logins are recorded for unknown usernames as well.
+type AuthEvent struct {
+	ID        int64
+	EventType string
+	Username  string
+	ClientIP  string
+	UserAgent string
+	Outcome   string
+	CreatedAt time.Time
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserDataExport is everything the auth service stores about a user, without
+// the password, secrets and codes.
+type UserDataExport struct {
+	ExportedAt          time.Time                    `json:"exported_at"`
+	Profile             ExportedProfile              `json:"profile"`
+	Roles               []string                     `json:"roles"`
+	Sessions            []ExportedSession            `json:"sessions"`
+	VerifyEmails        []ExportedEmailCode          `json:"verify_emails"`
+	ResetPasswords      []ExportedEmailCode          `json:"reset_passwords"`
+	RecoveryCodes       []ExportedRecoveryCode       `json:"recovery_codes"`
+	WebauthnCredentials []ExportedWebauthnCredential `json:"webauthn_credentials"`
+	ExternalIdentities  []ExportedExternalIdentity   `json:"external_identities"`
+	AccountLockouts     []ExportedAccountLockout     `json:"account_lockouts"`
+	ServiceAccounts     []ExportedServiceAccount     `json:"service_accounts"`
+	AuthEvents          []ExportedAuthEvent          `json:"auth_events"`
+}
+
+type ExportedProfile struct {
+	Username              string     `db:"username" json:"username"`
+	FullName              string     `db:"full_name" json:"full_name"`
+	Email                 string     `db:"email" json:"email"`
+	Role                  string     `db:"role" json:"role"`
+	IsEmailVerified       bool       `db:"is_email_verified" json:"is_email_verified"`
+	IsTotpEnabled         bool       `db:"is_totp_enabled" json:"is_totp_enabled"`
+	PasswordChangedAt     time.Time  `db:"password_changed_at" json:"password_changed_at"`
+	CreatedAt             time.Time  `db:"created_at" json:"created_at"`
+	DisabledAt            *time.Time `db:"disabled_at" json:"disabled_at"`
+	PasswordResetRequired bool       `db:"password_reset_required" json:"password_reset_required"`
+	DeletedAt             *time.Time `db:"deleted_at" json:"deleted_at"`
+}
+
+type ExportedSession struct {
+	ID        uuid.UUID  `db:"id" json:"id"`
+	ClientID  *string    `db:"client_id" json:"client_id"`
+	UserAgent string     `db:"user_agent" json:"user_agent"`
+	ClientIp  string     `db:"client_ip" json:"client_ip"`
+	IsBlocked bool       `db:"is_blocked" json:"is_blocked"`
+	ExpiresAt time.Time  `db:"expires_at" json:"expires_at"`
+	RotatedAt *time.Time `db:"rotated_at" json:"rotated_at"`
+	CreatedAt time.Time  `db:"created_at" json:"created_at"`
+}
+
+// ExportedEmailCode is an email verification or password reset code sent to
+// the user.
+type ExportedEmailCode struct {
+	Email     string    `db:"email" json:"email"`
+	IsUsed    bool      `db:"is_used" json:"is_used"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	ExpiredAt time.Time `db:"expired_at" json:"expired_at"`
+}
+
+type ExportedRecoveryCode struct {
+	UsedAt    *time.Time `db:"used_at" json:"used_at"`
+	CreatedAt time.Time  `db:"created_at" json:"created_at"`
+}
+
+type ExportedWebauthnCredential struct {
+	ID              []byte     `db:"id" json:"id"`
+	AttestationType string     `db:"attestation_type" json:"attestation_type"`
+	Transports      []string   `db:"transports" json:"transports"`
+	BackupEligible  bool       `db:"backup_eligible" json:"backup_eligible"`
+	BackupState     bool       `db:"backup_state" json:"backup_state"`
+	LastUsedAt      *time.Time `db:"last_used_at" json:"last_used_at"`
+	CreatedAt       time.Time  `db:"created_at" json:"created_at"`
+}
+
+type ExportedExternalIdentity struct {
+	Provider  string    `db:"provider" json:"provider"`
+	Subject   string    `db:"subject" json:"subject"`
+	Email     string    `db:"email" json:"email"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+type ExportedAccountLockout struct {
+	ClientIP    string     `db:"client_ip" json:"client_ip"`
+	Failures    int32      `db:"failures" json:"failures"`
+	LockedAt    time.Time  `db:"locked_at" json:"locked_at"`
+	LockedUntil time.Time  `db:"locked_until" json:"locked_until"`
+	UnlockedAt  *time.Time `db:"unlocked_at" json:"unlocked_at"`
+	UnlockedBy  *string    `db:"unlocked_by" json:"unlocked_by"`
+}
+
+type ExportedServiceAccount struct {
+	Name        string    `db:"name" json:"name"`
+	Description string    `db:"description" json:"description"`
+	Role        string    `db:"role" json:"role"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+}
+
+type ExportedAuthEvent struct {
+	EventType string    `db:"event_type" json:"event_type"`
+	ClientIP  string    `db:"client_ip" json:"client_ip"`
+	UserAgent string    `db:"user_agent" json:"user_agent"`
+	Outcome   string    `db:"outcome" json:"outcome"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrExternalLoginStateNotFound   = errors.New("external login state not found")
+	ErrExternalIdentityNotFound     = errors.New("external identity not found")
+	ErrExternalIdentityAlreadyExist = errors.New("external identity already exists")
+)
+
+// ExternalLoginState is kept between the redirect to an identity provider and
+// its callback. State is sent to the provider, CodeVerifier and Nonce never
+// leave the service.
+type ExternalLoginState struct {
+	State        string
+	Provider     string
+	CodeVerifier string
+	Nonce        string
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+}
+
+// ExternalIdentity links the subject of an identity provider to a local user.
+// Email is the address the provider reported when the link was made.
+type ExternalIdentity struct {
+	Provider  string
+	Subject   string
+	Username  string
+	Email     string
+	CreatedAt time.Time
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrLoginFailureNotFound   = errors.New("login failure not found")
+	ErrAccountLockoutNotFound = errors.New("account is not locked")
+)
+
+// Scopes of a LoginFailure, failed logins are counted both for the username
+// and for the client IP they came from.
+const (
+	LoginFailureScopeUsername = "username"
+	LoginFailureScopeIP       = "ip"
+)
+
+// LoginFailure counts the failed logins of a username or client IP since the
+// last successful login.
+type LoginFailure struct {
+	Scope        string
+	Subject      string
+	Failures     int32
+	LastFailedAt time.Time
+	LockedUntil  *time.Time
+}
+
+// AccountLockout records that a username was locked after too many failed
+// logins, and which admin unlocked it, if any.
+type AccountLockout struct {
+	ID          int64
+	Username    string
+	ClientIP    string
+	Failures    int32
+	LockedAt    time.Time
+	LockedUntil time.Time
+	UnlockedAt  *time.Time
+	UnlockedBy  *string
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrOauthClientNotFound            = errors.New("oauth client not found")
+	ErrOauthClientAlreadyExist        = errors.New("oauth client already exists")
+	ErrOauthAuthorizationCodeNotFound = errors.New("oauth authorization code not found")
+)
+
+// OauthClient is an application that delegates login to this service through
+// OpenID Connect. Public clients, such as single page apps, have no
+// HashedSecret and must use PKCE instead.
+type OauthClient struct {
+	ID           string
+	HashedSecret *string
+	Name         string
+	RedirectURIs []string
+	Scopes       []string
+	CreatedAt    time.Time
+}
+
+func (c *OauthClient) IsPublic() bool {
+	return c.HashedSecret == nil
+}
+
+// OauthAuthorizationCode is issued by the authorize endpoint once the user
+// logged in, and exchanged by the client for tokens. Only the hash of the code
+// is stored.
+type OauthAuthorizationCode struct {
+	CodeHash      string
+	ClientID      string
+	Username      string
+	RedirectURI   string
+	Scopes        []string
+	Nonce         string
+	CodeChallenge string
+	AuthTime      time.Time
+	ExpiresAt     time.Time
+	CreatedAt     time.Time
+}
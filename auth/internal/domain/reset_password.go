@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrResetPasswordNotFound = errors.New("reset password code is invalid or expired")
+
+type ResetPassword struct {
+	ID       int64
+	Username string
+	Email    string
+	// SecretCodeHash is the SHA-256 of the code sent by email, the code
+	// itself is never stored.
+	SecretCodeHash string
+	IsUsed         bool
+	CreatedAt      time.Time
+	ExpiredAt      time.Time
+}
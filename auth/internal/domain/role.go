@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrRoleNotFound           = errors.New("role not found")
+	ErrRoleAlreadyExist       = errors.New("role already exists")
+	ErrRoleInUse              = errors.New("role is the primary role of a user or service account")
+	ErrPermissionNotFound     = errors.New("permission not found")
+	ErrPermissionAlreadyExist = errors.New("permission already exists")
+	ErrUserRoleNotFound       = errors.New("user does not have this role")
+	ErrUserRoleAlreadyExist   = errors.New("user already has this role")
+)
+
+// Role grants its Permissions to the users it is assigned to, either as their
+// primary User.Role or through additional user roles.
+type Role struct {
+	Name        string
+	Description string
+	Permissions []string
+	CreatedAt   time.Time
+}
+
+// Permission is a name the gateway ACL can require, such as product:write.
+type Permission struct {
+	Name        string
+	Description string
+	CreatedAt   time.Time
+}
+
+// RoleAssignment assigns a role to a user in addition to their primary role.
+type RoleAssignment struct {
+	Username  string
+	Role      string
+	CreatedAt time.Time
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrServiceAccountNotFound     = errors.New("service account not found")
+	ErrServiceAccountAlreadyExist = errors.New("service account already exists")
+	ErrApiKeyNotFound             = errors.New("api key not found")
+)
+
+// ServiceRole is the default role of service accounts.
+const ServiceRole = "service"
+
+// ServiceAccountUsernamePrefix is prepended to the service account name in the
+// identity forwarded by the gateway. Usernames cannot contain a colon, so a
+// service account never acts as the user of the same name.
+const ServiceAccountUsernamePrefix = "service:"
+
+// ServiceAccount is a non human caller, such as a batch job or a partner
+// integration, that authenticates with API keys instead of a password.
+type ServiceAccount struct {
+	Name        string
+	Description string
+	Role        string
+	CreatedBy   string
+	CreatedAt   time.Time
+	ApiKeys     []*ApiKey `db:"-"`
+}
+
+func (a *ServiceAccount) Username() string {
+	return ServiceAccountUsernamePrefix + a.Name
+}
+
+// ApiKey authenticates a service account. Only the hash of the key is stored,
+// Prefix is kept so admins can tell keys apart.
+type ApiKey struct {
+	ID             uuid.UUID
+	ServiceAccount string
+	Prefix         string
+	HashedKey      string
+	Scopes         []string
+	ExpiresAt      *time.Time
+	LastUsedAt     *time.Time
+	RevokedAt      *time.Time
+	CreatedAt      time.Time
+}
+
+// IsActive tells whether the key was neither revoked nor expired at now.
+func (k *ApiKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
@@ -11,15 +11,31 @@ var (
 	ErrSessionNotFound = errors.New("session not found")
 	ErrReadSession     = errors.New("failed to get session")
 	ErrCreateSession   = errors.New("failed to create session")
+	ErrUpdateSession   = errors.New("failed to update session")
+	ErrSessionRotated  = errors.New("session already rotated")
 )
 
+// Session is a refresh token issued at login. Every renewal rotates it into a
+// new session of the same family, pointing at its predecessor via ParentID.
+// ClientID is set for refresh tokens issued to an OauthClient, which is then
+// the only one allowed to renew them.
 type Session struct {
 	ID           uuid.UUID
+	FamilyID     uuid.UUID
+	ParentID     *uuid.UUID
+	ClientID     *string
 	Username     string
 	RefreshToken string
 	UserAgent    string
 	ClientIp     string
 	IsBlocked    bool
 	ExpiresAt    time.Time
+	RotatedAt    *time.Time
 	CreatedAt    time.Time
 }
+
+// SessionDevice is a user agent and client IP a user has logged in from.
+type SessionDevice struct {
+	UserAgent string
+	ClientIp  string
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrTotpNotFound         = errors.New("totp is not enrolled")
+	ErrTotpAlreadyEnabled   = errors.New("totp is already enabled")
+	ErrTotpCodeReused       = errors.New("totp code was already used")
+	ErrRecoveryCodeNotFound = errors.New("recovery code is invalid or used")
+)
+
+// UserTotp is the TOTP state of a user. Secret is encrypted and LastCounter is
+// the time step of the last accepted code, so a code can only be used once.
+type UserTotp struct {
+	Username      string
+	Secret        []byte `db:"totp_secret"`
+	IsTotpEnabled bool
+	LastCounter   int64 `db:"totp_last_counter"`
+}
+
+// RecoveryCode is a one-time code that replaces a TOTP code when the
+// authenticator is lost. Only its hash is stored.
+type RecoveryCode struct {
+	ID         int64
+	Username   string
+	HashedCode string
+	UsedAt     *time.Time
+	CreatedAt  time.Time
+}
@@ -12,6 +12,8 @@ var (
 	ErrReadUser             = errors.New("failed to get user")
 	ErrCreateUser           = errors.New("failed to create user")
 	ErrUpdateUser           = errors.New("failed to update user")
+	ErrDeleteUser           = errors.New("failed to delete user")
+	ErrUserInUse            = errors.New("user created service accounts")
 )
 
 const (
@@ -26,6 +28,24 @@ type User struct {
 	FullName          string    `db:"full_name" `
 	Email             string    `db:"email" `
 	IsEmailVerified   bool      `db:"is_email_verified"`
+	IsTotpEnabled     bool      `db:"is_totp_enabled"`
 	PasswordChangedAt time.Time `db:"password_changed_at"`
 	CreatedAt         time.Time `db:"created_at"`
+	// DisabledAt is set while an admin has disabled the account, which then
+	// cannot login.
+	DisabledAt *time.Time `db:"disabled_at"`
+	// PasswordResetRequired is set by an admin to refuse logins until the
+	// password is reset.
+	PasswordResetRequired bool `db:"password_reset_required"`
+	// DeletedAt is set when the user deleted their account. It is purged
+	// after a grace period.
+	DeletedAt *time.Time `db:"deleted_at"`
+}
+
+func (u *User) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
+
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
 }
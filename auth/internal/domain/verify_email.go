@@ -1,9 +1,15 @@
 package domain
 
 import (
+	"errors"
 	"time"
 )
 
+var (
+	ErrVerifyEmailNotFound = errors.New("verify email code is invalid or expired")
+	ErrVerifyEmailMismatch = errors.New("verify email code was sent to a previous email")
+)
+
 type VerifyEmail struct {
 	ID         int64
 	Username   string
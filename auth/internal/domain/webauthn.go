@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWebauthnCredentialNotFound     = errors.New("webauthn credential not found")
+	ErrWebauthnCredentialAlreadyExist = errors.New("webauthn credential already exists")
+	ErrWebauthnSignCountNotIncreased  = errors.New("webauthn sign count did not increase")
+	ErrWebauthnSessionNotFound        = errors.New("webauthn session not found")
+	ErrWebauthnUserHandleNotFound     = errors.New("webauthn user handle not found")
+)
+
+// Ceremonies of a WebauthnSession.
+const (
+	WebauthnRegistration = "registration"
+	WebauthnLogin        = "login"
+)
+
+// WebauthnCredential is a passkey registered by a user. SignCount is the last
+// signature counter reported by the authenticator, and CloneWarning is set
+// once a counter did not increase, after which the credential is refused.
+type WebauthnCredential struct {
+	ID              []byte
+	Username        string
+	PublicKey       []byte
+	AttestationType string
+	Transports      []string
+	Aaguid          []byte
+	SignCount       int64
+	CloneWarning    bool
+	BackupEligible  bool
+	BackupState     bool
+	LastUsedAt      *time.Time
+	CreatedAt       time.Time
+}
+
+// WebauthnSession holds the challenge of a registration or login ceremony
+// between its begin and finish calls. Login sessions have no Username, the
+// user is only known once the authenticator answers.
+type WebauthnSession struct {
+	ID        uuid.UUID
+	Username  *string
+	Ceremony  string
+	Data      []byte
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// WebauthnUserHandle is the random user handle the passkeys of a user are
+// registered with. Authenticators store it and return it on discoverable
+// logins, so it must not reveal who the user is.
+type WebauthnUserHandle struct {
+	Handle    []byte
+	Username  string
+	CreatedAt time.Time
+}
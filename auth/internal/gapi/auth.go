@@ -8,6 +8,7 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/proto/gen"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
@@ -18,22 +19,88 @@ type UserApplication interface {
 	Create(ctx context.Context, arg application.CreateUser) (*domain.User, error)
 	Update(ctx context.Context, arg application.UpdateUser) (*domain.User, error)
 	Login(ctx context.Context, arg application.LoginUser) (*application.LoginUserResult, error)
+	RenewAccessToken(ctx context.Context, arg application.RenewAccessToken) (*application.RenewAccessTokenResult, error)
+	ListSessions(ctx context.Context, arg application.ListSessions) ([]*domain.Session, error)
+	RevokeSession(ctx context.Context, arg application.RevokeSession) error
+	RevokeAllSessions(ctx context.Context, arg application.RevokeAllSessions) error
+	RevokeNewDeviceSession(ctx context.Context, arg application.RevokeNewDeviceSession) error
+	ResendVerifyEmail(ctx context.Context, arg application.ResendVerifyEmail) error
+	RequestPasswordReset(ctx context.Context, arg application.RequestPasswordReset) error
+	ResetPassword(ctx context.Context, arg application.ResetPassword) (*domain.User, error)
+	UnlockUser(ctx context.Context, arg application.UnlockUser) (*domain.AccountLockout, error)
+	VerifyLoginTotp(ctx context.Context, arg application.VerifyLoginTotp) (*application.LoginUserResult, error)
+	EnrollTotp(ctx context.Context, arg application.EnrollTotp) (*application.EnrollTotpResult, error)
+	ConfirmTotp(ctx context.Context, arg application.ConfirmTotp) ([]string, error)
+	DisableTotp(ctx context.Context, arg application.DisableTotp) error
+	GenerateRecoveryCodes(ctx context.Context, arg application.GenerateRecoveryCodes) ([]string, error)
+	BeginWebauthnRegistration(ctx context.Context, arg application.BeginWebauthnRegistration) (*application.WebauthnCeremony, error)
+	FinishWebauthnRegistration(ctx context.Context, arg application.FinishWebauthnRegistration) (*domain.WebauthnCredential, error)
+	BeginWebauthnLogin(ctx context.Context) (*application.WebauthnCeremony, error)
+	FinishWebauthnLogin(ctx context.Context, arg application.FinishWebauthnLogin) (*application.LoginUserResult, error)
+	BeginExternalLogin(ctx context.Context, arg application.BeginExternalLogin) (*application.BeginExternalLoginResult, error)
+	CompleteExternalLogin(ctx context.Context, arg application.CompleteExternalLogin) (*application.LoginUserResult, error)
+	ListUsers(ctx context.Context, arg application.ListUsers) ([]*domain.User, error)
+	GetUser(ctx context.Context, arg application.GetUser) (*domain.User, error)
+	SetUserRole(ctx context.Context, arg application.SetUserRole) (*domain.User, error)
+	DisableUser(ctx context.Context, arg application.ManageUser) (*domain.User, error)
+	EnableUser(ctx context.Context, arg application.ManageUser) (*domain.User, error)
+	ForcePasswordReset(ctx context.Context, arg application.ManageUser) (*domain.User, error)
+	DeleteUser(ctx context.Context, arg application.ManageUser) error
+	DeleteAccount(ctx context.Context, arg application.DeleteAccount) error
+	ExportMyData(ctx context.Context, arg application.ExportMyData) ([]byte, error)
+	ListAuthEvents(ctx context.Context, arg application.ListAuthEvents) (*application.ListAuthEventsResult, error)
 }
 
 type VerifyEmailApplication interface {
 	VerifyEmail(ctx context.Context, arg application.VerifyEmail) (*application.VerifyEmailResult, error)
 }
 
+type OidcApplication interface {
+	CreateOauthClient(ctx context.Context, arg application.CreateOauthClient) (*application.CreateOauthClientResult, error)
+}
+
+type ServiceAccountApplication interface {
+	CreateServiceAccount(ctx context.Context, arg application.CreateServiceAccount) (*application.CreateServiceAccountResult, error)
+	ListServiceAccounts(ctx context.Context) ([]*domain.ServiceAccount, error)
+	RotateApiKey(ctx context.Context, arg application.RotateApiKey) (*application.RotateApiKeyResult, error)
+	RevokeApiKey(ctx context.Context, arg application.RevokeApiKey) (*domain.ApiKey, error)
+	VerifyApiKey(ctx context.Context, key string) (*application.VerifyApiKeyResult, error)
+}
+
+type RoleApplication interface {
+	CreateRole(ctx context.Context, arg application.CreateRole) (*domain.Role, error)
+	ListRoles(ctx context.Context) ([]*domain.Role, error)
+	DeleteRole(ctx context.Context, arg application.DeleteRole) error
+	SetRolePermissions(ctx context.Context, arg application.SetRolePermissions) (*domain.Role, error)
+	CreatePermission(ctx context.Context, arg application.CreatePermission) (*domain.Permission, error)
+	ListPermissions(ctx context.Context) ([]*domain.Permission, error)
+	GetUserRoles(ctx context.Context, arg application.GetUserRoles) (*application.UserRolesResult, error)
+	AssignUserRole(ctx context.Context, arg application.UserRoleParams) (*application.UserRolesResult, error)
+	RemoveUserRole(ctx context.Context, arg application.UserRoleParams) (*application.UserRolesResult, error)
+}
+
+type TokenVerifier interface {
+	VerifyToken(token string, opts ...token.VerifyOption) (*token.Payload, error)
+}
+
 type AuthServer struct {
 	gen.UnimplementedAuthServiceServer
-	userApplication        UserApplication
-	verifyEmailApplication VerifyEmailApplication
+	userApplication           UserApplication
+	verifyEmailApplication    VerifyEmailApplication
+	oidcApplication           OidcApplication
+	serviceAccountApplication ServiceAccountApplication
+	roleApplication           RoleApplication
+	tokenVerifier             TokenVerifier
 }
 
-func NewAuthServer(userApplication UserApplication, verVerifyEmailApplication VerifyEmailApplication) *AuthServer {
+func NewAuthServer(userApplication UserApplication, verVerifyEmailApplication VerifyEmailApplication, oidcApplication OidcApplication, serviceAccountApplication ServiceAccountApplication, roleApplication RoleApplication, tokenVerifier TokenVerifier) *AuthServer {
 	return &AuthServer{
-		userApplication:        userApplication,
-		verifyEmailApplication: verVerifyEmailApplication,
+		userApplication:           userApplication,
+		verifyEmailApplication:    verVerifyEmailApplication,
+		oidcApplication:           oidcApplication,
+		serviceAccountApplication: serviceAccountApplication,
+		roleApplication:           roleApplication,
+		tokenVerifier:             tokenVerifier,
 	}
 }
 
@@ -53,12 +120,30 @@ func (server *AuthServer) CreateUser(ctx context.Context, req *gen.CreateUserReq
 }
 
 func (server *AuthServer) UpdateUser(ctx context.Context, req *gen.UpdateUserRequest) (*gen.UpdateUserResponse, error) {
-	user, err := server.userApplication.Update(ctx, toUpdateUserApp(req))
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if err := checkOwnership(authPayload, req.GetUsername()); err != nil {
+		return nil, permissionDeniedError(err)
+	}
+
+	arg := toUpdateUserApp(req)
+	if authPayload.Username == req.GetUsername() {
+		arg.CurrentSessionID = authPayload.SessionID
+		arg.RequireCurrentPassword = true
+	}
+
+	user, err := server.userApplication.Update(ctx, arg)
 	if err != nil {
 		var valErr validation.Errors
 		if errors.As(err, &valErr) && valErr != nil {
 			return nil, invalidArgumentError(valErr)
 		}
+		if errors.Is(err, application.ErrInvalidCurrentPassword) {
+			return nil, invalidArgumentError(validation.Errors{"current_password": application.ErrInvalidCurrentPassword})
+		}
 		if errors.Is(err, domain.ErrUserNotFound) {
 			return nil, status.Errorf(codes.NotFound, "user not found")
 		}
@@ -79,6 +164,16 @@ func (server *AuthServer) LoginUser(ctx context.Context, req *gen.LoginUserReque
 		if errors.Is(err, domain.ErrUserNotFound) {
 			return nil, status.Errorf(codes.NotFound, "user not found")
 		}
+		if errors.Is(err, application.ErrEmailNotVerified) {
+			return nil, emailNotVerifiedError()
+		}
+		if inactiveErr := accountInactiveError(err); inactiveErr != nil {
+			return nil, inactiveErr
+		}
+		var throttledErr *application.LoginThrottledError
+		if errors.As(err, &throttledErr) {
+			return nil, loginThrottledError(throttledErr)
+		}
 		log.Error().Err(err).Msg("failed to login user")
 		return nil, status.Errorf(codes.Internal, "failed to login user: %s", err)
 	}
@@ -86,6 +181,97 @@ func (server *AuthServer) LoginUser(ctx context.Context, req *gen.LoginUserReque
 	return toLoginUserResponse(res), nil
 }
 
+func (server *AuthServer) RenewAccessToken(ctx context.Context, req *gen.RenewAccessTokenRequest) (*gen.RenewAccessTokenResponse, error) {
+	res, err := server.userApplication.RenewAccessToken(ctx, toRenewAccessTokenApp(req))
+	if err != nil {
+		var valErr validation.Errors
+		if errors.As(err, &valErr) && valErr != nil {
+			return nil, invalidArgumentError(valErr)
+		}
+		if sessionErr := sessionError(err); sessionErr != nil {
+			return nil, sessionErr
+		}
+		log.Error().Err(err).Msg("failed to renew access token")
+		return nil, status.Errorf(codes.Internal, "failed to renew access token: %s", err)
+	}
+
+	return toRenewAccessTokenResponse(res), nil
+}
+
+func (server *AuthServer) ListSessions(ctx context.Context, req *gen.ListSessionsRequest) (*gen.ListSessionsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	sessions, err := server.userApplication.ListSessions(ctx, application.ListSessions{Username: authPayload.Username})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list sessions")
+		return nil, status.Errorf(codes.Internal, "failed to list sessions: %s", err)
+	}
+
+	return toListSessionsResponse(sessions, authPayload.SessionID), nil
+}
+
+func (server *AuthServer) RevokeSession(ctx context.Context, req *gen.RevokeSessionRequest) (*gen.RevokeSessionResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	arg, err := toRevokeSessionApp(req, authPayload)
+	if err != nil {
+		return nil, invalidArgumentError(validation.Errors{"session_id": err})
+	}
+
+	err = server.userApplication.RevokeSession(ctx, arg)
+	if err != nil {
+		var valErr validation.Errors
+		if errors.As(err, &valErr) && valErr != nil {
+			return nil, invalidArgumentError(valErr)
+		}
+		if sessionErr := sessionError(err); sessionErr != nil {
+			return nil, sessionErr
+		}
+		log.Error().Err(err).Msg("failed to revoke session")
+		return nil, status.Errorf(codes.Internal, "failed to revoke session: %s", err)
+	}
+
+	return &gen.RevokeSessionResponse{}, nil
+}
+
+func (server *AuthServer) RevokeNewDeviceSession(ctx context.Context, req *gen.RevokeNewDeviceSessionRequest) (*gen.RevokeNewDeviceSessionResponse, error) {
+	err := server.userApplication.RevokeNewDeviceSession(ctx, toRevokeNewDeviceSessionApp(req))
+	if err != nil {
+		var valErr validation.Errors
+		if errors.As(err, &valErr) && valErr != nil {
+			return nil, invalidArgumentError(valErr)
+		}
+		if sessionErr := sessionError(err); sessionErr != nil {
+			return nil, sessionErr
+		}
+		log.Error().Err(err).Msg("failed to revoke new device session")
+		return nil, status.Errorf(codes.Internal, "failed to revoke session: %s", err)
+	}
+
+	return &gen.RevokeNewDeviceSessionResponse{}, nil
+}
+
+func (server *AuthServer) RevokeAllSessions(ctx context.Context, req *gen.RevokeAllSessionsRequest) (*gen.RevokeAllSessionsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	err = server.userApplication.RevokeAllSessions(ctx, toRevokeAllSessionsApp(req, authPayload))
+	if err != nil {
+		log.Error().Err(err).Msg("failed to revoke all sessions")
+		return nil, status.Errorf(codes.Internal, "failed to revoke all sessions: %s", err)
+	}
+
+	return &gen.RevokeAllSessionsResponse{}, nil
+}
+
 func (server *AuthServer) VerifyEmail(ctx context.Context, req *gen.VerifyEmailRequest) (*gen.VerifyEmailResponse, error) {
 	res, err := server.verifyEmailApplication.VerifyEmail(ctx, toVerifyEmailApp(req))
 	if err != nil {
@@ -93,9 +279,750 @@ func (server *AuthServer) VerifyEmail(ctx context.Context, req *gen.VerifyEmailR
 		if errors.As(err, &valErr) && valErr != nil {
 			return nil, invalidArgumentError(valErr)
 		}
+		if errors.Is(err, domain.ErrVerifyEmailNotFound) {
+			return nil, invalidArgumentError(validation.Errors{"secret_code": domain.ErrVerifyEmailNotFound})
+		}
+		if errors.Is(err, domain.ErrVerifyEmailMismatch) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s, request a new one", domain.ErrVerifyEmailMismatch)
+		}
 		log.Error().Err(err).Msg("failed to verify email")
 		return nil, status.Errorf(codes.Internal, "failed to verify email: %s", err)
 	}
 
 	return toVerifyEmailResponse(res), nil
 }
+
+func (server *AuthServer) ResendVerifyEmail(ctx context.Context, req *gen.ResendVerifyEmailRequest) (*gen.ResendVerifyEmailResponse, error) {
+	err := server.userApplication.ResendVerifyEmail(ctx, toResendVerifyEmailApp(req))
+	if err != nil {
+		var valErr validation.Errors
+		if errors.As(err, &valErr) && valErr != nil {
+			return nil, invalidArgumentError(valErr)
+		}
+		log.Error().Err(err).Msg("failed to resend verify email")
+		return nil, status.Errorf(codes.Internal, "failed to resend verify email")
+	}
+
+	return &gen.ResendVerifyEmailResponse{}, nil
+}
+
+func (server *AuthServer) RequestPasswordReset(ctx context.Context, req *gen.RequestPasswordResetRequest) (*gen.RequestPasswordResetResponse, error) {
+	err := server.userApplication.RequestPasswordReset(ctx, toRequestPasswordResetApp(req))
+	if err != nil {
+		var valErr validation.Errors
+		if errors.As(err, &valErr) && valErr != nil {
+			return nil, invalidArgumentError(valErr)
+		}
+		log.Error().Err(err).Msg("failed to request password reset")
+		return nil, status.Errorf(codes.Internal, "failed to request password reset")
+	}
+
+	return &gen.RequestPasswordResetResponse{}, nil
+}
+
+func (server *AuthServer) ResetPassword(ctx context.Context, req *gen.ResetPasswordRequest) (*gen.ResetPasswordResponse, error) {
+	_, err := server.userApplication.ResetPassword(ctx, toResetPasswordApp(req))
+	if err != nil {
+		var valErr validation.Errors
+		if errors.As(err, &valErr) && valErr != nil {
+			return nil, invalidArgumentError(valErr)
+		}
+		if errors.Is(err, domain.ErrResetPasswordNotFound) {
+			return nil, invalidArgumentError(validation.Errors{"secret_code": domain.ErrResetPasswordNotFound})
+		}
+		log.Error().Err(err).Msg("failed to reset password")
+		return nil, status.Errorf(codes.Internal, "failed to reset password: %s", err)
+	}
+
+	return &gen.ResetPasswordResponse{}, nil
+}
+
+func (server *AuthServer) UnlockUser(ctx context.Context, req *gen.UnlockUserRequest) (*gen.UnlockUserResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	lockout, err := server.userApplication.UnlockUser(ctx, toUnlockUserApp(req, authPayload))
+	if err != nil {
+		var valErr validation.Errors
+		if errors.As(err, &valErr) && valErr != nil {
+			return nil, invalidArgumentError(valErr)
+		}
+		if errors.Is(err, domain.ErrAccountLockoutNotFound) {
+			return nil, status.Errorf(codes.NotFound, "%s", domain.ErrAccountLockoutNotFound)
+		}
+		log.Error().Err(err).Msg("failed to unlock user")
+		return nil, status.Errorf(codes.Internal, "failed to unlock user: %s", err)
+	}
+
+	return toUnlockUserResponse(lockout), nil
+}
+
+func (server *AuthServer) VerifyLoginTotp(ctx context.Context, req *gen.VerifyLoginTotpRequest) (*gen.LoginUserResponse, error) {
+	res, err := server.userApplication.VerifyLoginTotp(ctx, toVerifyLoginTotpApp(req))
+	if err != nil {
+		var valErr validation.Errors
+		if errors.As(err, &valErr) && valErr != nil {
+			return nil, invalidArgumentError(valErr)
+		}
+		if errors.Is(err, application.ErrInvalidMfaToken) || errors.Is(err, application.ErrInvalidTotpCode) || errors.Is(err, application.ErrTotpNotEnabled) {
+			return nil, unauthenticatedError(err)
+		}
+		if errors.Is(err, application.ErrEmailNotVerified) {
+			return nil, emailNotVerifiedError()
+		}
+		if inactiveErr := accountInactiveError(err); inactiveErr != nil {
+			return nil, inactiveErr
+		}
+		var throttledErr *application.LoginThrottledError
+		if errors.As(err, &throttledErr) {
+			return nil, loginThrottledError(throttledErr)
+		}
+		log.Error().Err(err).Msg("failed to verify login totp")
+		return nil, status.Errorf(codes.Internal, "failed to verify login totp: %s", err)
+	}
+
+	return toLoginUserResponse(res), nil
+}
+
+func (server *AuthServer) EnrollTotp(ctx context.Context, req *gen.EnrollTotpRequest) (*gen.EnrollTotpResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	res, err := server.userApplication.EnrollTotp(ctx, application.EnrollTotp{Username: authPayload.Username})
+	if err != nil {
+		return nil, totpError(err, "failed to enroll totp")
+	}
+
+	return toEnrollTotpResponse(res), nil
+}
+
+func (server *AuthServer) ConfirmTotp(ctx context.Context, req *gen.ConfirmTotpRequest) (*gen.ConfirmTotpResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	recoveryCodes, err := server.userApplication.ConfirmTotp(ctx, application.ConfirmTotp{
+		Username: authPayload.Username,
+		Code:     req.GetCode(),
+	})
+	if err != nil {
+		return nil, totpError(err, "failed to confirm totp")
+	}
+
+	return &gen.ConfirmTotpResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (server *AuthServer) DisableTotp(ctx context.Context, req *gen.DisableTotpRequest) (*gen.DisableTotpResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	err = server.userApplication.DisableTotp(ctx, application.DisableTotp{
+		Username: authPayload.Username,
+		Code:     req.GetCode(),
+	})
+	if err != nil {
+		return nil, totpError(err, "failed to disable totp")
+	}
+
+	return &gen.DisableTotpResponse{}, nil
+}
+
+func (server *AuthServer) GenerateRecoveryCodes(ctx context.Context, req *gen.GenerateRecoveryCodesRequest) (*gen.GenerateRecoveryCodesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	recoveryCodes, err := server.userApplication.GenerateRecoveryCodes(ctx, application.GenerateRecoveryCodes{
+		Username: authPayload.Username,
+		Code:     req.GetCode(),
+	})
+	if err != nil {
+		return nil, totpError(err, "failed to generate recovery codes")
+	}
+
+	return &gen.GenerateRecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (server *AuthServer) BeginWebauthnRegistration(ctx context.Context, req *gen.BeginWebauthnRegistrationRequest) (*gen.BeginWebauthnRegistrationResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	res, err := server.userApplication.BeginWebauthnRegistration(ctx, application.BeginWebauthnRegistration{Username: authPayload.Username})
+	if err != nil {
+		return nil, webauthnRegistrationError(err, "failed to begin webauthn registration")
+	}
+
+	options, err := toWebauthnOptions(res)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to convert webauthn options")
+		return nil, status.Errorf(codes.Internal, "failed to convert webauthn options: %s", err)
+	}
+
+	return &gen.BeginWebauthnRegistrationResponse{
+		SessionId: res.SessionID.String(),
+		Options:   options,
+	}, nil
+}
+
+func (server *AuthServer) FinishWebauthnRegistration(ctx context.Context, req *gen.FinishWebauthnRegistrationRequest) (*gen.FinishWebauthnRegistrationResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	arg, err := toFinishWebauthnRegistrationApp(req, authPayload)
+	if err != nil {
+		return nil, invalidArgumentError(validation.Errors{"session_id": err})
+	}
+
+	credential, err := server.userApplication.FinishWebauthnRegistration(ctx, arg)
+	if err != nil {
+		return nil, webauthnRegistrationError(err, "failed to finish webauthn registration")
+	}
+
+	return &gen.FinishWebauthnRegistrationResponse{
+		Credential: toWebauthnCredentialResponse(credential),
+	}, nil
+}
+
+func (server *AuthServer) BeginWebauthnLogin(ctx context.Context, req *gen.BeginWebauthnLoginRequest) (*gen.BeginWebauthnLoginResponse, error) {
+	res, err := server.userApplication.BeginWebauthnLogin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to begin webauthn login")
+		return nil, status.Errorf(codes.Internal, "failed to begin webauthn login: %s", err)
+	}
+
+	options, err := toWebauthnOptions(res)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to convert webauthn options")
+		return nil, status.Errorf(codes.Internal, "failed to convert webauthn options: %s", err)
+	}
+
+	return &gen.BeginWebauthnLoginResponse{
+		SessionId: res.SessionID.String(),
+		Options:   options,
+	}, nil
+}
+
+func (server *AuthServer) FinishWebauthnLogin(ctx context.Context, req *gen.FinishWebauthnLoginRequest) (*gen.LoginUserResponse, error) {
+	arg, err := toFinishWebauthnLoginApp(req)
+	if err != nil {
+		return nil, invalidArgumentError(validation.Errors{"session_id": err})
+	}
+
+	res, err := server.userApplication.FinishWebauthnLogin(ctx, arg)
+	if err != nil {
+		var valErr validation.Errors
+		if errors.As(err, &valErr) && valErr != nil {
+			return nil, invalidArgumentError(valErr)
+		}
+		if errors.Is(err, application.ErrInvalidWebauthnSession) || errors.Is(err, application.ErrInvalidWebauthnCredential) || errors.Is(err, application.ErrWebauthnCloneDetected) {
+			return nil, unauthenticatedError(err)
+		}
+		if errors.Is(err, application.ErrEmailNotVerified) {
+			return nil, emailNotVerifiedError()
+		}
+		if inactiveErr := accountInactiveError(err); inactiveErr != nil {
+			return nil, inactiveErr
+		}
+		var throttledErr *application.LoginThrottledError
+		if errors.As(err, &throttledErr) {
+			return nil, loginThrottledError(throttledErr)
+		}
+		log.Error().Err(err).Msg("failed to finish webauthn login")
+		return nil, status.Errorf(codes.Internal, "failed to finish webauthn login: %s", err)
+	}
+
+	return toLoginUserResponse(res), nil
+}
+
+func (server *AuthServer) BeginExternalLogin(ctx context.Context, req *gen.BeginExternalLoginRequest) (*gen.BeginExternalLoginResponse, error) {
+	res, err := server.userApplication.BeginExternalLogin(ctx, toBeginExternalLoginApp(req))
+	if err != nil {
+		return nil, externalLoginError(err, "failed to begin external login")
+	}
+
+	return toBeginExternalLoginResponse(res), nil
+}
+
+func (server *AuthServer) CompleteExternalLogin(ctx context.Context, req *gen.CompleteExternalLoginRequest) (*gen.LoginUserResponse, error) {
+	res, err := server.userApplication.CompleteExternalLogin(ctx, toCompleteExternalLoginApp(req))
+	if err != nil {
+		return nil, externalLoginError(err, "failed to complete external login")
+	}
+
+	return toLoginUserResponse(res), nil
+}
+
+func (server *AuthServer) CreateOauthClient(ctx context.Context, req *gen.CreateOauthClientRequest) (*gen.CreateOauthClientResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	res, err := server.oidcApplication.CreateOauthClient(ctx, toCreateOauthClientApp(req))
+	if err != nil {
+		var valErr validation.Errors
+		if errors.As(err, &valErr) && valErr != nil {
+			return nil, invalidArgumentError(valErr)
+		}
+		if errors.Is(err, domain.ErrOauthClientAlreadyExist) {
+			return nil, status.Errorf(codes.AlreadyExists, "%s", err)
+		}
+		log.Error().Err(err).Msg("failed to create oauth client")
+		return nil, status.Errorf(codes.Internal, "failed to create oauth client: %s", err)
+	}
+
+	return toCreateOauthClientResponse(res), nil
+}
+
+func (server *AuthServer) CreateServiceAccount(ctx context.Context, req *gen.CreateServiceAccountRequest) (*gen.CreateServiceAccountResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	res, err := server.serviceAccountApplication.CreateServiceAccount(ctx, toCreateServiceAccountApp(req, authPayload))
+	if err != nil {
+		return nil, serviceAccountError(err, "failed to create service account")
+	}
+
+	return toCreateServiceAccountResponse(res), nil
+}
+
+func (server *AuthServer) ListServiceAccounts(ctx context.Context, req *gen.ListServiceAccountsRequest) (*gen.ListServiceAccountsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	accounts, err := server.serviceAccountApplication.ListServiceAccounts(ctx)
+	if err != nil {
+		return nil, serviceAccountError(err, "failed to list service accounts")
+	}
+
+	return toListServiceAccountsResponse(accounts), nil
+}
+
+func (server *AuthServer) RotateApiKey(ctx context.Context, req *gen.RotateApiKeyRequest) (*gen.RotateApiKeyResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	arg, err := toRotateApiKeyApp(req)
+	if err != nil {
+		return nil, invalidArgumentError(validation.Errors{"id": err})
+	}
+
+	res, err := server.serviceAccountApplication.RotateApiKey(ctx, arg)
+	if err != nil {
+		return nil, serviceAccountError(err, "failed to rotate api key")
+	}
+
+	return toRotateApiKeyResponse(res), nil
+}
+
+func (server *AuthServer) RevokeApiKey(ctx context.Context, req *gen.RevokeApiKeyRequest) (*gen.RevokeApiKeyResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	arg, err := toRevokeApiKeyApp(req)
+	if err != nil {
+		return nil, invalidArgumentError(validation.Errors{"id": err})
+	}
+
+	apiKey, err := server.serviceAccountApplication.RevokeApiKey(ctx, arg)
+	if err != nil {
+		return nil, serviceAccountError(err, "failed to revoke api key")
+	}
+
+	return &gen.RevokeApiKeyResponse{Key: toApiKeyResponse(apiKey)}, nil
+}
+
+// VerifyApiKey lets the gateway authenticate the X-API-Key header. The
+// response is the identity the gateway forwards to the backend services.
+func (server *AuthServer) VerifyApiKey(ctx context.Context, req *gen.VerifyApiKeyRequest) (*gen.VerifyApiKeyResponse, error) {
+	res, err := server.serviceAccountApplication.VerifyApiKey(ctx, req.GetApiKey())
+	if err != nil {
+		return nil, serviceAccountError(err, "failed to verify api key")
+	}
+
+	return toVerifyApiKeyResponse(res), nil
+}
+
+func (server *AuthServer) CreateRole(ctx context.Context, req *gen.CreateRoleRequest) (*gen.CreateRoleResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	role, err := server.roleApplication.CreateRole(ctx, application.CreateRole{Name: req.GetName(), Description: req.GetDescription()})
+	if err != nil {
+		return nil, roleError(err, "failed to create role")
+	}
+
+	return &gen.CreateRoleResponse{Role: toRoleResponse(role)}, nil
+}
+
+func (server *AuthServer) ListRoles(ctx context.Context, req *gen.ListRolesRequest) (*gen.ListRolesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	roles, err := server.roleApplication.ListRoles(ctx)
+	if err != nil {
+		return nil, roleError(err, "failed to list roles")
+	}
+
+	return toListRolesResponse(roles), nil
+}
+
+func (server *AuthServer) DeleteRole(ctx context.Context, req *gen.DeleteRoleRequest) (*gen.DeleteRoleResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	err = server.roleApplication.DeleteRole(ctx, application.DeleteRole{Name: req.GetName()})
+	if err != nil {
+		return nil, roleError(err, "failed to delete role")
+	}
+
+	return &gen.DeleteRoleResponse{}, nil
+}
+
+func (server *AuthServer) SetRolePermissions(ctx context.Context, req *gen.SetRolePermissionsRequest) (*gen.SetRolePermissionsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	role, err := server.roleApplication.SetRolePermissions(ctx, application.SetRolePermissions{Role: req.GetName(), Permissions: req.GetPermissions()})
+	if err != nil {
+		return nil, roleError(err, "failed to set role permissions")
+	}
+
+	return &gen.SetRolePermissionsResponse{Role: toRoleResponse(role)}, nil
+}
+
+func (server *AuthServer) CreatePermission(ctx context.Context, req *gen.CreatePermissionRequest) (*gen.CreatePermissionResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	permission, err := server.roleApplication.CreatePermission(ctx, application.CreatePermission{Name: req.GetName(), Description: req.GetDescription()})
+	if err != nil {
+		return nil, roleError(err, "failed to create permission")
+	}
+
+	return &gen.CreatePermissionResponse{Permission: toPermissionResponse(permission)}, nil
+}
+
+func (server *AuthServer) ListPermissions(ctx context.Context, req *gen.ListPermissionsRequest) (*gen.ListPermissionsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	permissions, err := server.roleApplication.ListPermissions(ctx)
+	if err != nil {
+		return nil, roleError(err, "failed to list permissions")
+	}
+
+	return toListPermissionsResponse(permissions), nil
+}
+
+func (server *AuthServer) GetUserRoles(ctx context.Context, req *gen.GetUserRolesRequest) (*gen.GetUserRolesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	res, err := server.roleApplication.GetUserRoles(ctx, application.GetUserRoles{Username: req.GetUsername()})
+	if err != nil {
+		return nil, roleError(err, "failed to get user roles")
+	}
+
+	return &gen.GetUserRolesResponse{UserRoles: toUserRolesResponse(res)}, nil
+}
+
+func (server *AuthServer) AssignUserRole(ctx context.Context, req *gen.AssignUserRoleRequest) (*gen.AssignUserRoleResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	res, err := server.roleApplication.AssignUserRole(ctx, application.UserRoleParams{Username: req.GetUsername(), Role: req.GetRole()})
+	if err != nil {
+		return nil, roleError(err, "failed to assign user role")
+	}
+
+	return &gen.AssignUserRoleResponse{UserRoles: toUserRolesResponse(res)}, nil
+}
+
+func (server *AuthServer) RemoveUserRole(ctx context.Context, req *gen.RemoveUserRoleRequest) (*gen.RemoveUserRoleResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	res, err := server.roleApplication.RemoveUserRole(ctx, application.UserRoleParams{Username: req.GetUsername(), Role: req.GetRole()})
+	if err != nil {
+		return nil, roleError(err, "failed to remove user role")
+	}
+
+	return &gen.RemoveUserRoleResponse{UserRoles: toUserRolesResponse(res)}, nil
+}
+
+func (server *AuthServer) ListUsers(ctx context.Context, req *gen.ListUsersRequest) (*gen.ListUsersResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	users, err := server.userApplication.ListUsers(ctx, toListUsersApp(req))
+	if err != nil {
+		return nil, userAdminError(err, "failed to list users")
+	}
+
+	return toListUsersResponse(users), nil
+}
+
+func (server *AuthServer) GetUser(ctx context.Context, req *gen.GetUserRequest) (*gen.GetUserResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	user, err := server.userApplication.GetUser(ctx, application.GetUser{Username: req.GetUsername()})
+	if err != nil {
+		return nil, userAdminError(err, "failed to get user")
+	}
+
+	return &gen.GetUserResponse{User: toAdminUserResponse(user)}, nil
+}
+
+func (server *AuthServer) SetUserRole(ctx context.Context, req *gen.SetUserRoleRequest) (*gen.SetUserRoleResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	user, err := server.userApplication.SetUserRole(ctx, toSetUserRoleApp(req, authPayload))
+	if err != nil {
+		return nil, userAdminError(err, "failed to set user role")
+	}
+
+	return &gen.SetUserRoleResponse{User: toAdminUserResponse(user)}, nil
+}
+
+func (server *AuthServer) DisableUser(ctx context.Context, req *gen.DisableUserRequest) (*gen.DisableUserResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	user, err := server.userApplication.DisableUser(ctx, toManageUserApp(req.GetUsername(), authPayload))
+	if err != nil {
+		return nil, userAdminError(err, "failed to disable user")
+	}
+
+	return &gen.DisableUserResponse{User: toAdminUserResponse(user)}, nil
+}
+
+func (server *AuthServer) EnableUser(ctx context.Context, req *gen.EnableUserRequest) (*gen.EnableUserResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	user, err := server.userApplication.EnableUser(ctx, toManageUserApp(req.GetUsername(), authPayload))
+	if err != nil {
+		return nil, userAdminError(err, "failed to enable user")
+	}
+
+	return &gen.EnableUserResponse{User: toAdminUserResponse(user)}, nil
+}
+
+func (server *AuthServer) ForcePasswordReset(ctx context.Context, req *gen.ForcePasswordResetRequest) (*gen.ForcePasswordResetResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	user, err := server.userApplication.ForcePasswordReset(ctx, toManageUserApp(req.GetUsername(), authPayload))
+	if err != nil {
+		return nil, userAdminError(err, "failed to force password reset")
+	}
+
+	return &gen.ForcePasswordResetResponse{User: toAdminUserResponse(user)}, nil
+}
+
+func (server *AuthServer) DeleteUser(ctx context.Context, req *gen.DeleteUserRequest) (*gen.DeleteUserResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	err = server.userApplication.DeleteUser(ctx, toManageUserApp(req.GetUsername(), authPayload))
+	if err != nil {
+		return nil, userAdminError(err, "failed to delete user")
+	}
+
+	return &gen.DeleteUserResponse{}, nil
+}
+
+func (server *AuthServer) DeleteAccount(ctx context.Context, req *gen.DeleteAccountRequest) (*gen.DeleteAccountResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	err = server.userApplication.DeleteAccount(ctx, application.DeleteAccount{Username: authPayload.Username})
+	if err != nil {
+		return nil, accountError(err, "failed to delete account")
+	}
+
+	return &gen.DeleteAccountResponse{}, nil
+}
+
+func (server *AuthServer) ExportMyData(ctx context.Context, req *gen.ExportMyDataRequest) (*gen.ExportMyDataResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	archive, err := server.userApplication.ExportMyData(ctx, application.ExportMyData{Username: authPayload.Username})
+	if err != nil {
+		return nil, accountError(err, "failed to export data")
+	}
+
+	res, err := toExportMyDataResponse(archive)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to convert data export")
+		return nil, status.Errorf(codes.Internal, "failed to convert data export: %s", err)
+	}
+
+	return res, nil
+}
+
+func (server *AuthServer) ListAuthEvents(ctx context.Context, req *gen.ListAuthEventsRequest) (*gen.ListAuthEventsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if authPayload.Role != domain.AdminRole {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	res, err := server.userApplication.ListAuthEvents(ctx, toListAuthEventsApp(req))
+	if err != nil {
+		return nil, userAdminError(err, "failed to list auth events")
+	}
+
+	return toListAuthEventsResponse(res), nil
+}
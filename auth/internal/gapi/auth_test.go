@@ -2,6 +2,9 @@ package gapi
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"testing"
 	"time"
 
@@ -11,12 +14,17 @@ import (
 	mockdb "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application/mock"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/sso"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/denylist"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/proto/gen"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func TestCreateUserAPI(t *testing.T) {
@@ -247,8 +255,8 @@ func TestCreateUserAPI(t *testing.T) {
 
 			tc.buildMocks(userRespository)
 
-			userApplication := application.NewUserApplication(userRespository, nil, nil, nil, nil)
-			server := NewAuthServer(userApplication, nil)
+			userApplication := application.NewUserApplication(userRespository, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			server := NewAuthServer(userApplication, nil, nil, nil, nil, nil)
 
 			res, err := server.CreateUser(context.Background(), tc.req)
 			tc.checkResponse(t, res, err)
@@ -263,9 +271,23 @@ func TestUpdateUserAPI(t *testing.T) {
 	newEmail := util.RandomEmail()
 	invalidEmail := "invalid-email"
 
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	updatedUser := &domain.User{
+		Username:          user.Username,
+		HashedPassword:    user.HashedPassword,
+		FullName:          newName,
+		Email:             newEmail,
+		PasswordChangedAt: user.PasswordChangedAt,
+		CreatedAt:         user.CreatedAt,
+		IsEmailVerified:   user.IsEmailVerified,
+	}
+
 	testCases := []struct {
 		name          string
 		req           *gen.UpdateUserRequest
+		buildContext  func(t *testing.T) context.Context
 		buildMocks    func(userRepository *mockdb.MockUserRepository)
 		checkResponse func(t *testing.T, res *gen.UpdateUserResponse, err error)
 	}{
@@ -276,21 +298,14 @@ func TestUpdateUserAPI(t *testing.T) {
 				FullName: &newName,
 				Email:    &newEmail,
 			},
+			buildContext: func(t *testing.T) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, uuid.New())
+			},
 			buildMocks: func(userRepository *mockdb.MockUserRepository) {
-				updatedUser := &domain.User{
-					Username:          user.Username,
-					HashedPassword:    user.HashedPassword,
-					FullName:          newName,
-					Email:             newEmail,
-					PasswordChangedAt: user.PasswordChangedAt,
-					CreatedAt:         user.CreatedAt,
-					IsEmailVerified:   user.IsEmailVerified,
-				}
-
 				userRepository.EXPECT().
-					UpdateUser(gomock.Any(), gomock.Any()).
+					UpdateUserTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(updatedUser, nil)
+					Return(infra.UpdateUserTxResult{User: *updatedUser, EmailChanged: true}, nil)
 			},
 			checkResponse: func(t *testing.T, res *gen.UpdateUserResponse, err error) {
 				require.NoError(t, err)
@@ -302,17 +317,157 @@ func TestUpdateUserAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "UserNotFound",
+			name: "AdminUpdatesOtherUser",
+			req: &gen.UpdateUserRequest{
+				Username: user.Username,
+				FullName: &newName,
+				Email:    &newEmail,
+			},
+			buildContext: func(t *testing.T) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, util.RandomUsername(), domain.AdminRole, uuid.New())
+			},
+			buildMocks: func(userRepository *mockdb.MockUserRepository) {
+				userRepository.EXPECT().
+					UpdateUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(infra.UpdateUserTxResult{User: *updatedUser, EmailChanged: true}, nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.UpdateUserResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				require.Equal(t, user.Username, res.GetUser().Username)
+			},
+		},
+		{
+			name: "ForwardedIdentityWithoutToken",
+			req: &gen.UpdateUserRequest{
+				Username: user.Username,
+				FullName: &newName,
+				Email:    &newEmail,
+			},
+			buildContext: func(t *testing.T) context.Context {
+				return newContextWithForwardedIdentity(user.Username, user.Role)
+			},
+			buildMocks: func(userRepository *mockdb.MockUserRepository) {
+				userRepository.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.UpdateUserResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "AuthenticatedByInterceptor",
+			req: &gen.UpdateUserRequest{
+				Username: user.Username,
+				FullName: &newName,
+				Email:    &newEmail,
+			},
+			buildContext: func(t *testing.T) context.Context {
+				payload, err := token.NewPayload(user.Username, user.Role, time.Minute)
+				require.NoError(t, err)
+				return contextWithPayload(context.Background(), payload)
+			},
+			buildMocks: func(userRepository *mockdb.MockUserRepository) {
+				userRepository.EXPECT().
+					UpdateUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(infra.UpdateUserTxResult{User: *updatedUser, EmailChanged: true}, nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.UpdateUserResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				require.Equal(t, user.Username, res.GetUser().Username)
+			},
+		},
+		{
+			name: "OtherUser",
+			req: &gen.UpdateUserRequest{
+				Username: user.Username,
+				FullName: &newName,
+				Email:    &newEmail,
+			},
+			buildContext: func(t *testing.T) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, util.RandomUsername(), domain.UserRole, uuid.New())
+			},
+			buildMocks: func(userRepository *mockdb.MockUserRepository) {
+				userRepository.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.UpdateUserResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &gen.UpdateUserRequest{
+				Username: user.Username,
+				FullName: &newName,
+				Email:    &newEmail,
+			},
+			buildContext: func(t *testing.T) context.Context {
+				return context.Background()
+			},
+			buildMocks: func(userRepository *mockdb.MockUserRepository) {
+				userRepository.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.UpdateUserResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "InvalidToken",
 			req: &gen.UpdateUserRequest{
 				Username: user.Username,
 				FullName: &newName,
 				Email:    &newEmail,
 			},
+			buildContext: func(t *testing.T) context.Context {
+				md := metadata.MD{
+					authorizationHeader: []string{fmt.Sprintf("%s %s", authorizationBearer, "invalid-token")},
+				}
+				return metadata.NewIncomingContext(context.Background(), md)
+			},
 			buildMocks: func(userRepository *mockdb.MockUserRepository) {
 				userRepository.EXPECT().
 					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.UpdateUserResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "UserNotFound",
+			req: &gen.UpdateUserRequest{
+				Username: user.Username,
+				FullName: &newName,
+				Email:    &newEmail,
+			},
+			buildContext: func(t *testing.T) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, uuid.New())
+			},
+			buildMocks: func(userRepository *mockdb.MockUserRepository) {
+				userRepository.EXPECT().
+					UpdateUserTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(&domain.User{}, domain.ErrUserNotFound)
+					Return(infra.UpdateUserTxResult{}, domain.ErrUserNotFound)
 			},
 			checkResponse: func(t *testing.T, res *gen.UpdateUserResponse, err error) {
 				require.Error(t, err)
@@ -321,6 +476,26 @@ func TestUpdateUserAPI(t *testing.T) {
 				require.Equal(t, codes.NotFound, st.Code())
 			},
 		},
+		{
+			name: "PasswordWithoutCurrentPassword",
+			req: &gen.UpdateUserRequest{
+				Username: user.Username,
+				Password: &newName,
+			},
+			buildContext: func(t *testing.T) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, uuid.New())
+			},
+			buildMocks: func(userRepository *mockdb.MockUserRepository) {
+				userRepository.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				userRepository.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.UpdateUserResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
 		{
 			name: "InvalidEmail",
 			req: &gen.UpdateUserRequest{
@@ -328,6 +503,9 @@ func TestUpdateUserAPI(t *testing.T) {
 				FullName: &newName,
 				Email:    &invalidEmail,
 			},
+			buildContext: func(t *testing.T) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, uuid.New())
+			},
 			buildMocks: func(userRepository *mockdb.MockUserRepository) {
 				userRepository.EXPECT().
 					UpdateUser(gomock.Any(), gomock.Any()).
@@ -349,10 +527,10 @@ func TestUpdateUserAPI(t *testing.T) {
 
 			tc.buildMocks(userRespository)
 
-			userApplication := application.NewUserApplication(userRespository, nil, nil, nil, nil)
-			server := NewAuthServer(userApplication, nil)
+			userApplication := application.NewUserApplication(userRespository, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			server := NewAuthServer(userApplication, nil, nil, nil, nil, tokenMaker)
 
-			res, err := server.UpdateUser(context.Background(), tc.req)
+			res, err := server.UpdateUser(tc.buildContext(t), tc.req)
 			tc.checkResponse(t, res, err)
 		})
 	}
@@ -414,8 +592,8 @@ func TestLoginUserAPI(t *testing.T) {
 			tokenMaker, err := token.NewJwtToken(util.RandomString(32))
 			require.NoError(t, err)
 
-			userApplication := application.NewUserApplication(userRespository, sessionRepository, nil, tokenMaker, &config)
-			server := NewAuthServer(userApplication, nil)
+			userApplication := application.NewUserApplication(userRespository, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, discardAuthEvents{}, nil, nil, tokenMaker, nil, &config)
+			server := NewAuthServer(userApplication, nil, nil, nil, nil, nil)
 
 			res, err := server.LoginUser(context.Background(), tc.req)
 			tc.checkResponse(t, res, err)
@@ -423,59 +601,2117 @@ func TestLoginUserAPI(t *testing.T) {
 	}
 }
 
-func TestVerifyEmailAPI(t *testing.T) {
+func TestLoginUserUnverifiedEmailAPI(t *testing.T) {
+	user, password := randomUser(t)
+	user.IsEmailVerified = false
+
+	ctrl := gomock.NewController(t)
+	userRepository := mockdb.NewMockUserRepository(ctrl)
+	sessionRepository := mockdb.NewMockSessionRepository(ctrl)
+
+	userRepository.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+
+	sessionRepository.EXPECT().
+		CreateSession(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	config := util.Config{
+		AccessTokenDuration:   time.Minute,
+		RefreshTokenDuration:  time.Minute,
+		UnverifiedEmailPolicy: util.UnverifiedEmailDeny,
+	}
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	userApplication := application.NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, discardAuthEvents{}, nil, nil, tokenMaker, nil, &config)
+	server := NewAuthServer(userApplication, nil, nil, nil, nil, nil)
+
+	res, err := server.LoginUser(context.Background(), &gen.LoginUserRequest{Username: user.Username, Password: password})
+	require.Nil(t, res)
+	requireStatusCode(t, codes.FailedPrecondition, err)
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Len(t, st.Details(), 1)
+
+	preconditionFailure, ok := st.Details()[0].(*errdetails.PreconditionFailure)
+	require.True(t, ok)
+	require.Equal(t, "EMAIL_NOT_VERIFIED", preconditionFailure.GetViolations()[0].GetType())
+}
+
+func TestLoginUserLockedAPI(t *testing.T) {
+	user, password := randomUser(t)
+	lockedUntil := time.Now().Add(time.Minute)
+
+	ctrl := gomock.NewController(t)
+	userRepository := mockdb.NewMockUserRepository(ctrl)
+	loginFailureRepository := mockdb.NewMockLoginFailureRepository(ctrl)
+
+	loginFailureRepository.EXPECT().
+		GetLoginFailure(gomock.Any(), gomock.Eq(domain.LoginFailureScopeUsername), gomock.Eq(user.Username)).
+		Times(1).
+		Return(&domain.LoginFailure{Failures: 5, LastFailedAt: time.Now(), LockedUntil: &lockedUntil}, nil)
+
+	userRepository.EXPECT().
+		GetUser(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	config := util.Config{
+		AccessTokenDuration:  time.Minute,
+		RefreshTokenDuration: time.Minute,
+		LoginMaxFailures:     5,
+		LoginLockoutDuration: time.Minute,
+	}
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	userApplication := application.NewUserApplication(userRepository, nil, nil, loginFailureRepository, nil, nil, nil, staticPermissions{}, discardAuthEvents{}, nil, nil, tokenMaker, nil, &config)
+	server := NewAuthServer(userApplication, nil, nil, nil, nil, nil)
+
+	res, err := server.LoginUser(context.Background(), &gen.LoginUserRequest{Username: user.Username, Password: password})
+	require.Nil(t, res)
+	requireStatusCode(t, codes.PermissionDenied, err)
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Len(t, st.Details(), 1)
+
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	require.InDelta(t, time.Minute, retryInfo.GetRetryDelay().AsDuration(), float64(time.Second))
+}
+
+func TestLoginUserDisabledAPI(t *testing.T) {
+	user, password := randomUser(t)
+	disabledAt := time.Now()
+	user.DisabledAt = &disabledAt
+
+	ctrl := gomock.NewController(t)
+	userRepository := mockdb.NewMockUserRepository(ctrl)
+	sessionRepository := mockdb.NewMockSessionRepository(ctrl)
+
+	userRepository.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+
+	sessionRepository.EXPECT().
+		CreateSession(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	config := util.Config{
+		AccessTokenDuration:  time.Minute,
+		RefreshTokenDuration: time.Minute,
+	}
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	userApplication := application.NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, discardAuthEvents{}, nil, nil, tokenMaker, nil, &config)
+	server := NewAuthServer(userApplication, nil, nil, nil, nil, nil)
+
+	res, err := server.LoginUser(context.Background(), &gen.LoginUserRequest{Username: user.Username, Password: password})
+	require.Nil(t, res)
+	requireStatusCode(t, codes.PermissionDenied, err)
+}
+
+func TestRenewAccessTokenAPI(t *testing.T) {
 	user, _ := randomUser(t)
-	verifyEmail := randomVerifyEmail(user)
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	refreshToken, refreshPayload, err := tokenMaker.CreateToken(user.Username, user.Role, time.Minute, token.WithPurpose(token.PurposeRefresh))
+	require.NoError(t, err)
 
 	testCases := []struct {
 		name          string
-		req           *gen.VerifyEmailRequest
-		buildMocks    func(verifyEmailRepository *mockdb.MockVerifyEmailRepository)
-		checkResponse func(t *testing.T, res *gen.VerifyEmailResponse, err error)
+		req           *gen.RenewAccessTokenRequest
+		buildMocks    func(sessionRepository *mockdb.MockSessionRepository)
+		checkResponse func(t *testing.T, res *gen.RenewAccessTokenResponse, err error)
 	}{
 		{
 			name: "OK",
-			req: &gen.VerifyEmailRequest{
-				EmailId:    verifyEmail.ID,
-				SecretCode: verifyEmail.SecretCode,
+			req: &gen.RenewAccessTokenRequest{
+				RefreshToken: refreshToken,
 			},
-			buildMocks: func(verifyEmailRepository *mockdb.MockVerifyEmailRepository) {
-				user.IsEmailVerified = true
+			buildMocks: func(sessionRepository *mockdb.MockSessionRepository) {
+				session := randomSession(t, user.Username)
+				session.ID = refreshPayload.ID
+				session.RefreshToken = refreshToken
 
-				txRes := infra.VerifyEmailTxResult{
-					User:        user,
-					VerifyEmail: verifyEmail,
-				}
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(refreshPayload.ID)).
+					Times(1).
+					Return(session, nil)
 
-				verifyEmailRepository.EXPECT().
-					VerifyEmailTx(gomock.Any(), gomock.Any()).
+				rotatedSession := randomSession(t, user.Username)
+				rotatedSession.FamilyID = session.ID
+				rotatedSession.ParentID = &session.ID
+
+				sessionRepository.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(txRes, nil)
+					Return(infra.RotateSessionTxResult{Parent: session, Session: rotatedSession}, nil)
 			},
-			checkResponse: func(t *testing.T, res *gen.VerifyEmailResponse, err error) {
+			checkResponse: func(t *testing.T, res *gen.RenewAccessTokenResponse, err error) {
 				require.NoError(t, err)
 				require.NotNil(t, res)
-				require.True(t, res.IsVerified)
+				require.NotEmpty(t, res.AccessToken)
+				require.NotEmpty(t, res.RefreshToken)
+				require.NotEmpty(t, res.SessionId)
+				require.NotNil(t, res.AccessTokenExpiresAt)
+				require.NotNil(t, res.RefreshTokenExpiresAt)
+			},
+		},
+		{
+			name: "SessionNotFound",
+			req: &gen.RenewAccessTokenRequest{
+				RefreshToken: refreshToken,
+			},
+			buildMocks: func(sessionRepository *mockdb.MockSessionRepository) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(refreshPayload.ID)).
+					Times(1).
+					Return(nil, domain.ErrSessionNotFound)
+			},
+			checkResponse: func(t *testing.T, res *gen.RenewAccessTokenResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "BlockedSession",
+			req: &gen.RenewAccessTokenRequest{
+				RefreshToken: refreshToken,
+			},
+			buildMocks: func(sessionRepository *mockdb.MockSessionRepository) {
+				session := randomSession(t, user.Username)
+				session.ID = refreshPayload.ID
+				session.RefreshToken = refreshToken
+				session.IsBlocked = true
+
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(refreshPayload.ID)).
+					Times(1).
+					Return(session, nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.RenewAccessTokenResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "ReusedRefreshToken",
+			req: &gen.RenewAccessTokenRequest{
+				RefreshToken: refreshToken,
+			},
+			buildMocks: func(sessionRepository *mockdb.MockSessionRepository) {
+				session := randomSession(t, user.Username)
+				session.ID = refreshPayload.ID
+				session.FamilyID = refreshPayload.ID
+				session.RefreshToken = refreshToken
+				rotatedAt := time.Now()
+				session.RotatedAt = &rotatedAt
+
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(refreshPayload.ID)).
+					Times(1).
+					Return(session, nil)
+
+				sessionRepository.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Eq(session.FamilyID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.RenewAccessTokenResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "InvalidRefreshToken",
+			req: &gen.RenewAccessTokenRequest{
+				RefreshToken: "invalid",
+			},
+			buildMocks: func(sessionRepository *mockdb.MockSessionRepository) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.RenewAccessTokenResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
 			},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			repositoryCtrl := gomock.NewController(t)
-			verifyEmailRepository := mockdb.NewMockVerifyEmailRepository(repositoryCtrl)
+			sessionCtrl := gomock.NewController(t)
+			sessionRepository := mockdb.NewMockSessionRepository(sessionCtrl)
 
-			tc.buildMocks(verifyEmailRepository)
+			tc.buildMocks(sessionRepository)
 
-			verifyEmailApplication := application.NewVerifyEmailApplication(verifyEmailRepository)
-			server := NewAuthServer(nil, verifyEmailApplication)
+			config := util.Config{
+				AccessTokenDuration: time.Minute,
+			}
 
-			res, err := server.VerifyEmail(context.Background(), tc.req)
+			userApplication := application.NewUserApplication(nil, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, nil, nil, nil, tokenMaker, denylist.NewMemoryDenylist(), &config)
+			server := NewAuthServer(userApplication, nil, nil, nil, nil, nil)
+
+			res, err := server.RenewAccessToken(context.Background(), tc.req)
 			tc.checkResponse(t, res, err)
 		})
 	}
 }
 
+func TestListSessionsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	current := randomSession(t, user.Username)
+	other := randomSession(t, user.Username)
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		buildContext  func(t *testing.T) context.Context
+		buildMocks    func(sessionRepository *mockdb.MockSessionRepository)
+		checkResponse func(t *testing.T, res *gen.ListSessionsResponse, err error)
+	}{
+		{
+			name: "OK",
+			buildContext: func(t *testing.T) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, current.FamilyID)
+			},
+			buildMocks: func(sessionRepository *mockdb.MockSessionRepository) {
+				sessionRepository.EXPECT().
+					ListActiveSessions(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]*domain.Session{current, other}, nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.ListSessionsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.Sessions, 2)
+				require.Equal(t, current.ID.String(), res.Sessions[0].Id)
+				require.True(t, res.Sessions[0].Current)
+				require.Equal(t, other.ID.String(), res.Sessions[1].Id)
+				require.False(t, res.Sessions[1].Current)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			buildContext: func(t *testing.T) context.Context {
+				return context.Background()
+			},
+			buildMocks: func(sessionRepository *mockdb.MockSessionRepository) {
+				sessionRepository.EXPECT().
+					ListActiveSessions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.ListSessionsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sessionCtrl := gomock.NewController(t)
+			sessionRepository := mockdb.NewMockSessionRepository(sessionCtrl)
+
+			tc.buildMocks(sessionRepository)
+
+			userApplication := application.NewUserApplication(nil, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, nil, nil, nil, tokenMaker, nil, nil)
+			server := NewAuthServer(userApplication, nil, nil, nil, nil, tokenMaker)
+
+			res, err := server.ListSessions(tc.buildContext(t), &gen.ListSessionsRequest{})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestRevokeSessionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	session := randomSession(t, user.Username)
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		req           *gen.RevokeSessionRequest
+		buildMocks    func(sessionRepository *mockdb.MockSessionRepository)
+		checkResponse func(t *testing.T, res *gen.RevokeSessionResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &gen.RevokeSessionRequest{
+				SessionId: session.ID.String(),
+			},
+			buildMocks: func(sessionRepository *mockdb.MockSessionRepository) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.ID)).
+					Times(1).
+					Return(session, nil)
+
+				sessionRepository.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Eq(session.FamilyID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.RevokeSessionResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			name: "InvalidSessionID",
+			req: &gen.RevokeSessionRequest{
+				SessionId: "invalid",
+			},
+			buildMocks: func(sessionRepository *mockdb.MockSessionRepository) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.RevokeSessionResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "SessionOfAnotherUser",
+			req: &gen.RevokeSessionRequest{
+				SessionId: session.ID.String(),
+			},
+			buildMocks: func(sessionRepository *mockdb.MockSessionRepository) {
+				otherSession := randomSession(t, util.RandomUsername())
+				otherSession.ID = session.ID
+
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.ID)).
+					Times(1).
+					Return(otherSession, nil)
+
+				sessionRepository.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.RevokeSessionResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sessionCtrl := gomock.NewController(t)
+			sessionRepository := mockdb.NewMockSessionRepository(sessionCtrl)
+
+			tc.buildMocks(sessionRepository)
+
+			config := util.Config{AccessTokenDuration: time.Minute}
+			userApplication := application.NewUserApplication(nil, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, nil, nil, nil, tokenMaker, denylist.NewMemoryDenylist(), &config)
+			server := NewAuthServer(userApplication, nil, nil, nil, nil, tokenMaker)
+
+			ctx := newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, session.FamilyID)
+			res, err := server.RevokeSession(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestRevokeNewDeviceSessionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	session := randomSession(t, user.Username)
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	revokeToken, _, err := tokenMaker.CreateToken(user.Username, user.Role, time.Minute, token.WithPurpose(token.PurposeRevokeSession), token.WithSessionID(session.FamilyID))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		req           *gen.RevokeNewDeviceSessionRequest
+		buildMocks    func(sessionRepository *mockdb.MockSessionRepository)
+		checkResponse func(t *testing.T, res *gen.RevokeNewDeviceSessionResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &gen.RevokeNewDeviceSessionRequest{
+				Token: revokeToken,
+			},
+			buildMocks: func(sessionRepository *mockdb.MockSessionRepository) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.FamilyID)).
+					Times(1).
+					Return(session, nil)
+
+				sessionRepository.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Eq(session.FamilyID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.RevokeNewDeviceSessionResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			name: "RequiredToken",
+			req:  &gen.RevokeNewDeviceSessionRequest{},
+			buildMocks: func(sessionRepository *mockdb.MockSessionRepository) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.RevokeNewDeviceSessionResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "InvalidToken",
+			req: &gen.RevokeNewDeviceSessionRequest{
+				Token: "invalid",
+			},
+			buildMocks: func(sessionRepository *mockdb.MockSessionRepository) {
+				sessionRepository.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.RevokeNewDeviceSessionResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sessionCtrl := gomock.NewController(t)
+			sessionRepository := mockdb.NewMockSessionRepository(sessionCtrl)
+
+			tc.buildMocks(sessionRepository)
+
+			config := util.Config{AccessTokenDuration: time.Minute}
+			userApplication := application.NewUserApplication(nil, sessionRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, tokenMaker, denylist.NewMemoryDenylist(), &config)
+			server := NewAuthServer(userApplication, nil, nil, nil, nil, tokenMaker)
+
+			res, err := server.RevokeNewDeviceSession(context.Background(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestVerifyEmailAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	verifyEmail := randomVerifyEmail(user)
+
+	testCases := []struct {
+		name          string
+		req           *gen.VerifyEmailRequest
+		buildMocks    func(verifyEmailRepository *mockdb.MockVerifyEmailRepository)
+		checkResponse func(t *testing.T, res *gen.VerifyEmailResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &gen.VerifyEmailRequest{
+				EmailId:    verifyEmail.ID,
+				SecretCode: verifyEmail.SecretCode,
+			},
+			buildMocks: func(verifyEmailRepository *mockdb.MockVerifyEmailRepository) {
+				user.IsEmailVerified = true
+
+				txRes := infra.VerifyEmailTxResult{
+					User:        user,
+					VerifyEmail: verifyEmail,
+				}
+
+				verifyEmailRepository.EXPECT().
+					VerifyEmailTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(txRes, nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.VerifyEmailResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				require.True(t, res.IsVerified)
+			},
+		},
+		{
+			name: "EmailChanged",
+			req: &gen.VerifyEmailRequest{
+				EmailId:    verifyEmail.ID,
+				SecretCode: verifyEmail.SecretCode,
+			},
+			buildMocks: func(verifyEmailRepository *mockdb.MockVerifyEmailRepository) {
+				verifyEmailRepository.EXPECT().
+					VerifyEmailTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(infra.VerifyEmailTxResult{}, domain.ErrVerifyEmailMismatch)
+
+				verifyEmailRepository.EXPECT().
+					GetVerifyEmail(gomock.Any(), gomock.Eq(verifyEmail.ID)).
+					Times(1).
+					Return(verifyEmail, nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.VerifyEmailResponse, err error) {
+				requireStatusCode(t, codes.FailedPrecondition, err)
+			},
+		},
+		{
+			name: "ExpiredCode",
+			req: &gen.VerifyEmailRequest{
+				EmailId:    verifyEmail.ID,
+				SecretCode: verifyEmail.SecretCode,
+			},
+			buildMocks: func(verifyEmailRepository *mockdb.MockVerifyEmailRepository) {
+				verifyEmailRepository.EXPECT().
+					VerifyEmailTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(infra.VerifyEmailTxResult{}, domain.ErrVerifyEmailNotFound)
+
+				verifyEmailRepository.EXPECT().
+					GetVerifyEmail(gomock.Any(), gomock.Eq(verifyEmail.ID)).
+					Times(1).
+					Return(verifyEmail, nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.VerifyEmailResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repositoryCtrl := gomock.NewController(t)
+			verifyEmailRepository := mockdb.NewMockVerifyEmailRepository(repositoryCtrl)
+
+			tc.buildMocks(verifyEmailRepository)
+
+			verifyEmailApplication := application.NewVerifyEmailApplication(verifyEmailRepository, discardAuthEvents{}, &util.Config{})
+			server := NewAuthServer(nil, verifyEmailApplication, nil, nil, nil, nil)
+
+			res, err := server.VerifyEmail(context.Background(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestRequestPasswordResetAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		req           *gen.RequestPasswordResetRequest
+		buildMocks    func(userRepository *mockdb.MockUserRepository, taskDistributor *mockdb.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *gen.RequestPasswordResetResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &gen.RequestPasswordResetRequest{Email: user.Email},
+			buildMocks: func(userRepository *mockdb.MockUserRepository, taskDistributor *mockdb.MockTaskDistributor) {
+				userRepository.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendResetPassword(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.RequestPasswordResetResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			name: "UnknownEmailLooksTheSame",
+			req:  &gen.RequestPasswordResetRequest{Email: util.RandomEmail()},
+			buildMocks: func(userRepository *mockdb.MockUserRepository, taskDistributor *mockdb.MockTaskDistributor) {
+				userRepository.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrUserNotFound)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendResetPassword(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.RequestPasswordResetResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			name: "InvalidEmail",
+			req:  &gen.RequestPasswordResetRequest{Email: "invalid"},
+			buildMocks: func(userRepository *mockdb.MockUserRepository, taskDistributor *mockdb.MockTaskDistributor) {
+				userRepository.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.RequestPasswordResetResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepository := mockdb.NewMockUserRepository(ctrl)
+			taskDistributor := mockdb.NewMockTaskDistributor(ctrl)
+
+			tc.buildMocks(userRepository, taskDistributor)
+
+			userApplication := application.NewUserApplication(userRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, taskDistributor, nil, nil, nil)
+			server := NewAuthServer(userApplication, nil, nil, nil, nil, nil)
+
+			res, err := server.RequestPasswordReset(context.Background(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestResetPasswordAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	secretCode := util.RandomString(32)
+
+	testCases := []struct {
+		name          string
+		req           *gen.ResetPasswordRequest
+		buildMocks    func(resetPasswordRepository *mockdb.MockResetPasswordRepository, sessionRepository *mockdb.MockSessionRepository)
+		checkResponse func(t *testing.T, res *gen.ResetPasswordResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &gen.ResetPasswordRequest{
+				ResetId:    1,
+				SecretCode: secretCode,
+				Password:   util.RandomString(8),
+			},
+			buildMocks: func(resetPasswordRepository *mockdb.MockResetPasswordRepository, sessionRepository *mockdb.MockSessionRepository) {
+				resetPasswordRepository.EXPECT().
+					ResetPasswordTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(infra.ResetPasswordTxResult{User: user}, nil)
+
+				sessionRepository.EXPECT().
+					BlockUserSessions(gomock.Any(), gomock.Eq(infra.BlockUserSessions{Username: user.Username})).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.ResetPasswordResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			name: "InvalidCode",
+			req: &gen.ResetPasswordRequest{
+				ResetId:    1,
+				SecretCode: secretCode,
+				Password:   util.RandomString(8),
+			},
+			buildMocks: func(resetPasswordRepository *mockdb.MockResetPasswordRepository, sessionRepository *mockdb.MockSessionRepository) {
+				resetPasswordRepository.EXPECT().
+					ResetPasswordTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(infra.ResetPasswordTxResult{}, domain.ErrResetPasswordNotFound)
+
+				sessionRepository.EXPECT().
+					BlockUserSessions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.ResetPasswordResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			resetPasswordRepository := mockdb.NewMockResetPasswordRepository(ctrl)
+			sessionRepository := mockdb.NewMockSessionRepository(ctrl)
+
+			tc.buildMocks(resetPasswordRepository, sessionRepository)
+
+			config := util.Config{AccessTokenDuration: time.Minute}
+			userApplication := application.NewUserApplication(nil, sessionRepository, resetPasswordRepository, nil, nil, nil, nil, nil, discardAuthEvents{}, nil, nil, nil, denylist.NewMemoryDenylist(), &config)
+			server := NewAuthServer(userApplication, nil, nil, nil, nil, nil)
+
+			res, err := server.ResetPassword(context.Background(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestUnlockUserAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	admin, _ := randomUser(t)
+	admin.Role = domain.AdminRole
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		req           *gen.UnlockUserRequest
+		caller        *domain.User
+		buildMocks    func(loginFailureRepository *mockdb.MockLoginFailureRepository)
+		checkResponse func(t *testing.T, res *gen.UnlockUserResponse, err error)
+	}{
+		{
+			name:   "OK",
+			req:    &gen.UnlockUserRequest{Username: user.Username},
+			caller: admin,
+			buildMocks: func(loginFailureRepository *mockdb.MockLoginFailureRepository) {
+				unlockedAt := time.Now()
+				loginFailureRepository.EXPECT().
+					UnlockUserTx(gomock.Any(), gomock.Eq(infra.UnlockUserTx{Username: user.Username, UnlockedBy: admin.Username})).
+					Times(1).
+					Return(infra.UnlockUserTxResult{
+						Lockout: &domain.AccountLockout{
+							Username:    user.Username,
+							ClientIP:    "10.0.0.1",
+							Failures:    5,
+							LockedAt:    time.Now().Add(-time.Minute),
+							LockedUntil: time.Now().Add(time.Minute),
+							UnlockedAt:  &unlockedAt,
+							UnlockedBy:  &admin.Username,
+						},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.UnlockUserResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user.Username, res.GetLockout().GetUsername())
+				require.Equal(t, admin.Username, res.GetLockout().GetUnlockedBy())
+				require.NotNil(t, res.GetLockout().GetUnlockedAt())
+			},
+		},
+		{
+			name:   "NotLocked",
+			req:    &gen.UnlockUserRequest{Username: user.Username},
+			caller: admin,
+			buildMocks: func(loginFailureRepository *mockdb.MockLoginFailureRepository) {
+				loginFailureRepository.EXPECT().
+					UnlockUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(infra.UnlockUserTxResult{}, domain.ErrAccountLockoutNotFound)
+			},
+			checkResponse: func(t *testing.T, res *gen.UnlockUserResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.NotFound, err)
+			},
+		},
+		{
+			name:   "NotAdmin",
+			req:    &gen.UnlockUserRequest{Username: user.Username},
+			caller: user,
+			buildMocks: func(loginFailureRepository *mockdb.MockLoginFailureRepository) {
+				loginFailureRepository.EXPECT().
+					UnlockUserTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.UnlockUserResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.PermissionDenied, err)
+			},
+		},
+		{
+			name:   "InvalidUsername",
+			req:    &gen.UnlockUserRequest{Username: "invalid user"},
+			caller: admin,
+			buildMocks: func(loginFailureRepository *mockdb.MockLoginFailureRepository) {
+				loginFailureRepository.EXPECT().
+					UnlockUserTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.UnlockUserResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			loginFailureRepository := mockdb.NewMockLoginFailureRepository(ctrl)
+
+			tc.buildMocks(loginFailureRepository)
+
+			userApplication := application.NewUserApplication(nil, nil, nil, loginFailureRepository, nil, nil, nil, staticPermissions{}, nil, nil, nil, tokenMaker, nil, &util.Config{})
+			server := NewAuthServer(userApplication, nil, nil, nil, nil, tokenMaker)
+
+			ctx := newContextWithBearerToken(t, tokenMaker, tc.caller.Username, tc.caller.Role, uuid.New())
+			res, err := server.UnlockUser(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestLoginUserTotpAPI(t *testing.T) {
+	user, password := randomUser(t)
+	user.IsTotpEnabled = true
+
+	ctrl := gomock.NewController(t)
+	userRepository := mockdb.NewMockUserRepository(ctrl)
+	sessionRepository := mockdb.NewMockSessionRepository(ctrl)
+
+	userRepository.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+
+	sessionRepository.EXPECT().
+		CreateSession(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	config := util.Config{
+		AccessTokenDuration:  time.Minute,
+		RefreshTokenDuration: time.Minute,
+		MfaChallengeDuration: time.Minute,
+	}
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	userApplication := application.NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, staticPermissions{}, nil, nil, nil, tokenMaker, nil, &config)
+	server := NewAuthServer(userApplication, nil, nil, nil, nil, tokenMaker)
+
+	res, err := server.LoginUser(context.Background(), &gen.LoginUserRequest{Username: user.Username, Password: password})
+	require.NoError(t, err)
+	require.True(t, res.GetMfaRequired())
+	require.NotEmpty(t, res.GetMfaToken())
+	require.NotNil(t, res.GetMfaTokenExpiresAt())
+	require.Empty(t, res.GetAccessToken())
+	require.Empty(t, res.GetRefreshToken())
+	require.Empty(t, res.GetSessionId())
+
+	accessToken, _, err := tokenMaker.CreateToken(user.Username, user.Role, time.Minute)
+	require.NoError(t, err)
+
+	verifyRes, err := server.VerifyLoginTotp(context.Background(), &gen.VerifyLoginTotpRequest{MfaToken: accessToken, Code: "123456"})
+	require.Nil(t, verifyRes)
+	requireStatusCode(t, codes.Unauthenticated, err)
+}
+
+func TestConfirmTotpAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		req           *gen.ConfirmTotpRequest
+		buildMocks    func(totpRepository *mockdb.MockTotpRepository)
+		checkResponse func(t *testing.T, res *gen.ConfirmTotpResponse, err error)
+	}{
+		{
+			name: "InvalidCodeFormat",
+			req:  &gen.ConfirmTotpRequest{Code: "abc"},
+			buildMocks: func(totpRepository *mockdb.MockTotpRepository) {
+				totpRepository.EXPECT().
+					GetUserTotp(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.ConfirmTotpResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+		{
+			name: "AlreadyEnabled",
+			req:  &gen.ConfirmTotpRequest{Code: "123456"},
+			buildMocks: func(totpRepository *mockdb.MockTotpRepository) {
+				totpRepository.EXPECT().
+					GetUserTotp(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(&domain.UserTotp{Username: user.Username, Secret: []byte("secret"), IsTotpEnabled: true}, nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.ConfirmTotpResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.FailedPrecondition, err)
+			},
+		},
+		{
+			name: "NotEnrolled",
+			req:  &gen.ConfirmTotpRequest{Code: "123456"},
+			buildMocks: func(totpRepository *mockdb.MockTotpRepository) {
+				totpRepository.EXPECT().
+					GetUserTotp(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(&domain.UserTotp{Username: user.Username}, nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.ConfirmTotpResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.FailedPrecondition, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			totpRepository := mockdb.NewMockTotpRepository(ctrl)
+
+			tc.buildMocks(totpRepository)
+
+			config := util.Config{TotpEncryptionKey: util.RandomString(32)}
+			userApplication := application.NewUserApplication(nil, nil, nil, nil, totpRepository, nil, nil, staticPermissions{}, nil, nil, nil, tokenMaker, nil, &config)
+			server := NewAuthServer(userApplication, nil, nil, nil, nil, tokenMaker)
+
+			ctx := newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, uuid.New())
+			res, err := server.ConfirmTotp(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestBeginWebauthnRegistrationAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		buildContext  func(t *testing.T) context.Context
+		buildMocks    func(userRepository *mockdb.MockUserRepository, webauthnRepository *mockdb.MockWebauthnRepository)
+		checkResponse func(t *testing.T, res *gen.BeginWebauthnRegistrationResponse, err error)
+	}{
+		{
+			name: "OK",
+			buildContext: func(t *testing.T) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, uuid.New())
+			},
+			buildMocks: func(userRepository *mockdb.MockUserRepository, webauthnRepository *mockdb.MockWebauthnRepository) {
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				webauthnRepository.EXPECT().
+					CreateWebauthnUserHandle(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg infra.CreateWebauthnUserHandle) (*domain.WebauthnUserHandle, error) {
+						return &domain.WebauthnUserHandle{Handle: arg.Handle, Username: arg.Username}, nil
+					})
+
+				webauthnRepository.EXPECT().
+					ListWebauthnCredentials(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(nil, nil)
+
+				webauthnRepository.EXPECT().
+					CreateWebauthnSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg infra.CreateWebauthnSession) (*domain.WebauthnSession, error) {
+						return &domain.WebauthnSession{ID: arg.ID, Username: arg.Username, Ceremony: arg.Ceremony, Data: arg.Data, ExpiresAt: arg.ExpiresAt}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *gen.BeginWebauthnRegistrationResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetSessionId())
+
+				publicKey := res.GetOptions().GetFields()["publicKey"].GetStructValue()
+				require.NotNil(t, publicKey)
+				require.NotEmpty(t, publicKey.GetFields()["challenge"].GetStringValue())
+			},
+		},
+		{
+			name: "NoAuthorization",
+			buildContext: func(t *testing.T) context.Context {
+				return context.Background()
+			},
+			buildMocks: func(userRepository *mockdb.MockUserRepository, webauthnRepository *mockdb.MockWebauthnRepository) {
+				webauthnRepository.EXPECT().
+					CreateWebauthnSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.BeginWebauthnRegistrationResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepository := mockdb.NewMockUserRepository(ctrl)
+			webauthnRepository := mockdb.NewMockWebauthnRepository(ctrl)
+
+			tc.buildMocks(userRepository, webauthnRepository)
+
+			config := util.Config{
+				WebauthnRPID:              "localhost",
+				WebauthnRPDisplayName:     "test",
+				WebauthnRPOrigins:         []string{"http://localhost:3000"},
+				WebauthnChallengeDuration: time.Minute,
+			}
+			userApplication := application.NewUserApplication(userRepository, nil, nil, nil, nil, webauthnRepository, nil, staticPermissions{}, nil, nil, nil, tokenMaker, nil, &config)
+			server := NewAuthServer(userApplication, nil, nil, nil, nil, tokenMaker)
+
+			res, err := server.BeginWebauthnRegistration(tc.buildContext(t), &gen.BeginWebauthnRegistrationRequest{})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestFinishWebauthnLoginAPI(t *testing.T) {
+	credential, err := structpb.NewStruct(map[string]any{"id": "abc", "type": "public-key"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		req           *gen.FinishWebauthnLoginRequest
+		buildMocks    func(webauthnRepository *mockdb.MockWebauthnRepository)
+		checkResponse func(t *testing.T, res *gen.LoginUserResponse, err error)
+	}{
+		{
+			name: "InvalidSessionID",
+			req:  &gen.FinishWebauthnLoginRequest{SessionId: "invalid", Credential: credential},
+			buildMocks: func(webauthnRepository *mockdb.MockWebauthnRepository) {
+				webauthnRepository.EXPECT().
+					ConsumeWebauthnSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.LoginUserResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+		{
+			name: "MissingCredential",
+			req:  &gen.FinishWebauthnLoginRequest{SessionId: uuid.NewString()},
+			buildMocks: func(webauthnRepository *mockdb.MockWebauthnRepository) {
+				webauthnRepository.EXPECT().
+					ConsumeWebauthnSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.LoginUserResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+		{
+			name: "SessionNotFound",
+			req:  &gen.FinishWebauthnLoginRequest{SessionId: uuid.NewString(), Credential: credential},
+			buildMocks: func(webauthnRepository *mockdb.MockWebauthnRepository) {
+				webauthnRepository.EXPECT().
+					ConsumeWebauthnSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrWebauthnSessionNotFound)
+			},
+			checkResponse: func(t *testing.T, res *gen.LoginUserResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			webauthnRepository := mockdb.NewMockWebauthnRepository(ctrl)
+
+			tc.buildMocks(webauthnRepository)
+
+			userApplication := application.NewUserApplication(nil, nil, nil, nil, nil, webauthnRepository, nil, nil, nil, nil, nil, nil, nil, &util.Config{})
+			server := NewAuthServer(userApplication, nil, nil, nil, nil, nil)
+
+			res, err := server.FinishWebauthnLogin(context.Background(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestCompleteExternalLoginAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	loginState := &domain.ExternalLoginState{
+		State:        util.RandomString(32),
+		Provider:     "fake",
+		CodeVerifier: util.RandomString(43),
+		Nonce:        util.RandomString(32),
+	}
+	identity := &sso.Identity{
+		Provider:      "fake",
+		Subject:       util.RandomString(12),
+		Email:         user.Email,
+		EmailVerified: true,
+	}
+
+	testCases := []struct {
+		name          string
+		req           *gen.CompleteExternalLoginRequest
+		buildMocks    func(userRepository *mockdb.MockUserRepository, externalLoginRepository *mockdb.MockExternalLoginRepository, identityProvider *mockdb.MockExternalIdentityProvider)
+		checkResponse func(t *testing.T, res *gen.LoginUserResponse, err error)
+	}{
+		{
+			name: "MissingCode",
+			req:  &gen.CompleteExternalLoginRequest{Provider: "fake", State: loginState.State},
+			buildMocks: func(userRepository *mockdb.MockUserRepository, externalLoginRepository *mockdb.MockExternalLoginRepository, identityProvider *mockdb.MockExternalIdentityProvider) {
+				externalLoginRepository.EXPECT().
+					ConsumeExternalLoginState(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.LoginUserResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+		{
+			name: "InvalidState",
+			req:  &gen.CompleteExternalLoginRequest{Provider: "fake", State: loginState.State, Code: "code"},
+			buildMocks: func(userRepository *mockdb.MockUserRepository, externalLoginRepository *mockdb.MockExternalLoginRepository, identityProvider *mockdb.MockExternalIdentityProvider) {
+				externalLoginRepository.EXPECT().
+					ConsumeExternalLoginState(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrExternalLoginStateNotFound)
+
+				identityProvider.EXPECT().
+					Exchange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.LoginUserResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name: "UnknownProvider",
+			req:  &gen.CompleteExternalLoginRequest{Provider: "fake", State: loginState.State, Code: "code"},
+			buildMocks: func(userRepository *mockdb.MockUserRepository, externalLoginRepository *mockdb.MockExternalLoginRepository, identityProvider *mockdb.MockExternalIdentityProvider) {
+				externalLoginRepository.EXPECT().
+					ConsumeExternalLoginState(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(loginState, nil)
+
+				identityProvider.EXPECT().
+					Exchange(gomock.Any(), gomock.Eq("fake"), gomock.Eq("code"), gomock.Eq(loginState.CodeVerifier), gomock.Eq(loginState.Nonce)).
+					Times(1).
+					Return(nil, sso.ErrUnknownProvider)
+			},
+			checkResponse: func(t *testing.T, res *gen.LoginUserResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.NotFound, err)
+			},
+		},
+		{
+			name: "InvalidIDToken",
+			req:  &gen.CompleteExternalLoginRequest{Provider: "fake", State: loginState.State, Code: "code"},
+			buildMocks: func(userRepository *mockdb.MockUserRepository, externalLoginRepository *mockdb.MockExternalLoginRepository, identityProvider *mockdb.MockExternalIdentityProvider) {
+				externalLoginRepository.EXPECT().
+					ConsumeExternalLoginState(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(loginState, nil)
+
+				identityProvider.EXPECT().
+					Exchange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sso.ErrInvalidIDToken)
+			},
+			checkResponse: func(t *testing.T, res *gen.LoginUserResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name: "AccountConflict",
+			req:  &gen.CompleteExternalLoginRequest{Provider: "fake", State: loginState.State, Code: "code"},
+			buildMocks: func(userRepository *mockdb.MockUserRepository, externalLoginRepository *mockdb.MockExternalLoginRepository, identityProvider *mockdb.MockExternalIdentityProvider) {
+				externalLoginRepository.EXPECT().
+					ConsumeExternalLoginState(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(loginState, nil)
+
+				identityProvider.EXPECT().
+					Exchange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(identity, nil)
+
+				externalLoginRepository.EXPECT().
+					GetExternalIdentity(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrExternalIdentityNotFound)
+
+				userRepository.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.LoginUserResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.AlreadyExists, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepository := mockdb.NewMockUserRepository(ctrl)
+			externalLoginRepository := mockdb.NewMockExternalLoginRepository(ctrl)
+			identityProvider := mockdb.NewMockExternalIdentityProvider(ctrl)
+
+			tc.buildMocks(userRepository, externalLoginRepository, identityProvider)
+
+			userApplication := application.NewUserApplication(userRepository, nil, nil, nil, nil, nil, externalLoginRepository, nil, nil, identityProvider, nil, nil, nil, &util.Config{})
+			server := NewAuthServer(userApplication, nil, nil, nil, nil, nil)
+
+			res, err := server.CompleteExternalLogin(context.Background(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestCreateOauthClientAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	admin, _ := randomUser(t)
+	admin.Role = domain.AdminRole
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	req := &gen.CreateOauthClientRequest{
+		Name:         "Example App",
+		RedirectUris: []string{"https://app.example.com/callback"},
+		Scopes:       []string{application.ScopeOpenID, application.ScopeEmail},
+	}
+
+	testCases := []struct {
+		name          string
+		req           *gen.CreateOauthClientRequest
+		caller        *domain.User
+		buildMocks    func(oauthRepository *mockdb.MockOauthRepository)
+		checkResponse func(t *testing.T, res *gen.CreateOauthClientResponse, err error)
+	}{
+		{
+			name:   "OK",
+			req:    req,
+			caller: admin,
+			buildMocks: func(oauthRepository *mockdb.MockOauthRepository) {
+				oauthRepository.EXPECT().
+					CreateOauthClient(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg infra.CreateOauthClient) (*domain.OauthClient, error) {
+						return &domain.OauthClient{ID: arg.ID, HashedSecret: arg.HashedSecret, Name: arg.Name, RedirectURIs: arg.RedirectURIs, Scopes: arg.Scopes, CreatedAt: time.Now()}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *gen.CreateOauthClientResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetClient().GetId())
+				require.NotEmpty(t, res.GetClientSecret())
+				require.False(t, res.GetClient().GetPublic())
+				require.Equal(t, req.RedirectUris, res.GetClient().GetRedirectUris())
+			},
+		},
+		{
+			name:   "NotAdmin",
+			req:    req,
+			caller: user,
+			buildMocks: func(oauthRepository *mockdb.MockOauthRepository) {
+				oauthRepository.EXPECT().
+					CreateOauthClient(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.CreateOauthClientResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.PermissionDenied, err)
+			},
+		},
+		{
+			name:   "InvalidScope",
+			req:    &gen.CreateOauthClientRequest{Name: req.Name, RedirectUris: req.RedirectUris, Scopes: []string{"admin"}},
+			caller: admin,
+			buildMocks: func(oauthRepository *mockdb.MockOauthRepository) {
+				oauthRepository.EXPECT().
+					CreateOauthClient(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.CreateOauthClientResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			oauthRepository := mockdb.NewMockOauthRepository(ctrl)
+
+			tc.buildMocks(oauthRepository)
+
+			oidcApplication := application.NewOidcApplication(nil, oauthRepository, nil, &util.Config{})
+			server := NewAuthServer(nil, nil, oidcApplication, nil, nil, tokenMaker)
+
+			ctx := newContextWithBearerToken(t, tokenMaker, tc.caller.Username, tc.caller.Role, uuid.New())
+			res, err := server.CreateOauthClient(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestCreateServiceAccountAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	admin, _ := randomUser(t)
+	admin.Role = domain.AdminRole
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	req := &gen.CreateServiceAccountRequest{
+		Name:   "batch_job",
+		Scopes: []string{"product:read"},
+	}
+
+	testCases := []struct {
+		name          string
+		req           *gen.CreateServiceAccountRequest
+		caller        *domain.User
+		buildMocks    func(serviceAccountRepository *mockdb.MockServiceAccountRepository)
+		checkResponse func(t *testing.T, res *gen.CreateServiceAccountResponse, err error)
+	}{
+		{
+			name:   "OK",
+			req:    req,
+			caller: admin,
+			buildMocks: func(serviceAccountRepository *mockdb.MockServiceAccountRepository) {
+				serviceAccountRepository.EXPECT().
+					CreateServiceAccountTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg infra.CreateServiceAccountTx) (*domain.ServiceAccount, error) {
+						require.Equal(t, admin.Username, arg.ServiceAccount.CreatedBy)
+						apiKey := &domain.ApiKey{ID: arg.ApiKey.ID, ServiceAccount: arg.ServiceAccount.Name, Prefix: arg.ApiKey.Prefix, Scopes: arg.ApiKey.Scopes, CreatedAt: time.Now()}
+						return &domain.ServiceAccount{Name: arg.ServiceAccount.Name, Role: arg.ServiceAccount.Role, CreatedBy: arg.ServiceAccount.CreatedBy, CreatedAt: time.Now(), ApiKeys: []*domain.ApiKey{apiKey}}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *gen.CreateServiceAccountResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetApiKey())
+				require.Equal(t, domain.ServiceRole, res.GetServiceAccount().GetRole())
+				require.Len(t, res.GetServiceAccount().GetApiKeys(), 1)
+				require.Nil(t, res.GetServiceAccount().GetApiKeys()[0].GetExpiresAt())
+			},
+		},
+		{
+			name:   "NotAdmin",
+			req:    req,
+			caller: user,
+			buildMocks: func(serviceAccountRepository *mockdb.MockServiceAccountRepository) {
+				serviceAccountRepository.EXPECT().
+					CreateServiceAccountTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.CreateServiceAccountResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.PermissionDenied, err)
+			},
+		},
+		{
+			name:   "AlreadyExists",
+			req:    req,
+			caller: admin,
+			buildMocks: func(serviceAccountRepository *mockdb.MockServiceAccountRepository) {
+				serviceAccountRepository.EXPECT().
+					CreateServiceAccountTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrServiceAccountAlreadyExist)
+			},
+			checkResponse: func(t *testing.T, res *gen.CreateServiceAccountResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.AlreadyExists, err)
+			},
+		},
+		{
+			name:   "MissingScopes",
+			req:    &gen.CreateServiceAccountRequest{Name: req.Name},
+			caller: admin,
+			buildMocks: func(serviceAccountRepository *mockdb.MockServiceAccountRepository) {
+				serviceAccountRepository.EXPECT().
+					CreateServiceAccountTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.CreateServiceAccountResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			serviceAccountRepository := mockdb.NewMockServiceAccountRepository(ctrl)
+
+			tc.buildMocks(serviceAccountRepository)
+
+			serviceAccountApplication := application.NewServiceAccountApplication(serviceAccountRepository)
+			server := NewAuthServer(nil, nil, nil, serviceAccountApplication, nil, tokenMaker)
+
+			ctx := newContextWithBearerToken(t, tokenMaker, tc.caller.Username, tc.caller.Role, uuid.New())
+			res, err := server.CreateServiceAccount(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestRevokeApiKeyAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = domain.AdminRole
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	id := uuid.New()
+
+	testCases := []struct {
+		name          string
+		req           *gen.RevokeApiKeyRequest
+		buildMocks    func(serviceAccountRepository *mockdb.MockServiceAccountRepository)
+		checkResponse func(t *testing.T, res *gen.RevokeApiKeyResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &gen.RevokeApiKeyRequest{Id: id.String()},
+			buildMocks: func(serviceAccountRepository *mockdb.MockServiceAccountRepository) {
+				revokedAt := time.Now()
+				serviceAccountRepository.EXPECT().
+					RevokeApiKey(gomock.Any(), gomock.Eq(id)).
+					Times(1).
+					Return(&domain.ApiKey{ID: id, RevokedAt: &revokedAt}, nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.RevokeApiKeyResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, id.String(), res.GetKey().GetId())
+				require.NotNil(t, res.GetKey().GetRevokedAt())
+			},
+		},
+		{
+			name: "NotFound",
+			req:  &gen.RevokeApiKeyRequest{Id: id.String()},
+			buildMocks: func(serviceAccountRepository *mockdb.MockServiceAccountRepository) {
+				serviceAccountRepository.EXPECT().
+					RevokeApiKey(gomock.Any(), gomock.Eq(id)).
+					Times(1).
+					Return(nil, domain.ErrApiKeyNotFound)
+			},
+			checkResponse: func(t *testing.T, res *gen.RevokeApiKeyResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.NotFound, err)
+			},
+		},
+		{
+			name: "InvalidID",
+			req:  &gen.RevokeApiKeyRequest{Id: "invalid"},
+			buildMocks: func(serviceAccountRepository *mockdb.MockServiceAccountRepository) {
+				serviceAccountRepository.EXPECT().
+					RevokeApiKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.RevokeApiKeyResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			serviceAccountRepository := mockdb.NewMockServiceAccountRepository(ctrl)
+
+			tc.buildMocks(serviceAccountRepository)
+
+			serviceAccountApplication := application.NewServiceAccountApplication(serviceAccountRepository)
+			server := NewAuthServer(nil, nil, nil, serviceAccountApplication, nil, tokenMaker)
+
+			ctx := newContextWithBearerToken(t, tokenMaker, admin.Username, admin.Role, uuid.New())
+			res, err := server.RevokeApiKey(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestDeleteRoleAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = domain.AdminRole
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		req           *gen.DeleteRoleRequest
+		role          string
+		buildMocks    func(roleRepository *mockdb.MockRoleRepository)
+		checkResponse func(t *testing.T, res *gen.DeleteRoleResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &gen.DeleteRoleRequest{Name: "catalog_editor"},
+			role: admin.Role,
+			buildMocks: func(roleRepository *mockdb.MockRoleRepository) {
+				roleRepository.EXPECT().
+					DeleteRole(gomock.Any(), gomock.Eq("catalog_editor")).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.DeleteRoleResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			name: "InUse",
+			req:  &gen.DeleteRoleRequest{Name: "catalog_editor"},
+			role: admin.Role,
+			buildMocks: func(roleRepository *mockdb.MockRoleRepository) {
+				roleRepository.EXPECT().
+					DeleteRole(gomock.Any(), gomock.Eq("catalog_editor")).
+					Times(1).
+					Return(domain.ErrRoleInUse)
+			},
+			checkResponse: func(t *testing.T, res *gen.DeleteRoleResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.FailedPrecondition, err)
+			},
+		},
+		{
+			name: "NotFound",
+			req:  &gen.DeleteRoleRequest{Name: "catalog_editor"},
+			role: admin.Role,
+			buildMocks: func(roleRepository *mockdb.MockRoleRepository) {
+				roleRepository.EXPECT().
+					DeleteRole(gomock.Any(), gomock.Eq("catalog_editor")).
+					Times(1).
+					Return(domain.ErrRoleNotFound)
+			},
+			checkResponse: func(t *testing.T, res *gen.DeleteRoleResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.NotFound, err)
+			},
+		},
+		{
+			name: "BuiltinRole",
+			req:  &gen.DeleteRoleRequest{Name: domain.UserRole},
+			role: admin.Role,
+			buildMocks: func(roleRepository *mockdb.MockRoleRepository) {
+				roleRepository.EXPECT().
+					DeleteRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.DeleteRoleResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+		{
+			name: "NotAdmin",
+			req:  &gen.DeleteRoleRequest{Name: "catalog_editor"},
+			role: domain.UserRole,
+			buildMocks: func(roleRepository *mockdb.MockRoleRepository) {
+				roleRepository.EXPECT().
+					DeleteRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.DeleteRoleResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.PermissionDenied, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			roleRepository := mockdb.NewMockRoleRepository(ctrl)
+
+			tc.buildMocks(roleRepository)
+
+			roleApplication := application.NewRoleApplication(nil, roleRepository)
+			server := NewAuthServer(nil, nil, nil, nil, roleApplication, tokenMaker)
+
+			ctx := newContextWithBearerToken(t, tokenMaker, admin.Username, tc.role, uuid.New())
+			res, err := server.DeleteRole(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestAssignUserRoleAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = domain.AdminRole
+	user, _ := randomUser(t)
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		req           *gen.AssignUserRoleRequest
+		buildMocks    func(userRepository *mockdb.MockUserRepository, roleRepository *mockdb.MockRoleRepository)
+		checkResponse func(t *testing.T, res *gen.AssignUserRoleResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &gen.AssignUserRoleRequest{Username: user.Username, Role: "catalog_editor"},
+			buildMocks: func(userRepository *mockdb.MockUserRepository, roleRepository *mockdb.MockRoleRepository) {
+				roleRepository.EXPECT().
+					AddUserRole(gomock.Any(), gomock.Eq(infra.UserRoleParams{Username: user.Username, Role: "catalog_editor"})).
+					Times(1).
+					Return(&domain.RoleAssignment{Username: user.Username, Role: "catalog_editor"}, nil)
+				userRepository.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				roleRepository.EXPECT().
+					ListUserRoles(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]string{"catalog_editor"}, nil)
+				roleRepository.EXPECT().
+					ListUserPermissions(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]string{"product:read", "product:write"}, nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.AssignUserRoleResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user.Username, res.GetUserRoles().GetUsername())
+				require.Equal(t, user.Role, res.GetUserRoles().GetRole())
+				require.Equal(t, []string{"catalog_editor"}, res.GetUserRoles().GetRoles())
+				require.Equal(t, []string{"product:read", "product:write"}, res.GetUserRoles().GetPermissions())
+			},
+		},
+		{
+			name: "AlreadyAssigned",
+			req:  &gen.AssignUserRoleRequest{Username: user.Username, Role: "catalog_editor"},
+			buildMocks: func(userRepository *mockdb.MockUserRepository, roleRepository *mockdb.MockRoleRepository) {
+				roleRepository.EXPECT().
+					AddUserRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrUserRoleAlreadyExist)
+			},
+			checkResponse: func(t *testing.T, res *gen.AssignUserRoleResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.AlreadyExists, err)
+			},
+		},
+		{
+			name: "RoleNotFound",
+			req:  &gen.AssignUserRoleRequest{Username: user.Username, Role: "catalog_editor"},
+			buildMocks: func(userRepository *mockdb.MockUserRepository, roleRepository *mockdb.MockRoleRepository) {
+				roleRepository.EXPECT().
+					AddUserRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrRoleNotFound)
+			},
+			checkResponse: func(t *testing.T, res *gen.AssignUserRoleResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.NotFound, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepository := mockdb.NewMockUserRepository(ctrl)
+			roleRepository := mockdb.NewMockRoleRepository(ctrl)
+
+			tc.buildMocks(userRepository, roleRepository)
+
+			roleApplication := application.NewRoleApplication(userRepository, roleRepository)
+			server := NewAuthServer(nil, nil, nil, nil, roleApplication, tokenMaker)
+
+			ctx := newContextWithBearerToken(t, tokenMaker, admin.Username, admin.Role, uuid.New())
+			res, err := server.AssignUserRole(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestVerifyApiKeyAPI(t *testing.T) {
+	key := "ak_" + util.RandomString(43)
+	sum := sha256.Sum256([]byte(key))
+	hashedKey := hex.EncodeToString(sum[:])
+
+	account := &domain.ServiceAccount{Name: "batch_job", Role: domain.ServiceRole}
+	apiKey := &domain.ApiKey{ID: uuid.New(), ServiceAccount: account.Name, HashedKey: hashedKey, Scopes: []string{"product:read"}}
+
+	testCases := []struct {
+		name          string
+		key           string
+		buildMocks    func(serviceAccountRepository *mockdb.MockServiceAccountRepository)
+		checkResponse func(t *testing.T, res *gen.VerifyApiKeyResponse, err error)
+	}{
+		{
+			name: "OK",
+			key:  key,
+			buildMocks: func(serviceAccountRepository *mockdb.MockServiceAccountRepository) {
+				serviceAccountRepository.EXPECT().
+					GetApiKeyByHash(gomock.Any(), gomock.Eq(hashedKey)).
+					Times(1).
+					Return(apiKey, nil)
+				serviceAccountRepository.EXPECT().
+					GetServiceAccount(gomock.Any(), gomock.Eq(account.Name)).
+					Times(1).
+					Return(account, nil)
+				serviceAccountRepository.EXPECT().
+					TouchApiKey(gomock.Any(), gomock.Eq(apiKey.ID)).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, res *gen.VerifyApiKeyResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, apiKey.ID.String(), res.GetKeyId())
+				require.Equal(t, domain.ServiceAccountUsernamePrefix+account.Name, res.GetUsername())
+				require.Equal(t, domain.ServiceRole, res.GetRole())
+				require.Equal(t, apiKey.Scopes, res.GetScopes())
+			},
+		},
+		{
+			name: "Unknown",
+			key:  "ak_" + util.RandomString(43),
+			buildMocks: func(serviceAccountRepository *mockdb.MockServiceAccountRepository) {
+				serviceAccountRepository.EXPECT().
+					GetApiKeyByHash(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrApiKeyNotFound)
+			},
+			checkResponse: func(t *testing.T, res *gen.VerifyApiKeyResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			serviceAccountRepository := mockdb.NewMockServiceAccountRepository(ctrl)
+
+			tc.buildMocks(serviceAccountRepository)
+
+			serviceAccountApplication := application.NewServiceAccountApplication(serviceAccountRepository)
+			server := NewAuthServer(nil, nil, nil, serviceAccountApplication, nil, nil)
+
+			res, err := server.VerifyApiKey(context.Background(), &gen.VerifyApiKeyRequest{ApiKey: tc.key})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+// staticPermissions grants the same permissions to every user.
+func TestDisableUserAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = domain.AdminRole
+	user, _ := randomUser(t)
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	config := util.Config{AccessTokenDuration: time.Minute}
+
+	testCases := []struct {
+		name          string
+		req           *gen.DisableUserRequest
+		role          string
+		buildMocks    func(userRepository *mockdb.MockUserRepository, sessionRepository *mockdb.MockSessionRepository)
+		checkResponse func(t *testing.T, res *gen.DisableUserResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &gen.DisableUserRequest{Username: user.Username},
+			role: admin.Role,
+			buildMocks: func(userRepository *mockdb.MockUserRepository, sessionRepository *mockdb.MockSessionRepository) {
+				disabledAt := time.Now()
+				disabledUser := *user
+				disabledUser.DisabledAt = &disabledAt
+
+				userRepository.EXPECT().
+					SetUserDisabled(gomock.Any(), gomock.Eq(user.Username), gomock.Eq(true)).
+					Times(1).
+					Return(&disabledUser, nil)
+
+				sessionRepository.EXPECT().
+					BlockUserSessions(gomock.Any(), gomock.Eq(infra.BlockUserSessions{Username: user.Username})).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *gen.DisableUserResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user.Username, res.GetUser().GetUsername())
+				require.NotNil(t, res.GetUser().GetDisabledAt())
+			},
+		},
+		{
+			name: "NotFound",
+			req:  &gen.DisableUserRequest{Username: user.Username},
+			role: admin.Role,
+			buildMocks: func(userRepository *mockdb.MockUserRepository, sessionRepository *mockdb.MockSessionRepository) {
+				userRepository.EXPECT().
+					SetUserDisabled(gomock.Any(), gomock.Eq(user.Username), gomock.Eq(true)).
+					Times(1).
+					Return(nil, domain.ErrUserNotFound)
+			},
+			checkResponse: func(t *testing.T, res *gen.DisableUserResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.NotFound, err)
+			},
+		},
+		{
+			name: "SelfManagement",
+			req:  &gen.DisableUserRequest{Username: admin.Username},
+			role: admin.Role,
+			buildMocks: func(userRepository *mockdb.MockUserRepository, sessionRepository *mockdb.MockSessionRepository) {
+				userRepository.EXPECT().
+					SetUserDisabled(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.DisableUserResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.FailedPrecondition, err)
+			},
+		},
+		{
+			name: "NotAdmin",
+			req:  &gen.DisableUserRequest{Username: user.Username},
+			role: domain.UserRole,
+			buildMocks: func(userRepository *mockdb.MockUserRepository, sessionRepository *mockdb.MockSessionRepository) {
+				userRepository.EXPECT().
+					SetUserDisabled(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *gen.DisableUserResponse, err error) {
+				require.Nil(t, res)
+				requireStatusCode(t, codes.PermissionDenied, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepository := mockdb.NewMockUserRepository(ctrl)
+			sessionRepository := mockdb.NewMockSessionRepository(ctrl)
+
+			tc.buildMocks(userRepository, sessionRepository)
+
+			userApplication := application.NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, denylist.NewMemoryDenylist(), &config)
+			server := NewAuthServer(userApplication, nil, nil, nil, nil, tokenMaker)
+
+			ctx := newContextWithBearerToken(t, tokenMaker, admin.Username, tc.role, uuid.New())
+			res, err := server.DisableUser(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestListUsersAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = domain.AdminRole
+	user, _ := randomUser(t)
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	userRepository := mockdb.NewMockUserRepository(ctrl)
+
+	verified := true
+	userRepository.EXPECT().
+		ListUsers(gomock.Any(), gomock.Eq(infra.ListUsers{IsEmailVerified: &verified, Limit: 5, Offset: 0})).
+		Times(1).
+		Return([]*domain.User{user}, nil)
+
+	userApplication := application.NewUserApplication(userRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	server := NewAuthServer(userApplication, nil, nil, nil, nil, tokenMaker)
+
+	ctx := newContextWithBearerToken(t, tokenMaker, admin.Username, admin.Role, uuid.New())
+	res, err := server.ListUsers(ctx, &gen.ListUsersRequest{IsEmailVerified: &verified, PageId: 1, PageSize: 5})
+	require.NoError(t, err)
+	require.Len(t, res.GetUsers(), 1)
+	require.Equal(t, user.Username, res.GetUsers()[0].GetUsername())
+
+	res, err = server.ListUsers(ctx, &gen.ListUsersRequest{PageId: 0, PageSize: 5})
+	require.Nil(t, res)
+	requireStatusCode(t, codes.InvalidArgument, err)
+}
+
+func TestDeleteAccountAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	userRepository := mockdb.NewMockUserRepository(ctrl)
+	sessionRepository := mockdb.NewMockSessionRepository(ctrl)
+	taskDistributor := mockdb.NewMockTaskDistributor(ctrl)
+
+	deletedAt := time.Now()
+	deletedUser := *user
+	deletedUser.DeletedAt = &deletedAt
+
+	userRepository.EXPECT().
+		SoftDeleteUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(&deletedUser, nil)
+
+	taskDistributor.EXPECT().
+		DistributeTaskPurgeAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(nil)
+
+	sessionRepository.EXPECT().
+		BlockUserSessions(gomock.Any(), gomock.Eq(infra.BlockUserSessions{Username: user.Username})).
+		Times(1).
+		Return(nil)
+
+	config := util.Config{AccessTokenDuration: time.Minute, AccountDeletionGracePeriod: time.Hour}
+	userApplication := application.NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, nil, nil, nil, taskDistributor, nil, denylist.NewMemoryDenylist(), &config)
+	server := NewAuthServer(userApplication, nil, nil, nil, nil, tokenMaker)
+
+	ctx := newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, uuid.New())
+	res, err := server.DeleteAccount(ctx, &gen.DeleteAccountRequest{})
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	res, err = server.DeleteAccount(context.Background(), &gen.DeleteAccountRequest{})
+	require.Nil(t, res)
+	requireStatusCode(t, codes.Unauthenticated, err)
+}
+
+func TestExportMyDataAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	userRepository := mockdb.NewMockUserRepository(ctrl)
+
+	userRepository.EXPECT().
+		ExportUserData(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(&domain.UserDataExport{
+			ExportedAt: time.Now(),
+			Profile: domain.ExportedProfile{
+				Username: user.Username,
+				Email:    user.Email,
+			},
+		}, nil)
+
+	userApplication := application.NewUserApplication(userRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	server := NewAuthServer(userApplication, nil, nil, nil, nil, tokenMaker)
+
+	ctx := newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, uuid.New())
+	res, err := server.ExportMyData(ctx, &gen.ExportMyDataRequest{})
+	require.NoError(t, err)
+
+	profile := res.GetArchive().GetFields()["profile"].GetStructValue().GetFields()
+	require.Equal(t, user.Username, profile["username"].GetStringValue())
+	require.Equal(t, user.Email, profile["email"].GetStringValue())
+}
+
+type staticPermissions []string
+
+func (p staticPermissions) ListUserPermissions(ctx context.Context, username string) ([]string, error) {
+	return p, nil
+}
+
+func TestListAuthEventsAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = domain.AdminRole
+	user, _ := randomUser(t)
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	authEventRepository := mockdb.NewMockAuthEventRepository(ctrl)
+
+	outcome := domain.AuthEventFailure
+	authEventRepository.EXPECT().
+		ListAuthEvents(gomock.Any(), gomock.Eq(infra.ListAuthEvents{Username: &user.Username, Outcome: &outcome, Limit: 6})).
+		Times(1).
+		Return([]*domain.AuthEvent{{
+			ID:        1,
+			EventType: domain.AuthEventLogin,
+			Username:  user.Username,
+			ClientIP:  "203.0.113.7",
+			Outcome:   outcome,
+			CreatedAt: time.Now(),
+		}}, nil)
+
+	userApplication := application.NewUserApplication(nil, nil, nil, nil, nil, nil, nil, nil, authEventRepository, nil, nil, nil, nil, nil)
+	server := NewAuthServer(userApplication, nil, nil, nil, nil, tokenMaker)
+
+	ctx := newContextWithBearerToken(t, tokenMaker, admin.Username, admin.Role, uuid.New())
+	res, err := server.ListAuthEvents(ctx, &gen.ListAuthEventsRequest{Username: &user.Username, Outcome: &outcome, PageSize: 5})
+	require.NoError(t, err)
+	require.Len(t, res.GetEvents(), 1)
+	require.Equal(t, user.Username, res.GetEvents()[0].GetUsername())
+	require.Equal(t, "203.0.113.7", res.GetEvents()[0].GetClientIp())
+	require.Empty(t, res.GetNextPageToken())
+
+	res, err = server.ListAuthEvents(ctx, &gen.ListAuthEventsRequest{PageToken: "not a token", PageSize: 5})
+	require.Nil(t, res)
+	requireStatusCode(t, codes.InvalidArgument, err)
+
+	ctx = newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, uuid.New())
+	res, err = server.ListAuthEvents(ctx, &gen.ListAuthEventsRequest{PageSize: 5})
+	require.Nil(t, res)
+	requireStatusCode(t, codes.PermissionDenied, err)
+}
+
+// discardAuthEvents stands in for the audit trail in the tests that do not
+// check it.
+type discardAuthEvents struct{}
+
+func (discardAuthEvents) CreateAuthEvent(ctx context.Context, arg infra.CreateAuthEvent) (*domain.AuthEvent, error) {
+	return &domain.AuthEvent{EventType: arg.EventType, Username: arg.Username, Outcome: arg.Outcome}, nil
+}
+
+func (discardAuthEvents) ListAuthEvents(ctx context.Context, arg infra.ListAuthEvents) ([]*domain.AuthEvent, error) {
+	return nil, nil
+}
+
 func randomUser(t *testing.T) (*domain.User, string) {
 	t.Helper()
 
@@ -497,8 +2733,10 @@ func randomUser(t *testing.T) (*domain.User, string) {
 func randomSession(t *testing.T, username string) *domain.Session {
 	t.Helper()
 
+	id := uuid.New()
 	session := domain.Session{
-		ID:           uuid.New(),
+		ID:           id,
+		FamilyID:     id,
 		Username:     username,
 		RefreshToken: "",
 		UserAgent:    "",
@@ -522,3 +2760,29 @@ func randomVerifyEmail(user *domain.User) *domain.VerifyEmail {
 		ExpiredAt:  time.Now().Add(time.Minute),
 	}
 }
+
+func newContextWithBearerToken(t *testing.T, tokenMaker *token.JwtToken, username string, role string, sessionID uuid.UUID) context.Context {
+	t.Helper()
+
+	accessToken, _, err := tokenMaker.CreateToken(username, role, time.Minute, token.WithSessionID(sessionID))
+	require.NoError(t, err)
+
+	md := metadata.MD{
+		authorizationHeader: []string{
+			fmt.Sprintf("%s %s", authorizationBearer, accessToken),
+		},
+	}
+
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func newContextWithForwardedIdentity(username string, role string) context.Context {
+	md := metadata.Pairs(
+		"x-auth-username", username,
+		"x-auth-role", role,
+		"x-auth-token-id", uuid.NewString(),
+		"x-auth-session-id", uuid.NewString(),
+	)
+
+	return metadata.NewIncomingContext(context.Background(), md)
+}
@@ -0,0 +1,79 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader = "authorization"
+	authorizationBearer = "bearer"
+)
+
+var (
+	errMissingMetadata      = errors.New("missing metadata")
+	errMissingAuthorization = errors.New("missing authorization header")
+	errInvalidAuthorization = errors.New("invalid authorization header format")
+	errPermissionDenied     = errors.New("cannot act on behalf of another user")
+)
+
+// authorizeUser returns the caller authenticated by the AuthInterceptor, or
+// verifies the bearer token itself when the handler is called without it.
+// Identities forwarded by the gateway are only accepted by the interceptor,
+// which verifies their signature.
+func (server *AuthServer) authorizeUser(ctx context.Context) (*token.Payload, error) {
+	if payload, ok := payloadFromContext(ctx); ok {
+		return payload, nil
+	}
+
+	return verifyBearerToken(ctx, server.tokenVerifier)
+}
+
+func verifyBearerToken(ctx context.Context, tokenVerifier TokenVerifier) (*token.Payload, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, errMissingMetadata
+	}
+
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		return nil, errMissingAuthorization
+	}
+
+	fields := strings.Fields(values[0])
+	if len(fields) != 2 || strings.ToLower(fields[0]) != authorizationBearer {
+		return nil, errInvalidAuthorization
+	}
+
+	payload, err := tokenVerifier.VerifyToken(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid access token: %w", err)
+	}
+
+	return payload, nil
+}
+
+func unauthenticatedError(err error) error {
+	return status.Errorf(codes.Unauthenticated, "unauthorized: %s", err)
+}
+
+// checkOwnership allows callers to act on their own account, and admins on any.
+func checkOwnership(payload *token.Payload, username string) error {
+	if payload.Username != username && payload.Role != domain.AdminRole {
+		return errPermissionDenied
+	}
+
+	return nil
+}
+
+func permissionDeniedError(err error) error {
+	return status.Errorf(codes.PermissionDenied, "permission denied: %s", err)
+}
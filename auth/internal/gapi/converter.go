@@ -1,9 +1,16 @@
 package gapi
 
 import (
+	"encoding/base64"
+	"time"
+
+	"github.com/google/uuid"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/proto/gen"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -34,10 +41,11 @@ func toCreateUserResponse(user *domain.User) *gen.CreateUserResponse {
 
 func toUpdateUserApp(req *gen.UpdateUserRequest) application.UpdateUser {
 	return application.UpdateUser{
-		Username: req.GetUsername(),
-		FullName: req.FullName,
-		Email:    req.Email,
-		Password: req.Password,
+		Username:        req.GetUsername(),
+		FullName:        req.FullName,
+		Email:           req.Email,
+		Password:        req.Password,
+		CurrentPassword: req.CurrentPassword,
 	}
 }
 
@@ -55,6 +63,15 @@ func toLoginUserApp(req *gen.LoginUserRequest) application.LoginUser {
 }
 
 func toLoginUserResponse(res *application.LoginUserResult) *gen.LoginUserResponse {
+	if res.MfaRequired {
+		return &gen.LoginUserResponse{
+			User:              toUserResponse(res.User),
+			MfaRequired:       true,
+			MfaToken:          res.MfaToken,
+			MfaTokenExpiresAt: timestamppb.New(res.MfaTokenExpiresAt),
+		}
+	}
+
 	return &gen.LoginUserResponse{
 		User:                  toUserResponse(res.User),
 		SessionId:             res.SessionId.String(),
@@ -65,6 +82,74 @@ func toLoginUserResponse(res *application.LoginUserResult) *gen.LoginUserRespons
 	}
 }
 
+func toRenewAccessTokenApp(req *gen.RenewAccessTokenRequest) application.RenewAccessToken {
+	return application.RenewAccessToken{
+		RefreshToken: req.GetRefreshToken(),
+	}
+}
+
+func toRenewAccessTokenResponse(res *application.RenewAccessTokenResult) *gen.RenewAccessTokenResponse {
+	return &gen.RenewAccessTokenResponse{
+		SessionId:             res.SessionId.String(),
+		AccessToken:           res.AccessToken,
+		RefreshToken:          res.RefreshToken,
+		AccessTokenExpiresAt:  timestamppb.New(res.AccessTokenExpiresAt),
+		RefreshTokenExpiresAt: timestamppb.New(res.RefreshTokenExpiresAt),
+	}
+}
+
+func toSessionResponse(session *domain.Session, currentSessionID uuid.UUID) *gen.Session {
+	return &gen.Session{
+		Id:        session.ID.String(),
+		UserAgent: session.UserAgent,
+		ClientIp:  session.ClientIp,
+		Current:   session.FamilyID == currentSessionID,
+		ExpiresAt: timestamppb.New(session.ExpiresAt),
+		CreatedAt: timestamppb.New(session.CreatedAt),
+	}
+}
+
+func toListSessionsResponse(sessions []*domain.Session, currentSessionID uuid.UUID) *gen.ListSessionsResponse {
+	res := &gen.ListSessionsResponse{
+		Sessions: make([]*gen.Session, 0, len(sessions)),
+	}
+
+	for _, session := range sessions {
+		res.Sessions = append(res.Sessions, toSessionResponse(session, currentSessionID))
+	}
+
+	return res
+}
+
+func toRevokeSessionApp(req *gen.RevokeSessionRequest, authPayload *token.Payload) (application.RevokeSession, error) {
+	sessionID, err := uuid.Parse(req.GetSessionId())
+	if err != nil {
+		return application.RevokeSession{}, err
+	}
+
+	return application.RevokeSession{
+		Username:         authPayload.Username,
+		SessionID:        sessionID,
+		CurrentSessionID: authPayload.SessionID,
+		AccessTokenID:    authPayload.ID,
+		AccessExpiredAt:  authPayload.ExpiredAt,
+	}, nil
+}
+
+func toRevokeNewDeviceSessionApp(req *gen.RevokeNewDeviceSessionRequest) application.RevokeNewDeviceSession {
+	return application.RevokeNewDeviceSession{
+		Token: req.GetToken(),
+	}
+}
+
+func toRevokeAllSessionsApp(req *gen.RevokeAllSessionsRequest, authPayload *token.Payload) application.RevokeAllSessions {
+	return application.RevokeAllSessions{
+		Username:         authPayload.Username,
+		CurrentSessionID: authPayload.SessionID,
+		KeepCurrent:      req.GetKeepCurrent(),
+	}
+}
+
 func toVerifyEmailApp(req *gen.VerifyEmailRequest) application.VerifyEmail {
 	return application.VerifyEmail{
 		EmailId:    req.GetEmailId(),
@@ -72,8 +157,451 @@ func toVerifyEmailApp(req *gen.VerifyEmailRequest) application.VerifyEmail {
 	}
 }
 
+func toResendVerifyEmailApp(req *gen.ResendVerifyEmailRequest) application.ResendVerifyEmail {
+	return application.ResendVerifyEmail{
+		Email: req.GetEmail(),
+	}
+}
+
+func toRequestPasswordResetApp(req *gen.RequestPasswordResetRequest) application.RequestPasswordReset {
+	return application.RequestPasswordReset{
+		Email: req.GetEmail(),
+	}
+}
+
+func toResetPasswordApp(req *gen.ResetPasswordRequest) application.ResetPassword {
+	return application.ResetPassword{
+		ResetID:    req.GetResetId(),
+		SecretCode: req.GetSecretCode(),
+		Password:   req.GetPassword(),
+	}
+}
+
 func toVerifyEmailResponse(res *application.VerifyEmailResult) *gen.VerifyEmailResponse {
 	return &gen.VerifyEmailResponse{
 		IsVerified: res.User.IsEmailVerified,
 	}
 }
+
+func toUnlockUserApp(req *gen.UnlockUserRequest, payload *token.Payload) application.UnlockUser {
+	return application.UnlockUser{
+		Username:   req.GetUsername(),
+		UnlockedBy: payload.Username,
+	}
+}
+
+func toUnlockUserResponse(lockout *domain.AccountLockout) *gen.UnlockUserResponse {
+	res := &gen.UnlockUserResponse{
+		Lockout: &gen.AccountLockout{
+			Username:    lockout.Username,
+			ClientIp:    lockout.ClientIP,
+			Failures:    lockout.Failures,
+			LockedAt:    timestamppb.New(lockout.LockedAt),
+			LockedUntil: timestamppb.New(lockout.LockedUntil),
+		},
+	}
+
+	if lockout.UnlockedAt != nil {
+		res.Lockout.UnlockedAt = timestamppb.New(*lockout.UnlockedAt)
+	}
+
+	if lockout.UnlockedBy != nil {
+		res.Lockout.UnlockedBy = *lockout.UnlockedBy
+	}
+
+	return res
+}
+
+func toVerifyLoginTotpApp(req *gen.VerifyLoginTotpRequest) application.VerifyLoginTotp {
+	return application.VerifyLoginTotp{
+		MfaToken: req.GetMfaToken(),
+		Code:     req.GetCode(),
+	}
+}
+
+func toEnrollTotpResponse(res *application.EnrollTotpResult) *gen.EnrollTotpResponse {
+	return &gen.EnrollTotpResponse{
+		Secret:          res.Secret,
+		ProvisioningUri: res.ProvisioningURI,
+	}
+}
+
+func toWebauthnOptions(res *application.WebauthnCeremony) (*structpb.Struct, error) {
+	options := &structpb.Struct{}
+	err := protojson.Unmarshal(res.Options, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// toWebauthnCredentialJSON returns the authenticator response as sent by the
+// browser. A missing credential is left empty for the validation to report.
+func toWebauthnCredentialJSON(credential *structpb.Struct) ([]byte, error) {
+	if credential == nil {
+		return nil, nil
+	}
+
+	return protojson.Marshal(credential)
+}
+
+func toFinishWebauthnRegistrationApp(req *gen.FinishWebauthnRegistrationRequest, authPayload *token.Payload) (application.FinishWebauthnRegistration, error) {
+	sessionID, err := uuid.Parse(req.GetSessionId())
+	if err != nil {
+		return application.FinishWebauthnRegistration{}, err
+	}
+
+	credential, err := toWebauthnCredentialJSON(req.GetCredential())
+	if err != nil {
+		return application.FinishWebauthnRegistration{}, err
+	}
+
+	return application.FinishWebauthnRegistration{
+		Username:   authPayload.Username,
+		SessionID:  sessionID,
+		Credential: credential,
+	}, nil
+}
+
+func toFinishWebauthnLoginApp(req *gen.FinishWebauthnLoginRequest) (application.FinishWebauthnLogin, error) {
+	sessionID, err := uuid.Parse(req.GetSessionId())
+	if err != nil {
+		return application.FinishWebauthnLogin{}, err
+	}
+
+	credential, err := toWebauthnCredentialJSON(req.GetCredential())
+	if err != nil {
+		return application.FinishWebauthnLogin{}, err
+	}
+
+	return application.FinishWebauthnLogin{
+		SessionID:  sessionID,
+		Credential: credential,
+	}, nil
+}
+
+func toWebauthnCredentialResponse(credential *domain.WebauthnCredential) *gen.WebauthnCredential {
+	return &gen.WebauthnCredential{
+		Id:              base64.RawURLEncoding.EncodeToString(credential.ID),
+		AttestationType: credential.AttestationType,
+		Transports:      credential.Transports,
+		BackupEligible:  credential.BackupEligible,
+		CreatedAt:       timestamppb.New(credential.CreatedAt),
+	}
+}
+
+func toBeginExternalLoginApp(req *gen.BeginExternalLoginRequest) application.BeginExternalLogin {
+	return application.BeginExternalLogin{
+		Provider: req.GetProvider(),
+	}
+}
+
+func toBeginExternalLoginResponse(res *application.BeginExternalLoginResult) *gen.BeginExternalLoginResponse {
+	return &gen.BeginExternalLoginResponse{
+		AuthorizationUrl: res.AuthorizationURL,
+		State:            res.State,
+	}
+}
+
+func toCompleteExternalLoginApp(req *gen.CompleteExternalLoginRequest) application.CompleteExternalLogin {
+	return application.CompleteExternalLogin{
+		Provider: req.GetProvider(),
+		State:    req.GetState(),
+		Code:     req.GetCode(),
+	}
+}
+
+func toCreateOauthClientApp(req *gen.CreateOauthClientRequest) application.CreateOauthClient {
+	return application.CreateOauthClient{
+		Name:         req.GetName(),
+		RedirectURIs: req.GetRedirectUris(),
+		Scopes:       req.GetScopes(),
+		Public:       req.GetPublic(),
+	}
+}
+
+func toCreateOauthClientResponse(res *application.CreateOauthClientResult) *gen.CreateOauthClientResponse {
+	return &gen.CreateOauthClientResponse{
+		Client: &gen.OauthClient{
+			Id:           res.Client.ID,
+			Name:         res.Client.Name,
+			RedirectUris: res.Client.RedirectURIs,
+			Scopes:       res.Client.Scopes,
+			Public:       res.Client.IsPublic(),
+			CreatedAt:    timestamppb.New(res.Client.CreatedAt),
+		},
+		ClientSecret: res.ClientSecret,
+	}
+}
+
+func toCreateServiceAccountApp(req *gen.CreateServiceAccountRequest, authPayload *token.Payload) application.CreateServiceAccount {
+	return application.CreateServiceAccount{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Role:        req.GetRole(),
+		Scopes:      req.GetScopes(),
+		ExpiresAt:   toOptionalTime(req.GetExpiresAt()),
+		CreatedBy:   authPayload.Username,
+	}
+}
+
+func toCreateServiceAccountResponse(res *application.CreateServiceAccountResult) *gen.CreateServiceAccountResponse {
+	return &gen.CreateServiceAccountResponse{
+		ServiceAccount: toServiceAccountResponse(res.ServiceAccount),
+		ApiKey:         res.Key,
+	}
+}
+
+func toListServiceAccountsResponse(accounts []*domain.ServiceAccount) *gen.ListServiceAccountsResponse {
+	res := &gen.ListServiceAccountsResponse{
+		ServiceAccounts: make([]*gen.ServiceAccount, 0, len(accounts)),
+	}
+
+	for _, account := range accounts {
+		res.ServiceAccounts = append(res.ServiceAccounts, toServiceAccountResponse(account))
+	}
+
+	return res
+}
+
+func toServiceAccountResponse(account *domain.ServiceAccount) *gen.ServiceAccount {
+	res := &gen.ServiceAccount{
+		Name:        account.Name,
+		Description: account.Description,
+		Role:        account.Role,
+		CreatedBy:   account.CreatedBy,
+		CreatedAt:   timestamppb.New(account.CreatedAt),
+		ApiKeys:     make([]*gen.ApiKey, 0, len(account.ApiKeys)),
+	}
+
+	for _, apiKey := range account.ApiKeys {
+		res.ApiKeys = append(res.ApiKeys, toApiKeyResponse(apiKey))
+	}
+
+	return res
+}
+
+func toApiKeyResponse(apiKey *domain.ApiKey) *gen.ApiKey {
+	res := &gen.ApiKey{
+		Id:             apiKey.ID.String(),
+		ServiceAccount: apiKey.ServiceAccount,
+		Prefix:         apiKey.Prefix,
+		Scopes:         apiKey.Scopes,
+		CreatedAt:      timestamppb.New(apiKey.CreatedAt),
+	}
+
+	if apiKey.ExpiresAt != nil {
+		res.ExpiresAt = timestamppb.New(*apiKey.ExpiresAt)
+	}
+
+	if apiKey.LastUsedAt != nil {
+		res.LastUsedAt = timestamppb.New(*apiKey.LastUsedAt)
+	}
+
+	if apiKey.RevokedAt != nil {
+		res.RevokedAt = timestamppb.New(*apiKey.RevokedAt)
+	}
+
+	return res
+}
+
+func toRotateApiKeyApp(req *gen.RotateApiKeyRequest) (application.RotateApiKey, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return application.RotateApiKey{}, err
+	}
+
+	return application.RotateApiKey{
+		ID:        id,
+		ExpiresAt: toOptionalTime(req.GetExpiresAt()),
+	}, nil
+}
+
+func toRotateApiKeyResponse(res *application.RotateApiKeyResult) *gen.RotateApiKeyResponse {
+	return &gen.RotateApiKeyResponse{
+		Key:    toApiKeyResponse(res.ApiKey),
+		ApiKey: res.Key,
+	}
+}
+
+func toRevokeApiKeyApp(req *gen.RevokeApiKeyRequest) (application.RevokeApiKey, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return application.RevokeApiKey{}, err
+	}
+
+	return application.RevokeApiKey{ID: id}, nil
+}
+
+func toVerifyApiKeyResponse(res *application.VerifyApiKeyResult) *gen.VerifyApiKeyResponse {
+	return &gen.VerifyApiKeyResponse{
+		KeyId:    res.ApiKey.ID.String(),
+		Username: res.ServiceAccount.Username(),
+		Role:     res.ServiceAccount.Role,
+		Scopes:   res.ApiKey.Scopes,
+	}
+}
+
+func toOptionalTime(timestamp *timestamppb.Timestamp) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+
+	t := timestamp.AsTime()
+	return &t
+}
+
+func toRoleResponse(role *domain.Role) *gen.Role {
+	return &gen.Role{
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.Permissions,
+		CreatedAt:   timestamppb.New(role.CreatedAt),
+	}
+}
+
+func toListRolesResponse(roles []*domain.Role) *gen.ListRolesResponse {
+	res := &gen.ListRolesResponse{
+		Roles: make([]*gen.Role, 0, len(roles)),
+	}
+
+	for _, role := range roles {
+		res.Roles = append(res.Roles, toRoleResponse(role))
+	}
+
+	return res
+}
+
+func toPermissionResponse(permission *domain.Permission) *gen.Permission {
+	return &gen.Permission{
+		Name:        permission.Name,
+		Description: permission.Description,
+		CreatedAt:   timestamppb.New(permission.CreatedAt),
+	}
+}
+
+func toListPermissionsResponse(permissions []*domain.Permission) *gen.ListPermissionsResponse {
+	res := &gen.ListPermissionsResponse{
+		Permissions: make([]*gen.Permission, 0, len(permissions)),
+	}
+
+	for _, permission := range permissions {
+		res.Permissions = append(res.Permissions, toPermissionResponse(permission))
+	}
+
+	return res
+}
+
+func toUserRolesResponse(res *application.UserRolesResult) *gen.UserRoles {
+	return &gen.UserRoles{
+		Username:    res.Username,
+		Role:        res.Role,
+		Roles:       res.Roles,
+		Permissions: res.Permissions,
+	}
+}
+
+func toAdminUserResponse(user *domain.User) *gen.AdminUser {
+	res := &gen.AdminUser{
+		Username:              user.Username,
+		FullName:              user.FullName,
+		Email:                 user.Email,
+		Role:                  user.Role,
+		IsEmailVerified:       user.IsEmailVerified,
+		IsTotpEnabled:         user.IsTotpEnabled,
+		PasswordResetRequired: user.PasswordResetRequired,
+		PasswordChangedAt:     timestamppb.New(user.PasswordChangedAt),
+		CreatedAt:             timestamppb.New(user.CreatedAt),
+	}
+
+	if user.DisabledAt != nil {
+		res.DisabledAt = timestamppb.New(*user.DisabledAt)
+	}
+
+	if user.DeletedAt != nil {
+		res.DeletedAt = timestamppb.New(*user.DeletedAt)
+	}
+
+	return res
+}
+
+func toListUsersApp(req *gen.ListUsersRequest) application.ListUsers {
+	return application.ListUsers{
+		Role:            req.Role,
+		IsEmailVerified: req.IsEmailVerified,
+		CreatedAfter:    toOptionalTime(req.GetCreatedAfter()),
+		CreatedBefore:   toOptionalTime(req.GetCreatedBefore()),
+		PageID:          req.GetPageId(),
+		PageSize:        req.GetPageSize(),
+	}
+}
+
+func toListUsersResponse(users []*domain.User) *gen.ListUsersResponse {
+	res := &gen.ListUsersResponse{
+		Users: make([]*gen.AdminUser, 0, len(users)),
+	}
+
+	for _, user := range users {
+		res.Users = append(res.Users, toAdminUserResponse(user))
+	}
+
+	return res
+}
+
+func toListAuthEventsApp(req *gen.ListAuthEventsRequest) application.ListAuthEvents {
+	return application.ListAuthEvents{
+		EventType:     req.EventType,
+		Username:      req.Username,
+		Outcome:       req.Outcome,
+		CreatedAfter:  toOptionalTime(req.GetCreatedAfter()),
+		CreatedBefore: toOptionalTime(req.GetCreatedBefore()),
+		PageToken:     req.GetPageToken(),
+		PageSize:      req.GetPageSize(),
+	}
+}
+
+func toListAuthEventsResponse(res *application.ListAuthEventsResult) *gen.ListAuthEventsResponse {
+	events := make([]*gen.AuthEvent, 0, len(res.Events))
+	for _, event := range res.Events {
+		events = append(events, &gen.AuthEvent{
+			Id:        event.ID,
+			EventType: event.EventType,
+			Username:  event.Username,
+			ClientIp:  event.ClientIP,
+			UserAgent: event.UserAgent,
+			Outcome:   event.Outcome,
+			CreatedAt: timestamppb.New(event.CreatedAt),
+		})
+	}
+
+	return &gen.ListAuthEventsResponse{
+		Events:        events,
+		NextPageToken: res.NextPageToken,
+	}
+}
+
+func toManageUserApp(username string, authPayload *token.Payload) application.ManageUser {
+	return application.ManageUser{
+		Username:  username,
+		ManagedBy: authPayload.Username,
+	}
+}
+
+func toSetUserRoleApp(req *gen.SetUserRoleRequest, authPayload *token.Payload) application.SetUserRole {
+	return application.SetUserRole{
+		Username:  req.GetUsername(),
+		Role:      req.GetRole(),
+		ManagedBy: authPayload.Username,
+	}
+}
+
+func toExportMyDataResponse(archive []byte) (*gen.ExportMyDataResponse, error) {
+	res := &gen.ExportMyDataResponse{Archive: &structpb.Struct{}}
+	err := protojson.Unmarshal(archive, res.Archive)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -1,10 +1,17 @@
 package gapi
 
 import (
+	"errors"
+
 	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/sso"
+	"github.com/rs/zerolog/log"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func toBadRequestFieldValidation(validations validation.Errors) []*errdetails.BadRequest_FieldViolation {
@@ -29,3 +36,247 @@ func invalidArgumentError(validations validation.Errors) error {
 
 	return statusDetails.Err()
 }
+
+// emailNotVerifiedError tells the client how to get a new verification code,
+// since the one sent at sign up may have expired.
+func emailNotVerifiedError() error {
+	preconditionFailure := &errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{
+			{
+				Type:        "EMAIL_NOT_VERIFIED",
+				Subject:     "email",
+				Description: "verify the email with the link sent at sign up, or call ResendVerifyEmail (POST /v1/user/verify-email/resend) to get a new one",
+			},
+		},
+	}
+
+	statusFailed := status.New(codes.FailedPrecondition, "email address is not verified, check your inbox or request a new verification email")
+
+	statusDetails, err := statusFailed.WithDetails(preconditionFailure)
+	if err != nil {
+		return statusFailed.Err()
+	}
+
+	return statusDetails.Err()
+}
+
+// loginThrottledError tells the client how long to wait before trying to log
+// in again.
+func loginThrottledError(err *application.LoginThrottledError) error {
+	code := codes.ResourceExhausted
+	if errors.Is(err, application.ErrAccountLocked) {
+		code = codes.PermissionDenied
+	}
+
+	statusThrottled := status.New(code, err.Error())
+
+	statusDetails, errDetails := statusThrottled.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(err.RetryAfter),
+	})
+	if errDetails != nil {
+		return statusThrottled.Err()
+	}
+
+	return statusDetails.Err()
+}
+
+// accountInactiveError maps the login errors of accounts an admin disabled or
+// asked to reset their password, it returns nil for other errors.
+func accountInactiveError(err error) error {
+	switch {
+	case errors.Is(err, application.ErrAccountDeleted):
+		return status.Errorf(codes.NotFound, "%s", err)
+	case errors.Is(err, application.ErrAccountDisabled):
+		return status.Errorf(codes.PermissionDenied, "%s", err)
+	case errors.Is(err, application.ErrPasswordResetRequired):
+		return status.Errorf(codes.FailedPrecondition, "%s, request a reset code with RequestPasswordReset (POST /v1/user/forgot-password)", err)
+	}
+
+	return nil
+}
+
+// totpError maps the errors of the TOTP management methods.
+func totpError(err error, msg string) error {
+	var valErr validation.Errors
+	if errors.As(err, &valErr) && valErr != nil {
+		return invalidArgumentError(valErr)
+	}
+
+	switch {
+	case errors.Is(err, application.ErrInvalidTotpCode):
+		return invalidArgumentError(validation.Errors{"code": application.ErrInvalidTotpCode})
+	case errors.Is(err, domain.ErrTotpAlreadyEnabled),
+		errors.Is(err, domain.ErrTotpNotFound),
+		errors.Is(err, application.ErrTotpNotEnabled):
+		return status.Errorf(codes.FailedPrecondition, "%s", err)
+	case errors.Is(err, domain.ErrUserNotFound):
+		return status.Errorf(codes.NotFound, "user not found")
+	}
+
+	log.Error().Err(err).Msg(msg)
+	return status.Errorf(codes.Internal, "%s: %s", msg, err)
+}
+
+func webauthnRegistrationError(err error, msg string) error {
+	var valErr validation.Errors
+	if errors.As(err, &valErr) && valErr != nil {
+		return invalidArgumentError(valErr)
+	}
+
+	switch {
+	case errors.Is(err, application.ErrInvalidWebauthnSession):
+		return invalidArgumentError(validation.Errors{"session_id": application.ErrInvalidWebauthnSession})
+	case errors.Is(err, application.ErrInvalidWebauthnCredential):
+		return invalidArgumentError(validation.Errors{"credential": application.ErrInvalidWebauthnCredential})
+	case errors.Is(err, domain.ErrWebauthnCredentialAlreadyExist):
+		return status.Errorf(codes.AlreadyExists, "%s", err)
+	case errors.Is(err, domain.ErrUserNotFound):
+		return status.Errorf(codes.NotFound, "user not found")
+	}
+
+	log.Error().Err(err).Msg(msg)
+	return status.Errorf(codes.Internal, "%s: %s", msg, err)
+}
+
+// externalLoginError maps the errors of the external login methods. Errors of
+// the identity provider only tell the client the login failed, the details
+// are logged.
+func externalLoginError(err error, msg string) error {
+	var valErr validation.Errors
+	if errors.As(err, &valErr) && valErr != nil {
+		return invalidArgumentError(valErr)
+	}
+
+	var throttledErr *application.LoginThrottledError
+	if errors.As(err, &throttledErr) {
+		return loginThrottledError(throttledErr)
+	}
+
+	switch {
+	case errors.Is(err, sso.ErrUnknownProvider):
+		return status.Errorf(codes.NotFound, "%s", sso.ErrUnknownProvider)
+	case errors.Is(err, application.ErrInvalidExternalLoginState):
+		return unauthenticatedError(application.ErrInvalidExternalLoginState)
+	case errors.Is(err, sso.ErrExchangeCode), errors.Is(err, sso.ErrInvalidIDToken):
+		log.Warn().Err(err).Msg(msg)
+		return status.Errorf(codes.Unauthenticated, "identity provider login failed")
+	case errors.Is(err, application.ErrExternalEmailRequired):
+		return status.Errorf(codes.FailedPrecondition, "%s", err)
+	case errors.Is(err, application.ErrExternalAccountConflict):
+		return status.Errorf(codes.AlreadyExists, "%s", err)
+	case errors.Is(err, application.ErrEmailNotVerified):
+		return emailNotVerifiedError()
+	}
+
+	if inactiveErr := accountInactiveError(err); inactiveErr != nil {
+		return inactiveErr
+	}
+
+	log.Error().Err(err).Msg(msg)
+	return status.Errorf(codes.Internal, "%s: %s", msg, err)
+}
+
+// serviceAccountError maps the errors of the service account methods.
+func serviceAccountError(err error, msg string) error {
+	var valErr validation.Errors
+	if errors.As(err, &valErr) && valErr != nil {
+		return invalidArgumentError(valErr)
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrServiceAccountAlreadyExist):
+		return status.Errorf(codes.AlreadyExists, "%s", err)
+	case errors.Is(err, domain.ErrRoleNotFound):
+		return status.Errorf(codes.NotFound, "%s", domain.ErrRoleNotFound)
+	case errors.Is(err, domain.ErrApiKeyNotFound):
+		return status.Errorf(codes.NotFound, "%s", domain.ErrApiKeyNotFound)
+	case errors.Is(err, application.ErrInvalidApiKey):
+		return unauthenticatedError(application.ErrInvalidApiKey)
+	}
+
+	log.Error().Err(err).Msg(msg)
+	return status.Errorf(codes.Internal, "%s: %s", msg, err)
+}
+
+// roleError maps the errors of the role and permission methods.
+func roleError(err error, msg string) error {
+	var valErr validation.Errors
+	if errors.As(err, &valErr) && valErr != nil {
+		return invalidArgumentError(valErr)
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrRoleAlreadyExist),
+		errors.Is(err, domain.ErrPermissionAlreadyExist),
+		errors.Is(err, domain.ErrUserRoleAlreadyExist):
+		return status.Errorf(codes.AlreadyExists, "%s", err)
+	case errors.Is(err, domain.ErrRoleNotFound),
+		errors.Is(err, domain.ErrPermissionNotFound),
+		errors.Is(err, domain.ErrUserRoleNotFound),
+		errors.Is(err, domain.ErrUserNotFound):
+		return status.Errorf(codes.NotFound, "%s", err)
+	case errors.Is(err, domain.ErrRoleInUse):
+		return status.Errorf(codes.FailedPrecondition, "%s", err)
+	}
+
+	log.Error().Err(err).Msg(msg)
+	return status.Errorf(codes.Internal, "%s: %s", msg, err)
+}
+
+// userAdminError maps the errors of the user management methods.
+func userAdminError(err error, msg string) error {
+	var valErr validation.Errors
+	if errors.As(err, &valErr) && valErr != nil {
+		return invalidArgumentError(valErr)
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound),
+		errors.Is(err, domain.ErrRoleNotFound):
+		return status.Errorf(codes.NotFound, "%s", err)
+	case errors.Is(err, domain.ErrUserInUse),
+		errors.Is(err, application.ErrSelfManagement):
+		return status.Errorf(codes.FailedPrecondition, "%s", err)
+	}
+
+	log.Error().Err(err).Msg(msg)
+	return status.Errorf(codes.Internal, "%s: %s", msg, err)
+}
+
+// accountError maps the errors of the methods the logged user calls on their
+// own account.
+func accountError(err error, msg string) error {
+	var valErr validation.Errors
+	if errors.As(err, &valErr) && valErr != nil {
+		return invalidArgumentError(valErr)
+	}
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return status.Errorf(codes.NotFound, "user not found")
+	}
+
+	log.Error().Err(err).Msg(msg)
+	return status.Errorf(codes.Internal, "%s: %s", msg, err)
+}
+
+func sessionError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrSessionNotFound):
+		return status.Errorf(codes.NotFound, "session not found")
+	case errors.Is(err, application.ErrEmailNotVerified):
+		return emailNotVerifiedError()
+	case errors.Is(err, application.ErrRefreshTokenReused):
+		return status.Errorf(codes.PermissionDenied, "refresh token reuse detected, all sessions of this login were revoked")
+	case errors.Is(err, application.ErrBlockedSession):
+		return status.Errorf(codes.PermissionDenied, "session is blocked")
+	case errors.Is(err, application.ErrInvalidRefreshToken),
+		errors.Is(err, application.ErrIncorrectSessionUser),
+		errors.Is(err, application.ErrIncorrectSessionClient),
+		errors.Is(err, application.ErrMismatchedSessionToken),
+		errors.Is(err, application.ErrExpiredSession),
+		errors.Is(err, application.ErrInvalidRevokeToken):
+		return status.Errorf(codes.Unauthenticated, "%s", err)
+	}
+
+	return nil
+}
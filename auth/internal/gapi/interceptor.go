@@ -0,0 +1,199 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/identity"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/proto/gen"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+var (
+	errUnknownMethod = errors.New("method has no access policy")
+	errRoleDenied    = errors.New("role is not allowed to call this method")
+)
+
+type Access int
+
+const (
+	AccessPublic Access = iota
+	AccessAuthenticated
+	AccessRestricted
+)
+
+// MethodPolicy describes who may call a gRPC method. Roles is only used by
+// restricted methods and is matched against the primary role of the caller,
+// the role of the token. The other roles of a user only grant their
+// permissions, so giving a user the admin role with AssignUserRole does not
+// open the admin methods, SetUserRole does.
+type MethodPolicy struct {
+	Access Access
+	Roles  []string
+}
+
+// MethodPolicies is the access policy of every method served by the auth gRPC
+// server. Methods missing from the table are rejected.
+var MethodPolicies = map[string]MethodPolicy{
+	gen.AuthService_CreateUser_FullMethodName:                 {Access: AccessPublic},
+	gen.AuthService_LoginUser_FullMethodName:                  {Access: AccessPublic},
+	gen.AuthService_RenewAccessToken_FullMethodName:           {Access: AccessPublic},
+	gen.AuthService_RevokeNewDeviceSession_FullMethodName:     {Access: AccessPublic},
+	gen.AuthService_VerifyEmail_FullMethodName:                {Access: AccessPublic},
+	gen.AuthService_ResendVerifyEmail_FullMethodName:          {Access: AccessPublic},
+	gen.AuthService_RequestPasswordReset_FullMethodName:       {Access: AccessPublic},
+	gen.AuthService_ResetPassword_FullMethodName:              {Access: AccessPublic},
+	gen.AuthService_UpdateUser_FullMethodName:                 {Access: AccessAuthenticated},
+	gen.AuthService_ListSessions_FullMethodName:               {Access: AccessAuthenticated},
+	gen.AuthService_RevokeSession_FullMethodName:              {Access: AccessAuthenticated},
+	gen.AuthService_RevokeAllSessions_FullMethodName:          {Access: AccessAuthenticated},
+	gen.AuthService_UnlockUser_FullMethodName:                 {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_VerifyLoginTotp_FullMethodName:            {Access: AccessPublic},
+	gen.AuthService_EnrollTotp_FullMethodName:                 {Access: AccessAuthenticated},
+	gen.AuthService_ConfirmTotp_FullMethodName:                {Access: AccessAuthenticated},
+	gen.AuthService_DisableTotp_FullMethodName:                {Access: AccessAuthenticated},
+	gen.AuthService_GenerateRecoveryCodes_FullMethodName:      {Access: AccessAuthenticated},
+	gen.AuthService_BeginWebauthnRegistration_FullMethodName:  {Access: AccessAuthenticated},
+	gen.AuthService_FinishWebauthnRegistration_FullMethodName: {Access: AccessAuthenticated},
+	gen.AuthService_BeginWebauthnLogin_FullMethodName:         {Access: AccessPublic},
+	gen.AuthService_FinishWebauthnLogin_FullMethodName:        {Access: AccessPublic},
+	gen.AuthService_BeginExternalLogin_FullMethodName:         {Access: AccessPublic},
+	gen.AuthService_CompleteExternalLogin_FullMethodName:      {Access: AccessPublic},
+	gen.AuthService_CreateOauthClient_FullMethodName:          {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_CreateServiceAccount_FullMethodName:       {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_ListServiceAccounts_FullMethodName:        {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_RotateApiKey_FullMethodName:               {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_RevokeApiKey_FullMethodName:               {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_CreateRole_FullMethodName:                 {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_ListRoles_FullMethodName:                  {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_DeleteRole_FullMethodName:                 {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_SetRolePermissions_FullMethodName:         {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_CreatePermission_FullMethodName:           {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_ListPermissions_FullMethodName:            {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_GetUserRoles_FullMethodName:               {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_AssignUserRole_FullMethodName:             {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_RemoveUserRole_FullMethodName:             {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_ListUsers_FullMethodName:                  {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_GetUser_FullMethodName:                    {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_SetUserRole_FullMethodName:                {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_DisableUser_FullMethodName:                {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_EnableUser_FullMethodName:                 {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_ForcePasswordReset_FullMethodName:         {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_DeleteUser_FullMethodName:                 {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_DeleteAccount_FullMethodName:              {Access: AccessAuthenticated},
+	gen.AuthService_ExportMyData_FullMethodName:               {Access: AccessAuthenticated},
+	gen.AuthService_ListAuthEvents_FullMethodName:             {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+	gen.AuthService_VerifyApiKey_FullMethodName:               {Access: AccessPublic},
+
+	grpc_reflection_v1.ServerReflection_ServerReflectionInfo_FullMethodName:      {Access: AccessPublic},
+	grpc_reflection_v1alpha.ServerReflection_ServerReflectionInfo_FullMethodName: {Access: AccessPublic},
+}
+
+// IdentityVerifier verifies the caller identity the gateway forwards as gRPC
+// metadata for callers without an access token, such as service accounts.
+type IdentityVerifier interface {
+	Verify(md metadata.MD) (*token.Payload, error)
+}
+
+type AuthInterceptor struct {
+	tokenVerifier    TokenVerifier
+	identityVerifier IdentityVerifier
+	policies         map[string]MethodPolicy
+}
+
+// NewAuthInterceptor only accepts forwarded identities when identityVerifier
+// is not nil.
+func NewAuthInterceptor(tokenVerifier TokenVerifier, identityVerifier IdentityVerifier, policies map[string]MethodPolicy) *AuthInterceptor {
+	return &AuthInterceptor{
+		tokenVerifier:    tokenVerifier,
+		identityVerifier: identityVerifier,
+		policies:         policies,
+	}
+}
+
+func (interceptor *AuthInterceptor) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := interceptor.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (interceptor *AuthInterceptor) Stream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := interceptor.authorize(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+}
+
+func (interceptor *AuthInterceptor) authorize(ctx context.Context, method string) (context.Context, error) {
+	policy, ok := interceptor.policies[method]
+	if !ok {
+		return nil, permissionDeniedError(errUnknownMethod)
+	}
+
+	if policy.Access == AccessPublic {
+		return ctx, nil
+	}
+
+	payload, err := interceptor.authenticate(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if policy.Access == AccessRestricted && !slices.Contains(policy.Roles, payload.Role) {
+		return nil, permissionDeniedError(errRoleDenied)
+	}
+
+	return contextWithPayload(ctx, payload), nil
+}
+
+// authenticate verifies the bearer token of the request, or the signed identity
+// forwarded by the gateway when the request carries no token.
+func (interceptor *AuthInterceptor) authenticate(ctx context.Context) (*token.Payload, error) {
+	payload, err := verifyBearerToken(ctx, interceptor.tokenVerifier)
+	if !errors.Is(err, errMissingAuthorization) || interceptor.identityVerifier == nil {
+		return payload, err
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	payload, err = interceptor.identityVerifier.Verify(md)
+	if err != nil {
+		if errors.Is(err, identity.ErrMissingIdentity) {
+			return nil, errMissingAuthorization
+		}
+		return nil, fmt.Errorf("invalid forwarded identity: %w", err)
+	}
+
+	return payload, nil
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *authenticatedStream) Context() context.Context {
+	return stream.ctx
+}
+
+type payloadContextKey struct{}
+
+func contextWithPayload(ctx context.Context, payload *token.Payload) context.Context {
+	return context.WithValue(ctx, payloadContextKey{}, payload)
+}
+
+func payloadFromContext(ctx context.Context) (*token.Payload, bool) {
+	payload, ok := ctx.Value(payloadContextKey{}).(*token.Payload)
+	return payload, ok
+}
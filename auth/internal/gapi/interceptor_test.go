@@ -0,0 +1,306 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/identity"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	publicMethod        = "/test.Service/Public"
+	authenticatedMethod = "/test.Service/Authenticated"
+	adminMethod         = "/test.Service/Admin"
+)
+
+var testPolicies = map[string]MethodPolicy{
+	publicMethod:        {Access: AccessPublic},
+	authenticatedMethod: {Access: AccessAuthenticated},
+	adminMethod:         {Access: AccessRestricted, Roles: []string{domain.AdminRole}},
+}
+
+func TestAuthInterceptorUnary(t *testing.T) {
+	username := util.RandomUsername()
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	signer, err := identity.NewSigner(util.RandomString(32), time.Minute)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		method        string
+		buildContext  func(t *testing.T) context.Context
+		checkResponse func(t *testing.T, payload *token.Payload, called bool, err error)
+	}{
+		{
+			name:   "PublicWithoutToken",
+			method: publicMethod,
+			buildContext: func(t *testing.T) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, called bool, err error) {
+				require.NoError(t, err)
+				require.True(t, called)
+				require.Nil(t, payload)
+			},
+		},
+		{
+			name:   "Authenticated",
+			method: authenticatedMethod,
+			buildContext: func(t *testing.T) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, username, domain.UserRole, uuid.New())
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, called bool, err error) {
+				require.NoError(t, err)
+				require.True(t, called)
+				require.NotNil(t, payload)
+				require.Equal(t, username, payload.Username)
+			},
+		},
+		{
+			name:   "AuthenticatedWithoutToken",
+			method: authenticatedMethod,
+			buildContext: func(t *testing.T) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, called bool, err error) {
+				require.False(t, called)
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name:   "UnsignedForwardedIdentity",
+			method: authenticatedMethod,
+			buildContext: func(t *testing.T) context.Context {
+				return newContextWithForwardedIdentity(username, domain.AdminRole)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, called bool, err error) {
+				require.False(t, called)
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name:   "SignedForwardedIdentity",
+			method: adminMethod,
+			buildContext: func(t *testing.T) context.Context {
+				return newContextWithSignedIdentity(t, signer, username, domain.AdminRole)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, called bool, err error) {
+				require.NoError(t, err)
+				require.True(t, called)
+				require.Equal(t, username, payload.Username)
+				require.Equal(t, domain.AdminRole, payload.Role)
+			},
+		},
+		{
+			name:   "TamperedForwardedIdentity",
+			method: adminMethod,
+			buildContext: func(t *testing.T) context.Context {
+				ctx := newContextWithSignedIdentity(t, signer, username, domain.UserRole)
+				md, _ := metadata.FromIncomingContext(ctx)
+				md.Set(identity.RoleMetadata, domain.AdminRole)
+				return metadata.NewIncomingContext(context.Background(), md)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, called bool, err error) {
+				require.False(t, called)
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name:   "SignedForwardedIdentityDeniedRole",
+			method: adminMethod,
+			buildContext: func(t *testing.T) context.Context {
+				return newContextWithSignedIdentity(t, signer, username, domain.UserRole)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, called bool, err error) {
+				require.False(t, called)
+				requireStatusCode(t, codes.PermissionDenied, err)
+			},
+		},
+		{
+			name:   "InvalidAuthorizationType",
+			method: authenticatedMethod,
+			buildContext: func(t *testing.T) context.Context {
+				accessToken, _, err := tokenMaker.CreateToken(username, domain.UserRole, time.Minute)
+				require.NoError(t, err)
+				md := metadata.MD{
+					authorizationHeader: []string{fmt.Sprintf("%s %s", "basic", accessToken)},
+				}
+				return metadata.NewIncomingContext(context.Background(), md)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, called bool, err error) {
+				require.False(t, called)
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name:   "ExpiredToken",
+			method: authenticatedMethod,
+			buildContext: func(t *testing.T) context.Context {
+				accessToken, _, err := tokenMaker.CreateToken(username, domain.UserRole, -time.Minute)
+				require.NoError(t, err)
+				md := metadata.MD{
+					authorizationHeader: []string{fmt.Sprintf("%s %s", authorizationBearer, accessToken)},
+				}
+				return metadata.NewIncomingContext(context.Background(), md)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, called bool, err error) {
+				require.False(t, called)
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name:   "RefreshTokenAsBearer",
+			method: authenticatedMethod,
+			buildContext: func(t *testing.T) context.Context {
+				refreshToken, _, err := tokenMaker.CreateToken(username, domain.UserRole, time.Minute, token.WithPurpose(token.PurposeRefresh))
+				require.NoError(t, err)
+				md := metadata.MD{
+					authorizationHeader: []string{fmt.Sprintf("%s %s", authorizationBearer, refreshToken)},
+				}
+				return metadata.NewIncomingContext(context.Background(), md)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, called bool, err error) {
+				require.False(t, called)
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name:   "RestrictedAllowedRole",
+			method: adminMethod,
+			buildContext: func(t *testing.T) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, username, domain.AdminRole, uuid.New())
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, called bool, err error) {
+				require.NoError(t, err)
+				require.True(t, called)
+				require.Equal(t, domain.AdminRole, payload.Role)
+			},
+		},
+		{
+			name:   "RestrictedDeniedRole",
+			method: adminMethod,
+			buildContext: func(t *testing.T) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, username, domain.UserRole, uuid.New())
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, called bool, err error) {
+				require.False(t, called)
+				requireStatusCode(t, codes.PermissionDenied, err)
+			},
+		},
+		{
+			name:   "UnknownMethod",
+			method: "/test.Service/Unknown",
+			buildContext: func(t *testing.T) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, username, domain.AdminRole, uuid.New())
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, called bool, err error) {
+				require.False(t, called)
+				requireStatusCode(t, codes.PermissionDenied, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			interceptor := NewAuthInterceptor(tokenMaker, signer, testPolicies)
+
+			var payload *token.Payload
+			called := false
+			handler := func(ctx context.Context, req any) (any, error) {
+				called = true
+				payload, _ = payloadFromContext(ctx)
+				return nil, nil
+			}
+
+			info := &grpc.UnaryServerInfo{FullMethod: tc.method}
+			_, err := interceptor.Unary(tc.buildContext(t), nil, info, handler)
+			tc.checkResponse(t, payload, called, err)
+		})
+	}
+}
+
+func TestAuthInterceptorStream(t *testing.T) {
+	username := util.RandomUsername()
+
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	interceptor := NewAuthInterceptor(tokenMaker, nil, testPolicies)
+	info := &grpc.StreamServerInfo{FullMethod: authenticatedMethod}
+
+	var payload *token.Payload
+	handler := func(srv any, stream grpc.ServerStream) error {
+		payload, _ = payloadFromContext(stream.Context())
+		return nil
+	}
+
+	ctx := newContextWithBearerToken(t, tokenMaker, username, domain.UserRole, uuid.New())
+	err = interceptor.Stream(nil, &testServerStream{ctx: ctx}, info, handler)
+	require.NoError(t, err)
+	require.NotNil(t, payload)
+	require.Equal(t, username, payload.Username)
+
+	err = interceptor.Stream(nil, &testServerStream{ctx: context.Background()}, info, handler)
+	requireStatusCode(t, codes.Unauthenticated, err)
+}
+
+func TestAuthInterceptorWithoutIdentityVerifier(t *testing.T) {
+	tokenMaker, err := token.NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	signer, err := identity.NewSigner(util.RandomString(32), time.Minute)
+	require.NoError(t, err)
+
+	interceptor := NewAuthInterceptor(tokenMaker, nil, testPolicies)
+	info := &grpc.UnaryServerInfo{FullMethod: authenticatedMethod}
+
+	called := false
+	handler := func(ctx context.Context, req any) (any, error) {
+		called = true
+		return nil, nil
+	}
+
+	ctx := newContextWithSignedIdentity(t, signer, util.RandomUsername(), domain.UserRole)
+	_, err = interceptor.Unary(ctx, nil, info, handler)
+	require.False(t, called)
+	requireStatusCode(t, codes.Unauthenticated, err)
+}
+
+func newContextWithSignedIdentity(t *testing.T, signer *identity.Signer, username string, role string) context.Context {
+	payload, err := token.NewPayload(username, role, time.Minute, token.WithSessionID(uuid.New()))
+	require.NoError(t, err)
+
+	return metadata.NewIncomingContext(context.Background(), signer.Sign(payload))
+}
+
+func requireStatusCode(t *testing.T, code codes.Code, err error) {
+	t.Helper()
+
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, code, st.Code())
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *testServerStream) Context() context.Context {
+	return stream.ctx
+}
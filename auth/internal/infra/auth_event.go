@@ -0,0 +1,98 @@
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+type AuthEventRepository struct {
+	connPool DBTX
+}
+
+func NewAuthEventRepository(connPool DBTX) *AuthEventRepository {
+	return &AuthEventRepository{connPool}
+}
+
+const createAuthEvent = `
+INSERT INTO auth_events (
+    event_type,
+    username,
+    client_ip,
+    user_agent,
+    outcome
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, event_type, username, client_ip, user_agent, outcome, created_at
+`
+
+type CreateAuthEvent struct {
+	EventType string `json:"event_type"`
+	Username  string `json:"username"`
+	ClientIP  string `json:"client_ip"`
+	UserAgent string `json:"user_agent"`
+	Outcome   string `json:"outcome"`
+}
+
+func (r *AuthEventRepository) CreateAuthEvent(ctx context.Context, arg CreateAuthEvent) (*domain.AuthEvent, error) {
+	rows, _ := r.connPool.Query(ctx, createAuthEvent, arg.EventType, arg.Username, arg.ClientIP, arg.UserAgent, arg.Outcome)
+
+	event, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.AuthEvent])
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create auth event")
+		return nil, err
+	}
+
+	return event, nil
+}
+
+const listAuthEvents = `
+SELECT id, event_type, username, client_ip, user_agent, outcome, created_at FROM auth_events
+WHERE ($1::varchar IS NULL OR event_type = $1)
+AND ($2::varchar IS NULL OR username = $2)
+AND ($3::varchar IS NULL OR outcome = $3)
+AND ($4::timestamptz IS NULL OR created_at >= $4)
+AND ($5::timestamptz IS NULL OR created_at < $5)
+AND ($6::bigint IS NULL OR id < $6)
+ORDER BY id DESC
+LIMIT $7
+`
+
+type ListAuthEvents struct {
+	EventType     *string    `json:"event_type"`
+	Username      *string    `json:"username"`
+	Outcome       *string    `json:"outcome"`
+	CreatedAfter  *time.Time `json:"created_after"`
+	CreatedBefore *time.Time `json:"created_before"`
+	// BeforeID is the cursor of the page, only the events older than it are
+	// returned.
+	BeforeID *int64 `json:"before_id"`
+	Limit    int32  `json:"limit"`
+}
+
+// ListAuthEvents returns the newest events first.
+func (r *AuthEventRepository) ListAuthEvents(ctx context.Context, arg ListAuthEvents) ([]*domain.AuthEvent, error) {
+	args := []any{
+		util.StringToText(arg.EventType),
+		util.StringToText(arg.Username),
+		util.StringToText(arg.Outcome),
+		util.TimeToTimestamptz(arg.CreatedAfter),
+		util.TimeToTimestamptz(arg.CreatedBefore),
+		util.Int64ToInt8(arg.BeforeID),
+		arg.Limit,
+	}
+
+	rows, _ := r.connPool.Query(ctx, listAuthEvents, args...)
+
+	events, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.AuthEvent])
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list auth events")
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -0,0 +1,92 @@
+package infra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomAuthEvent(t *testing.T, username string, eventType string, outcome string) *domain.AuthEvent {
+	arg := CreateAuthEvent{
+		EventType: eventType,
+		Username:  username,
+		ClientIP:  randomClientIP(),
+		UserAgent: util.RandomString(10),
+		Outcome:   outcome,
+	}
+
+	event, err := repositories.AuthEvent().CreateAuthEvent(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, event.ID)
+	require.Equal(t, arg.EventType, event.EventType)
+	require.Equal(t, arg.Username, event.Username)
+	require.Equal(t, arg.ClientIP, event.ClientIP)
+	require.Equal(t, arg.UserAgent, event.UserAgent)
+	require.Equal(t, arg.Outcome, event.Outcome)
+	require.WithinDuration(t, time.Now(), event.CreatedAt, time.Second)
+
+	return event
+}
+
+func TestCreateAuthEvent(t *testing.T) {
+	createRandomAuthEvent(t, util.RandomUsername(), domain.AuthEventLogin, domain.AuthEventFailure)
+}
+
+func TestAuthEventIsAppendOnly(t *testing.T) {
+	event := createRandomAuthEvent(t, util.RandomUsername(), domain.AuthEventLogin, domain.AuthEventFailure)
+
+	_, err := repositories.connPool.Exec(context.Background(), `UPDATE auth_events SET outcome = 'success' WHERE id = $1`, event.ID)
+	require.Error(t, err)
+
+	_, err = repositories.connPool.Exec(context.Background(), `DELETE FROM auth_events WHERE id = $1`, event.ID)
+	require.Error(t, err)
+
+	_, err = repositories.connPool.Exec(context.Background(), `TRUNCATE auth_events`)
+	require.Error(t, err)
+}
+
+func TestListAuthEvents(t *testing.T) {
+	username := util.RandomUsername()
+
+	var events []*domain.AuthEvent
+	for i := 0; i < 3; i++ {
+		events = append(events, createRandomAuthEvent(t, username, domain.AuthEventLogin, domain.AuthEventSuccess))
+	}
+	failure := createRandomAuthEvent(t, username, domain.AuthEventLogin, domain.AuthEventFailure)
+	createRandomAuthEvent(t, util.RandomUsername(), domain.AuthEventLogin, domain.AuthEventSuccess)
+
+	outcome := domain.AuthEventSuccess
+	page, err := repositories.AuthEvent().ListAuthEvents(context.Background(), ListAuthEvents{
+		Username: &username,
+		Outcome:  &outcome,
+		Limit:    2,
+	})
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.Equal(t, events[2].ID, page[0].ID)
+	require.Equal(t, events[1].ID, page[1].ID)
+
+	page, err = repositories.AuthEvent().ListAuthEvents(context.Background(), ListAuthEvents{
+		Username: &username,
+		Outcome:  &outcome,
+		BeforeID: &page[1].ID,
+		Limit:    2,
+	})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, events[0].ID, page[0].ID)
+
+	eventType := domain.AuthEventLogin
+	page, err = repositories.AuthEvent().ListAuthEvents(context.Background(), ListAuthEvents{
+		EventType: &eventType,
+		Username:  &username,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, page, 4)
+	require.Equal(t, failure.ID, page[0].ID)
+}
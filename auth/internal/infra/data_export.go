@@ -0,0 +1,153 @@
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+)
+
+const exportProfile = `
+SELECT username, full_name, email, role, is_email_verified, is_totp_enabled, password_changed_at, created_at, disabled_at, password_reset_required, deleted_at FROM users
+WHERE username = $1
+`
+
+const exportRoles = `
+SELECT role FROM user_roles
+WHERE username = $1
+ORDER BY role
+`
+
+const exportSessions = `
+SELECT id, client_id, user_agent, client_ip, is_blocked, expires_at, rotated_at, created_at FROM sessions
+WHERE username = $1
+ORDER BY created_at
+`
+
+const exportVerifyEmails = `
+SELECT email, is_used, created_at, expired_at FROM verify_emails
+WHERE username = $1
+ORDER BY created_at
+`
+
+const exportResetPasswords = `
+SELECT email, is_used, created_at, expired_at FROM reset_passwords
+WHERE username = $1
+ORDER BY created_at
+`
+
+const exportRecoveryCodes = `
+SELECT used_at, created_at FROM recovery_codes
+WHERE username = $1
+ORDER BY created_at
+`
+
+const exportWebauthnCredentials = `
+SELECT id, attestation_type, transports, backup_eligible, backup_state, last_used_at, created_at FROM webauthn_credentials
+WHERE username = $1
+ORDER BY created_at
+`
+
+const exportExternalIdentities = `
+SELECT provider, subject, email, created_at FROM external_identities
+WHERE username = $1
+ORDER BY created_at
+`
+
+const exportAccountLockouts = `
+SELECT client_ip, failures, locked_at, locked_until, unlocked_at, unlocked_by FROM account_lockouts
+WHERE username = $1
+ORDER BY locked_at
+`
+
+const exportServiceAccounts = `
+SELECT name, description, role, created_at FROM service_accounts
+WHERE created_by = $1
+ORDER BY created_at
+`
+
+const exportAuthEvents = `
+SELECT event_type, client_ip, user_agent, outcome, created_at FROM auth_events
+WHERE username = $1
+ORDER BY id
+`
+
+// ExportUserData reads everything stored about a user, leaving out the hashed
+// password, secrets and codes.
+func (u *UserRepository) ExportUserData(ctx context.Context, username string) (*domain.UserDataExport, error) {
+	export := &domain.UserDataExport{ExportedAt: time.Now()}
+
+	err := execTx(ctx, u.connPool, func(tx pgx.Tx) error {
+		rows, _ := tx.Query(ctx, exportProfile, username)
+
+		var err error
+		export.Profile, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[domain.ExportedProfile])
+		if err != nil {
+			return getUserError(err, domain.ErrReadUser, "failed to export profile")
+		}
+
+		rows, _ = tx.Query(ctx, exportRoles, username)
+		export.Roles, err = pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return getUserError(err, domain.ErrReadUser, "failed to export roles")
+		}
+
+		export.Sessions, err = collectExport[domain.ExportedSession](ctx, tx, exportSessions, username)
+		if err != nil {
+			return getUserError(err, domain.ErrReadUser, "failed to export sessions")
+		}
+
+		export.VerifyEmails, err = collectExport[domain.ExportedEmailCode](ctx, tx, exportVerifyEmails, username)
+		if err != nil {
+			return getUserError(err, domain.ErrReadUser, "failed to export verify emails")
+		}
+
+		export.ResetPasswords, err = collectExport[domain.ExportedEmailCode](ctx, tx, exportResetPasswords, username)
+		if err != nil {
+			return getUserError(err, domain.ErrReadUser, "failed to export reset passwords")
+		}
+
+		export.RecoveryCodes, err = collectExport[domain.ExportedRecoveryCode](ctx, tx, exportRecoveryCodes, username)
+		if err != nil {
+			return getUserError(err, domain.ErrReadUser, "failed to export recovery codes")
+		}
+
+		export.WebauthnCredentials, err = collectExport[domain.ExportedWebauthnCredential](ctx, tx, exportWebauthnCredentials, username)
+		if err != nil {
+			return getUserError(err, domain.ErrReadUser, "failed to export webauthn credentials")
+		}
+
+		export.ExternalIdentities, err = collectExport[domain.ExportedExternalIdentity](ctx, tx, exportExternalIdentities, username)
+		if err != nil {
+			return getUserError(err, domain.ErrReadUser, "failed to export external identities")
+		}
+
+		export.AccountLockouts, err = collectExport[domain.ExportedAccountLockout](ctx, tx, exportAccountLockouts, username)
+		if err != nil {
+			return getUserError(err, domain.ErrReadUser, "failed to export account lockouts")
+		}
+
+		export.ServiceAccounts, err = collectExport[domain.ExportedServiceAccount](ctx, tx, exportServiceAccounts, username)
+		if err != nil {
+			return getUserError(err, domain.ErrReadUser, "failed to export service accounts")
+		}
+
+		export.AuthEvents, err = collectExport[domain.ExportedAuthEvent](ctx, tx, exportAuthEvents, username)
+		if err != nil {
+			return getUserError(err, domain.ErrReadUser, "failed to export auth events")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+func collectExport[T any](ctx context.Context, tx pgx.Tx, query string, username string) ([]T, error) {
+	rows, _ := tx.Query(ctx, query, username)
+	return pgx.CollectRows(rows, pgx.RowToStructByName[T])
+}
@@ -0,0 +1,163 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+type ExternalLoginRepository struct {
+	connPool DBTX
+}
+
+func NewExternalLoginRepository(connPool DBTX) *ExternalLoginRepository {
+	return &ExternalLoginRepository{connPool}
+}
+
+func getExternalLoginError(err error, notFound error, msg string) error {
+	if errors.Is(err, ErrRecordNotFound) {
+		return notFound
+	}
+
+	if pgError := GetPgError(err); pgError != nil {
+		switch pgError.Code {
+		case UniqueViolation:
+			if pgError.ConstraintName == "external_identities_pkey" {
+				return domain.ErrExternalIdentityAlreadyExist
+			}
+		case ForeignKeyViolation:
+			if pgError.ConstraintName == "external_identities_username_fkey" {
+				return domain.ErrUserNotFound
+			}
+		}
+	}
+
+	log.Error().Err(err).Msg(msg)
+	return err
+}
+
+const deleteExpiredExternalLoginStates = `
+DELETE FROM external_login_states
+WHERE expires_at < now()
+`
+
+const createExternalLoginState = `
+INSERT INTO external_login_states (
+    state,
+    provider,
+    code_verifier,
+    nonce,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING state, provider, code_verifier, nonce, expires_at, created_at
+`
+
+type CreateExternalLoginState struct {
+	State        string    `json:"state"`
+	Provider     string    `json:"provider"`
+	CodeVerifier string    `json:"code_verifier"`
+	Nonce        string    `json:"nonce"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// CreateExternalLoginState stores the state of a login redirected to an
+// identity provider. Expired states that were never completed are deleted on
+// the way.
+func (r *ExternalLoginRepository) CreateExternalLoginState(ctx context.Context, arg CreateExternalLoginState) (*domain.ExternalLoginState, error) {
+	_, err := r.connPool.Exec(ctx, deleteExpiredExternalLoginStates)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to delete expired external login states")
+		return nil, err
+	}
+
+	rows, _ := r.connPool.Query(ctx, createExternalLoginState, arg.State, arg.Provider, arg.CodeVerifier, arg.Nonce, arg.ExpiresAt)
+
+	state, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.ExternalLoginState])
+	if err != nil {
+		return nil, getExternalLoginError(err, domain.ErrExternalLoginStateNotFound, "failed to create external login state")
+	}
+
+	return state, nil
+}
+
+const consumeExternalLoginState = `
+DELETE FROM external_login_states
+WHERE state = $1
+AND provider = $2
+AND expires_at > now()
+RETURNING state, provider, code_verifier, nonce, expires_at, created_at
+`
+
+type ConsumeExternalLoginState struct {
+	State    string `json:"state"`
+	Provider string `json:"provider"`
+}
+
+// ConsumeExternalLoginState deletes and returns a state, so each callback can
+// only be completed once. Unknown and expired states return
+// domain.ErrExternalLoginStateNotFound.
+func (r *ExternalLoginRepository) ConsumeExternalLoginState(ctx context.Context, arg ConsumeExternalLoginState) (*domain.ExternalLoginState, error) {
+	rows, _ := r.connPool.Query(ctx, consumeExternalLoginState, arg.State, arg.Provider)
+
+	state, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.ExternalLoginState])
+	if err != nil {
+		return nil, getExternalLoginError(err, domain.ErrExternalLoginStateNotFound, "failed to consume external login state")
+	}
+
+	return state, nil
+}
+
+const getExternalIdentity = `
+SELECT provider, subject, username, email, created_at FROM external_identities
+WHERE provider = $1 AND subject = $2 LIMIT 1
+`
+
+type GetExternalIdentity struct {
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+}
+
+func (r *ExternalLoginRepository) GetExternalIdentity(ctx context.Context, arg GetExternalIdentity) (*domain.ExternalIdentity, error) {
+	rows, _ := r.connPool.Query(ctx, getExternalIdentity, arg.Provider, arg.Subject)
+
+	identity, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.ExternalIdentity])
+	if err != nil {
+		return nil, getExternalLoginError(err, domain.ErrExternalIdentityNotFound, "failed to get external identity")
+	}
+
+	return identity, nil
+}
+
+const createExternalIdentity = `
+INSERT INTO external_identities (
+    provider,
+    subject,
+    username,
+    email
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING provider, subject, username, email, created_at
+`
+
+type CreateExternalIdentity struct {
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+func (r *ExternalLoginRepository) CreateExternalIdentity(ctx context.Context, arg CreateExternalIdentity) (*domain.ExternalIdentity, error) {
+	rows, _ := r.connPool.Query(ctx, createExternalIdentity, arg.Provider, arg.Subject, arg.Username, arg.Email)
+
+	identity, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.ExternalIdentity])
+	if err != nil {
+		return nil, getExternalLoginError(err, domain.ErrExternalIdentityNotFound, "failed to create external identity")
+	}
+
+	return identity, nil
+}
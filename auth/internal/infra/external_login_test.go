@@ -0,0 +1,112 @@
+package infra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsumeExternalLoginState(t *testing.T) {
+	arg := CreateExternalLoginState{
+		State:        util.RandomString(32),
+		Provider:     "fake",
+		CodeVerifier: util.RandomString(43),
+		Nonce:        util.RandomString(32),
+		ExpiresAt:    time.Now().Add(time.Minute),
+	}
+
+	state, err := repositories.ExternalLogin().CreateExternalLoginState(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.State, state.State)
+	require.Equal(t, arg.CodeVerifier, state.CodeVerifier)
+	require.Equal(t, arg.Nonce, state.Nonce)
+
+	_, err = repositories.ExternalLogin().ConsumeExternalLoginState(context.Background(), ConsumeExternalLoginState{State: arg.State, Provider: "other"})
+	require.ErrorIs(t, err, domain.ErrExternalLoginStateNotFound)
+
+	consumed, err := repositories.ExternalLogin().ConsumeExternalLoginState(context.Background(), ConsumeExternalLoginState{State: arg.State, Provider: arg.Provider})
+	require.NoError(t, err)
+	require.Equal(t, arg.CodeVerifier, consumed.CodeVerifier)
+
+	_, err = repositories.ExternalLogin().ConsumeExternalLoginState(context.Background(), ConsumeExternalLoginState{State: arg.State, Provider: arg.Provider})
+	require.ErrorIs(t, err, domain.ErrExternalLoginStateNotFound)
+}
+
+func TestConsumeExpiredExternalLoginState(t *testing.T) {
+	arg := CreateExternalLoginState{
+		State:        util.RandomString(32),
+		Provider:     "fake",
+		CodeVerifier: util.RandomString(43),
+		Nonce:        util.RandomString(32),
+		ExpiresAt:    time.Now().Add(-time.Minute),
+	}
+
+	_, err := repositories.ExternalLogin().CreateExternalLoginState(context.Background(), arg)
+	require.NoError(t, err)
+
+	_, err = repositories.ExternalLogin().ConsumeExternalLoginState(context.Background(), ConsumeExternalLoginState{State: arg.State, Provider: arg.Provider})
+	require.ErrorIs(t, err, domain.ErrExternalLoginStateNotFound)
+}
+
+func TestCreateExternalIdentity(t *testing.T) {
+	user := createRandomUser(t)
+
+	arg := CreateExternalIdentity{
+		Provider: "fake",
+		Subject:  util.RandomString(12),
+		Username: user.Username,
+		Email:    user.Email,
+	}
+
+	identity, err := repositories.ExternalLogin().CreateExternalIdentity(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Username, identity.Username)
+	require.NotZero(t, identity.CreatedAt)
+
+	_, err = repositories.ExternalLogin().CreateExternalIdentity(context.Background(), arg)
+	require.ErrorIs(t, err, domain.ErrExternalIdentityAlreadyExist)
+
+	found, err := repositories.ExternalLogin().GetExternalIdentity(context.Background(), GetExternalIdentity{Provider: arg.Provider, Subject: arg.Subject})
+	require.NoError(t, err)
+	require.Equal(t, user.Username, found.Username)
+
+	_, err = repositories.ExternalLogin().GetExternalIdentity(context.Background(), GetExternalIdentity{Provider: arg.Provider, Subject: util.RandomString(12)})
+	require.ErrorIs(t, err, domain.ErrExternalIdentityNotFound)
+
+	_, err = repositories.ExternalLogin().CreateExternalIdentity(context.Background(), CreateExternalIdentity{Provider: "fake", Subject: util.RandomString(12), Username: util.RandomUsername()})
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestCreateUserTxWithExternalIdentity(t *testing.T) {
+	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	arg := CreateUserTx{
+		CreateUser: CreateUser{
+			Username:        util.RandomUsername(),
+			HashedPassword:  hashedPassword,
+			FullName:        util.RandomUsername(),
+			Email:           util.RandomEmail(),
+			IsEmailVerified: true,
+		},
+		ExternalIdentity: &CreateExternalIdentity{
+			Provider: "fake",
+			Subject:  util.RandomString(12),
+		},
+		AfterCreate: func(user domain.User) error {
+			return nil
+		},
+	}
+
+	result, err := repositories.User().CreateUserTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, result.User.IsEmailVerified)
+
+	identity, err := repositories.ExternalLogin().GetExternalIdentity(context.Background(), GetExternalIdentity{Provider: "fake", Subject: arg.ExternalIdentity.Subject})
+	require.NoError(t, err)
+	require.Equal(t, arg.Username, identity.Username)
+}
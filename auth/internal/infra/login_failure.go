@@ -0,0 +1,274 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+type LoginFailureRepository struct {
+	connPool DBTX
+}
+
+func NewLoginFailureRepository(connPool DBTX) *LoginFailureRepository {
+	return &LoginFailureRepository{connPool}
+}
+
+func getLoginFailureError(err error, notFound error, msg string) error {
+	if errors.Is(err, ErrRecordNotFound) {
+		return notFound
+	}
+
+	log.Error().Err(err).Msg(msg)
+	return err
+}
+
+const getLoginFailure = `
+SELECT scope, subject, failures, last_failed_at, locked_until FROM login_failures
+WHERE scope = $1 AND subject = $2 LIMIT 1
+`
+
+func (r *LoginFailureRepository) GetLoginFailure(ctx context.Context, scope string, subject string) (*domain.LoginFailure, error) {
+	rows, _ := r.connPool.Query(ctx, getLoginFailure, scope, subject)
+
+	loginFailure, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.LoginFailure])
+	if err != nil {
+		return nil, getLoginFailureError(err, domain.ErrLoginFailureNotFound, "failed to get login failure")
+	}
+
+	return loginFailure, nil
+}
+
+// incrementLoginFailure starts counting again once a previous lock expired.
+const incrementLoginFailure = `
+INSERT INTO login_failures (
+    scope,
+    subject,
+    failures
+) VALUES (
+    $1, $2, 1
+)
+ON CONFLICT (scope, subject) DO UPDATE
+SET
+    failures = CASE WHEN login_failures.locked_until <= now() THEN 1 ELSE login_failures.failures + 1 END,
+    locked_until = CASE WHEN login_failures.locked_until <= now() THEN NULL ELSE login_failures.locked_until END,
+    last_failed_at = now()
+RETURNING scope, subject, failures, last_failed_at, locked_until
+`
+
+func (r *LoginFailureRepository) incrementLoginFailure(ctx context.Context, scope string, subject string) (*domain.LoginFailure, error) {
+	rows, _ := r.connPool.Query(ctx, incrementLoginFailure, scope, subject)
+
+	loginFailure, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.LoginFailure])
+	if err != nil {
+		return nil, getLoginFailureError(err, domain.ErrLoginFailureNotFound, "failed to increment login failure")
+	}
+
+	return loginFailure, nil
+}
+
+const lockLoginFailure = `
+UPDATE login_failures
+SET locked_until = $3
+WHERE scope = $1 AND subject = $2
+RETURNING scope, subject, failures, last_failed_at, locked_until
+`
+
+func (r *LoginFailureRepository) lockLoginFailure(ctx context.Context, scope string, subject string, lockedUntil time.Time) (*domain.LoginFailure, error) {
+	rows, _ := r.connPool.Query(ctx, lockLoginFailure, scope, subject, lockedUntil)
+
+	loginFailure, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.LoginFailure])
+	if err != nil {
+		return nil, getLoginFailureError(err, domain.ErrLoginFailureNotFound, "failed to lock login failure")
+	}
+
+	return loginFailure, nil
+}
+
+const createAccountLockout = `
+INSERT INTO account_lockouts (
+    username,
+    client_ip,
+    failures,
+    locked_until
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, username, client_ip, failures, locked_at, locked_until, unlocked_at, unlocked_by
+`
+
+type CreateAccountLockout struct {
+	Username    string    `json:"username"`
+	ClientIP    string    `json:"client_ip"`
+	Failures    int32     `json:"failures"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+func (r *LoginFailureRepository) CreateAccountLockout(ctx context.Context, arg CreateAccountLockout) (*domain.AccountLockout, error) {
+	args := []any{
+		arg.Username,
+		arg.ClientIP,
+		arg.Failures,
+		arg.LockedUntil,
+	}
+
+	rows, _ := r.connPool.Query(ctx, createAccountLockout, args...)
+
+	lockout, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.AccountLockout])
+	if err != nil {
+		return nil, getLoginFailureError(err, domain.ErrAccountLockoutNotFound, "failed to create account lockout")
+	}
+
+	return lockout, nil
+}
+
+type RecordLoginFailureTx struct {
+	Username        string        `json:"username"`
+	ClientIP        string        `json:"client_ip"`
+	MaxFailures     int32         `json:"max_failures"`
+	MaxIPFailures   int32         `json:"max_ip_failures"`
+	LockoutDuration time.Duration `json:"lockout_duration"`
+}
+
+type RecordLoginFailureTxResult struct {
+	Username *domain.LoginFailure   `json:"username"`
+	ClientIP *domain.LoginFailure   `json:"client_ip"`
+	Lockout  *domain.AccountLockout `json:"lockout"`
+}
+
+// RecordLoginFailureTx counts a login attempt for the username and, when known,
+// the client IP. Attempts are counted before the credentials are checked and
+// the counters are deleted after a successful login, so every remaining count
+// is a failure. The upsert locks each row until the transaction ends, so
+// concurrent attempts see each other's counts. An attempt beyond MaxFailures
+// locks the username for LockoutDuration and records an account lockout, one
+// beyond MaxIPFailures locks the client IP. A zero maximum never locks.
+func (r *LoginFailureRepository) RecordLoginFailureTx(ctx context.Context, arg RecordLoginFailureTx) (RecordLoginFailureTxResult, error) {
+	var result RecordLoginFailureTxResult
+
+	err := execTx(ctx, r.connPool, func(tx pgx.Tx) error {
+		var err error
+
+		loginFailureRepository := NewLoginFailureRepository(tx)
+		lockedUntil := time.Now().Add(arg.LockoutDuration)
+
+		result.Username, err = loginFailureRepository.incrementLoginFailure(ctx, domain.LoginFailureScopeUsername, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		if reachedMaxFailures(result.Username, arg.MaxFailures) {
+			result.Username, err = loginFailureRepository.lockLoginFailure(ctx, domain.LoginFailureScopeUsername, arg.Username, lockedUntil)
+			if err != nil {
+				return err
+			}
+
+			result.Lockout, err = loginFailureRepository.CreateAccountLockout(ctx, CreateAccountLockout{
+				Username:    arg.Username,
+				ClientIP:    arg.ClientIP,
+				Failures:    arg.MaxFailures,
+				LockedUntil: lockedUntil,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if arg.ClientIP == "" {
+			return nil
+		}
+
+		result.ClientIP, err = loginFailureRepository.incrementLoginFailure(ctx, domain.LoginFailureScopeIP, arg.ClientIP)
+		if err != nil {
+			return err
+		}
+
+		if reachedMaxFailures(result.ClientIP, arg.MaxIPFailures) {
+			result.ClientIP, err = loginFailureRepository.lockLoginFailure(ctx, domain.LoginFailureScopeIP, arg.ClientIP, lockedUntil)
+		}
+
+		return err
+	})
+
+	return result, err
+}
+
+func reachedMaxFailures(loginFailure *domain.LoginFailure, maxFailures int32) bool {
+	return maxFailures > 0 && loginFailure.LockedUntil == nil && loginFailure.Failures > maxFailures
+}
+
+const deleteLoginFailures = `
+DELETE FROM login_failures
+WHERE (scope = 'username' AND subject = $1)
+OR (scope = 'ip' AND subject = $2)
+`
+
+type DeleteLoginFailures struct {
+	Username string `json:"username"`
+	ClientIP string `json:"client_ip"`
+}
+
+// DeleteLoginFailures resets the counters of a username and client IP after a
+// successful login.
+func (r *LoginFailureRepository) DeleteLoginFailures(ctx context.Context, arg DeleteLoginFailures) error {
+	_, err := r.connPool.Exec(ctx, deleteLoginFailures, arg.Username, arg.ClientIP)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to delete login failures")
+	}
+
+	return err
+}
+
+const unlockAccountLockouts = `
+UPDATE account_lockouts
+SET
+    unlocked_at = now(),
+    unlocked_by = $2
+WHERE username = $1
+AND unlocked_at IS NULL
+AND locked_until > now()
+RETURNING id, username, client_ip, failures, locked_at, locked_until, unlocked_at, unlocked_by
+`
+
+type UnlockUserTx struct {
+	Username   string `json:"username"`
+	UnlockedBy string `json:"unlocked_by"`
+}
+
+type UnlockUserTxResult struct {
+	Lockout *domain.AccountLockout `json:"lockout"`
+}
+
+// UnlockUserTx lifts the active lockout of a username and resets its failed
+// login counter. A username without an active lockout returns
+// domain.ErrAccountLockoutNotFound.
+func (r *LoginFailureRepository) UnlockUserTx(ctx context.Context, arg UnlockUserTx) (UnlockUserTxResult, error) {
+	var result UnlockUserTxResult
+
+	err := execTx(ctx, r.connPool, func(tx pgx.Tx) error {
+		rows, _ := tx.Query(ctx, unlockAccountLockouts, arg.Username, arg.UnlockedBy)
+
+		lockouts, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.AccountLockout])
+		if err != nil {
+			return getLoginFailureError(err, domain.ErrAccountLockoutNotFound, "failed to unlock account lockouts")
+		}
+
+		if len(lockouts) == 0 {
+			return domain.ErrAccountLockoutNotFound
+		}
+
+		result.Lockout = lockouts[len(lockouts)-1]
+
+		_, err = tx.Exec(ctx, deleteLoginFailures, arg.Username, "")
+		if err != nil {
+			log.Error().Err(err).Msg("failed to delete login failures")
+		}
+
+		return err
+	})
+
+	return result, err
+}
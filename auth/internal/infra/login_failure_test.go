@@ -0,0 +1,142 @@
+package infra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomClientIP() string {
+	return "10.0." + util.RandomString(6) + ".1"
+}
+
+func TestRecordLoginFailureTx(t *testing.T) {
+	user := createRandomUser(t)
+	clientIP := randomClientIP()
+
+	arg := RecordLoginFailureTx{
+		Username:        user.Username,
+		ClientIP:        clientIP,
+		MaxFailures:     3,
+		MaxIPFailures:   10,
+		LockoutDuration: time.Minute,
+	}
+
+	for i := int32(1); i <= arg.MaxFailures; i++ {
+		result, err := repositories.LoginFailure().RecordLoginFailureTx(context.Background(), arg)
+		require.NoError(t, err)
+		require.Equal(t, i, result.Username.Failures)
+		require.Equal(t, i, result.ClientIP.Failures)
+		require.Nil(t, result.Username.LockedUntil)
+		require.Nil(t, result.Lockout)
+	}
+
+	result, err := repositories.LoginFailure().RecordLoginFailureTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.MaxFailures+1, result.Username.Failures)
+	require.NotNil(t, result.Username.LockedUntil)
+	require.WithinDuration(t, time.Now().Add(arg.LockoutDuration), *result.Username.LockedUntil, time.Second)
+	require.Nil(t, result.ClientIP.LockedUntil)
+
+	require.NotNil(t, result.Lockout)
+	require.Equal(t, user.Username, result.Lockout.Username)
+	require.Equal(t, clientIP, result.Lockout.ClientIP)
+	require.Equal(t, arg.MaxFailures, result.Lockout.Failures)
+	require.Nil(t, result.Lockout.UnlockedAt)
+
+	loginFailure, err := repositories.LoginFailure().GetLoginFailure(context.Background(), domain.LoginFailureScopeUsername, user.Username)
+	require.NoError(t, err)
+	require.Equal(t, result.Username.Failures, loginFailure.Failures)
+	require.NotNil(t, loginFailure.LockedUntil)
+}
+
+func TestRecordLoginFailureTxRestartsAfterLockExpires(t *testing.T) {
+	user := createRandomUser(t)
+
+	arg := RecordLoginFailureTx{
+		Username:        user.Username,
+		MaxFailures:     1,
+		LockoutDuration: -time.Second,
+	}
+
+	result, err := repositories.LoginFailure().RecordLoginFailureTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Nil(t, result.Username.LockedUntil)
+
+	result, err = repositories.LoginFailure().RecordLoginFailureTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotNil(t, result.Username.LockedUntil)
+	require.NotNil(t, result.Lockout)
+	require.Nil(t, result.ClientIP)
+
+	arg.MaxFailures = 0
+	result, err = repositories.LoginFailure().RecordLoginFailureTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), result.Username.Failures)
+	require.Nil(t, result.Username.LockedUntil)
+	require.Nil(t, result.Lockout)
+}
+
+func TestDeleteLoginFailures(t *testing.T) {
+	user := createRandomUser(t)
+	clientIP := randomClientIP()
+
+	_, err := repositories.LoginFailure().RecordLoginFailureTx(context.Background(), RecordLoginFailureTx{
+		Username: user.Username,
+		ClientIP: clientIP,
+	})
+	require.NoError(t, err)
+
+	err = repositories.LoginFailure().DeleteLoginFailures(context.Background(), DeleteLoginFailures{
+		Username: user.Username,
+		ClientIP: clientIP,
+	})
+	require.NoError(t, err)
+
+	loginFailure, err := repositories.LoginFailure().GetLoginFailure(context.Background(), domain.LoginFailureScopeUsername, user.Username)
+	require.ErrorIs(t, err, domain.ErrLoginFailureNotFound)
+	require.Nil(t, loginFailure)
+
+	loginFailure, err = repositories.LoginFailure().GetLoginFailure(context.Background(), domain.LoginFailureScopeIP, clientIP)
+	require.ErrorIs(t, err, domain.ErrLoginFailureNotFound)
+	require.Nil(t, loginFailure)
+}
+
+func TestUnlockUserTx(t *testing.T) {
+	user := createRandomUser(t)
+	admin := createRandomUser(t)
+
+	arg := RecordLoginFailureTx{
+		Username:        user.Username,
+		MaxFailures:     1,
+		LockoutDuration: time.Minute,
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := repositories.LoginFailure().RecordLoginFailureTx(context.Background(), arg)
+		require.NoError(t, err)
+	}
+
+	result, err := repositories.LoginFailure().UnlockUserTx(context.Background(), UnlockUserTx{
+		Username:   user.Username,
+		UnlockedBy: admin.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.Username, result.Lockout.Username)
+	require.NotNil(t, result.Lockout.UnlockedAt)
+	require.Equal(t, admin.Username, *result.Lockout.UnlockedBy)
+
+	loginFailure, err := repositories.LoginFailure().GetLoginFailure(context.Background(), domain.LoginFailureScopeUsername, user.Username)
+	require.ErrorIs(t, err, domain.ErrLoginFailureNotFound)
+	require.Nil(t, loginFailure)
+
+	_, err = repositories.LoginFailure().UnlockUserTx(context.Background(), UnlockUserTx{
+		Username:   user.Username,
+		UnlockedBy: admin.Username,
+	})
+	require.ErrorIs(t, err, domain.ErrAccountLockoutNotFound)
+}
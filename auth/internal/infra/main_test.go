@@ -11,10 +11,19 @@ import (
 )
 
 type testRepositories struct {
-	connPool    *pgxpool.Pool
-	user        *UserRepository
-	verifyEmail *VerifyEmailRepository
-	session     *SessionRepository
+	connPool       *pgxpool.Pool
+	user           *UserRepository
+	verifyEmail    *VerifyEmailRepository
+	session        *SessionRepository
+	resetPassword  *ResetPasswordRepository
+	loginFailure   *LoginFailureRepository
+	totp           *TotpRepository
+	webauthn       *WebauthnRepository
+	externalLogin  *ExternalLoginRepository
+	oauth          *OauthRepository
+	serviceAccount *ServiceAccountRepository
+	role           *RoleRepository
+	authEvent      *AuthEventRepository
 }
 
 func (r *testRepositories) User() *UserRepository {
@@ -41,6 +50,78 @@ func (r *testRepositories) Session() *SessionRepository {
 	return r.session
 }
 
+func (r *testRepositories) ResetPassword() *ResetPasswordRepository {
+	if r.resetPassword == nil {
+		r.resetPassword = NewResetPasswordRepository(r.connPool)
+	}
+
+	return r.resetPassword
+}
+
+func (r *testRepositories) LoginFailure() *LoginFailureRepository {
+	if r.loginFailure == nil {
+		r.loginFailure = NewLoginFailureRepository(r.connPool)
+	}
+
+	return r.loginFailure
+}
+
+func (r *testRepositories) Totp() *TotpRepository {
+	if r.totp == nil {
+		r.totp = NewTotpRepository(r.connPool)
+	}
+
+	return r.totp
+}
+
+func (r *testRepositories) Webauthn() *WebauthnRepository {
+	if r.webauthn == nil {
+		r.webauthn = NewWebauthnRepository(r.connPool)
+	}
+
+	return r.webauthn
+}
+
+func (r *testRepositories) ExternalLogin() *ExternalLoginRepository {
+	if r.externalLogin == nil {
+		r.externalLogin = NewExternalLoginRepository(r.connPool)
+	}
+
+	return r.externalLogin
+}
+
+func (r *testRepositories) Oauth() *OauthRepository {
+	if r.oauth == nil {
+		r.oauth = NewOauthRepository(r.connPool)
+	}
+
+	return r.oauth
+}
+
+func (r *testRepositories) ServiceAccount() *ServiceAccountRepository {
+	if r.serviceAccount == nil {
+		r.serviceAccount = NewServiceAccountRepository(r.connPool)
+	}
+
+	return r.serviceAccount
+}
+
+func (r *testRepositories) Role() *RoleRepository {
+	if r.role == nil {
+		r.role = NewRoleRepository(r.connPool)
+	}
+
+	return r.role
+}
+
+func (r *testRepositories) AuthEvent() *AuthEventRepository {
+	if r.authEvent == nil {
+		r.authEvent = NewAuthEventRepository(r.connPool)
+	}
+
+	return r.authEvent
+}
+
 var repositories testRepositories
 
 func TestMain(m *testing.M) {
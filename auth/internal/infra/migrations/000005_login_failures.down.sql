@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "account_lockouts" CASCADE;
+DROP TABLE IF EXISTS "login_failures" CASCADE;
@@ -0,0 +1,21 @@
+CREATE TABLE "login_failures" (
+  "scope" varchar NOT NULL,
+  "subject" varchar NOT NULL,
+  "failures" int NOT NULL DEFAULT 0,
+  "last_failed_at" timestamptz NOT NULL DEFAULT (now()),
+  "locked_until" timestamptz,
+  PRIMARY KEY ("scope", "subject")
+);
+
+CREATE TABLE "account_lockouts" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "client_ip" varchar NOT NULL,
+  "failures" int NOT NULL,
+  "locked_at" timestamptz NOT NULL DEFAULT (now()),
+  "locked_until" timestamptz NOT NULL,
+  "unlocked_at" timestamptz,
+  "unlocked_by" varchar
+);
+
+CREATE INDEX ON "account_lockouts" ("username");
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "recovery_codes" CASCADE;
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "totp_last_counter";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "is_totp_enabled";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "totp_secret";
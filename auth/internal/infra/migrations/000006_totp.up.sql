@@ -0,0 +1,15 @@
+ALTER TABLE "users" ADD COLUMN "totp_secret" bytea;
+ALTER TABLE "users" ADD COLUMN "is_totp_enabled" bool NOT NULL DEFAULT false;
+ALTER TABLE "users" ADD COLUMN "totp_last_counter" bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE "recovery_codes" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "hashed_code" varchar NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "recovery_codes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+CREATE UNIQUE INDEX ON "recovery_codes" ("username", "hashed_code");
@@ -0,0 +1,31 @@
+CREATE TABLE "webauthn_credentials" (
+  "id" bytea PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "public_key" bytea NOT NULL,
+  "attestation_type" varchar NOT NULL,
+  "transports" varchar[] NOT NULL DEFAULT '{}',
+  "aaguid" bytea NOT NULL,
+  "sign_count" bigint NOT NULL DEFAULT 0,
+  "clone_warning" bool NOT NULL DEFAULT false,
+  "backup_eligible" bool NOT NULL DEFAULT false,
+  "backup_state" bool NOT NULL DEFAULT false,
+  "last_used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "webauthn_credentials" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+CREATE INDEX ON "webauthn_credentials" ("username");
+
+CREATE TABLE "webauthn_sessions" (
+  "id" uuid PRIMARY KEY,
+  "username" varchar,
+  "ceremony" varchar NOT NULL,
+  "data" jsonb NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "webauthn_sessions" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+CREATE INDEX ON "webauthn_sessions" ("expires_at");
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "external_identities" CASCADE;
+DROP TABLE IF EXISTS "external_login_states" CASCADE;
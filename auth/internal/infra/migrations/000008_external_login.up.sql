@@ -0,0 +1,23 @@
+CREATE TABLE "external_login_states" (
+  "state" varchar PRIMARY KEY,
+  "provider" varchar NOT NULL,
+  "code_verifier" varchar NOT NULL,
+  "nonce" varchar NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "external_login_states" ("expires_at");
+
+CREATE TABLE "external_identities" (
+  "provider" varchar NOT NULL,
+  "subject" varchar NOT NULL,
+  "username" varchar NOT NULL,
+  "email" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("provider", "subject")
+);
+
+ALTER TABLE "external_identities" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+CREATE INDEX ON "external_identities" ("username");
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS "sessions" DROP COLUMN IF EXISTS "client_id";
+
+DROP TABLE IF EXISTS "oauth_authorization_codes" CASCADE;
+DROP TABLE IF EXISTS "oauth_clients" CASCADE;
@@ -0,0 +1,31 @@
+CREATE TABLE "oauth_clients" (
+  "id" varchar PRIMARY KEY,
+  "hashed_secret" varchar,
+  "name" varchar NOT NULL,
+  "redirect_uris" varchar[] NOT NULL,
+  "scopes" varchar[] NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "oauth_authorization_codes" (
+  "code_hash" varchar PRIMARY KEY,
+  "client_id" varchar NOT NULL,
+  "username" varchar NOT NULL,
+  "redirect_uri" varchar NOT NULL,
+  "scopes" varchar[] NOT NULL,
+  "nonce" varchar NOT NULL DEFAULT '',
+  "code_challenge" varchar NOT NULL DEFAULT '',
+  "auth_time" timestamptz NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "oauth_authorization_codes" ADD FOREIGN KEY ("client_id") REFERENCES "oauth_clients" ("id");
+
+ALTER TABLE "oauth_authorization_codes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+CREATE INDEX ON "oauth_authorization_codes" ("expires_at");
+
+ALTER TABLE "sessions" ADD COLUMN "client_id" varchar;
+
+ALTER TABLE "sessions" ADD FOREIGN KEY ("client_id") REFERENCES "oauth_clients" ("id");
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "api_keys" CASCADE;
+DROP TABLE IF EXISTS "service_accounts" CASCADE;
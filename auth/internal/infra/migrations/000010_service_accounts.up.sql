@@ -0,0 +1,25 @@
+CREATE TABLE "service_accounts" (
+  "name" varchar PRIMARY KEY,
+  "description" varchar NOT NULL DEFAULT '',
+  "role" varchar NOT NULL,
+  "created_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "api_keys" (
+  "id" uuid PRIMARY KEY,
+  "service_account" varchar NOT NULL,
+  "prefix" varchar NOT NULL,
+  "hashed_key" varchar UNIQUE NOT NULL,
+  "scopes" varchar[] NOT NULL,
+  "expires_at" timestamptz,
+  "last_used_at" timestamptz,
+  "revoked_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "service_accounts" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("username");
+
+ALTER TABLE "api_keys" ADD FOREIGN KEY ("service_account") REFERENCES "service_accounts" ("name") ON DELETE CASCADE;
+
+CREATE INDEX ON "api_keys" ("service_account");
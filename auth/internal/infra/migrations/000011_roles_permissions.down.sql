@@ -0,0 +1,7 @@
+ALTER TABLE IF EXISTS "service_accounts" DROP CONSTRAINT IF EXISTS "service_accounts_role_fkey";
+ALTER TABLE IF EXISTS "users" DROP CONSTRAINT IF EXISTS "users_role_fkey";
+
+DROP TABLE IF EXISTS "user_roles" CASCADE;
+DROP TABLE IF EXISTS "role_permissions" CASCADE;
+DROP TABLE IF EXISTS "permissions" CASCADE;
+DROP TABLE IF EXISTS "roles" CASCADE;
@@ -0,0 +1,53 @@
+CREATE TABLE "roles" (
+  "name" varchar PRIMARY KEY,
+  "description" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "permissions" (
+  "name" varchar PRIMARY KEY,
+  "description" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "role_permissions" (
+  "role" varchar NOT NULL,
+  "permission" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("role", "permission")
+);
+
+CREATE TABLE "user_roles" (
+  "username" varchar NOT NULL,
+  "role" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "role")
+);
+
+ALTER TABLE "role_permissions" ADD FOREIGN KEY ("role") REFERENCES "roles" ("name") ON DELETE CASCADE;
+
+ALTER TABLE "role_permissions" ADD FOREIGN KEY ("permission") REFERENCES "permissions" ("name") ON DELETE CASCADE;
+
+ALTER TABLE "user_roles" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+ALTER TABLE "user_roles" ADD FOREIGN KEY ("role") REFERENCES "roles" ("name") ON DELETE CASCADE;
+
+CREATE INDEX ON "user_roles" ("role");
+
+INSERT INTO "roles" ("name", "description") VALUES
+  ('user', 'Every registered user'),
+  ('admin', 'Operators of the platform'),
+  ('service', 'Default role of service accounts');
+
+INSERT INTO "permissions" ("name", "description") VALUES
+  ('product:read', 'Read the product catalog'),
+  ('product:write', 'Create and update products');
+
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+  ('user', 'product:read'),
+  ('admin', 'product:read'),
+  ('admin', 'product:write');
+
+ALTER TABLE "users" ADD FOREIGN KEY ("role") REFERENCES "roles" ("name");
+
+ALTER TABLE "service_accounts" ADD FOREIGN KEY ("role") REFERENCES "roles" ("name");
@@ -0,0 +1,28 @@
+ALTER TABLE "verify_emails" DROP CONSTRAINT "verify_emails_username_fkey";
+ALTER TABLE "verify_emails" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "sessions" DROP CONSTRAINT "sessions_username_fkey";
+ALTER TABLE "sessions" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "reset_passwords" DROP CONSTRAINT "reset_passwords_username_fkey";
+ALTER TABLE "reset_passwords" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "recovery_codes" DROP CONSTRAINT "recovery_codes_username_fkey";
+ALTER TABLE "recovery_codes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "webauthn_credentials" DROP CONSTRAINT "webauthn_credentials_username_fkey";
+ALTER TABLE "webauthn_credentials" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "webauthn_sessions" DROP CONSTRAINT "webauthn_sessions_username_fkey";
+ALTER TABLE "webauthn_sessions" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "external_identities" DROP CONSTRAINT "external_identities_username_fkey";
+ALTER TABLE "external_identities" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "oauth_authorization_codes" DROP CONSTRAINT "oauth_authorization_codes_username_fkey";
+ALTER TABLE "oauth_authorization_codes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+DROP INDEX IF EXISTS "users_created_at_idx";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "password_reset_required";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "disabled_at";
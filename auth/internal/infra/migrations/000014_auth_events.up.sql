@@ -0,0 +1,24 @@
+CREATE TABLE "auth_events" (
+  "id" bigserial PRIMARY KEY,
+  "event_type" varchar NOT NULL,
+  "username" varchar NOT NULL,
+  "client_ip" varchar NOT NULL,
+  "user_agent" varchar NOT NULL,
+  "outcome" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "auth_events" ("username", "id");
+
+CREATE INDEX ON "auth_events" ("event_type", "id");
+
+CREATE INDEX ON "auth_events" ("created_at");
+
+CREATE FUNCTION "auth_events_append_only"() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'auth_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "auth_events_no_update" BEFORE UPDATE ON "auth_events"
+FOR EACH ROW EXECUTE FUNCTION "auth_events_append_only"();
@@ -0,0 +1,12 @@
+CREATE TABLE "webauthn_user_handles" (
+  "handle" bytea PRIMARY KEY,
+  "username" varchar UNIQUE NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "webauthn_user_handles" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+-- Passkeys registered before this table keep the username as their user
+-- handle, the authenticators have it stored and would no longer be found.
+INSERT INTO "webauthn_user_handles" ("handle", "username")
+SELECT DISTINCT convert_to("username", 'UTF8'), "username" FROM "webauthn_credentials";
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS "auth_events_no_truncate" ON "auth_events";
+DROP TRIGGER IF EXISTS "auth_events_no_delete" ON "auth_events";
+DROP FUNCTION IF EXISTS "auth_events_purge_only";
@@ -0,0 +1,14 @@
+CREATE FUNCTION "auth_events_purge_only"() RETURNS trigger AS $$
+BEGIN
+  IF current_setting('auth.purged_user', true) IS DISTINCT FROM OLD.username THEN
+    RAISE EXCEPTION 'auth_events is append-only';
+  END IF;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "auth_events_no_delete" BEFORE DELETE ON "auth_events"
+FOR EACH ROW EXECUTE FUNCTION "auth_events_purge_only"();
+
+CREATE TRIGGER "auth_events_no_truncate" BEFORE TRUNCATE ON "auth_events"
+FOR EACH STATEMENT EXECUTE FUNCTION "auth_events_append_only"();
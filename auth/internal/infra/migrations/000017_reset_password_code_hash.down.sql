@@ -0,0 +1,4 @@
+-- Hashed codes cannot be recovered, pending resets have to be requested again.
+UPDATE "reset_passwords" SET "is_used" = true WHERE "is_used" = false;
+
+ALTER TABLE "reset_passwords" RENAME COLUMN "secret_code_hash" TO "secret_code";
@@ -0,0 +1,4 @@
+ALTER TABLE "reset_passwords" RENAME COLUMN "secret_code" TO "secret_code_hash";
+
+-- Pending codes were stored in plaintext, hash them so their links still work.
+UPDATE "reset_passwords" SET "secret_code_hash" = encode(sha256(convert_to("secret_code_hash", 'UTF8')), 'hex');
@@ -0,0 +1,182 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+type OauthRepository struct {
+	connPool DBTX
+}
+
+func NewOauthRepository(connPool DBTX) *OauthRepository {
+	return &OauthRepository{connPool}
+}
+
+func getOauthError(err error, notFound error, msg string) error {
+	if errors.Is(err, ErrRecordNotFound) {
+		return notFound
+	}
+
+	if pgError := GetPgError(err); pgError != nil {
+		switch pgError.Code {
+		case UniqueViolation:
+			if pgError.ConstraintName == "oauth_clients_pkey" {
+				return domain.ErrOauthClientAlreadyExist
+			}
+		case ForeignKeyViolation:
+			switch pgError.ConstraintName {
+			case "oauth_authorization_codes_client_id_fkey":
+				return domain.ErrOauthClientNotFound
+			case "oauth_authorization_codes_username_fkey":
+				return domain.ErrUserNotFound
+			}
+		}
+	}
+
+	log.Error().Err(err).Msg(msg)
+	return err
+}
+
+const createOauthClient = `
+INSERT INTO oauth_clients (
+    id,
+    hashed_secret,
+    name,
+    redirect_uris,
+    scopes
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, hashed_secret, name, redirect_uris, scopes, created_at
+`
+
+type CreateOauthClient struct {
+	ID           string   `json:"id"`
+	HashedSecret *string  `json:"hashed_secret"`
+	Name         string   `json:"name"`
+	RedirectURIs []string `json:"redirect_uris"`
+	Scopes       []string `json:"scopes"`
+}
+
+func (r *OauthRepository) CreateOauthClient(ctx context.Context, arg CreateOauthClient) (*domain.OauthClient, error) {
+	rows, _ := r.connPool.Query(ctx, createOauthClient, arg.ID, arg.HashedSecret, arg.Name, arg.RedirectURIs, arg.Scopes)
+
+	client, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.OauthClient])
+	if err != nil {
+		return nil, getOauthError(err, domain.ErrOauthClientNotFound, "failed to create oauth client")
+	}
+
+	return client, nil
+}
+
+const getOauthClient = `
+SELECT id, hashed_secret, name, redirect_uris, scopes, created_at FROM oauth_clients
+WHERE id = $1 LIMIT 1
+`
+
+func (r *OauthRepository) GetOauthClient(ctx context.Context, id string) (*domain.OauthClient, error) {
+	rows, _ := r.connPool.Query(ctx, getOauthClient, id)
+
+	client, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.OauthClient])
+	if err != nil {
+		return nil, getOauthError(err, domain.ErrOauthClientNotFound, "failed to get oauth client")
+	}
+
+	return client, nil
+}
+
+const deleteExpiredOauthAuthorizationCodes = `
+DELETE FROM oauth_authorization_codes
+WHERE expires_at < now()
+`
+
+const createOauthAuthorizationCode = `
+INSERT INTO oauth_authorization_codes (
+    code_hash,
+    client_id,
+    username,
+    redirect_uri,
+    scopes,
+    nonce,
+    code_challenge,
+    auth_time,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING code_hash, client_id, username, redirect_uri, scopes, nonce, code_challenge, auth_time, expires_at, created_at
+`
+
+type CreateOauthAuthorizationCode struct {
+	CodeHash      string    `json:"code_hash"`
+	ClientID      string    `json:"client_id"`
+	Username      string    `json:"username"`
+	RedirectURI   string    `json:"redirect_uri"`
+	Scopes        []string  `json:"scopes"`
+	Nonce         string    `json:"nonce"`
+	CodeChallenge string    `json:"code_challenge"`
+	AuthTime      time.Time `json:"auth_time"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// CreateOauthAuthorizationCode stores a code until the client exchanges it.
+// Expired codes that were never exchanged are deleted on the way.
+func (r *OauthRepository) CreateOauthAuthorizationCode(ctx context.Context, arg CreateOauthAuthorizationCode) (*domain.OauthAuthorizationCode, error) {
+	_, err := r.connPool.Exec(ctx, deleteExpiredOauthAuthorizationCodes)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to delete expired oauth authorization codes")
+		return nil, err
+	}
+
+	args := []any{
+		arg.CodeHash,
+		arg.ClientID,
+		arg.Username,
+		arg.RedirectURI,
+		arg.Scopes,
+		arg.Nonce,
+		arg.CodeChallenge,
+		arg.AuthTime,
+		arg.ExpiresAt,
+	}
+
+	rows, _ := r.connPool.Query(ctx, createOauthAuthorizationCode, args...)
+
+	code, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.OauthAuthorizationCode])
+	if err != nil {
+		return nil, getOauthError(err, domain.ErrOauthAuthorizationCodeNotFound, "failed to create oauth authorization code")
+	}
+
+	return code, nil
+}
+
+const consumeOauthAuthorizationCode = `
+DELETE FROM oauth_authorization_codes
+WHERE code_hash = $1
+AND client_id = $2
+AND expires_at > now()
+RETURNING code_hash, client_id, username, redirect_uri, scopes, nonce, code_challenge, auth_time, expires_at, created_at
+`
+
+type ConsumeOauthAuthorizationCode struct {
+	CodeHash string `json:"code_hash"`
+	ClientID string `json:"client_id"`
+}
+
+// ConsumeOauthAuthorizationCode deletes and returns a code, so it can only be
+// exchanged once. Unknown, expired and other clients' codes return
+// domain.ErrOauthAuthorizationCodeNotFound.
+func (r *OauthRepository) ConsumeOauthAuthorizationCode(ctx context.Context, arg ConsumeOauthAuthorizationCode) (*domain.OauthAuthorizationCode, error) {
+	rows, _ := r.connPool.Query(ctx, consumeOauthAuthorizationCode, arg.CodeHash, arg.ClientID)
+
+	code, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.OauthAuthorizationCode])
+	if err != nil {
+		return nil, getOauthError(err, domain.ErrOauthAuthorizationCodeNotFound, "failed to consume oauth authorization code")
+	}
+
+	return code, nil
+}
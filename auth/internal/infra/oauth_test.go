@@ -0,0 +1,113 @@
+package infra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomOauthClient(t *testing.T) *domain.OauthClient {
+	hashedSecret := util.RandomString(64)
+
+	arg := CreateOauthClient{
+		ID:           util.RandomString(24),
+		HashedSecret: &hashedSecret,
+		Name:         util.RandomString(10),
+		RedirectURIs: []string{"https://app.example.com/callback"},
+		Scopes:       []string{"openid", "profile", "email"},
+	}
+
+	client, err := repositories.Oauth().CreateOauthClient(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, client.ID)
+	require.Equal(t, arg.HashedSecret, client.HashedSecret)
+	require.Equal(t, arg.RedirectURIs, client.RedirectURIs)
+	require.Equal(t, arg.Scopes, client.Scopes)
+	require.False(t, client.IsPublic())
+	require.NotZero(t, client.CreatedAt)
+
+	return client
+}
+
+func TestCreateOauthClient(t *testing.T) {
+	client := createRandomOauthClient(t)
+
+	found, err := repositories.Oauth().GetOauthClient(context.Background(), client.ID)
+	require.NoError(t, err)
+	require.Equal(t, client.Name, found.Name)
+
+	_, err = repositories.Oauth().CreateOauthClient(context.Background(), CreateOauthClient{ID: client.ID, Name: client.Name})
+	require.ErrorIs(t, err, domain.ErrOauthClientAlreadyExist)
+
+	_, err = repositories.Oauth().GetOauthClient(context.Background(), util.RandomString(24))
+	require.ErrorIs(t, err, domain.ErrOauthClientNotFound)
+}
+
+func TestConsumeOauthAuthorizationCode(t *testing.T) {
+	user := createRandomUser(t)
+	client := createRandomOauthClient(t)
+
+	arg := CreateOauthAuthorizationCode{
+		CodeHash:      util.RandomString(43),
+		ClientID:      client.ID,
+		Username:      user.Username,
+		RedirectURI:   client.RedirectURIs[0],
+		Scopes:        []string{"openid"},
+		Nonce:         util.RandomString(16),
+		CodeChallenge: util.RandomString(43),
+		AuthTime:      time.Now(),
+		ExpiresAt:     time.Now().Add(time.Minute),
+	}
+
+	code, err := repositories.Oauth().CreateOauthAuthorizationCode(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Username, code.Username)
+	require.Equal(t, arg.Nonce, code.Nonce)
+
+	other := createRandomOauthClient(t)
+	_, err = repositories.Oauth().ConsumeOauthAuthorizationCode(context.Background(), ConsumeOauthAuthorizationCode{CodeHash: arg.CodeHash, ClientID: other.ID})
+	require.ErrorIs(t, err, domain.ErrOauthAuthorizationCodeNotFound)
+
+	consumed, err := repositories.Oauth().ConsumeOauthAuthorizationCode(context.Background(), ConsumeOauthAuthorizationCode{CodeHash: arg.CodeHash, ClientID: client.ID})
+	require.NoError(t, err)
+	require.Equal(t, arg.CodeChallenge, consumed.CodeChallenge)
+	require.Equal(t, arg.Scopes, consumed.Scopes)
+
+	_, err = repositories.Oauth().ConsumeOauthAuthorizationCode(context.Background(), ConsumeOauthAuthorizationCode{CodeHash: arg.CodeHash, ClientID: client.ID})
+	require.ErrorIs(t, err, domain.ErrOauthAuthorizationCodeNotFound)
+}
+
+func TestConsumeExpiredOauthAuthorizationCode(t *testing.T) {
+	user := createRandomUser(t)
+	client := createRandomOauthClient(t)
+
+	arg := CreateOauthAuthorizationCode{
+		CodeHash:    util.RandomString(43),
+		ClientID:    client.ID,
+		Username:    user.Username,
+		RedirectURI: client.RedirectURIs[0],
+		Scopes:      []string{"openid"},
+		AuthTime:    time.Now(),
+		ExpiresAt:   time.Now().Add(-time.Minute),
+	}
+
+	_, err := repositories.Oauth().CreateOauthAuthorizationCode(context.Background(), arg)
+	require.NoError(t, err)
+
+	_, err = repositories.Oauth().ConsumeOauthAuthorizationCode(context.Background(), ConsumeOauthAuthorizationCode{CodeHash: arg.CodeHash, ClientID: client.ID})
+	require.ErrorIs(t, err, domain.ErrOauthAuthorizationCodeNotFound)
+
+	_, err = repositories.Oauth().CreateOauthAuthorizationCode(context.Background(), CreateOauthAuthorizationCode{
+		CodeHash:  util.RandomString(43),
+		ClientID:  util.RandomString(24),
+		Username:  user.Username,
+		Scopes:    []string{"openid"},
+		AuthTime:  time.Now(),
+		ExpiresAt: time.Now().Add(time.Minute),
+	})
+	require.ErrorIs(t, err, domain.ErrOauthClientNotFound)
+}
@@ -0,0 +1,146 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+type ResetPasswordRepository struct {
+	connPool DBTX
+}
+
+func NewResetPasswordRepository(connPool DBTX) *ResetPasswordRepository {
+	return &ResetPasswordRepository{connPool}
+}
+
+func getResetPasswordError(err error, msg string) error {
+	if errors.Is(err, ErrRecordNotFound) {
+		return domain.ErrResetPasswordNotFound
+	}
+
+	if pgError := GetPgError(err); pgError != nil {
+		if pgError.Code == ForeignKeyViolation {
+			switch pgError.ConstraintName {
+			case "reset_passwords_username_fkey":
+				return domain.ErrUserNotFound
+			}
+		}
+	}
+
+	log.Error().Err(err).Msg(msg)
+	return err
+}
+
+const createResetPassword = `
+INSERT INTO "reset_passwords" (
+    username,
+    email,
+    secret_code_hash
+) VALUES (
+    $1, $2, $3
+) RETURNING id, username, email, secret_code_hash, is_used, created_at, expired_at
+`
+
+type CreateResetPassword struct {
+	Username       string `json:"username"`
+	Email          string `json:"email"`
+	SecretCodeHash string `json:"secret_code_hash"`
+}
+
+func (r *ResetPasswordRepository) CreateResetPassword(ctx context.Context, arg CreateResetPassword) (*domain.ResetPassword, error) {
+	args := []any{
+		arg.Username,
+		arg.Email,
+		arg.SecretCodeHash,
+	}
+
+	rows, _ := r.connPool.Query(ctx, createResetPassword, args...)
+
+	resetPassword, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.ResetPassword])
+	if err != nil {
+		return nil, getResetPasswordError(err, "failed to create reset password")
+	}
+
+	return resetPassword, nil
+}
+
+const updateResetPassword = `
+UPDATE reset_passwords
+SET is_used = true
+WHERE id = $1
+AND secret_code_hash = $2
+AND is_used = FALSE
+AND expired_at > now()
+RETURNING id, username, email, secret_code_hash, is_used, created_at, expired_at
+`
+
+type UpdateResetPassword struct {
+	ID             int64  `json:"id"`
+	SecretCodeHash string `json:"secret_code_hash"`
+}
+
+// UpdateResetPassword marks a pending code as used. Codes that are unknown,
+// used or expired return domain.ErrResetPasswordNotFound.
+func (r *ResetPasswordRepository) UpdateResetPassword(ctx context.Context, arg UpdateResetPassword) (*domain.ResetPassword, error) {
+	args := []any{
+		arg.ID,
+		arg.SecretCodeHash,
+	}
+
+	rows, _ := r.connPool.Query(ctx, updateResetPassword, args...)
+
+	resetPassword, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.ResetPassword])
+	if err != nil {
+		return nil, getResetPasswordError(err, "failed to update reset password")
+	}
+
+	return resetPassword, nil
+}
+
+type ResetPasswordTx struct {
+	ResetPasswordID int64  `json:"reset_password_id"`
+	SecretCodeHash  string `json:"secret_code_hash"`
+	HashedPassword  string `json:"hashed_password"`
+}
+
+type ResetPasswordTxResult struct {
+	User          *domain.User          `json:"user"`
+	ResetPassword *domain.ResetPassword `json:"reset_password"`
+}
+
+// ResetPasswordTx consumes the reset code and sets the new password in the
+// same transaction, so a code can never be used twice.
+func (r *ResetPasswordRepository) ResetPasswordTx(ctx context.Context, arg ResetPasswordTx) (ResetPasswordTxResult, error) {
+	var result ResetPasswordTxResult
+
+	err := execTx(ctx, r.connPool, func(tx pgx.Tx) error {
+		var err error
+
+		resetPasswordRepository := NewResetPasswordRepository(tx)
+		result.ResetPassword, err = resetPasswordRepository.UpdateResetPassword(ctx, UpdateResetPassword{
+			ID:             arg.ResetPasswordID,
+			SecretCodeHash: arg.SecretCodeHash,
+		})
+		if err != nil {
+			return err
+		}
+
+		passwordChangedAt := time.Now()
+
+		userRepository := NewUserRepository(tx)
+		result.User, err = userRepository.UpdateUser(ctx, UpdateUser{
+			Username:          result.ResetPassword.Username,
+			HashedPassword:    &arg.HashedPassword,
+			PasswordChangedAt: &passwordChangedAt,
+		})
+
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,92 @@
+package infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomResetPassword(t *testing.T) domain.ResetPassword {
+	t.Helper()
+
+	user := createRandomUser(t)
+
+	arg := CreateResetPassword{
+		Username:       user.Username,
+		Email:          user.Email,
+		SecretCodeHash: util.HashSecretCode(util.RandomString(32)),
+	}
+
+	resetPassword, err := repositories.ResetPassword().CreateResetPassword(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resetPassword)
+
+	require.Equal(t, arg.Username, resetPassword.Username)
+	require.Equal(t, arg.Email, resetPassword.Email)
+	require.Equal(t, arg.SecretCodeHash, resetPassword.SecretCodeHash)
+
+	require.False(t, resetPassword.IsUsed)
+	require.NotZero(t, resetPassword.CreatedAt)
+	require.NotZero(t, resetPassword.ExpiredAt)
+
+	return *resetPassword
+}
+
+func TestCreateResetPassword(t *testing.T) {
+	createRandomResetPassword(t)
+}
+
+func TestCreateResetPasswordUsernameInvalid(t *testing.T) {
+	arg := CreateResetPassword{
+		Username:       "username invalid",
+		Email:          "email invalid",
+		SecretCodeHash: util.HashSecretCode(util.RandomString(32)),
+	}
+
+	resetPassword, err := repositories.ResetPassword().CreateResetPassword(context.Background(), arg)
+
+	require.Error(t, err)
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+	require.Nil(t, resetPassword)
+}
+
+func TestResetPasswordTx(t *testing.T) {
+	resetPassword := createRandomResetPassword(t)
+
+	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	arg := ResetPasswordTx{
+		ResetPasswordID: resetPassword.ID,
+		SecretCodeHash:  resetPassword.SecretCodeHash,
+		HashedPassword:  hashedPassword,
+	}
+
+	result, err := repositories.ResetPassword().ResetPasswordTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.True(t, result.ResetPassword.IsUsed)
+	require.Equal(t, resetPassword.Username, result.User.Username)
+	require.Equal(t, hashedPassword, result.User.HashedPassword)
+	require.False(t, result.User.PasswordChangedAt.IsZero())
+
+	// a used code cannot reset the password again
+	_, err = repositories.ResetPassword().ResetPasswordTx(context.Background(), arg)
+	require.ErrorIs(t, err, domain.ErrResetPasswordNotFound)
+}
+
+func TestResetPasswordTxWrongSecretCode(t *testing.T) {
+	resetPassword := createRandomResetPassword(t)
+
+	result, err := repositories.ResetPassword().ResetPasswordTx(context.Background(), ResetPasswordTx{
+		ResetPasswordID: resetPassword.ID,
+		SecretCodeHash:  util.HashSecretCode(util.RandomString(32)),
+		HashedPassword:  util.RandomString(60),
+	})
+	require.ErrorIs(t, err, domain.ErrResetPasswordNotFound)
+	require.Nil(t, result.User)
+}
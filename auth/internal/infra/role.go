@@ -0,0 +1,314 @@
+package infra
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+type RoleRepository struct {
+	connPool DBTX
+}
+
+func NewRoleRepository(connPool DBTX) *RoleRepository {
+	return &RoleRepository{connPool}
+}
+
+func getRoleError(err error, notFound error, msg string) error {
+	if errors.Is(err, ErrRecordNotFound) {
+		return notFound
+	}
+
+	if pgError := GetPgError(err); pgError != nil {
+		switch pgError.Code {
+		case UniqueViolation:
+			switch pgError.ConstraintName {
+			case "roles_pkey":
+				return domain.ErrRoleAlreadyExist
+			case "permissions_pkey":
+				return domain.ErrPermissionAlreadyExist
+			case "user_roles_pkey":
+				return domain.ErrUserRoleAlreadyExist
+			}
+		case ForeignKeyViolation:
+			switch pgError.ConstraintName {
+			case "role_permissions_role_fkey", "user_roles_role_fkey":
+				return domain.ErrRoleNotFound
+			case "role_permissions_permission_fkey":
+				return domain.ErrPermissionNotFound
+			case "user_roles_username_fkey":
+				return domain.ErrUserNotFound
+			case "users_role_fkey", "service_accounts_role_fkey":
+				return domain.ErrRoleInUse
+			}
+		}
+	}
+
+	log.Error().Err(err).Msg(msg)
+	return err
+}
+
+const createRole = `
+INSERT INTO roles (
+    name,
+    description
+) VALUES (
+    $1, $2
+) RETURNING name, description, '{}'::varchar[] AS permissions, created_at
+`
+
+type CreateRole struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (r *RoleRepository) CreateRole(ctx context.Context, arg CreateRole) (*domain.Role, error) {
+	rows, _ := r.connPool.Query(ctx, createRole, arg.Name, arg.Description)
+
+	role, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Role])
+	if err != nil {
+		return nil, getRoleError(err, domain.ErrRoleNotFound, "failed to create role")
+	}
+
+	return role, nil
+}
+
+const selectRoles = `
+SELECT r.name, r.description,
+    COALESCE(array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}') AS permissions,
+    r.created_at
+FROM roles r
+LEFT JOIN role_permissions rp ON rp.role = r.name
+`
+
+const getRole = selectRoles + `
+WHERE r.name = $1
+GROUP BY r.name
+`
+
+func (r *RoleRepository) GetRole(ctx context.Context, name string) (*domain.Role, error) {
+	rows, _ := r.connPool.Query(ctx, getRole, name)
+
+	role, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Role])
+	if err != nil {
+		return nil, getRoleError(err, domain.ErrRoleNotFound, "failed to get role")
+	}
+
+	return role, nil
+}
+
+const listRoles = selectRoles + `
+GROUP BY r.name
+ORDER BY r.name
+`
+
+// ListRoles returns every role with the permissions it grants.
+func (r *RoleRepository) ListRoles(ctx context.Context) ([]*domain.Role, error) {
+	rows, _ := r.connPool.Query(ctx, listRoles)
+
+	roles, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.Role])
+	if err != nil {
+		return nil, getRoleError(err, domain.ErrRoleNotFound, "failed to list roles")
+	}
+
+	return roles, nil
+}
+
+const deleteRole = `
+DELETE FROM roles
+WHERE name = $1
+`
+
+// DeleteRole deletes a role along with its permissions and user roles. A role
+// that is still the primary role of a user or service account returns
+// domain.ErrRoleInUse.
+func (r *RoleRepository) DeleteRole(ctx context.Context, name string) error {
+	result, err := r.connPool.Exec(ctx, deleteRole, name)
+	if err != nil {
+		return getRoleError(err, domain.ErrRoleNotFound, "failed to delete role")
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrRoleNotFound
+	}
+
+	return nil
+}
+
+const createPermission = `
+INSERT INTO permissions (
+    name,
+    description
+) VALUES (
+    $1, $2
+) RETURNING name, description, created_at
+`
+
+type CreatePermission struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (r *RoleRepository) CreatePermission(ctx context.Context, arg CreatePermission) (*domain.Permission, error) {
+	rows, _ := r.connPool.Query(ctx, createPermission, arg.Name, arg.Description)
+
+	permission, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Permission])
+	if err != nil {
+		return nil, getRoleError(err, domain.ErrPermissionNotFound, "failed to create permission")
+	}
+
+	return permission, nil
+}
+
+const listPermissions = `
+SELECT name, description, created_at FROM permissions
+ORDER BY name
+`
+
+func (r *RoleRepository) ListPermissions(ctx context.Context) ([]*domain.Permission, error) {
+	rows, _ := r.connPool.Query(ctx, listPermissions)
+
+	permissions, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.Permission])
+	if err != nil {
+		return nil, getRoleError(err, domain.ErrPermissionNotFound, "failed to list permissions")
+	}
+
+	return permissions, nil
+}
+
+const lockRole = `
+SELECT name FROM roles
+WHERE name = $1
+FOR UPDATE
+`
+
+const deleteRolePermissions = `
+DELETE FROM role_permissions
+WHERE role = $1
+`
+
+const insertRolePermissions = `
+INSERT INTO role_permissions (role, permission)
+SELECT $1, unnest($2::varchar[])
+`
+
+type SetRolePermissionsTx struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+// SetRolePermissionsTx replaces the permissions granted by a role.
+func (r *RoleRepository) SetRolePermissionsTx(ctx context.Context, arg SetRolePermissionsTx) (*domain.Role, error) {
+	var role *domain.Role
+
+	err := execTx(ctx, r.connPool, func(tx pgx.Tx) error {
+		rows, _ := tx.Query(ctx, lockRole, arg.Role)
+		_, err := pgx.CollectOneRow(rows, pgx.RowTo[string])
+		if err != nil {
+			return getRoleError(err, domain.ErrRoleNotFound, "failed to lock role")
+		}
+
+		_, err = tx.Exec(ctx, deleteRolePermissions, arg.Role)
+		if err != nil {
+			return getRoleError(err, domain.ErrRoleNotFound, "failed to delete role permissions")
+		}
+
+		_, err = tx.Exec(ctx, insertRolePermissions, arg.Role, arg.Permissions)
+		if err != nil {
+			return getRoleError(err, domain.ErrRoleNotFound, "failed to insert role permissions")
+		}
+
+		role, err = NewRoleRepository(tx).GetRole(ctx, arg.Role)
+		return err
+	})
+
+	return role, err
+}
+
+const addUserRole = `
+INSERT INTO user_roles (
+    username,
+    role
+) VALUES (
+    $1, $2
+) RETURNING username, role, created_at
+`
+
+type UserRoleParams struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+func (r *RoleRepository) AddUserRole(ctx context.Context, arg UserRoleParams) (*domain.RoleAssignment, error) {
+	rows, _ := r.connPool.Query(ctx, addUserRole, arg.Username, arg.Role)
+
+	userRole, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.RoleAssignment])
+	if err != nil {
+		return nil, getRoleError(err, domain.ErrUserRoleNotFound, "failed to add user role")
+	}
+
+	return userRole, nil
+}
+
+const removeUserRole = `
+DELETE FROM user_roles
+WHERE username = $1
+AND role = $2
+`
+
+func (r *RoleRepository) RemoveUserRole(ctx context.Context, arg UserRoleParams) error {
+	result, err := r.connPool.Exec(ctx, removeUserRole, arg.Username, arg.Role)
+	if err != nil {
+		return getRoleError(err, domain.ErrUserRoleNotFound, "failed to remove user role")
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrUserRoleNotFound
+	}
+
+	return nil
+}
+
+const listUserRoles = `
+SELECT role FROM user_roles
+WHERE username = $1
+ORDER BY role
+`
+
+// ListUserRoles returns the roles of a user besides their primary role.
+func (r *RoleRepository) ListUserRoles(ctx context.Context, username string) ([]string, error) {
+	rows, _ := r.connPool.Query(ctx, listUserRoles, username)
+
+	roles, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, getRoleError(err, domain.ErrUserRoleNotFound, "failed to list user roles")
+	}
+
+	return roles, nil
+}
+
+const listUserPermissions = `
+SELECT DISTINCT permission FROM role_permissions
+WHERE role IN (
+    SELECT role FROM users WHERE username = $1
+    UNION
+    SELECT role FROM user_roles WHERE username = $1
+)
+ORDER BY permission
+`
+
+// ListUserPermissions returns the effective permissions of a user, granted by
+// their primary role and their other roles.
+func (r *RoleRepository) ListUserPermissions(ctx context.Context, username string) ([]string, error) {
+	rows, _ := r.connPool.Query(ctx, listUserPermissions, username)
+
+	permissions, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, getRoleError(err, domain.ErrUserNotFound, "failed to list user permissions")
+	}
+
+	return permissions, nil
+}
@@ -0,0 +1,184 @@
+package infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomRole(t *testing.T) *domain.Role {
+	t.Helper()
+
+	arg := CreateRole{
+		Name:        util.RandomUsername(),
+		Description: util.RandomString(20),
+	}
+
+	role, err := repositories.Role().CreateRole(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, role.Name)
+	require.Equal(t, arg.Description, role.Description)
+	require.Empty(t, role.Permissions)
+	require.NotZero(t, role.CreatedAt)
+
+	return role
+}
+
+func createRandomPermission(t *testing.T) *domain.Permission {
+	t.Helper()
+
+	arg := CreatePermission{
+		Name:        util.RandomUsername() + ":" + util.RandomString(6),
+		Description: util.RandomString(20),
+	}
+
+	permission, err := repositories.Role().CreatePermission(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, permission.Name)
+	require.Equal(t, arg.Description, permission.Description)
+	require.NotZero(t, permission.CreatedAt)
+
+	return permission
+}
+
+func TestCreateRole(t *testing.T) {
+	role := createRandomRole(t)
+
+	found, err := repositories.Role().GetRole(context.Background(), role.Name)
+	require.NoError(t, err)
+	require.Equal(t, role.Name, found.Name)
+	require.Empty(t, found.Permissions)
+
+	_, err = repositories.Role().CreateRole(context.Background(), CreateRole{Name: role.Name})
+	require.ErrorIs(t, err, domain.ErrRoleAlreadyExist)
+
+	_, err = repositories.Role().GetRole(context.Background(), util.RandomUsername())
+	require.ErrorIs(t, err, domain.ErrRoleNotFound)
+}
+
+func TestSetRolePermissionsTx(t *testing.T) {
+	role := createRandomRole(t)
+	permission1 := createRandomPermission(t)
+	permission2 := createRandomPermission(t)
+
+	updated, err := repositories.Role().SetRolePermissionsTx(context.Background(), SetRolePermissionsTx{
+		Role:        role.Name,
+		Permissions: []string{permission1.Name, permission2.Name},
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{permission1.Name, permission2.Name}, updated.Permissions)
+
+	updated, err = repositories.Role().SetRolePermissionsTx(context.Background(), SetRolePermissionsTx{
+		Role:        role.Name,
+		Permissions: []string{permission2.Name},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{permission2.Name}, updated.Permissions)
+
+	_, err = repositories.Role().SetRolePermissionsTx(context.Background(), SetRolePermissionsTx{
+		Role:        role.Name,
+		Permissions: []string{util.RandomUsername()},
+	})
+	require.ErrorIs(t, err, domain.ErrPermissionNotFound)
+
+	found, err := repositories.Role().GetRole(context.Background(), role.Name)
+	require.NoError(t, err)
+	require.Equal(t, []string{permission2.Name}, found.Permissions)
+
+	_, err = repositories.Role().SetRolePermissionsTx(context.Background(), SetRolePermissionsTx{
+		Role:        util.RandomUsername(),
+		Permissions: []string{permission1.Name},
+	})
+	require.ErrorIs(t, err, domain.ErrRoleNotFound)
+}
+
+func TestListRolesAndPermissions(t *testing.T) {
+	role := createRandomRole(t)
+	permission := createRandomPermission(t)
+
+	roles, err := repositories.Role().ListRoles(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, roles)
+
+	var names []string
+	for _, r := range roles {
+		names = append(names, r.Name)
+	}
+	require.Contains(t, names, role.Name)
+	require.Contains(t, names, domain.AdminRole)
+
+	permissions, err := repositories.Role().ListPermissions(context.Background())
+	require.NoError(t, err)
+
+	names = nil
+	for _, p := range permissions {
+		names = append(names, p.Name)
+	}
+	require.Contains(t, names, permission.Name)
+}
+
+func TestDeleteRole(t *testing.T) {
+	role := createRandomRole(t)
+
+	err := repositories.Role().DeleteRole(context.Background(), role.Name)
+	require.NoError(t, err)
+
+	err = repositories.Role().DeleteRole(context.Background(), role.Name)
+	require.ErrorIs(t, err, domain.ErrRoleNotFound)
+
+	err = repositories.Role().DeleteRole(context.Background(), domain.UserRole)
+	require.ErrorIs(t, err, domain.ErrRoleInUse)
+}
+
+func TestUserRoles(t *testing.T) {
+	user := createRandomUser(t)
+	role := createRandomRole(t)
+	permission := createRandomPermission(t)
+
+	_, err := repositories.Role().SetRolePermissionsTx(context.Background(), SetRolePermissionsTx{
+		Role:        role.Name,
+		Permissions: []string{permission.Name},
+	})
+	require.NoError(t, err)
+
+	permissions, err := repositories.Role().ListUserPermissions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, []string{"product:read"}, permissions)
+
+	arg := UserRoleParams{Username: user.Username, Role: role.Name}
+
+	userRole, err := repositories.Role().AddUserRole(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, user.Username, userRole.Username)
+	require.Equal(t, role.Name, userRole.Role)
+
+	_, err = repositories.Role().AddUserRole(context.Background(), arg)
+	require.ErrorIs(t, err, domain.ErrUserRoleAlreadyExist)
+
+	_, err = repositories.Role().AddUserRole(context.Background(), UserRoleParams{Username: user.Username, Role: util.RandomUsername()})
+	require.ErrorIs(t, err, domain.ErrRoleNotFound)
+
+	_, err = repositories.Role().AddUserRole(context.Background(), UserRoleParams{Username: util.RandomUsername(), Role: role.Name})
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	roles, err := repositories.Role().ListUserRoles(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, []string{role.Name}, roles)
+
+	permissions, err = repositories.Role().ListUserPermissions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"product:read", permission.Name}, permissions)
+
+	err = repositories.Role().RemoveUserRole(context.Background(), arg)
+	require.NoError(t, err)
+
+	err = repositories.Role().RemoveUserRole(context.Background(), arg)
+	require.ErrorIs(t, err, domain.ErrUserRoleNotFound)
+
+	roles, err = repositories.Role().ListUserRoles(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Empty(t, roles)
+}
@@ -0,0 +1,301 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+type ServiceAccountRepository struct {
+	connPool DBTX
+}
+
+func NewServiceAccountRepository(connPool DBTX) *ServiceAccountRepository {
+	return &ServiceAccountRepository{connPool}
+}
+
+func getServiceAccountError(err error, notFound error, msg string) error {
+	if errors.Is(err, ErrRecordNotFound) {
+		return notFound
+	}
+
+	if pgError := GetPgError(err); pgError != nil {
+		switch pgError.Code {
+		case UniqueViolation:
+			if pgError.ConstraintName == "service_accounts_pkey" {
+				return domain.ErrServiceAccountAlreadyExist
+			}
+		case ForeignKeyViolation:
+			switch pgError.ConstraintName {
+			case "service_accounts_created_by_fkey":
+				return domain.ErrUserNotFound
+			case "api_keys_service_account_fkey":
+				return domain.ErrServiceAccountNotFound
+			case "service_accounts_role_fkey":
+				return domain.ErrRoleNotFound
+			}
+		}
+	}
+
+	log.Error().Err(err).Msg(msg)
+	return err
+}
+
+const createServiceAccount = `
+INSERT INTO service_accounts (
+    name,
+    description,
+    role,
+    created_by
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING name, description, role, created_by, created_at
+`
+
+type CreateServiceAccount struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Role        string `json:"role"`
+	CreatedBy   string `json:"created_by"`
+}
+
+func (r *ServiceAccountRepository) CreateServiceAccount(ctx context.Context, arg CreateServiceAccount) (*domain.ServiceAccount, error) {
+	rows, _ := r.connPool.Query(ctx, createServiceAccount, arg.Name, arg.Description, arg.Role, arg.CreatedBy)
+
+	account, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.ServiceAccount])
+	if err != nil {
+		return nil, getServiceAccountError(err, domain.ErrServiceAccountNotFound, "failed to create service account")
+	}
+
+	return account, nil
+}
+
+const getServiceAccount = `
+SELECT name, description, role, created_by, created_at FROM service_accounts
+WHERE name = $1 LIMIT 1
+`
+
+func (r *ServiceAccountRepository) GetServiceAccount(ctx context.Context, name string) (*domain.ServiceAccount, error) {
+	rows, _ := r.connPool.Query(ctx, getServiceAccount, name)
+
+	account, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.ServiceAccount])
+	if err != nil {
+		return nil, getServiceAccountError(err, domain.ErrServiceAccountNotFound, "failed to get service account")
+	}
+
+	return account, nil
+}
+
+const listServiceAccounts = `
+SELECT name, description, role, created_by, created_at FROM service_accounts
+ORDER BY name
+`
+
+const listApiKeys = `
+SELECT id, service_account, prefix, hashed_key, scopes, expires_at, last_used_at, revoked_at, created_at FROM api_keys
+ORDER BY created_at
+`
+
+// ListServiceAccounts returns every service account with its API keys,
+// including the revoked and expired ones.
+func (r *ServiceAccountRepository) ListServiceAccounts(ctx context.Context) ([]*domain.ServiceAccount, error) {
+	rows, _ := r.connPool.Query(ctx, listServiceAccounts)
+
+	accounts, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.ServiceAccount])
+	if err != nil {
+		return nil, getServiceAccountError(err, domain.ErrServiceAccountNotFound, "failed to list service accounts")
+	}
+
+	rows, _ = r.connPool.Query(ctx, listApiKeys)
+
+	apiKeys, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.ApiKey])
+	if err != nil {
+		return nil, getServiceAccountError(err, domain.ErrApiKeyNotFound, "failed to list api keys")
+	}
+
+	byName := make(map[string]*domain.ServiceAccount, len(accounts))
+	for _, account := range accounts {
+		byName[account.Name] = account
+	}
+
+	for _, apiKey := range apiKeys {
+		if account, ok := byName[apiKey.ServiceAccount]; ok {
+			account.ApiKeys = append(account.ApiKeys, apiKey)
+		}
+	}
+
+	return accounts, nil
+}
+
+const createApiKey = `
+INSERT INTO api_keys (
+    id,
+    service_account,
+    prefix,
+    hashed_key,
+    scopes,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, service_account, prefix, hashed_key, scopes, expires_at, last_used_at, revoked_at, created_at
+`
+
+type CreateApiKey struct {
+	ID             uuid.UUID  `json:"id"`
+	ServiceAccount string     `json:"service_account"`
+	Prefix         string     `json:"prefix"`
+	HashedKey      string     `json:"hashed_key"`
+	Scopes         []string   `json:"scopes"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+}
+
+func (r *ServiceAccountRepository) CreateApiKey(ctx context.Context, arg CreateApiKey) (*domain.ApiKey, error) {
+	rows, _ := r.connPool.Query(ctx, createApiKey, arg.ID, arg.ServiceAccount, arg.Prefix, arg.HashedKey, arg.Scopes, arg.ExpiresAt)
+
+	apiKey, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.ApiKey])
+	if err != nil {
+		return nil, getServiceAccountError(err, domain.ErrApiKeyNotFound, "failed to create api key")
+	}
+
+	return apiKey, nil
+}
+
+type CreateServiceAccountTx struct {
+	ServiceAccount CreateServiceAccount `json:"service_account"`
+	ApiKey         CreateApiKey         `json:"api_key"`
+}
+
+// CreateServiceAccountTx creates a service account along with its first API
+// key.
+func (r *ServiceAccountRepository) CreateServiceAccountTx(ctx context.Context, arg CreateServiceAccountTx) (*domain.ServiceAccount, error) {
+	var account *domain.ServiceAccount
+
+	err := execTx(ctx, r.connPool, func(tx pgx.Tx) error {
+		serviceAccountRepository := NewServiceAccountRepository(tx)
+
+		var err error
+		account, err = serviceAccountRepository.CreateServiceAccount(ctx, arg.ServiceAccount)
+		if err != nil {
+			return err
+		}
+
+		arg.ApiKey.ServiceAccount = account.Name
+		apiKey, err := serviceAccountRepository.CreateApiKey(ctx, arg.ApiKey)
+		if err != nil {
+			return err
+		}
+
+		account.ApiKeys = []*domain.ApiKey{apiKey}
+		return nil
+	})
+
+	return account, err
+}
+
+const getApiKey = `
+SELECT id, service_account, prefix, hashed_key, scopes, expires_at, last_used_at, revoked_at, created_at FROM api_keys
+WHERE id = $1 LIMIT 1
+`
+
+func (r *ServiceAccountRepository) GetApiKey(ctx context.Context, id uuid.UUID) (*domain.ApiKey, error) {
+	rows, _ := r.connPool.Query(ctx, getApiKey, id)
+
+	apiKey, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.ApiKey])
+	if err != nil {
+		return nil, getServiceAccountError(err, domain.ErrApiKeyNotFound, "failed to get api key")
+	}
+
+	return apiKey, nil
+}
+
+const getApiKeyByHash = `
+SELECT id, service_account, prefix, hashed_key, scopes, expires_at, last_used_at, revoked_at, created_at FROM api_keys
+WHERE hashed_key = $1 LIMIT 1
+`
+
+func (r *ServiceAccountRepository) GetApiKeyByHash(ctx context.Context, hashedKey string) (*domain.ApiKey, error) {
+	rows, _ := r.connPool.Query(ctx, getApiKeyByHash, hashedKey)
+
+	apiKey, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.ApiKey])
+	if err != nil {
+		return nil, getServiceAccountError(err, domain.ErrApiKeyNotFound, "failed to get api key")
+	}
+
+	return apiKey, nil
+}
+
+const revokeApiKey = `
+UPDATE api_keys
+SET revoked_at = now()
+WHERE id = $1
+AND revoked_at IS NULL
+RETURNING id, service_account, prefix, hashed_key, scopes, expires_at, last_used_at, revoked_at, created_at
+`
+
+// RevokeApiKey revokes a key. Unknown and already revoked keys return
+// domain.ErrApiKeyNotFound.
+func (r *ServiceAccountRepository) RevokeApiKey(ctx context.Context, id uuid.UUID) (*domain.ApiKey, error) {
+	rows, _ := r.connPool.Query(ctx, revokeApiKey, id)
+
+	apiKey, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.ApiKey])
+	if err != nil {
+		return nil, getServiceAccountError(err, domain.ErrApiKeyNotFound, "failed to revoke api key")
+	}
+
+	return apiKey, nil
+}
+
+type RotateApiKeyTx struct {
+	ID     uuid.UUID    `json:"id"`
+	ApiKey CreateApiKey `json:"api_key"`
+}
+
+type RotateApiKeyTxResult struct {
+	RevokedApiKey *domain.ApiKey `json:"revoked_api_key"`
+	ApiKey        *domain.ApiKey `json:"api_key"`
+}
+
+// RotateApiKeyTx revokes a key and creates its replacement for the same
+// service account, so there is no window with both or neither.
+func (r *ServiceAccountRepository) RotateApiKeyTx(ctx context.Context, arg RotateApiKeyTx) (RotateApiKeyTxResult, error) {
+	var result RotateApiKeyTxResult
+
+	err := execTx(ctx, r.connPool, func(tx pgx.Tx) error {
+		serviceAccountRepository := NewServiceAccountRepository(tx)
+
+		var err error
+		result.RevokedApiKey, err = serviceAccountRepository.RevokeApiKey(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		arg.ApiKey.ServiceAccount = result.RevokedApiKey.ServiceAccount
+		result.ApiKey, err = serviceAccountRepository.CreateApiKey(ctx, arg.ApiKey)
+		return err
+	})
+
+	return result, err
+}
+
+const touchApiKey = `
+UPDATE api_keys
+SET last_used_at = now()
+WHERE id = $1
+AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute')
+`
+
+// TouchApiKey records that a key was used. It is written at most once a minute
+// per key, so busy keys do not write on every request.
+func (r *ServiceAccountRepository) TouchApiKey(ctx context.Context, id uuid.UUID) error {
+	_, err := r.connPool.Exec(ctx, touchApiKey, id)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to touch api key")
+	}
+
+	return err
+}
@@ -0,0 +1,167 @@
+package infra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomCreateApiKey() CreateApiKey {
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+
+	return CreateApiKey{
+		ID:        uuid.New(),
+		Prefix:    "ak_" + util.RandomString(8),
+		HashedKey: util.RandomString(64),
+		Scopes:    []string{"product:read"},
+		ExpiresAt: &expiresAt,
+	}
+}
+
+func createRandomServiceAccount(t *testing.T) *domain.ServiceAccount {
+	user := createRandomUser(t)
+
+	arg := CreateServiceAccountTx{
+		ServiceAccount: CreateServiceAccount{
+			Name:        util.RandomUsername(),
+			Description: util.RandomString(20),
+			Role:        domain.ServiceRole,
+			CreatedBy:   user.Username,
+		},
+		ApiKey: randomCreateApiKey(),
+	}
+
+	account, err := repositories.ServiceAccount().CreateServiceAccountTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ServiceAccount.Name, account.Name)
+	require.Equal(t, arg.ServiceAccount.Role, account.Role)
+	require.Equal(t, user.Username, account.CreatedBy)
+	require.NotZero(t, account.CreatedAt)
+
+	require.Len(t, account.ApiKeys, 1)
+	apiKey := account.ApiKeys[0]
+	require.Equal(t, arg.ApiKey.ID, apiKey.ID)
+	require.Equal(t, account.Name, apiKey.ServiceAccount)
+	require.Equal(t, arg.ApiKey.HashedKey, apiKey.HashedKey)
+	require.Equal(t, arg.ApiKey.Scopes, apiKey.Scopes)
+	require.WithinDuration(t, *arg.ApiKey.ExpiresAt, *apiKey.ExpiresAt, time.Second)
+	require.Nil(t, apiKey.LastUsedAt)
+	require.Nil(t, apiKey.RevokedAt)
+	require.True(t, apiKey.IsActive(time.Now()))
+
+	return account
+}
+
+func TestCreateServiceAccount(t *testing.T) {
+	account := createRandomServiceAccount(t)
+
+	found, err := repositories.ServiceAccount().GetServiceAccount(context.Background(), account.Name)
+	require.NoError(t, err)
+	require.Equal(t, account.Description, found.Description)
+
+	_, err = repositories.ServiceAccount().CreateServiceAccountTx(context.Background(), CreateServiceAccountTx{
+		ServiceAccount: CreateServiceAccount{Name: account.Name, Role: domain.ServiceRole, CreatedBy: account.CreatedBy},
+		ApiKey:         randomCreateApiKey(),
+	})
+	require.ErrorIs(t, err, domain.ErrServiceAccountAlreadyExist)
+
+	_, err = repositories.ServiceAccount().GetServiceAccount(context.Background(), util.RandomUsername())
+	require.ErrorIs(t, err, domain.ErrServiceAccountNotFound)
+}
+
+func TestCreateApiKeyUnknownServiceAccount(t *testing.T) {
+	arg := randomCreateApiKey()
+	arg.ServiceAccount = util.RandomUsername()
+
+	_, err := repositories.ServiceAccount().CreateApiKey(context.Background(), arg)
+	require.ErrorIs(t, err, domain.ErrServiceAccountNotFound)
+}
+
+func TestListServiceAccounts(t *testing.T) {
+	account := createRandomServiceAccount(t)
+
+	accounts, err := repositories.ServiceAccount().ListServiceAccounts(context.Background())
+	require.NoError(t, err)
+
+	var found *domain.ServiceAccount
+	for _, a := range accounts {
+		if a.Name == account.Name {
+			found = a
+		}
+	}
+
+	require.NotNil(t, found)
+	require.Len(t, found.ApiKeys, 1)
+	require.Equal(t, account.ApiKeys[0].ID, found.ApiKeys[0].ID)
+}
+
+func TestGetApiKeyByHash(t *testing.T) {
+	account := createRandomServiceAccount(t)
+	apiKey := account.ApiKeys[0]
+
+	found, err := repositories.ServiceAccount().GetApiKeyByHash(context.Background(), apiKey.HashedKey)
+	require.NoError(t, err)
+	require.Equal(t, apiKey.ID, found.ID)
+
+	_, err = repositories.ServiceAccount().GetApiKeyByHash(context.Background(), util.RandomString(64))
+	require.ErrorIs(t, err, domain.ErrApiKeyNotFound)
+}
+
+func TestRevokeApiKey(t *testing.T) {
+	account := createRandomServiceAccount(t)
+	apiKey := account.ApiKeys[0]
+
+	revoked, err := repositories.ServiceAccount().RevokeApiKey(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+	require.NotNil(t, revoked.RevokedAt)
+	require.False(t, revoked.IsActive(time.Now()))
+
+	_, err = repositories.ServiceAccount().RevokeApiKey(context.Background(), apiKey.ID)
+	require.ErrorIs(t, err, domain.ErrApiKeyNotFound)
+}
+
+func TestRotateApiKeyTx(t *testing.T) {
+	account := createRandomServiceAccount(t)
+	apiKey := account.ApiKeys[0]
+
+	arg := RotateApiKeyTx{
+		ID:     apiKey.ID,
+		ApiKey: randomCreateApiKey(),
+	}
+
+	result, err := repositories.ServiceAccount().RotateApiKeyTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, apiKey.ID, result.RevokedApiKey.ID)
+	require.NotNil(t, result.RevokedApiKey.RevokedAt)
+	require.Equal(t, arg.ApiKey.ID, result.ApiKey.ID)
+	require.Equal(t, account.Name, result.ApiKey.ServiceAccount)
+	require.Nil(t, result.ApiKey.RevokedAt)
+
+	_, err = repositories.ServiceAccount().RotateApiKeyTx(context.Background(), RotateApiKeyTx{ID: apiKey.ID, ApiKey: randomCreateApiKey()})
+	require.ErrorIs(t, err, domain.ErrApiKeyNotFound)
+}
+
+func TestTouchApiKey(t *testing.T) {
+	account := createRandomServiceAccount(t)
+	apiKey := account.ApiKeys[0]
+
+	err := repositories.ServiceAccount().TouchApiKey(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+
+	touched, err := repositories.ServiceAccount().GetApiKey(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+	require.NotNil(t, touched.LastUsedAt)
+	require.WithinDuration(t, time.Now(), *touched.LastUsedAt, time.Minute)
+
+	err = repositories.ServiceAccount().TouchApiKey(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+
+	again, err := repositories.ServiceAccount().GetApiKey(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+	require.Equal(t, *touched.LastUsedAt, *again.LastUsedAt)
+}
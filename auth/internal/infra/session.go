@@ -29,6 +29,8 @@ func getSessionError(err error, defaultReturn error, msg string) error {
 			switch pgError.ConstraintName {
 			case "sessions_username_fkey":
 				return domain.ErrUserNotFound
+			case "sessions_client_id_fkey":
+				return domain.ErrOauthClientNotFound
 			}
 		}
 	}
@@ -40,6 +42,9 @@ func getSessionError(err error, defaultReturn error, msg string) error {
 const createSession = `
 INSERT INTO sessions (
   id,
+  family_id,
+  parent_id,
+  client_id,
   username,
   refresh_token,
   user_agent,
@@ -47,23 +52,29 @@ INSERT INTO sessions (
   is_blocked,
   expires_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7
-) RETURNING id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING id, family_id, parent_id, client_id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, rotated_at, created_at
 `
 
 type CreateSession struct {
-	ID           uuid.UUID `json:"id"`
-	Username     string    `json:"username"`
-	RefreshToken string    `json:"refresh_token"`
-	UserAgent    string    `json:"user_agent"`
-	ClientIp     string    `json:"client_ip"`
-	IsBlocked    bool      `json:"is_blocked"`
-	ExpiresAt    time.Time `json:"expires_at"`
+	ID           uuid.UUID  `json:"id"`
+	FamilyID     uuid.UUID  `json:"family_id"`
+	ParentID     *uuid.UUID `json:"parent_id"`
+	ClientID     *string    `json:"client_id"`
+	Username     string     `json:"username"`
+	RefreshToken string     `json:"refresh_token"`
+	UserAgent    string     `json:"user_agent"`
+	ClientIp     string     `json:"client_ip"`
+	IsBlocked    bool       `json:"is_blocked"`
+	ExpiresAt    time.Time  `json:"expires_at"`
 }
 
 func (s *SessionRepository) CreateSession(ctx context.Context, arg CreateSession) (*domain.Session, error) {
 	args := []any{
 		arg.ID,
+		arg.FamilyID,
+		arg.ParentID,
+		arg.ClientID,
 		arg.Username,
 		arg.RefreshToken,
 		arg.UserAgent,
@@ -83,7 +94,7 @@ func (s *SessionRepository) CreateSession(ctx context.Context, arg CreateSession
 }
 
 const getSession = `
-SELECT id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at
+SELECT id, family_id, parent_id, client_id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, rotated_at, created_at
 FROM sessions
 WHERE id = $1 LIMIT 1
 `
@@ -98,3 +109,141 @@ func (s *SessionRepository) GetSession(ctx context.Context, id uuid.UUID) (*doma
 
 	return session, err
 }
+
+const rotateSession = `
+UPDATE sessions
+SET rotated_at = now()
+WHERE id = $1
+AND rotated_at IS NULL
+AND is_blocked = false
+RETURNING id, family_id, parent_id, client_id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, rotated_at, created_at
+`
+
+func (s *SessionRepository) RotateSession(ctx context.Context, id uuid.UUID) (*domain.Session, error) {
+	rows, _ := s.connPool.Query(ctx, rotateSession, id)
+
+	session, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Session])
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return nil, domain.ErrSessionRotated
+		}
+		return nil, getSessionError(err, domain.ErrUpdateSession, "failed to rotate session")
+	}
+
+	return session, err
+}
+
+type RotateSessionTx struct {
+	ParentID uuid.UUID     `json:"parent_id"`
+	Session  CreateSession `json:"session"`
+}
+
+type RotateSessionTxResult struct {
+	Parent  *domain.Session `json:"parent"`
+	Session *domain.Session `json:"session"`
+}
+
+func (s *SessionRepository) RotateSessionTx(ctx context.Context, arg RotateSessionTx) (RotateSessionTxResult, error) {
+	var result RotateSessionTxResult
+
+	err := execTx(ctx, s.connPool, func(tx pgx.Tx) error {
+		var err error
+
+		sessionRepository := NewSessionRepository(tx)
+		result.Parent, err = sessionRepository.RotateSession(ctx, arg.ParentID)
+		if err != nil {
+			return err
+		}
+
+		arg.Session.FamilyID = result.Parent.FamilyID
+		arg.Session.ParentID = &result.Parent.ID
+		arg.Session.ClientID = result.Parent.ClientID
+
+		result.Session, err = sessionRepository.CreateSession(ctx, arg.Session)
+		return err
+	})
+
+	return result, err
+}
+
+const blockSessionFamily = `
+UPDATE sessions
+SET is_blocked = true
+WHERE family_id = $1
+`
+
+func (s *SessionRepository) BlockSessionFamily(ctx context.Context, familyID uuid.UUID) error {
+	_, err := s.connPool.Exec(ctx, blockSessionFamily, familyID)
+	if err != nil {
+		return getSessionError(err, domain.ErrUpdateSession, "failed to block session family")
+	}
+
+	return nil
+}
+
+const listActiveSessions = `
+SELECT id, family_id, parent_id, client_id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, rotated_at, created_at
+FROM sessions
+WHERE username = $1
+AND is_blocked = false
+AND rotated_at IS NULL
+AND expires_at > now()
+ORDER BY created_at DESC
+`
+
+func (s *SessionRepository) ListActiveSessions(ctx context.Context, username string) ([]*domain.Session, error) {
+	rows, _ := s.connPool.Query(ctx, listActiveSessions, username)
+
+	sessions, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.Session])
+	if err != nil {
+		return nil, getSessionError(err, domain.ErrReadSession, "failed to list sessions")
+	}
+
+	return sessions, nil
+}
+
+const listSessionDevices = `
+SELECT DISTINCT user_agent, client_ip
+FROM sessions
+WHERE username = $1
+`
+
+// ListSessionDevices returns the user agents and client IPs of every session
+// of the user, blocked and expired ones included.
+func (s *SessionRepository) ListSessionDevices(ctx context.Context, username string) ([]*domain.SessionDevice, error) {
+	rows, _ := s.connPool.Query(ctx, listSessionDevices, username)
+
+	devices, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.SessionDevice])
+	if err != nil {
+		return nil, getSessionError(err, domain.ErrReadSession, "failed to list session devices")
+	}
+
+	return devices, nil
+}
+
+const blockUserSessions = `
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1
+AND family_id <> $2
+AND is_blocked = false
+`
+
+type BlockUserSessions struct {
+	Username       string    `json:"username"`
+	ExceptFamilyID uuid.UUID `json:"except_family_id"`
+}
+
+func (s *SessionRepository) BlockUserSessions(ctx context.Context, arg BlockUserSessions) error {
+	args := []any{
+		arg.Username,
+		arg.ExceptFamilyID,
+	}
+
+	_, err := s.connPool.Exec(ctx, blockUserSessions, args...)
+	if err != nil {
+		return getSessionError(err, domain.ErrUpdateSession, "failed to block user sessions")
+	}
+
+	return nil
+}
@@ -14,8 +14,10 @@ import (
 func createRandomSession(t *testing.T) domain.Session {
 	user := createRandomUser(t)
 
+	id := uuid.New()
 	arg := CreateSession{
-		ID:           uuid.New(),
+		ID:           id,
+		FamilyID:     id,
 		Username:     user.Username,
 		RefreshToken: util.RandomString(32),
 		UserAgent:    util.RandomString(12),
@@ -28,6 +30,9 @@ func createRandomSession(t *testing.T) domain.Session {
 	require.NoError(t, err)
 	require.NotEmpty(t, session)
 
+	require.Equal(t, arg.FamilyID, session.FamilyID)
+	require.Nil(t, session.ParentID)
+	require.Nil(t, session.RotatedAt)
 	require.Equal(t, arg.Username, session.Username)
 	require.Equal(t, arg.RefreshToken, session.RefreshToken)
 	require.Equal(t, arg.UserAgent, session.UserAgent)
@@ -45,8 +50,10 @@ func TestCreateSession(t *testing.T) {
 }
 
 func TestCreateSessionInvalidUser(t *testing.T) {
+	id := uuid.New()
 	arg := CreateSession{
-		ID:           uuid.New(),
+		ID:           id,
+		FamilyID:     id,
 		Username:     "",
 		RefreshToken: util.RandomString(32),
 		UserAgent:    util.RandomString(12),
@@ -85,3 +92,177 @@ func TestSessionNotFound(t *testing.T) {
 	require.ErrorIs(t, err, domain.ErrSessionNotFound)
 	require.Nil(t, session)
 }
+
+func TestRotateSessionTx(t *testing.T) {
+	parent := createRandomSession(t)
+
+	arg := RotateSessionTx{
+		ParentID: parent.ID,
+		Session: CreateSession{
+			ID:           uuid.New(),
+			Username:     parent.Username,
+			RefreshToken: util.RandomString(32),
+			UserAgent:    util.RandomString(12),
+			ClientIp:     util.RandomString(9),
+			ExpiresAt:    parent.ExpiresAt,
+		},
+	}
+
+	result, err := repositories.Session().RotateSessionTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotNil(t, result.Parent)
+	require.NotNil(t, result.Session)
+
+	require.Equal(t, parent.ID, result.Parent.ID)
+	require.NotNil(t, result.Parent.RotatedAt)
+
+	require.Equal(t, arg.Session.ID, result.Session.ID)
+	require.Equal(t, parent.FamilyID, result.Session.FamilyID)
+	require.NotNil(t, result.Session.ParentID)
+	require.Equal(t, parent.ID, *result.Session.ParentID)
+	require.Nil(t, result.Session.RotatedAt)
+}
+
+func TestRotateSessionTxAlreadyRotated(t *testing.T) {
+	parent := createRandomSession(t)
+
+	newArg := func() RotateSessionTx {
+		return RotateSessionTx{
+			ParentID: parent.ID,
+			Session: CreateSession{
+				ID:           uuid.New(),
+				Username:     parent.Username,
+				RefreshToken: util.RandomString(32),
+				ExpiresAt:    parent.ExpiresAt,
+			},
+		}
+	}
+
+	_, err := repositories.Session().RotateSessionTx(context.Background(), newArg())
+	require.NoError(t, err)
+
+	arg := newArg()
+	result, err := repositories.Session().RotateSessionTx(context.Background(), arg)
+	require.ErrorIs(t, err, domain.ErrSessionRotated)
+	require.Nil(t, result.Session)
+
+	_, err = repositories.Session().GetSession(context.Background(), arg.Session.ID)
+	require.ErrorIs(t, err, domain.ErrSessionNotFound)
+}
+
+func TestBlockSessionFamily(t *testing.T) {
+	parent := createRandomSession(t)
+
+	result, err := repositories.Session().RotateSessionTx(context.Background(), RotateSessionTx{
+		ParentID: parent.ID,
+		Session: CreateSession{
+			ID:           uuid.New(),
+			Username:     parent.Username,
+			RefreshToken: util.RandomString(32),
+			ExpiresAt:    parent.ExpiresAt,
+		},
+	})
+	require.NoError(t, err)
+
+	other := createRandomSession(t)
+
+	err = repositories.Session().BlockSessionFamily(context.Background(), parent.FamilyID)
+	require.NoError(t, err)
+
+	for _, id := range []uuid.UUID{parent.ID, result.Session.ID} {
+		session, err := repositories.Session().GetSession(context.Background(), id)
+		require.NoError(t, err)
+		require.True(t, session.IsBlocked)
+	}
+
+	session, err := repositories.Session().GetSession(context.Background(), other.ID)
+	require.NoError(t, err)
+	require.False(t, session.IsBlocked)
+}
+
+func TestListActiveSessions(t *testing.T) {
+	session1 := createRandomSession(t)
+
+	session2, err := repositories.Session().CreateSession(context.Background(), CreateSession{
+		ID:           uuid.New(),
+		FamilyID:     uuid.New(),
+		Username:     session1.Username,
+		RefreshToken: util.RandomString(32),
+		ExpiresAt:    time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	expiredID := uuid.New()
+	_, err = repositories.Session().CreateSession(context.Background(), CreateSession{
+		ID:           expiredID,
+		FamilyID:     expiredID,
+		Username:     session1.Username,
+		RefreshToken: util.RandomString(32),
+		ExpiresAt:    time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	err = repositories.Session().BlockSessionFamily(context.Background(), session2.FamilyID)
+	require.NoError(t, err)
+
+	sessions, err := repositories.Session().ListActiveSessions(context.Background(), session1.Username)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, session1.ID, sessions[0].ID)
+}
+
+func TestListSessionDevices(t *testing.T) {
+	session1 := createRandomSession(t)
+
+	for i := 0; i < 2; i++ {
+		id := uuid.New()
+		_, err := repositories.Session().CreateSession(context.Background(), CreateSession{
+			ID:           id,
+			FamilyID:     id,
+			Username:     session1.Username,
+			RefreshToken: util.RandomString(32),
+			UserAgent:    session1.UserAgent,
+			ClientIp:     session1.ClientIp,
+			ExpiresAt:    time.Now().Add(-time.Minute),
+		})
+		require.NoError(t, err)
+	}
+
+	devices, err := repositories.Session().ListSessionDevices(context.Background(), session1.Username)
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	require.Equal(t, session1.UserAgent, devices[0].UserAgent)
+	require.Equal(t, session1.ClientIp, devices[0].ClientIp)
+
+	devices, err = repositories.Session().ListSessionDevices(context.Background(), util.RandomUsername())
+	require.NoError(t, err)
+	require.Empty(t, devices)
+}
+
+func TestBlockUserSessions(t *testing.T) {
+	current := createRandomSession(t)
+
+	otherID := uuid.New()
+	other, err := repositories.Session().CreateSession(context.Background(), CreateSession{
+		ID:           otherID,
+		FamilyID:     otherID,
+		Username:     current.Username,
+		RefreshToken: util.RandomString(32),
+		ExpiresAt:    time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	err = repositories.Session().BlockUserSessions(context.Background(), BlockUserSessions{
+		Username:       current.Username,
+		ExceptFamilyID: current.FamilyID,
+	})
+	require.NoError(t, err)
+
+	session, err := repositories.Session().GetSession(context.Background(), current.ID)
+	require.NoError(t, err)
+	require.False(t, session.IsBlocked)
+
+	session, err = repositories.Session().GetSession(context.Background(), other.ID)
+	require.NoError(t, err)
+	require.True(t, session.IsBlocked)
+}
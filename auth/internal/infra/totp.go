@@ -0,0 +1,250 @@
+package infra
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+type TotpRepository struct {
+	connPool DBTX
+}
+
+func NewTotpRepository(connPool DBTX) *TotpRepository {
+	return &TotpRepository{connPool}
+}
+
+func getTotpError(err error, notFound error, msg string) error {
+	if errors.Is(err, ErrRecordNotFound) {
+		return notFound
+	}
+
+	log.Error().Err(err).Msg(msg)
+	return err
+}
+
+const getUserTotp = `
+SELECT username, totp_secret, is_totp_enabled, totp_last_counter FROM users
+WHERE username = $1 LIMIT 1
+`
+
+// GetUserTotp returns the TOTP state of a user. Users that never enrolled have
+// a nil Secret.
+func (r *TotpRepository) GetUserTotp(ctx context.Context, username string) (*domain.UserTotp, error) {
+	rows, _ := r.connPool.Query(ctx, getUserTotp, username)
+
+	userTotp, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.UserTotp])
+	if err != nil {
+		return nil, getTotpError(err, domain.ErrUserNotFound, "failed to get user totp")
+	}
+
+	return userTotp, nil
+}
+
+const enrollTotp = `
+UPDATE users
+SET
+    totp_secret = $2,
+    totp_last_counter = 0
+WHERE username = $1
+AND is_totp_enabled = false
+RETURNING username, totp_secret, is_totp_enabled, totp_last_counter
+`
+
+type EnrollTotp struct {
+	Username string `json:"username"`
+	Secret   []byte `json:"secret"`
+}
+
+// EnrollTotp stores a pending secret, replacing any previous one that was
+// never confirmed. Users with TOTP enabled return domain.ErrTotpAlreadyEnabled.
+func (r *TotpRepository) EnrollTotp(ctx context.Context, arg EnrollTotp) (*domain.UserTotp, error) {
+	rows, _ := r.connPool.Query(ctx, enrollTotp, arg.Username, arg.Secret)
+
+	userTotp, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.UserTotp])
+	if err != nil {
+		return nil, getTotpError(err, domain.ErrTotpAlreadyEnabled, "failed to enroll totp")
+	}
+
+	return userTotp, nil
+}
+
+const useTotpCounter = `
+UPDATE users
+SET totp_last_counter = $2
+WHERE username = $1
+AND totp_secret IS NOT NULL
+AND totp_last_counter < $2
+`
+
+type UseTotpCounter struct {
+	Username string `json:"username"`
+	Counter  int64  `json:"counter"`
+}
+
+// UseTotpCounter records the time step of an accepted code. Steps at or
+// before the last accepted one return domain.ErrTotpCodeReused.
+func (r *TotpRepository) UseTotpCounter(ctx context.Context, arg UseTotpCounter) error {
+	result, err := r.connPool.Exec(ctx, useTotpCounter, arg.Username, arg.Counter)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to use totp counter")
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrTotpCodeReused
+	}
+
+	return nil
+}
+
+const enableTotp = `
+UPDATE users
+SET is_totp_enabled = true
+WHERE username = $1
+AND totp_secret IS NOT NULL
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, is_totp_enabled, role, disabled_at, password_reset_required, deleted_at
+`
+
+const disableTotp = `
+UPDATE users
+SET
+    totp_secret = NULL,
+    is_totp_enabled = false,
+    totp_last_counter = 0
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, is_totp_enabled, role, disabled_at, password_reset_required, deleted_at
+`
+
+const deleteRecoveryCodes = `
+DELETE FROM recovery_codes
+WHERE username = $1
+`
+
+const createRecoveryCode = `
+INSERT INTO recovery_codes (
+    username,
+    hashed_code
+) VALUES (
+    $1, $2
+)
+`
+
+func (r *TotpRepository) replaceRecoveryCodes(ctx context.Context, username string, hashedCodes []string) error {
+	_, err := r.connPool.Exec(ctx, deleteRecoveryCodes, username)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to delete recovery codes")
+		return err
+	}
+
+	for _, hashedCode := range hashedCodes {
+		_, err = r.connPool.Exec(ctx, createRecoveryCode, username, hashedCode)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to create recovery code")
+			return err
+		}
+	}
+
+	return nil
+}
+
+type EnableTotpTx struct {
+	Username            string   `json:"username"`
+	Counter             int64    `json:"counter"`
+	HashedRecoveryCodes []string `json:"hashed_recovery_codes"`
+}
+
+type EnableTotpTxResult struct {
+	User *domain.User `json:"user"`
+}
+
+// EnableTotpTx turns on the enrolled secret after a first valid code and
+// replaces the recovery codes of the user.
+func (r *TotpRepository) EnableTotpTx(ctx context.Context, arg EnableTotpTx) (EnableTotpTxResult, error) {
+	var result EnableTotpTxResult
+
+	err := execTx(ctx, r.connPool, func(tx pgx.Tx) error {
+		totpRepository := NewTotpRepository(tx)
+		err := totpRepository.UseTotpCounter(ctx, UseTotpCounter{Username: arg.Username, Counter: arg.Counter})
+		if err != nil {
+			return err
+		}
+
+		rows, _ := tx.Query(ctx, enableTotp, arg.Username)
+		result.User, err = pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.User])
+		if err != nil {
+			return getTotpError(err, domain.ErrTotpNotFound, "failed to enable totp")
+		}
+
+		return totpRepository.replaceRecoveryCodes(ctx, arg.Username, arg.HashedRecoveryCodes)
+	})
+
+	return result, err
+}
+
+// DisableTotpTx removes the secret and the recovery codes of a user.
+func (r *TotpRepository) DisableTotpTx(ctx context.Context, username string) (*domain.User, error) {
+	var user *domain.User
+
+	err := execTx(ctx, r.connPool, func(tx pgx.Tx) error {
+		var err error
+
+		rows, _ := tx.Query(ctx, disableTotp, username)
+		user, err = pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.User])
+		if err != nil {
+			return getTotpError(err, domain.ErrUserNotFound, "failed to disable totp")
+		}
+
+		_, err = tx.Exec(ctx, deleteRecoveryCodes, username)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to delete recovery codes")
+		}
+
+		return err
+	})
+
+	return user, err
+}
+
+type ReplaceRecoveryCodesTx struct {
+	Username    string   `json:"username"`
+	HashedCodes []string `json:"hashed_codes"`
+}
+
+// ReplaceRecoveryCodesTx invalidates every recovery code of the user in favor
+// of the new ones.
+func (r *TotpRepository) ReplaceRecoveryCodesTx(ctx context.Context, arg ReplaceRecoveryCodesTx) error {
+	return execTx(ctx, r.connPool, func(tx pgx.Tx) error {
+		return NewTotpRepository(tx).replaceRecoveryCodes(ctx, arg.Username, arg.HashedCodes)
+	})
+}
+
+const useRecoveryCode = `
+UPDATE recovery_codes
+SET used_at = now()
+WHERE username = $1
+AND hashed_code = $2
+AND used_at IS NULL
+RETURNING id, username, hashed_code, used_at, created_at
+`
+
+type UseRecoveryCode struct {
+	Username   string `json:"username"`
+	HashedCode string `json:"hashed_code"`
+}
+
+// UseRecoveryCode consumes a recovery code. Codes that are unknown or used
+// return domain.ErrRecoveryCodeNotFound.
+func (r *TotpRepository) UseRecoveryCode(ctx context.Context, arg UseRecoveryCode) (*domain.RecoveryCode, error) {
+	rows, _ := r.connPool.Query(ctx, useRecoveryCode, arg.Username, arg.HashedCode)
+
+	recoveryCode, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.RecoveryCode])
+	if err != nil {
+		return nil, getTotpError(err, domain.ErrRecoveryCodeNotFound, "failed to use recovery code")
+	}
+
+	return recoveryCode, nil
+}
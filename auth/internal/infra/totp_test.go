@@ -0,0 +1,111 @@
+package infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func enableRandomTotp(t *testing.T) (domain.User, []string) {
+	t.Helper()
+
+	user := createRandomUser(t)
+
+	_, err := repositories.Totp().EnrollTotp(context.Background(), EnrollTotp{
+		Username: user.Username,
+		Secret:   []byte(util.RandomString(32)),
+	})
+	require.NoError(t, err)
+
+	hashedCodes := []string{util.RandomString(64), util.RandomString(64)}
+
+	result, err := repositories.Totp().EnableTotpTx(context.Background(), EnableTotpTx{
+		Username:            user.Username,
+		Counter:             10,
+		HashedRecoveryCodes: hashedCodes,
+	})
+	require.NoError(t, err)
+	require.True(t, result.User.IsTotpEnabled)
+
+	return *result.User, hashedCodes
+}
+
+func TestEnrollTotp(t *testing.T) {
+	user := createRandomUser(t)
+	secret := []byte(util.RandomString(32))
+
+	userTotp, err := repositories.Totp().EnrollTotp(context.Background(), EnrollTotp{
+		Username: user.Username,
+		Secret:   secret,
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.Username, userTotp.Username)
+	require.Equal(t, secret, userTotp.Secret)
+	require.False(t, userTotp.IsTotpEnabled)
+
+	userTotp, err = repositories.Totp().GetUserTotp(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, secret, userTotp.Secret)
+}
+
+func TestEnrollTotpAlreadyEnabled(t *testing.T) {
+	user, _ := enableRandomTotp(t)
+
+	userTotp, err := repositories.Totp().EnrollTotp(context.Background(), EnrollTotp{
+		Username: user.Username,
+		Secret:   []byte(util.RandomString(32)),
+	})
+	require.ErrorIs(t, err, domain.ErrTotpAlreadyEnabled)
+	require.Nil(t, userTotp)
+}
+
+func TestUseTotpCounter(t *testing.T) {
+	user, _ := enableRandomTotp(t)
+
+	err := repositories.Totp().UseTotpCounter(context.Background(), UseTotpCounter{Username: user.Username, Counter: 10})
+	require.ErrorIs(t, err, domain.ErrTotpCodeReused)
+
+	err = repositories.Totp().UseTotpCounter(context.Background(), UseTotpCounter{Username: user.Username, Counter: 11})
+	require.NoError(t, err)
+
+	userTotp, err := repositories.Totp().GetUserTotp(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, int64(11), userTotp.LastCounter)
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	user, hashedCodes := enableRandomTotp(t)
+
+	recoveryCode, err := repositories.Totp().UseRecoveryCode(context.Background(), UseRecoveryCode{Username: user.Username, HashedCode: hashedCodes[0]})
+	require.NoError(t, err)
+	require.NotNil(t, recoveryCode.UsedAt)
+
+	recoveryCode, err = repositories.Totp().UseRecoveryCode(context.Background(), UseRecoveryCode{Username: user.Username, HashedCode: hashedCodes[0]})
+	require.ErrorIs(t, err, domain.ErrRecoveryCodeNotFound)
+	require.Nil(t, recoveryCode)
+
+	err = repositories.Totp().ReplaceRecoveryCodesTx(context.Background(), ReplaceRecoveryCodesTx{Username: user.Username, HashedCodes: []string{util.RandomString(64)}})
+	require.NoError(t, err)
+
+	_, err = repositories.Totp().UseRecoveryCode(context.Background(), UseRecoveryCode{Username: user.Username, HashedCode: hashedCodes[1]})
+	require.ErrorIs(t, err, domain.ErrRecoveryCodeNotFound)
+}
+
+func TestDisableTotpTx(t *testing.T) {
+	user, hashedCodes := enableRandomTotp(t)
+
+	disabled, err := repositories.Totp().DisableTotpTx(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.False(t, disabled.IsTotpEnabled)
+
+	userTotp, err := repositories.Totp().GetUserTotp(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Nil(t, userTotp.Secret)
+	require.False(t, userTotp.IsTotpEnabled)
+
+	_, err = repositories.Totp().UseRecoveryCode(context.Background(), UseRecoveryCode{Username: user.Username, HashedCode: hashedCodes[1]})
+	require.ErrorIs(t, err, domain.ErrRecoveryCodeNotFound)
+}
@@ -24,17 +24,19 @@ INSERT INTO users (
 	username,
 	hashed_password,
 	full_name,
-email
+	email,
+	is_email_verified
 ) VALUES (
-	$1, $2, $3, $4
-) RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role
+	$1, $2, $3, $4, $5
+) RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, is_totp_enabled, role, disabled_at, password_reset_required, deleted_at
 `
 
 type CreateUser struct {
-	Username       string `json:"username"`
-	HashedPassword string `json:"hashed_password"`
-	FullName       string `json:"full_name"`
-	Email          string `json:"email"`
+	Username        string `json:"username"`
+	HashedPassword  string `json:"hashed_password"`
+	FullName        string `json:"full_name"`
+	Email           string `json:"email"`
+	IsEmailVerified bool   `json:"is_email_verified"`
 }
 
 func (u *UserRepository) CreateUser(ctx context.Context, arg CreateUser) (*domain.User, error) {
@@ -43,6 +45,7 @@ func (u *UserRepository) CreateUser(ctx context.Context, arg CreateUser) (*domai
 		arg.HashedPassword,
 		arg.FullName,
 		arg.Email,
+		arg.IsEmailVerified,
 	}
 
 	rows, _ := u.connPool.Query(ctx, createUser, args...)
@@ -56,7 +59,7 @@ func (u *UserRepository) CreateUser(ctx context.Context, arg CreateUser) (*domai
 }
 
 const getUser = `
-SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role FROM users
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, is_totp_enabled, role, disabled_at, password_reset_required, deleted_at FROM users
 WHERE username = $1 LIMIT 1
 `
 
@@ -71,6 +74,39 @@ func (u *UserRepository) GetUser(ctx context.Context, username string) (*domain.
 	return user, err
 }
 
+const getUserByEmail = `
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, is_totp_enabled, role, disabled_at, password_reset_required, deleted_at FROM users
+WHERE email = $1 LIMIT 1
+`
+
+func (u *UserRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	rows, _ := u.connPool.Query(ctx, getUserByEmail, email)
+
+	user, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.User])
+	if err != nil {
+		return nil, getUserError(err, domain.ErrReadUser, "failed to get user by email")
+	}
+
+	return user, err
+}
+
+const getUserForUpdate = `
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, is_totp_enabled, role, disabled_at, password_reset_required, deleted_at FROM users
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (u *UserRepository) getUserForUpdate(ctx context.Context, username string) (*domain.User, error) {
+	rows, _ := u.connPool.Query(ctx, getUserForUpdate, username)
+
+	user, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.User])
+	if err != nil {
+		return nil, getUserError(err, domain.ErrReadUser, "failed to get user for update")
+	}
+
+	return user, err
+}
+
 const updateUser = `
 UPDATE users
 SET
@@ -78,10 +114,11 @@ SET
     password_changed_at = COALESCE($2, password_changed_at),
     full_name = COALESCE($3, full_name),
     email = COALESCE($4, email),
-    is_email_verified = COALESCE($5, is_email_verified)
+    is_email_verified = COALESCE($5, is_email_verified),
+    password_reset_required = password_reset_required AND $1::varchar IS NULL
 WHERE
     username = $6
-RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, is_totp_enabled, role, disabled_at, password_reset_required, deleted_at
 `
 
 type UpdateUser struct {
@@ -114,8 +151,9 @@ func (u *UserRepository) UpdateUser(ctx context.Context, arg UpdateUser) (*domai
 }
 
 type CreateUserTx struct {
-	CreateUser  `json:"create_user_repo"`
-	AfterCreate func(user domain.User) error `json:"after_create"`
+	CreateUser       `json:"create_user_repo"`
+	ExternalIdentity *CreateExternalIdentity      `json:"external_identity"`
+	AfterCreate      func(user domain.User) error `json:"after_create"`
 }
 
 type CreateUserTxResult struct {
@@ -134,25 +172,335 @@ func (u *UserRepository) CreateUserTx(ctx context.Context, arg CreateUserTx) (Cr
 
 		result.User = *user
 
+		if arg.ExternalIdentity != nil {
+			externalIdentity := *arg.ExternalIdentity
+			externalIdentity.Username = user.Username
+
+			_, err = NewExternalLoginRepository(tx).CreateExternalIdentity(ctx, externalIdentity)
+			if err != nil {
+				return err
+			}
+		}
+
 		return arg.AfterCreate(result.User)
 	})
 
 	return result, err
 }
 
+type UpdateUserTx struct {
+	UpdateUser       `json:"update_user"`
+	AfterEmailChange func(user domain.User) error `json:"after_email_change"`
+}
+
+type UpdateUserTxResult struct {
+	User         domain.User `json:"user"`
+	EmailChanged bool        `json:"email_changed"`
+}
+
+// UpdateUserTx updates the user like UpdateUser. When the email changes it is
+// marked as not verified and AfterEmailChange runs inside the transaction.
+func (u *UserRepository) UpdateUserTx(ctx context.Context, arg UpdateUserTx) (UpdateUserTxResult, error) {
+	var result UpdateUserTxResult
+
+	err := execTx(ctx, u.connPool, func(tx pgx.Tx) error {
+		userRepository := NewUserRepository(tx)
+		current, err := userRepository.getUserForUpdate(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		updateUser := arg.UpdateUser
+		result.EmailChanged = updateUser.Email != nil && *updateUser.Email != current.Email
+		if result.EmailChanged {
+			isEmailVerified := false
+			updateUser.IsEmailVerified = &isEmailVerified
+		}
+
+		user, err := userRepository.UpdateUser(ctx, updateUser)
+		if err != nil {
+			return err
+		}
+
+		result.User = *user
+
+		if result.EmailChanged && arg.AfterEmailChange != nil {
+			return arg.AfterEmailChange(result.User)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+const listUsers = `
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, is_totp_enabled, role, disabled_at, password_reset_required, deleted_at FROM users
+WHERE ($1::varchar IS NULL OR role = $1 OR EXISTS (
+    SELECT 1 FROM user_roles WHERE user_roles.username = users.username AND user_roles.role = $1
+))
+AND ($2::boolean IS NULL OR is_email_verified = $2)
+AND ($3::timestamptz IS NULL OR created_at >= $3)
+AND ($4::timestamptz IS NULL OR created_at < $4)
+ORDER BY created_at DESC, username
+LIMIT $5
+OFFSET $6
+`
+
+type ListUsers struct {
+	Role            *string    `json:"role"`
+	IsEmailVerified *bool      `json:"is_email_verified"`
+	CreatedAfter    *time.Time `json:"created_after"`
+	CreatedBefore   *time.Time `json:"created_before"`
+	Limit           int32      `json:"limit"`
+	Offset          int32      `json:"offset"`
+}
+
+// ListUsers returns the newest users first. Role matches the primary role and
+// the other roles of the users.
+func (u *UserRepository) ListUsers(ctx context.Context, arg ListUsers) ([]*domain.User, error) {
+	args := []any{
+		util.StringToText(arg.Role),
+		util.BoolToBool(arg.IsEmailVerified),
+		util.TimeToTimestamptz(arg.CreatedAfter),
+		util.TimeToTimestamptz(arg.CreatedBefore),
+		arg.Limit,
+		arg.Offset,
+	}
+
+	rows, _ := u.connPool.Query(ctx, listUsers, args...)
+
+	users, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.User])
+	if err != nil {
+		return nil, getUserError(err, domain.ErrReadUser, "failed to list users")
+	}
+
+	return users, nil
+}
+
+const setUserRole = `
+UPDATE users
+SET role = $2
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, is_totp_enabled, role, disabled_at, password_reset_required, deleted_at
+`
+
+func (u *UserRepository) SetUserRole(ctx context.Context, username string, role string) (*domain.User, error) {
+	rows, _ := u.connPool.Query(ctx, setUserRole, username, role)
+
+	user, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.User])
+	if err != nil {
+		return nil, getUserError(err, domain.ErrUpdateUser, "failed to set user role")
+	}
+
+	return user, nil
+}
+
+const setUserDisabled = `
+UPDATE users
+SET disabled_at = CASE WHEN $2::boolean THEN COALESCE(disabled_at, now()) END
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, is_totp_enabled, role, disabled_at, password_reset_required, deleted_at
+`
+
+// SetUserDisabled disables or enables an account. Disabling an account that
+// is already disabled keeps the original time.
+func (u *UserRepository) SetUserDisabled(ctx context.Context, username string, disabled bool) (*domain.User, error) {
+	rows, _ := u.connPool.Query(ctx, setUserDisabled, username, disabled)
+
+	user, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.User])
+	if err != nil {
+		return nil, getUserError(err, domain.ErrUpdateUser, "failed to set user disabled")
+	}
+
+	return user, nil
+}
+
+const requirePasswordReset = `
+UPDATE users
+SET password_reset_required = true
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, is_totp_enabled, role, disabled_at, password_reset_required, deleted_at
+`
+
+// RequirePasswordReset refuses the logins of a user until their password
+// changes, which clears the flag.
+func (u *UserRepository) RequirePasswordReset(ctx context.Context, username string) (*domain.User, error) {
+	rows, _ := u.connPool.Query(ctx, requirePasswordReset, username)
+
+	user, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.User])
+	if err != nil {
+		return nil, getUserError(err, domain.ErrUpdateUser, "failed to require password reset")
+	}
+
+	return user, nil
+}
+
+const deleteUser = `
+DELETE FROM users
+WHERE username = $1
+`
+
+// DeleteUser deletes a user with their sessions, codes, credentials and
+// external identities. Users who created service accounts return
+// domain.ErrUserInUse.
+func (u *UserRepository) DeleteUser(ctx context.Context, username string) error {
+	result, err := u.connPool.Exec(ctx, deleteUser, username)
+	if err != nil {
+		return getUserError(err, domain.ErrDeleteUser, "failed to delete user")
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+const softDeleteUser = `
+UPDATE users
+SET deleted_at = COALESCE(deleted_at, now())
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, is_totp_enabled, role, disabled_at, password_reset_required, deleted_at
+`
+
+// SoftDeleteUser marks the account of a user as deleted. Deleting an account
+// that is already deleted keeps the original time.
+func (u *UserRepository) SoftDeleteUser(ctx context.Context, username string) (*domain.User, error) {
+	rows, _ := u.connPool.Query(ctx, softDeleteUser, username)
+
+	user, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.User])
+	if err != nil {
+		return nil, getUserError(err, domain.ErrUpdateUser, "failed to soft delete user")
+	}
+
+	return user, nil
+}
+
+const getDeletedUserForUpdate = `
+SELECT username FROM users
+WHERE username = $1 AND deleted_at IS NOT NULL
+FOR UPDATE
+`
+
+// setPurgedUser lets the transaction delete the auth events of the purged
+// user, which the auth_events triggers refuse to delete otherwise.
+const setPurgedUser = `
+SELECT set_config('auth.purged_user', $1, true)
+`
+
+// purgeUserData are the rows about a user deleted by PurgeUserTx, whether the
+// users row is deleted or anonymized.
+var purgeUserData = []string{
+	`DELETE FROM sessions WHERE username = $1`,
+	`DELETE FROM verify_emails WHERE username = $1`,
+	`DELETE FROM reset_passwords WHERE username = $1`,
+	`DELETE FROM recovery_codes WHERE username = $1`,
+	`DELETE FROM webauthn_credentials WHERE username = $1`,
+	`DELETE FROM webauthn_sessions WHERE username = $1`,
+	`DELETE FROM webauthn_user_handles WHERE username = $1`,
+	`DELETE FROM external_identities WHERE username = $1`,
+	`DELETE FROM oauth_authorization_codes WHERE username = $1`,
+	`DELETE FROM user_roles WHERE username = $1`,
+	`DELETE FROM account_lockouts WHERE username = $1`,
+	`DELETE FROM login_failures WHERE scope = 'username' AND subject = $1`,
+	`DELETE FROM auth_events WHERE username = $1`,
+}
+
+const hasCreatedServiceAccounts = `
+SELECT EXISTS (SELECT 1 FROM service_accounts WHERE created_by = $1)
+`
+
+const anonymizeUser = `
+UPDATE users
+SET
+    hashed_password = '',
+    full_name = '',
+    email = username || '@deleted.invalid',
+    is_email_verified = false,
+    totp_secret = NULL,
+    is_totp_enabled = false
+WHERE
+    username = $1
+`
+
+type PurgeUserTxResult struct {
+	// Anonymized is set when the users row was kept, because the user created
+	// service accounts, and its personal data cleared.
+	Anonymized bool `json:"anonymized"`
+}
+
+// PurgeUserTx deletes a soft deleted user with their sessions, codes,
+// credentials and external identities. The users row of a user who created
+// service accounts is anonymized instead. Users that are not soft deleted
+// return domain.ErrUserNotFound.
+func (u *UserRepository) PurgeUserTx(ctx context.Context, username string) (PurgeUserTxResult, error) {
+	var result PurgeUserTxResult
+
+	err := execTx(ctx, u.connPool, func(tx pgx.Tx) error {
+		rows, _ := tx.Query(ctx, getDeletedUserForUpdate, username)
+
+		_, err := pgx.CollectOneRow(rows, pgx.RowTo[string])
+		if err != nil {
+			return getUserError(err, domain.ErrDeleteUser, "failed to get deleted user")
+		}
+
+		_, err = tx.Exec(ctx, setPurgedUser, username)
+		if err != nil {
+			return getUserError(err, domain.ErrDeleteUser, "failed to purge user data")
+		}
+
+		for _, query := range purgeUserData {
+			_, err = tx.Exec(ctx, query, username)
+			if err != nil {
+				return getUserError(err, domain.ErrDeleteUser, "failed to purge user data")
+			}
+		}
+
+		rows, _ = tx.Query(ctx, hasCreatedServiceAccounts, username)
+
+		result.Anonymized, err = pgx.CollectOneRow(rows, pgx.RowTo[bool])
+		if err != nil {
+			return getUserError(err, domain.ErrDeleteUser, "failed to check service accounts")
+		}
+
+		query := deleteUser
+		if result.Anonymized {
+			query = anonymizeUser
+		}
+
+		_, err = tx.Exec(ctx, query, username)
+		if err != nil {
+			return getUserError(err, domain.ErrDeleteUser, "failed to purge user")
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
 func getUserError(err error, defaultReturn error, msg string) error {
 	if errors.Is(err, ErrRecordNotFound) {
 		return domain.ErrUserNotFound
 	}
 
 	if pgError := GetPgError(err); pgError != nil {
-		if pgError.Code == UniqueViolation {
+		switch pgError.Code {
+		case UniqueViolation:
 			switch pgError.ConstraintName {
 			case "users_pkey":
 				return domain.ErrUsernameAlreadyExist
 			case "users_email_key":
 				return domain.ErrEmailAlreadyExist
 			}
+		case ForeignKeyViolation:
+			switch pgError.ConstraintName {
+			case "users_role_fkey":
+				return domain.ErrRoleNotFound
+			case "service_accounts_created_by_fkey":
+				return domain.ErrUserInUse
+			}
 		}
 	}
 
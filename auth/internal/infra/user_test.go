@@ -144,6 +144,24 @@ func TestGetUser(t *testing.T) {
 	require.WithinDuration(t, user1.CreatedAt, user2.CreatedAt, time.Second)
 }
 
+func TestGetUserByEmail(t *testing.T) {
+	user1 := createRandomUser(t)
+
+	user2, err := repositories.User().GetUserByEmail(context.Background(), user1.Email)
+	require.NoError(t, err)
+	require.NotEmpty(t, user2)
+
+	require.Equal(t, user1.Username, user2.Username)
+	require.Equal(t, user1.Email, user2.Email)
+}
+
+func TestUserByEmailNotFound(t *testing.T) {
+	user, err := repositories.User().GetUserByEmail(context.Background(), util.RandomEmail())
+	require.Error(t, err)
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+	require.Nil(t, user)
+}
+
 func TestUserNotFound(t *testing.T) {
 	user, err := repositories.User().GetUser(context.Background(), "not found")
 	require.Error(t, err)
@@ -224,3 +242,257 @@ func TestUpdateUserAllFields(t *testing.T) {
 	require.Equal(t, newFullName, updatedUser.FullName)
 	require.Equal(t, newEmail, updatedUser.Email)
 }
+
+func TestUpdateUserTxEmailChange(t *testing.T) {
+	user := createRandomUser(t)
+
+	isEmailVerified := true
+	_, err := repositories.User().UpdateUser(context.Background(), UpdateUser{
+		Username:        user.Username,
+		IsEmailVerified: &isEmailVerified,
+	})
+	require.NoError(t, err)
+
+	var afterEmailChange *domain.User
+	newEmail := util.RandomEmail()
+	result, err := repositories.User().UpdateUserTx(context.Background(), UpdateUserTx{
+		UpdateUser: UpdateUser{
+			Username: user.Username,
+			Email:    &newEmail,
+		},
+		AfterEmailChange: func(user domain.User) error {
+			afterEmailChange = &user
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, result.EmailChanged)
+	require.Equal(t, newEmail, result.User.Email)
+	require.False(t, result.User.IsEmailVerified)
+	require.NotNil(t, afterEmailChange)
+}
+
+func TestUpdateUserTxSameEmail(t *testing.T) {
+	user := createRandomUser(t)
+
+	result, err := repositories.User().UpdateUserTx(context.Background(), UpdateUserTx{
+		UpdateUser: UpdateUser{
+			Username: user.Username,
+			Email:    &user.Email,
+		},
+		AfterEmailChange: func(user domain.User) error {
+			return fmt.Errorf("email did not change")
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, result.EmailChanged)
+}
+
+func TestUpdateUserTxRollBack(t *testing.T) {
+	user := createRandomUser(t)
+
+	newEmail := util.RandomEmail()
+	_, err := repositories.User().UpdateUserTx(context.Background(), UpdateUserTx{
+		UpdateUser: UpdateUser{
+			Username: user.Username,
+			Email:    &newEmail,
+		},
+		AfterEmailChange: func(user domain.User) error {
+			return fmt.Errorf("failed to enqueue task")
+		},
+	})
+	require.Error(t, err)
+
+	storedUser, err := repositories.User().GetUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, user.Email, storedUser.Email)
+}
+
+func TestListUsers(t *testing.T) {
+	createdAfter := time.Now().Add(-time.Second)
+	user := createRandomUser(t)
+
+	role := createRandomRole(t)
+	_, err := repositories.Role().AddUserRole(context.Background(), UserRoleParams{Username: user.Username, Role: role.Name})
+	require.NoError(t, err)
+
+	users, err := repositories.User().ListUsers(context.Background(), ListUsers{
+		Role:         &role.Name,
+		CreatedAfter: &createdAfter,
+		Limit:        10,
+	})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, user.Username, users[0].Username)
+
+	verified := true
+	users, err = repositories.User().ListUsers(context.Background(), ListUsers{
+		Role:            &role.Name,
+		IsEmailVerified: &verified,
+		Limit:           10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, users)
+
+	createUserTestCount := 3
+	for range createUserTestCount {
+		createRandomUser(t)
+	}
+
+	users, err = repositories.User().ListUsers(context.Background(), ListUsers{CreatedAfter: &createdAfter, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+
+	page2, err := repositories.User().ListUsers(context.Background(), ListUsers{CreatedAfter: &createdAfter, Limit: 2, Offset: 2})
+	require.NoError(t, err)
+	require.NotEmpty(t, page2)
+	require.NotEqual(t, users[0].Username, page2[0].Username)
+}
+
+func TestSetUserRole(t *testing.T) {
+	user := createRandomUser(t)
+
+	updated, err := repositories.User().SetUserRole(context.Background(), user.Username, domain.AdminRole)
+	require.NoError(t, err)
+	require.Equal(t, domain.AdminRole, updated.Role)
+
+	_, err = repositories.User().SetUserRole(context.Background(), user.Username, util.RandomUsername())
+	require.ErrorIs(t, err, domain.ErrRoleNotFound)
+
+	_, err = repositories.User().SetUserRole(context.Background(), util.RandomUsername(), domain.AdminRole)
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestSetUserDisabled(t *testing.T) {
+	user := createRandomUser(t)
+	require.False(t, user.IsDisabled())
+
+	disabled, err := repositories.User().SetUserDisabled(context.Background(), user.Username, true)
+	require.NoError(t, err)
+	require.True(t, disabled.IsDisabled())
+
+	again, err := repositories.User().SetUserDisabled(context.Background(), user.Username, true)
+	require.NoError(t, err)
+	require.Equal(t, disabled.DisabledAt, again.DisabledAt)
+
+	enabled, err := repositories.User().SetUserDisabled(context.Background(), user.Username, false)
+	require.NoError(t, err)
+	require.False(t, enabled.IsDisabled())
+}
+
+func TestRequirePasswordReset(t *testing.T) {
+	user := createRandomUser(t)
+
+	updated, err := repositories.User().RequirePasswordReset(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.True(t, updated.PasswordResetRequired)
+
+	fullName := util.RandomUsername()
+	updated, err = repositories.User().UpdateUser(context.Background(), UpdateUser{Username: user.Username, FullName: &fullName})
+	require.NoError(t, err)
+	require.True(t, updated.PasswordResetRequired)
+
+	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	updated, err = repositories.User().UpdateUser(context.Background(), UpdateUser{Username: user.Username, HashedPassword: &hashedPassword})
+	require.NoError(t, err)
+	require.False(t, updated.PasswordResetRequired)
+}
+
+func TestDeleteUser(t *testing.T) {
+	session := createRandomSession(t)
+
+	err := repositories.User().DeleteUser(context.Background(), session.Username)
+	require.NoError(t, err)
+
+	_, err = repositories.User().GetUser(context.Background(), session.Username)
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	_, err = repositories.Session().GetSession(context.Background(), session.ID)
+	require.ErrorIs(t, err, domain.ErrSessionNotFound)
+
+	err = repositories.User().DeleteUser(context.Background(), session.Username)
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	account := createRandomServiceAccount(t)
+	err = repositories.User().DeleteUser(context.Background(), account.CreatedBy)
+	require.ErrorIs(t, err, domain.ErrUserInUse)
+}
+
+func TestSoftDeleteUser(t *testing.T) {
+	user := createRandomUser(t)
+
+	deleted, err := repositories.User().SoftDeleteUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.True(t, deleted.IsDeleted())
+
+	again, err := repositories.User().SoftDeleteUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.WithinDuration(t, *deleted.DeletedAt, *again.DeletedAt, time.Microsecond)
+
+	_, err = repositories.User().SoftDeleteUser(context.Background(), util.RandomUsername())
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestPurgeUserTx(t *testing.T) {
+	session := createRandomSession(t)
+	createRandomAuthEvent(t, session.Username, domain.AuthEventLogin, domain.AuthEventSuccess)
+	otherEvent := createRandomAuthEvent(t, util.RandomUsername(), domain.AuthEventLogin, domain.AuthEventSuccess)
+
+	_, err := repositories.User().PurgeUserTx(context.Background(), session.Username)
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	_, err = repositories.User().SoftDeleteUser(context.Background(), session.Username)
+	require.NoError(t, err)
+
+	result, err := repositories.User().PurgeUserTx(context.Background(), session.Username)
+	require.NoError(t, err)
+	require.False(t, result.Anonymized)
+
+	_, err = repositories.User().GetUser(context.Background(), session.Username)
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	_, err = repositories.Session().GetSession(context.Background(), session.ID)
+	require.ErrorIs(t, err, domain.ErrSessionNotFound)
+
+	events, err := repositories.AuthEvent().ListAuthEvents(context.Background(), ListAuthEvents{Username: &session.Username, Limit: 5})
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	events, err = repositories.AuthEvent().ListAuthEvents(context.Background(), ListAuthEvents{Username: &otherEvent.Username, Limit: 5})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+}
+
+func TestPurgeUserTxAnonymize(t *testing.T) {
+	account := createRandomServiceAccount(t)
+
+	_, err := repositories.User().SoftDeleteUser(context.Background(), account.CreatedBy)
+	require.NoError(t, err)
+
+	result, err := repositories.User().PurgeUserTx(context.Background(), account.CreatedBy)
+	require.NoError(t, err)
+	require.True(t, result.Anonymized)
+
+	user, err := repositories.User().GetUser(context.Background(), account.CreatedBy)
+	require.NoError(t, err)
+	require.Empty(t, user.FullName)
+	require.Empty(t, user.HashedPassword)
+	require.Equal(t, account.CreatedBy+"@deleted.invalid", user.Email)
+	require.True(t, user.IsDeleted())
+}
+
+func TestExportUserData(t *testing.T) {
+	session := createRandomSession(t)
+
+	export, err := repositories.User().ExportUserData(context.Background(), session.Username)
+	require.NoError(t, err)
+	require.Equal(t, session.Username, export.Profile.Username)
+	require.Len(t, export.Sessions, 1)
+	require.Equal(t, session.ID, export.Sessions[0].ID)
+	require.Empty(t, export.VerifyEmails)
+
+	_, err = repositories.User().ExportUserData(context.Background(), util.RandomUsername())
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+}
@@ -2,6 +2,7 @@ package infra
 
 import (
 	"context"
+	"errors"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
@@ -17,6 +18,10 @@ func NewVerifyEmailRepository(connPool DBTX) *VerifyEmailRepository {
 }
 
 func getVerifyEmailError(err error, msg string) error {
+	if errors.Is(err, ErrRecordNotFound) {
+		return domain.ErrVerifyEmailNotFound
+	}
+
 	if pgError := GetPgError(err); pgError != nil {
 		if pgError.Code == ForeignKeyViolation {
 			switch pgError.ConstraintName {
@@ -63,6 +68,22 @@ func (v *VerifyEmailRepository) CreateVerifyEmail(ctx context.Context, arg Creat
 	return verifyEmail, err
 }
 
+const getVerifyEmail = `
+SELECT id, username, email, secret_code, is_used, created_at, expired_at FROM verify_emails
+WHERE id = $1
+`
+
+func (v *VerifyEmailRepository) GetVerifyEmail(ctx context.Context, id int64) (*domain.VerifyEmail, error) {
+	rows, _ := v.connPool.Query(ctx, getVerifyEmail, id)
+
+	verifyEmail, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.VerifyEmail])
+	if err != nil {
+		return nil, getVerifyEmailError(err, "failed to get verify email")
+	}
+
+	return verifyEmail, nil
+}
+
 const updateVerifyEmail = `-- name: UpdateVerifyEmail :one
 UPDATE verify_emails 
 SET is_used = true
@@ -119,9 +140,19 @@ func (v *VerifyEmailRepository) VerifyEmailTx(ctx context.Context, arg VerifyEma
 			return err
 		}
 
+		userRepository := NewUserRepository(tx)
+		user, err := userRepository.getUserForUpdate(ctx, result.VerifyEmail.Username)
+		if err != nil {
+			return err
+		}
+
+		// the code only proves ownership of the address it was sent to
+		if user.Email != result.VerifyEmail.Email {
+			return domain.ErrVerifyEmailMismatch
+		}
+
 		isEmailVerified := true
 
-		userRepository := NewUserRepository(tx)
 		result.User, err = userRepository.UpdateUser(ctx, UpdateUser{
 			Username:        result.VerifyEmail.Username,
 			IsEmailVerified: &isEmailVerified,
@@ -54,6 +54,18 @@ func TestCreateVerifyEmailUsernameInvalid(t *testing.T) {
 	require.Nil(t, verifyEmail)
 }
 
+func TestGetVerifyEmail(t *testing.T) {
+	verifyEmail := createRandomVerifyEmail(t)
+
+	got, err := repositories.VerifyEmail().GetVerifyEmail(context.Background(), verifyEmail.ID)
+	require.NoError(t, err)
+	require.Equal(t, verifyEmail.Username, got.Username)
+	require.Equal(t, verifyEmail.Email, got.Email)
+
+	_, err = repositories.VerifyEmail().GetVerifyEmail(context.Background(), verifyEmail.ID+1000000)
+	require.ErrorIs(t, err, domain.ErrVerifyEmailNotFound)
+}
+
 func TestUpdateVerifyEmail(t *testing.T) {
 	verifyEmail := createRandomVerifyEmail(t)
 
@@ -69,3 +81,36 @@ func TestUpdateVerifyEmail(t *testing.T) {
 	require.Equal(t, updatedVerifyEmail.SecretCode, arg.SecretCode)
 	require.True(t, updatedVerifyEmail.IsUsed)
 }
+
+func TestVerifyEmailTx(t *testing.T) {
+	verifyEmail := createRandomVerifyEmail(t)
+
+	result, err := repositories.VerifyEmail().VerifyEmailTx(context.Background(), VerifyEmailTx{
+		EmailId:     verifyEmail.ID,
+		SecreteCode: verifyEmail.SecretCode,
+	})
+	require.NoError(t, err)
+	require.True(t, result.VerifyEmail.IsUsed)
+	require.True(t, result.User.IsEmailVerified)
+}
+
+func TestVerifyEmailTxEmailChanged(t *testing.T) {
+	verifyEmail := createRandomVerifyEmail(t)
+
+	newEmail := util.RandomEmail()
+	_, err := repositories.User().UpdateUser(context.Background(), UpdateUser{
+		Username: verifyEmail.Username,
+		Email:    &newEmail,
+	})
+	require.NoError(t, err)
+
+	_, err = repositories.VerifyEmail().VerifyEmailTx(context.Background(), VerifyEmailTx{
+		EmailId:     verifyEmail.ID,
+		SecreteCode: verifyEmail.SecretCode,
+	})
+	require.ErrorIs(t, err, domain.ErrVerifyEmailMismatch)
+
+	user, err := repositories.User().GetUser(context.Background(), verifyEmail.Username)
+	require.NoError(t, err)
+	require.False(t, user.IsEmailVerified)
+}
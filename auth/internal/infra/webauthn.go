@@ -0,0 +1,285 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+type WebauthnRepository struct {
+	connPool DBTX
+}
+
+func NewWebauthnRepository(connPool DBTX) *WebauthnRepository {
+	return &WebauthnRepository{connPool}
+}
+
+func getWebauthnError(err error, notFound error, msg string) error {
+	if errors.Is(err, ErrRecordNotFound) {
+		return notFound
+	}
+
+	if pgError := GetPgError(err); pgError != nil {
+		switch pgError.Code {
+		case UniqueViolation:
+			if pgError.ConstraintName == "webauthn_credentials_pkey" {
+				return domain.ErrWebauthnCredentialAlreadyExist
+			}
+		case ForeignKeyViolation:
+			switch pgError.ConstraintName {
+			case "webauthn_credentials_username_fkey", "webauthn_sessions_username_fkey", "webauthn_user_handles_username_fkey":
+				return domain.ErrUserNotFound
+			}
+		}
+	}
+
+	log.Error().Err(err).Msg(msg)
+	return err
+}
+
+const deleteExpiredWebauthnSessions = `
+DELETE FROM webauthn_sessions
+WHERE expires_at < now()
+`
+
+const createWebauthnSession = `
+INSERT INTO webauthn_sessions (
+    id,
+    username,
+    ceremony,
+    data,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, username, ceremony, data, expires_at, created_at
+`
+
+type CreateWebauthnSession struct {
+	ID        uuid.UUID `json:"id"`
+	Username  *string   `json:"username"`
+	Ceremony  string    `json:"ceremony"`
+	Data      []byte    `json:"data"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CreateWebauthnSession stores the challenge of a ceremony, so it can be
+// finished by any instance of the service. Expired sessions that were never
+// finished are deleted on the way.
+func (r *WebauthnRepository) CreateWebauthnSession(ctx context.Context, arg CreateWebauthnSession) (*domain.WebauthnSession, error) {
+	_, err := r.connPool.Exec(ctx, deleteExpiredWebauthnSessions)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to delete expired webauthn sessions")
+		return nil, err
+	}
+
+	rows, _ := r.connPool.Query(ctx, createWebauthnSession, arg.ID, arg.Username, arg.Ceremony, arg.Data, arg.ExpiresAt)
+
+	session, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.WebauthnSession])
+	if err != nil {
+		return nil, getWebauthnError(err, domain.ErrWebauthnSessionNotFound, "failed to create webauthn session")
+	}
+
+	return session, nil
+}
+
+const consumeWebauthnSession = `
+DELETE FROM webauthn_sessions
+WHERE id = $1
+AND ceremony = $2
+AND expires_at > now()
+RETURNING id, username, ceremony, data, expires_at, created_at
+`
+
+type ConsumeWebauthnSession struct {
+	ID       uuid.UUID `json:"id"`
+	Ceremony string    `json:"ceremony"`
+}
+
+// ConsumeWebauthnSession deletes and returns a session, so each challenge can
+// only be answered once. Unknown and expired sessions return
+// domain.ErrWebauthnSessionNotFound.
+func (r *WebauthnRepository) ConsumeWebauthnSession(ctx context.Context, arg ConsumeWebauthnSession) (*domain.WebauthnSession, error) {
+	rows, _ := r.connPool.Query(ctx, consumeWebauthnSession, arg.ID, arg.Ceremony)
+
+	session, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.WebauthnSession])
+	if err != nil {
+		return nil, getWebauthnError(err, domain.ErrWebauthnSessionNotFound, "failed to consume webauthn session")
+	}
+
+	return session, nil
+}
+
+const createWebauthnCredential = `
+INSERT INTO webauthn_credentials (
+    id,
+    username,
+    public_key,
+    attestation_type,
+    transports,
+    aaguid,
+    sign_count,
+    backup_eligible,
+    backup_state
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, username, public_key, attestation_type, transports, aaguid, sign_count, clone_warning, backup_eligible, backup_state, last_used_at, created_at
+`
+
+type CreateWebauthnCredential struct {
+	ID              []byte   `json:"id"`
+	Username        string   `json:"username"`
+	PublicKey       []byte   `json:"public_key"`
+	AttestationType string   `json:"attestation_type"`
+	Transports      []string `json:"transports"`
+	Aaguid          []byte   `json:"aaguid"`
+	SignCount       int64    `json:"sign_count"`
+	BackupEligible  bool     `json:"backup_eligible"`
+	BackupState     bool     `json:"backup_state"`
+}
+
+func (r *WebauthnRepository) CreateWebauthnCredential(ctx context.Context, arg CreateWebauthnCredential) (*domain.WebauthnCredential, error) {
+	args := []any{
+		arg.ID,
+		arg.Username,
+		arg.PublicKey,
+		arg.AttestationType,
+		arg.Transports,
+		arg.Aaguid,
+		arg.SignCount,
+		arg.BackupEligible,
+		arg.BackupState,
+	}
+
+	rows, _ := r.connPool.Query(ctx, createWebauthnCredential, args...)
+
+	credential, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.WebauthnCredential])
+	if err != nil {
+		return nil, getWebauthnError(err, domain.ErrWebauthnCredentialNotFound, "failed to create webauthn credential")
+	}
+
+	return credential, nil
+}
+
+const listWebauthnCredentials = `
+SELECT id, username, public_key, attestation_type, transports, aaguid, sign_count, clone_warning, backup_eligible, backup_state, last_used_at, created_at
+FROM webauthn_credentials
+WHERE username = $1
+ORDER BY created_at
+`
+
+func (r *WebauthnRepository) ListWebauthnCredentials(ctx context.Context, username string) ([]*domain.WebauthnCredential, error) {
+	rows, _ := r.connPool.Query(ctx, listWebauthnCredentials, username)
+
+	credentials, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.WebauthnCredential])
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list webauthn credentials")
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
+const useWebauthnCredential = `
+UPDATE webauthn_credentials
+SET
+    sign_count = $2,
+    backup_state = $3,
+    last_used_at = now()
+WHERE id = $1
+AND clone_warning = false
+AND (sign_count < $2 OR (sign_count = 0 AND $2 = 0))
+RETURNING id, username, public_key, attestation_type, transports, aaguid, sign_count, clone_warning, backup_eligible, backup_state, last_used_at, created_at
+`
+
+type UseWebauthnCredential struct {
+	ID          []byte `json:"id"`
+	SignCount   int64  `json:"sign_count"`
+	BackupState bool   `json:"backup_state"`
+}
+
+// UseWebauthnCredential records an accepted assertion. Authenticators without
+// a counter always report zero, the others must report a greater counter than
+// the stored one or domain.ErrWebauthnSignCountNotIncreased is returned.
+func (r *WebauthnRepository) UseWebauthnCredential(ctx context.Context, arg UseWebauthnCredential) (*domain.WebauthnCredential, error) {
+	rows, _ := r.connPool.Query(ctx, useWebauthnCredential, arg.ID, arg.SignCount, arg.BackupState)
+
+	credential, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.WebauthnCredential])
+	if err != nil {
+		return nil, getWebauthnError(err, domain.ErrWebauthnSignCountNotIncreased, "failed to use webauthn credential")
+	}
+
+	return credential, nil
+}
+
+const flagWebauthnCloneWarning = `
+UPDATE webauthn_credentials
+SET clone_warning = true
+WHERE id = $1
+`
+
+// FlagWebauthnCloneWarning marks a credential that may have been cloned, it
+// can no longer be used to login.
+func (r *WebauthnRepository) FlagWebauthnCloneWarning(ctx context.Context, id []byte) error {
+	result, err := r.connPool.Exec(ctx, flagWebauthnCloneWarning, id)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to flag webauthn clone warning")
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrWebauthnCredentialNotFound
+	}
+
+	return nil
+}
+
+const createWebauthnUserHandle = `
+INSERT INTO webauthn_user_handles (
+    handle,
+    username
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (username) DO UPDATE
+SET username = EXCLUDED.username
+RETURNING handle, username, created_at
+`
+
+type CreateWebauthnUserHandle struct {
+	Handle   []byte `json:"handle"`
+	Username string `json:"username"`
+}
+
+// CreateWebauthnUserHandle stores the user handle of a user, unless they
+// already have one, which is returned instead.
+func (r *WebauthnRepository) CreateWebauthnUserHandle(ctx context.Context, arg CreateWebauthnUserHandle) (*domain.WebauthnUserHandle, error) {
+	rows, _ := r.connPool.Query(ctx, createWebauthnUserHandle, arg.Handle, arg.Username)
+
+	userHandle, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.WebauthnUserHandle])
+	if err != nil {
+		return nil, getWebauthnError(err, domain.ErrWebauthnUserHandleNotFound, "failed to create webauthn user handle")
+	}
+
+	return userHandle, nil
+}
+
+const getWebauthnUserHandle = `
+SELECT handle, username, created_at FROM webauthn_user_handles
+WHERE handle = $1 LIMIT 1
+`
+
+func (r *WebauthnRepository) GetWebauthnUserHandle(ctx context.Context, handle []byte) (*domain.WebauthnUserHandle, error) {
+	rows, _ := r.connPool.Query(ctx, getWebauthnUserHandle, handle)
+
+	userHandle, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.WebauthnUserHandle])
+	if err != nil {
+		return nil, getWebauthnError(err, domain.ErrWebauthnUserHandleNotFound, "failed to get webauthn user handle")
+	}
+
+	return userHandle, nil
+}
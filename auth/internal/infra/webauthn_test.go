@@ -0,0 +1,162 @@
+package infra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomWebauthnCredential(t *testing.T, username string) *domain.WebauthnCredential {
+	t.Helper()
+
+	arg := CreateWebauthnCredential{
+		ID:              []byte(util.RandomString(16)),
+		Username:        username,
+		PublicKey:       []byte(util.RandomString(64)),
+		AttestationType: "none",
+		Transports:      []string{"internal", "hybrid"},
+		Aaguid:          make([]byte, 16),
+		SignCount:       1,
+		BackupEligible:  true,
+	}
+
+	credential, err := repositories.Webauthn().CreateWebauthnCredential(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, credential.ID)
+	require.Equal(t, arg.Username, credential.Username)
+	require.Equal(t, arg.PublicKey, credential.PublicKey)
+	require.Equal(t, arg.Transports, credential.Transports)
+	require.Equal(t, arg.SignCount, credential.SignCount)
+	require.True(t, credential.BackupEligible)
+	require.False(t, credential.CloneWarning)
+	require.Nil(t, credential.LastUsedAt)
+
+	return credential
+}
+
+func TestCreateWebauthnCredential(t *testing.T) {
+	user := createRandomUser(t)
+	credential := createRandomWebauthnCredential(t, user.Username)
+
+	_, err := repositories.Webauthn().CreateWebauthnCredential(context.Background(), CreateWebauthnCredential{
+		ID:              credential.ID,
+		Username:        user.Username,
+		PublicKey:       credential.PublicKey,
+		AttestationType: "none",
+		Aaguid:          credential.Aaguid,
+	})
+	require.ErrorIs(t, err, domain.ErrWebauthnCredentialAlreadyExist)
+
+	credentials, err := repositories.Webauthn().ListWebauthnCredentials(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, credentials, 1)
+	require.Equal(t, credential.ID, credentials[0].ID)
+}
+
+func TestUseWebauthnCredential(t *testing.T) {
+	user := createRandomUser(t)
+	credential := createRandomWebauthnCredential(t, user.Username)
+
+	used, err := repositories.Webauthn().UseWebauthnCredential(context.Background(), UseWebauthnCredential{ID: credential.ID, SignCount: 2, BackupState: true})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), used.SignCount)
+	require.True(t, used.BackupState)
+	require.NotNil(t, used.LastUsedAt)
+
+	_, err = repositories.Webauthn().UseWebauthnCredential(context.Background(), UseWebauthnCredential{ID: credential.ID, SignCount: 2})
+	require.ErrorIs(t, err, domain.ErrWebauthnSignCountNotIncreased)
+
+	err = repositories.Webauthn().FlagWebauthnCloneWarning(context.Background(), credential.ID)
+	require.NoError(t, err)
+
+	_, err = repositories.Webauthn().UseWebauthnCredential(context.Background(), UseWebauthnCredential{ID: credential.ID, SignCount: 3})
+	require.ErrorIs(t, err, domain.ErrWebauthnSignCountNotIncreased)
+}
+
+func TestConsumeWebauthnSession(t *testing.T) {
+	user := createRandomUser(t)
+
+	session, err := repositories.Webauthn().CreateWebauthnSession(context.Background(), CreateWebauthnSession{
+		ID:        uuid.New(),
+		Username:  &user.Username,
+		Ceremony:  domain.WebauthnRegistration,
+		Data:      []byte(`{"challenge":"abc"}`),
+		ExpiresAt: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.Username, *session.Username)
+
+	_, err = repositories.Webauthn().ConsumeWebauthnSession(context.Background(), ConsumeWebauthnSession{ID: session.ID, Ceremony: domain.WebauthnLogin})
+	require.ErrorIs(t, err, domain.ErrWebauthnSessionNotFound)
+
+	consumed, err := repositories.Webauthn().ConsumeWebauthnSession(context.Background(), ConsumeWebauthnSession{ID: session.ID, Ceremony: domain.WebauthnRegistration})
+	require.NoError(t, err)
+	require.Equal(t, session.ID, consumed.ID)
+	require.JSONEq(t, `{"challenge":"abc"}`, string(consumed.Data))
+
+	_, err = repositories.Webauthn().ConsumeWebauthnSession(context.Background(), ConsumeWebauthnSession{ID: session.ID, Ceremony: domain.WebauthnRegistration})
+	require.ErrorIs(t, err, domain.ErrWebauthnSessionNotFound)
+}
+
+func TestConsumeExpiredWebauthnSession(t *testing.T) {
+	session, err := repositories.Webauthn().CreateWebauthnSession(context.Background(), CreateWebauthnSession{
+		ID:        uuid.New(),
+		Ceremony:  domain.WebauthnLogin,
+		Data:      []byte(`{}`),
+		ExpiresAt: time.Now().Add(-time.Second),
+	})
+	require.NoError(t, err)
+	require.Nil(t, session.Username)
+
+	_, err = repositories.Webauthn().ConsumeWebauthnSession(context.Background(), ConsumeWebauthnSession{ID: session.ID, Ceremony: domain.WebauthnLogin})
+	require.ErrorIs(t, err, domain.ErrWebauthnSessionNotFound)
+}
+
+func TestCreateWebauthnUserHandle(t *testing.T) {
+	user := createRandomUser(t)
+
+	arg := CreateWebauthnUserHandle{
+		Handle:   []byte(util.RandomString(64)),
+		Username: user.Username,
+	}
+
+	userHandle, err := repositories.Webauthn().CreateWebauthnUserHandle(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Handle, userHandle.Handle)
+	require.Equal(t, user.Username, userHandle.Username)
+
+	existing, err := repositories.Webauthn().CreateWebauthnUserHandle(context.Background(), CreateWebauthnUserHandle{
+		Handle:   []byte(util.RandomString(64)),
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, arg.Handle, existing.Handle)
+
+	found, err := repositories.Webauthn().GetWebauthnUserHandle(context.Background(), arg.Handle)
+	require.NoError(t, err)
+	require.Equal(t, user.Username, found.Username)
+
+	_, err = repositories.Webauthn().GetWebauthnUserHandle(context.Background(), []byte(user.Username))
+	require.ErrorIs(t, err, domain.ErrWebauthnUserHandleNotFound)
+}
+
+func TestDeleteUserWithWebauthnUserHandle(t *testing.T) {
+	user := createRandomUser(t)
+
+	userHandle, err := repositories.Webauthn().CreateWebauthnUserHandle(context.Background(), CreateWebauthnUserHandle{
+		Handle:   []byte(util.RandomString(64)),
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+
+	err = repositories.User().DeleteUser(context.Background(), user.Username)
+	require.NoError(t, err)
+
+	_, err = repositories.Webauthn().GetWebauthnUserHandle(context.Background(), userHandle.Handle)
+	require.ErrorIs(t, err, domain.ErrWebauthnUserHandleNotFound)
+}
@@ -0,0 +1,92 @@
+package oidc
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application"
+	"github.com/rs/zerolog/log"
+)
+
+type loginPage struct {
+	Client   string
+	Request  application.AuthorizationRequest
+	Username string
+	// MfaToken switches the form to the second factor step.
+	MfaToken string
+	Error    string
+}
+
+var loginTemplate = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Login</title>
+</head>
+<body>
+<h1>Login to {{.Client}}</h1>
+{{if .Error}}<p role="alert">{{.Error}}</p>{{end}}
+<form method="post">
+<input type="hidden" name="response_type" value="{{.Request.ResponseType}}">
+<input type="hidden" name="client_id" value="{{.Request.ClientID}}">
+<input type="hidden" name="redirect_uri" value="{{.Request.RedirectURI}}">
+<input type="hidden" name="scope" value="{{.Request.Scope}}">
+<input type="hidden" name="state" value="{{.Request.State}}">
+<input type="hidden" name="nonce" value="{{.Request.Nonce}}">
+<input type="hidden" name="code_challenge" value="{{.Request.CodeChallenge}}">
+<input type="hidden" name="code_challenge_method" value="{{.Request.CodeChallengeMethod}}">
+{{if .MfaToken}}
+<input type="hidden" name="mfa_token" value="{{.MfaToken}}">
+<label>Authentication or recovery code <input name="code" autocomplete="one-time-code" required autofocus></label>
+{{else}}
+<label>Username <input name="username" value="{{.Username}}" autocomplete="username" required autofocus></label>
+<label>Password <input name="password" type="password" autocomplete="current-password" required></label>
+{{end}}
+<button type="submit">Continue</button>
+</form>
+</body>
+</html>
+`))
+
+var errorTemplate = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Login error</title>
+</head>
+<body>
+<h1>Login error</h1>
+<p>{{.}}</p>
+</body>
+</html>
+`))
+
+// setPageHeaders keeps the pages out of frames, so the login form cannot be
+// used for clickjacking, and out of caches.
+func setPageHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; form-action 'self'; frame-ancestors 'none'")
+}
+
+func renderLogin(w http.ResponseWriter, status int, page loginPage) {
+	setPageHeaders(w)
+	w.WriteHeader(status)
+
+	err := loginTemplate.Execute(w, page)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to render login page")
+	}
+}
+
+func renderError(w http.ResponseWriter, status int, message string) {
+	setPageHeaders(w)
+	w.WriteHeader(status)
+
+	err := errorTemplate.Execute(w, message)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to render error page")
+	}
+}
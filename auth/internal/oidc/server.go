@@ -0,0 +1,356 @@
+// Package oidc serves the OpenID Connect provider endpoints that let other
+// applications login users through this service.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	DiscoveryPath = "/.well-known/openid-configuration"
+	JwksPath      = "/.well-known/jwks.json"
+	AuthorizePath = "/oauth2/authorize"
+	TokenPath     = "/oauth2/token"
+	UserInfoPath  = "/oauth2/userinfo"
+)
+
+type Application interface {
+	ValidateAuthorizationRequest(ctx context.Context, arg application.AuthorizationRequest) (*domain.OauthClient, error)
+	Authorize(ctx context.Context, arg application.Authorize) (*application.AuthorizeResult, error)
+	Token(ctx context.Context, arg application.TokenRequest) (*application.TokenResult, error)
+	UserInfo(ctx context.Context, accessToken string) (*application.UserInfo, error)
+}
+
+type Server struct {
+	application Application
+	issuerURL   string
+}
+
+// NewServer serves the endpoints under issuerURL, which is also the iss claim
+// of the id tokens. The JWKS itself is served next to them at JwksPath.
+func NewServer(application Application, issuerURL string) *Server {
+	return &Server{
+		application: application,
+		issuerURL:   strings.TrimSuffix(issuerURL, "/"),
+	}
+}
+
+func (s *Server) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+DiscoveryPath, s.discovery)
+	mux.HandleFunc("GET "+AuthorizePath, s.authorizeForm)
+	mux.HandleFunc("POST "+AuthorizePath, s.authorize)
+	mux.HandleFunc("POST "+TokenPath, s.token)
+	mux.HandleFunc("GET "+UserInfoPath, s.userInfo)
+	mux.HandleFunc("POST "+UserInfoPath, s.userInfo)
+}
+
+type discoveryDocument struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JwksURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+func (s *Server) discovery(w http.ResponseWriter, r *http.Request) {
+	document := discoveryDocument{
+		Issuer:                            s.issuerURL,
+		AuthorizationEndpoint:             s.issuerURL + AuthorizePath,
+		TokenEndpoint:                     s.issuerURL + TokenPath,
+		UserInfoEndpoint:                  s.issuerURL + UserInfoPath,
+		JwksURI:                           s.issuerURL + JwksPath,
+		ScopesSupported:                   application.SupportedScopes,
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{application.GrantTypeAuthorizationCode, application.GrantTypeRefreshToken},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{"RS256", "ES256", "EdDSA"},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+		ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "name", "preferred_username", "email", "email_verified"},
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeJSON(w, http.StatusOK, document)
+}
+
+// authorizeForm shows the login form of a valid authorization request.
+func (s *Server) authorizeForm(w http.ResponseWriter, r *http.Request) {
+	request := authorizationRequest(r.URL.Query())
+
+	client, err := s.application.ValidateAuthorizationRequest(requestContext(r), request)
+	if err != nil {
+		s.authorizeError(w, r, request, err)
+		return
+	}
+
+	renderLogin(w, http.StatusOK, loginPage{Client: client.Name, Request: request})
+}
+
+// authorize logs in the user and sends them back to the client with the
+// authorization code. Users with TOTP enabled are asked for a code first.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	request := authorizationRequest(r.PostForm)
+	ctx := requestContext(r)
+
+	client, err := s.application.ValidateAuthorizationRequest(ctx, request)
+	if err != nil {
+		s.authorizeError(w, r, request, err)
+		return
+	}
+
+	page := loginPage{Client: client.Name, Request: request, Username: r.PostForm.Get("username")}
+
+	result, err := s.application.Authorize(ctx, application.Authorize{
+		AuthorizationRequest: request,
+		Username:             r.PostForm.Get("username"),
+		Password:             r.PostForm.Get("password"),
+		MfaToken:             r.PostForm.Get("mfa_token"),
+		Code:                 r.PostForm.Get("code"),
+	})
+	if err != nil {
+		message := loginErrorMessage(err)
+		if message == "" {
+			s.authorizeError(w, r, request, err)
+			return
+		}
+
+		// a wrong code keeps the user on the second factor step
+		if errors.Is(err, application.ErrInvalidTotpCode) {
+			page.MfaToken = r.PostForm.Get("mfa_token")
+		}
+
+		page.Error = message
+		renderLogin(w, http.StatusUnauthorized, page)
+		return
+	}
+
+	if result.MfaRequired {
+		page.MfaToken = result.MfaToken
+		renderLogin(w, http.StatusOK, page)
+		return
+	}
+
+	http.Redirect(w, r, result.RedirectURL, http.StatusFound)
+}
+
+// authorizeError reports an OauthError to the client through the redirect_uri,
+// which is only trusted once it was validated. Other errors are shown to the
+// user.
+func (s *Server) authorizeError(w http.ResponseWriter, r *http.Request, request application.AuthorizationRequest, err error) {
+	var oauthErr *application.OauthError
+	if errors.As(err, &oauthErr) {
+		redirectURL, errParse := url.Parse(request.RedirectURI)
+		if errParse == nil {
+			query := redirectURL.Query()
+			query.Set("error", oauthErr.Code)
+			query.Set("error_description", oauthErr.Description)
+			if request.State != "" {
+				query.Set("state", request.State)
+			}
+			redirectURL.RawQuery = query.Encode()
+
+			http.Redirect(w, r, redirectURL.String(), http.StatusFound)
+			return
+		}
+	}
+
+	switch {
+	case errors.Is(err, application.ErrInvalidOauthClient),
+		errors.Is(err, application.ErrInvalidOauthRedirectURI):
+		renderError(w, http.StatusBadRequest, err.Error())
+	default:
+		log.Error().Err(err).Msg("failed to authorize")
+		renderError(w, http.StatusInternalServerError, "something went wrong, please try again")
+	}
+}
+
+// loginErrorMessage is shown on the login form for errors the user can fix,
+// and is empty for any other error.
+func loginErrorMessage(err error) string {
+	var validationErrors validation.Errors
+	var throttledErr *application.LoginThrottledError
+
+	switch {
+	case errors.As(err, &throttledErr):
+		return fmt.Sprintf("Too many failed attempts, try again in %s.", throttledErr.RetryAfter.Round(time.Second))
+	case errors.As(err, &validationErrors),
+		errors.Is(err, application.ErrInvalidLoginPassword),
+		errors.Is(err, application.ErrAccountDeleted),
+		errors.Is(err, domain.ErrUserNotFound):
+		return "Invalid username or password."
+	case errors.Is(err, application.ErrEmailNotVerified):
+		return "Verify your email address before logging in."
+	case errors.Is(err, application.ErrAccountDisabled):
+		return "This account is disabled."
+	case errors.Is(err, application.ErrPasswordResetRequired):
+		return "Reset your password before logging in."
+	case errors.Is(err, application.ErrInvalidTotpCode):
+		return "Invalid code."
+	case errors.Is(err, application.ErrInvalidMfaToken):
+		return "The login expired, please login again."
+	}
+
+	return ""
+}
+
+// token implements the token endpoint. Confidential clients authenticate with
+// client_secret_basic or client_secret_post.
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+
+	err := r.ParseForm()
+	if err != nil {
+		writeOauthError(w, &application.OauthError{Code: application.OauthInvalidRequest, Description: "invalid form"})
+		return
+	}
+
+	clientID, clientSecret, basicAuth := clientCredentials(r)
+
+	result, err := s.application.Token(requestContext(r), application.TokenRequest{
+		GrantType:    r.PostForm.Get("grant_type"),
+		Code:         r.PostForm.Get("code"),
+		RedirectURI:  r.PostForm.Get("redirect_uri"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+		RefreshToken: r.PostForm.Get("refresh_token"),
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	})
+	if err != nil {
+		var oauthErr *application.OauthError
+		if !errors.As(err, &oauthErr) {
+			log.Error().Err(err).Msg("failed to issue oauth token")
+			writeJSON(w, http.StatusInternalServerError, oauthErrorResponse{Error: "server_error"})
+			return
+		}
+
+		if oauthErr.Code == application.OauthInvalidClient && basicAuth {
+			w.Header().Set("WWW-Authenticate", `Basic realm="oauth2"`)
+		}
+
+		writeOauthError(w, oauthErr)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// clientCredentials reads the client of a token request from the basic
+// authorization header, whose values are form encoded, or from the form.
+func clientCredentials(r *http.Request) (string, string, bool) {
+	if username, password, ok := r.BasicAuth(); ok {
+		clientID, errID := url.QueryUnescape(username)
+		clientSecret, errSecret := url.QueryUnescape(password)
+		if errID == nil && errSecret == nil {
+			return clientID, clientSecret, true
+		}
+	}
+
+	return r.PostForm.Get("client_id"), r.PostForm.Get("client_secret"), false
+}
+
+func (s *Server) userInfo(w http.ResponseWriter, r *http.Request) {
+	fields := strings.Fields(r.Header.Get("Authorization"))
+	if len(fields) != 2 || !strings.EqualFold(fields[0], "bearer") {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="oauth2"`)
+		writeJSON(w, http.StatusUnauthorized, oauthErrorResponse{Error: application.OauthInvalidToken, ErrorDescription: "missing bearer token"})
+		return
+	}
+
+	userInfo, err := s.application.UserInfo(requestContext(r), fields[1])
+	if err != nil {
+		var oauthErr *application.OauthError
+		if errors.As(err, &oauthErr) {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error=%q`, oauthErr.Code))
+			writeJSON(w, http.StatusUnauthorized, oauthErrorResponse{Error: oauthErr.Code, ErrorDescription: oauthErr.Description})
+			return
+		}
+
+		log.Error().Err(err).Msg("failed to get user info")
+		writeJSON(w, http.StatusInternalServerError, oauthErrorResponse{Error: "server_error"})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, userInfo)
+}
+
+func authorizationRequest(values url.Values) application.AuthorizationRequest {
+	return application.AuthorizationRequest{
+		ResponseType:        values.Get("response_type"),
+		ClientID:            values.Get("client_id"),
+		RedirectURI:         values.Get("redirect_uri"),
+		Scope:               values.Get("scope"),
+		State:               values.Get("state"),
+		Nonce:               values.Get("nonce"),
+		CodeChallenge:       values.Get("code_challenge"),
+		CodeChallengeMethod: values.Get("code_challenge_method"),
+	}
+}
+
+// requestContext carries the client address and user agent the way the gRPC
+// server does, so logins are throttled and sessions are recorded the same.
+func requestContext(r *http.Request) context.Context {
+	ctx := metadata.NewIncomingContext(r.Context(), metadata.Pairs("user-agent", r.UserAgent()))
+
+	addrPort, err := netip.ParseAddrPort(r.RemoteAddr)
+	if err != nil {
+		return ctx
+	}
+
+	return peer.NewContext(ctx, &peer.Peer{Addr: net.TCPAddrFromAddrPort(addrPort)})
+}
+
+type oauthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+func writeOauthError(w http.ResponseWriter, err *application.OauthError) {
+	status := http.StatusBadRequest
+	if err.Code == application.OauthInvalidClient {
+		status = http.StatusUnauthorized
+	}
+
+	writeJSON(w, status, oauthErrorResponse{Error: err.Code, ErrorDescription: err.Description})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(body)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to write response")
+	}
+}
@@ -0,0 +1,376 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application"
+	mockdb "github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/application/mock"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIssuerURL   = "https://auth.example.com"
+	testRedirectURI = "https://app.example.com/callback"
+)
+
+type testServer struct {
+	url          string
+	user         *domain.User
+	password     string
+	client       *domain.OauthClient
+	clientSecret string
+	privateKey   *rsa.PrivateKey
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	password := util.RandomString(6)
+	hashedPassword, err := util.HashPassword(password)
+	require.NoError(t, err)
+
+	user := &domain.User{
+		Username:        util.RandomUsername(),
+		FullName:        util.RandomUsername(),
+		Email:           util.RandomEmail(),
+		HashedPassword:  hashedPassword,
+		IsEmailVerified: true,
+		Role:            domain.UserRole,
+	}
+
+	clientSecret := util.RandomString(32)
+	secretSum := sha256.Sum256([]byte(clientSecret))
+	hashedSecret := hex.EncodeToString(secretSum[:])
+	client := &domain.OauthClient{
+		ID:           uuid.NewString(),
+		HashedSecret: &hashedSecret,
+		Name:         "Example App",
+		RedirectURIs: []string{testRedirectURI},
+		Scopes:       application.SupportedScopes,
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tokenMaker, err := token.NewAsymmetricJwtToken(util.RandomString(8), privateKey)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	userRepository := mockdb.NewMockUserRepository(ctrl)
+	sessionRepository := mockdb.NewMockSessionRepository(ctrl)
+	oauthRepository := mockdb.NewMockOauthRepository(ctrl)
+	authEventRepository := mockdb.NewMockAuthEventRepository(ctrl)
+
+	userRepository.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).AnyTimes().Return(user, nil)
+	userRepository.EXPECT().GetUser(gomock.Any(), gomock.Not(user.Username)).AnyTimes().Return(nil, domain.ErrUserNotFound)
+	oauthRepository.EXPECT().GetOauthClient(gomock.Any(), gomock.Eq(client.ID)).AnyTimes().Return(client, nil)
+	oauthRepository.EXPECT().GetOauthClient(gomock.Any(), gomock.Not(client.ID)).AnyTimes().Return(nil, domain.ErrOauthClientNotFound)
+
+	var storedCode *domain.OauthAuthorizationCode
+	oauthRepository.EXPECT().
+		CreateOauthAuthorizationCode(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, arg infra.CreateOauthAuthorizationCode) (*domain.OauthAuthorizationCode, error) {
+			storedCode = &domain.OauthAuthorizationCode{
+				CodeHash:      arg.CodeHash,
+				ClientID:      arg.ClientID,
+				Username:      arg.Username,
+				RedirectURI:   arg.RedirectURI,
+				Scopes:        arg.Scopes,
+				Nonce:         arg.Nonce,
+				CodeChallenge: arg.CodeChallenge,
+				AuthTime:      arg.AuthTime,
+				ExpiresAt:     arg.ExpiresAt,
+			}
+			return storedCode, nil
+		})
+	oauthRepository.EXPECT().
+		ConsumeOauthAuthorizationCode(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, arg infra.ConsumeOauthAuthorizationCode) (*domain.OauthAuthorizationCode, error) {
+			code := storedCode
+			if code == nil || code.CodeHash != arg.CodeHash || code.ClientID != arg.ClientID {
+				return nil, domain.ErrOauthAuthorizationCodeNotFound
+			}
+			storedCode = nil
+			return code, nil
+		})
+
+	sessionRepository.EXPECT().
+		CreateSession(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, arg infra.CreateSession) (*domain.Session, error) {
+			require.NotEmpty(t, arg.ClientIp)
+			require.Equal(t, "oidc-test", arg.UserAgent)
+			require.Equal(t, client.ID, *arg.ClientID)
+			return &domain.Session{ID: arg.ID, FamilyID: arg.FamilyID, Username: arg.Username, ClientID: arg.ClientID}, nil
+		})
+
+	authEventRepository.EXPECT().
+		CreateAuthEvent(gomock.Any(), gomock.Any()).
+		AnyTimes()
+
+	config := &util.Config{
+		AccessTokenDuration:           time.Minute,
+		RefreshTokenDuration:          time.Hour,
+		OidcIssuerURL:                 testIssuerURL,
+		OidcAuthorizationCodeDuration: time.Minute,
+	}
+
+	userApplication := application.NewUserApplication(userRepository, sessionRepository, nil, nil, nil, nil, nil, nil, authEventRepository, nil, nil, tokenMaker, nil, config)
+	oidcApplication := application.NewOidcApplication(userApplication, oauthRepository, tokenMaker, config)
+
+	mux := http.NewServeMux()
+	NewServer(oidcApplication, testIssuerURL).Register(mux)
+
+	httpServer := httptest.NewServer(mux)
+	t.Cleanup(httpServer.Close)
+
+	return &testServer{
+		url:          httpServer.URL,
+		user:         user,
+		password:     password,
+		client:       client,
+		clientSecret: clientSecret,
+		privateKey:   privateKey,
+	}
+}
+
+func (s *testServer) authorizeQuery(scope string) url.Values {
+	return url.Values{
+		"response_type": {"code"},
+		"client_id":     {s.client.ID},
+		"redirect_uri":  {testRedirectURI},
+		"scope":         {scope},
+		"state":         {"state"},
+		"nonce":         {"nonce"},
+	}
+}
+
+func postForm(t *testing.T, target string, form url.Values, prepare func(r *http.Request)) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, target, strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", "oidc-test")
+	if prepare != nil {
+		prepare(req)
+	}
+
+	res, err := noRedirectClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { res.Body.Close() })
+
+	return res
+}
+
+var noRedirectClient = &http.Client{
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+func TestDiscovery(t *testing.T) {
+	server := newTestServer(t)
+
+	res, err := http.Get(server.url + DiscoveryPath)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var document discoveryDocument
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&document))
+	require.Equal(t, testIssuerURL, document.Issuer)
+	require.Equal(t, testIssuerURL+TokenPath, document.TokenEndpoint)
+	require.Equal(t, testIssuerURL+JwksPath, document.JwksURI)
+	require.Contains(t, document.CodeChallengeMethodsSupported, "S256")
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	server := newTestServer(t)
+	query := server.authorizeQuery("openid profile email")
+
+	res, err := http.Get(server.url + AuthorizePath + "?" + query.Encode())
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "DENY", res.Header.Get("X-Frame-Options"))
+
+	form := server.authorizeQuery("openid profile email")
+	form.Set("username", server.user.Username)
+	form.Set("password", server.password)
+
+	res = postForm(t, server.url+AuthorizePath, form, nil)
+	require.Equal(t, http.StatusFound, res.StatusCode)
+
+	location, err := url.Parse(res.Header.Get("Location"))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(location.String(), testRedirectURI))
+	require.Equal(t, "state", location.Query().Get("state"))
+
+	code := location.Query().Get("code")
+	require.NotEmpty(t, code)
+
+	tokenForm := url.Values{
+		"grant_type":   {application.GrantTypeAuthorizationCode},
+		"code":         {code},
+		"redirect_uri": {testRedirectURI},
+	}
+	res = postForm(t, server.url+TokenPath, tokenForm, func(r *http.Request) {
+		r.SetBasicAuth(url.QueryEscape(server.client.ID), url.QueryEscape(server.clientSecret))
+	})
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "no-store", res.Header.Get("Cache-Control"))
+
+	var tokenResult application.TokenResult
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&tokenResult))
+	require.Equal(t, "Bearer", tokenResult.TokenType)
+	require.Empty(t, tokenResult.RefreshToken)
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(tokenResult.IDToken, claims, func(*jwt.Token) (any, error) {
+		return server.privateKey.Public(), nil
+	}, jwt.WithIssuer(testIssuerURL), jwt.WithAudience(server.client.ID))
+	require.NoError(t, err)
+	require.Equal(t, server.user.Username, claims["sub"])
+	require.Equal(t, "nonce", claims["nonce"])
+	require.Equal(t, server.user.FullName, claims["name"])
+
+	req, err := http.NewRequest(http.MethodGet, server.url+UserInfoPath, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+tokenResult.AccessToken)
+
+	res, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var userInfo application.UserInfo
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&userInfo))
+	require.Equal(t, server.user.Username, userInfo.Subject)
+	require.Equal(t, server.user.Email, userInfo.Email)
+
+	// the code was consumed by the first exchange
+	tokenForm.Set("client_id", server.client.ID)
+	tokenForm.Set("client_secret", server.clientSecret)
+	res = postForm(t, server.url+TokenPath, tokenForm, nil)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	var errorResult oauthErrorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errorResult))
+	require.Equal(t, application.OauthInvalidGrant, errorResult.Error)
+}
+
+func TestAuthorizeErrors(t *testing.T) {
+	server := newTestServer(t)
+
+	testCases := []struct {
+		name          string
+		query         func() url.Values
+		checkResponse func(t *testing.T, res *http.Response)
+	}{
+		{
+			name: "UnknownClient",
+			query: func() url.Values {
+				query := server.authorizeQuery("openid")
+				query.Set("client_id", uuid.NewString())
+				return query
+			},
+			checkResponse: func(t *testing.T, res *http.Response) {
+				require.Equal(t, http.StatusBadRequest, res.StatusCode)
+			},
+		},
+		{
+			name: "UnregisteredRedirectURI",
+			query: func() url.Values {
+				query := server.authorizeQuery("openid")
+				query.Set("redirect_uri", "https://evil.example.com/callback")
+				return query
+			},
+			checkResponse: func(t *testing.T, res *http.Response) {
+				require.Equal(t, http.StatusBadRequest, res.StatusCode)
+				require.Empty(t, res.Header.Get("Location"))
+			},
+		},
+		{
+			name: "InvalidScope",
+			query: func() url.Values {
+				return server.authorizeQuery("profile")
+			},
+			checkResponse: func(t *testing.T, res *http.Response) {
+				require.Equal(t, http.StatusFound, res.StatusCode)
+
+				location, err := url.Parse(res.Header.Get("Location"))
+				require.NoError(t, err)
+				require.Equal(t, application.OauthInvalidScope, location.Query().Get("error"))
+				require.Equal(t, "state", location.Query().Get("state"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := noRedirectClient.Get(server.url + AuthorizePath + "?" + tc.query().Encode())
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			tc.checkResponse(t, res)
+		})
+	}
+}
+
+func TestAuthorizeWrongPassword(t *testing.T) {
+	server := newTestServer(t)
+
+	form := server.authorizeQuery("openid")
+	form.Set("username", server.user.Username)
+	form.Set("password", "wrong password")
+
+	res := postForm(t, server.url+AuthorizePath, form, nil)
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	require.Empty(t, res.Header.Get("Location"))
+}
+
+func TestTokenInvalidClient(t *testing.T) {
+	server := newTestServer(t)
+
+	res := postForm(t, server.url+TokenPath, url.Values{"grant_type": {application.GrantTypeAuthorizationCode}, "code": {"code"}}, func(r *http.Request) {
+		r.SetBasicAuth(server.client.ID, "wrong")
+	})
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	require.NotEmpty(t, res.Header.Get("WWW-Authenticate"))
+}
+
+func TestUserInfoInvalidToken(t *testing.T) {
+	server := newTestServer(t)
+
+	req, err := http.NewRequest(http.MethodGet, server.url+UserInfoPath, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+base64.RawURLEncoding.EncodeToString([]byte("invalid")))
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	require.Contains(t, res.Header.Get("WWW-Authenticate"), application.OauthInvalidToken)
+}
@@ -0,0 +1,220 @@
+// Package sso signs users in with external OpenID Connect providers using the
+// authorization code flow with PKCE.
+package sso
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrUnknownProvider = errors.New("unknown identity provider")
+	ErrInvalidIDToken  = errors.New("invalid id token")
+	ErrExchangeCode    = errors.New("failed to exchange authorization code")
+)
+
+// ProviderConfig is one entry of the JSON array at OIDC_PROVIDERS_PATH.
+// Scopes defaults to openid, email and profile.
+type ProviderConfig struct {
+	Name         string   `json:"name"`
+	Issuer       string   `json:"issuer"`
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	RedirectURL  string   `json:"redirect_url"`
+	Scopes       []string `json:"scopes"`
+}
+
+func LoadProviderConfigs(path string) ([]ProviderConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []ProviderConfig
+	err = json.Unmarshal(data, &configs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid oidc providers file: %w", err)
+	}
+
+	for _, config := range configs {
+		if config.Name == "" || config.Issuer == "" || config.ClientID == "" || config.RedirectURL == "" {
+			return nil, fmt.Errorf("oidc provider %q needs a name, issuer, client_id and redirect_url", config.Name)
+		}
+	}
+
+	return configs, nil
+}
+
+// Identity is the user described by a verified id_token.
+type Identity struct {
+	Provider          string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+type provider struct {
+	oauth2Config oauth2.Config
+	verifier     *oidc.IDTokenVerifier
+}
+
+// Client knows the configured providers. Their discovery documents are
+// fetched on first use and kept, a failed discovery is retried on the next
+// call.
+type Client struct {
+	configs    map[string]ProviderConfig
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	providers map[string]*provider
+}
+
+func NewClient(configs []ProviderConfig, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	byName := make(map[string]ProviderConfig, len(configs))
+	for _, config := range configs {
+		byName[config.Name] = config
+	}
+
+	return &Client{
+		configs:    byName,
+		httpClient: httpClient,
+		providers:  make(map[string]*provider),
+	}
+}
+
+// AuthCodeURL returns the URL of the provider login page. The provider sends
+// state back with the code, nonce comes back inside the id_token and the
+// PKCE challenge is derived from codeVerifier.
+func (c *Client) AuthCodeURL(ctx context.Context, providerName, state, nonce, codeVerifier string) (string, error) {
+	p, err := c.provider(ctx, providerName)
+	if err != nil {
+		return "", err
+	}
+
+	return p.oauth2Config.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(codeVerifier)), nil
+}
+
+// Exchange trades the code for tokens and returns the identity of the
+// verified id_token, which must carry nonce.
+func (c *Client) Exchange(ctx context.Context, providerName, code, codeVerifier, nonce string) (*Identity, error) {
+	p, err := c.provider(ctx, providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = oidc.ClientContext(ctx, c.httpClient)
+
+	oauth2Token, err := p.oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrExchangeCode, err)
+	}
+
+	rawIDToken, ok := oauth2Token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("%w: missing from token response", ErrInvalidIDToken)
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+
+	if idToken.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+
+	var claims struct {
+		Email             string `json:"email"`
+		EmailVerified     bool   `json:"email_verified"`
+		Name              string `json:"name"`
+		PreferredUsername string `json:"preferred_username"`
+	}
+	err = idToken.Claims(&claims)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+
+	return &Identity{
+		Provider:          providerName,
+		Subject:           idToken.Subject,
+		Email:             claims.Email,
+		EmailVerified:     claims.EmailVerified,
+		Name:              claims.Name,
+		PreferredUsername: claims.PreferredUsername,
+	}, nil
+}
+
+// provider returns the discovered provider name. The discovery runs without
+// holding mu, so a slow or unreachable provider does not hold up logins with
+// the others. Concurrent first calls may both discover it, the first one
+// stored is kept.
+func (c *Client) provider(ctx context.Context, name string) (*provider, error) {
+	config, ok := c.configs[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	c.mu.Lock()
+	p, ok := c.providers[name]
+	c.mu.Unlock()
+	if ok {
+		return p, nil
+	}
+
+	p, err := c.discover(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if stored, ok := c.providers[name]; ok {
+		return stored, nil
+	}
+	c.providers[name] = p
+
+	return p, nil
+}
+
+func (c *Client) discover(ctx context.Context, config ProviderConfig) (*provider, error) {
+	// the provider keeps this context to refresh its signing keys, so it must
+	// outlive the request
+	providerCtx := oidc.ClientContext(context.WithoutCancel(ctx), c.httpClient)
+
+	oidcProvider, err := oidc.NewProvider(providerCtx, config.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover oidc provider %q: %w", config.Name, err)
+	}
+
+	scopes := config.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{oidc.ScopeOpenID, "email", "profile"}
+	}
+
+	return &provider{
+		oauth2Config: oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectURL,
+			Endpoint:     oidcProvider.Endpoint(),
+			Scopes:       scopes,
+		},
+		verifier: oidcProvider.Verifier(&oidc.Config{ClientID: config.ClientID}),
+	}, nil
+}
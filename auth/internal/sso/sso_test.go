@@ -0,0 +1,169 @@
+package sso_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/sso"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/sso/ssotest"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestClientExchange(t *testing.T) {
+	provider := ssotest.NewProvider(t)
+	client := sso.NewClient([]sso.ProviderConfig{provider.Config("fake")}, nil)
+
+	user := ssotest.User{
+		Subject:           "subject",
+		Email:             "user@example.com",
+		EmailVerified:     true,
+		Name:              "Fake User",
+		PreferredUsername: "fake_user",
+	}
+
+	testCases := []struct {
+		name         string
+		exchange     func(t *testing.T, code string, verifier string, nonce string) (*sso.Identity, error)
+		checkResults func(t *testing.T, identity *sso.Identity, err error)
+	}{
+		{
+			name: "OK",
+			exchange: func(t *testing.T, code string, verifier string, nonce string) (*sso.Identity, error) {
+				return client.Exchange(context.Background(), "fake", code, verifier, nonce)
+			},
+			checkResults: func(t *testing.T, identity *sso.Identity, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "fake", identity.Provider)
+				require.Equal(t, user.Subject, identity.Subject)
+				require.Equal(t, user.Email, identity.Email)
+				require.True(t, identity.EmailVerified)
+				require.Equal(t, user.Name, identity.Name)
+				require.Equal(t, user.PreferredUsername, identity.PreferredUsername)
+			},
+		},
+		{
+			name: "NonceMismatch",
+			exchange: func(t *testing.T, code string, verifier string, nonce string) (*sso.Identity, error) {
+				return client.Exchange(context.Background(), "fake", code, verifier, "other")
+			},
+			checkResults: func(t *testing.T, identity *sso.Identity, err error) {
+				require.ErrorIs(t, err, sso.ErrInvalidIDToken)
+				require.Nil(t, identity)
+			},
+		},
+		{
+			name: "WrongCodeVerifier",
+			exchange: func(t *testing.T, code string, verifier string, nonce string) (*sso.Identity, error) {
+				return client.Exchange(context.Background(), "fake", code, oauth2.GenerateVerifier(), nonce)
+			},
+			checkResults: func(t *testing.T, identity *sso.Identity, err error) {
+				require.ErrorIs(t, err, sso.ErrExchangeCode)
+				require.Nil(t, identity)
+			},
+		},
+		{
+			name: "CodeReused",
+			exchange: func(t *testing.T, code string, verifier string, nonce string) (*sso.Identity, error) {
+				_, err := client.Exchange(context.Background(), "fake", code, verifier, nonce)
+				require.NoError(t, err)
+
+				return client.Exchange(context.Background(), "fake", code, verifier, nonce)
+			},
+			checkResults: func(t *testing.T, identity *sso.Identity, err error) {
+				require.ErrorIs(t, err, sso.ErrExchangeCode)
+				require.Nil(t, identity)
+			},
+		},
+		{
+			name: "UnknownProvider",
+			exchange: func(t *testing.T, code string, verifier string, nonce string) (*sso.Identity, error) {
+				return client.Exchange(context.Background(), "unknown", code, verifier, nonce)
+			},
+			checkResults: func(t *testing.T, identity *sso.Identity, err error) {
+				require.ErrorIs(t, err, sso.ErrUnknownProvider)
+				require.Nil(t, identity)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			verifier := oauth2.GenerateVerifier()
+
+			authURL, err := client.AuthCodeURL(context.Background(), "fake", "state", "nonce", verifier)
+			require.NoError(t, err)
+
+			code, state := provider.Authorize(t, authURL, user)
+			require.Equal(t, "state", state)
+
+			identity, err := tc.exchange(t, code, verifier, "nonce")
+			tc.checkResults(t, identity, err)
+		})
+	}
+}
+
+func TestClientAuthCodeURL(t *testing.T) {
+	provider := ssotest.NewProvider(t)
+	client := sso.NewClient([]sso.ProviderConfig{provider.Config("fake")}, nil)
+
+	authURL, err := client.AuthCodeURL(context.Background(), "fake", "state", "nonce", oauth2.GenerateVerifier())
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	require.Equal(t, provider.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+
+	query := parsed.Query()
+	require.Equal(t, provider.ClientID, query.Get("client_id"))
+	require.Equal(t, "state", query.Get("state"))
+	require.Equal(t, "nonce", query.Get("nonce"))
+	require.Equal(t, "openid email profile", query.Get("scope"))
+
+	_, err = client.AuthCodeURL(context.Background(), "unknown", "state", "nonce", oauth2.GenerateVerifier())
+	require.ErrorIs(t, err, sso.ErrUnknownProvider)
+}
+
+// TestClientSlowDiscovery makes sure a provider whose discovery hangs does not
+// hold up the other providers.
+func TestClientSlowDiscovery(t *testing.T) {
+	discovering := make(chan struct{})
+	release := make(chan struct{})
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(discovering)
+		<-release
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(slow.Close)
+	t.Cleanup(func() { close(release) })
+
+	provider := ssotest.NewProvider(t)
+	slowConfig := provider.Config("slow")
+	slowConfig.Issuer = slow.URL
+
+	client := sso.NewClient([]sso.ProviderConfig{provider.Config("fake"), slowConfig}, nil)
+
+	slowErr := make(chan error, 1)
+	go func() {
+		_, err := client.AuthCodeURL(context.Background(), "slow", "state", "nonce", oauth2.GenerateVerifier())
+		slowErr <- err
+	}()
+	<-discovering
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := client.AuthCodeURL(ctx, "fake", "state", "nonce", oauth2.GenerateVerifier())
+	require.NoError(t, err)
+
+	select {
+	case err := <-slowErr:
+		t.Fatalf("slow discovery finished early: %v", err)
+	default:
+	}
+}
@@ -1,23 +1,67 @@
 package util
 
 import (
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
+// Values of UNVERIFIED_EMAIL_POLICY, which decides how users that have not
+// verified their email can log in. An empty value means allow.
+const (
+	UnverifiedEmailAllow = "allow"
+	UnverifiedEmailClaim = "claim"
+	UnverifiedEmailDeny  = "deny"
+)
+
+// DefaultVerifyEmailResendInterval is used when VERIFY_EMAIL_RESEND_INTERVAL
+// is not set, since resends are deduplicated over that interval.
+const DefaultVerifyEmailResendInterval = time.Minute
+
 type Config struct {
-	Environment          string        `mapstructure:"ENVIRONMENT"`
-	DBSource             string        `mapstructure:"DB_SOURCE"`
-	ServerAddress        string        `mapstructure:"SERVER_ADDRESS"`
-	TokenSecretKey       string        `mapstructure:"TOKEN_SECRET_KEY"`
-	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
-	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
-	RedisAddress         string        `mapstructure:"REDIS_ADDRESS"`
-	EmailSenderName      string        `mapstructure:"EMAIL_SENDER_NAME"`
-	EmailSenderAddress   string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
-	EmailSenderUsername  string        `mapstructure:"EMAIL_SENDER_USERNAME"`
-	EmailSenderPassword  string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	Environment                   string        `mapstructure:"ENVIRONMENT"`
+	DBSource                      string        `mapstructure:"DB_SOURCE"`
+	ServerAddress                 string        `mapstructure:"SERVER_ADDRESS"`
+	HttpServerAddress             string        `mapstructure:"HTTP_SERVER_ADDRESS"`
+	TokenFormat                   string        `mapstructure:"TOKEN_FORMAT"`
+	TokenSecretKey                string        `mapstructure:"TOKEN_SECRET_KEY"`
+	TokenPrivateKeyPath           string        `mapstructure:"TOKEN_PRIVATE_KEY_PATH"`
+	TokenKeyID                    string        `mapstructure:"TOKEN_KEY_ID"`
+	TokenKeyringPath              string        `mapstructure:"TOKEN_KEYRING_PATH"`
+	TokenIssuer                   string        `mapstructure:"TOKEN_ISSUER"`
+	TokenAudience                 []string      `mapstructure:"TOKEN_AUDIENCE"`
+	TokenServiceAudience          string        `mapstructure:"TOKEN_SERVICE_AUDIENCE"`
+	IdentitySigningKey            string        `mapstructure:"IDENTITY_SIGNING_KEY"`
+	AccessTokenDuration           time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration          time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	VerifyEmailResendInterval     time.Duration `mapstructure:"VERIFY_EMAIL_RESEND_INTERVAL"`
+	UnverifiedEmailPolicy         string        `mapstructure:"UNVERIFIED_EMAIL_POLICY"`
+	LoginMaxFailures              int32         `mapstructure:"LOGIN_MAX_FAILURES"`
+	LoginMaxIPFailures            int32         `mapstructure:"LOGIN_MAX_IP_FAILURES"`
+	LoginBackoffBase              time.Duration `mapstructure:"LOGIN_BACKOFF_BASE"`
+	LoginBackoffMax               time.Duration `mapstructure:"LOGIN_BACKOFF_MAX"`
+	TrustedProxies                []string      `mapstructure:"TRUSTED_PROXIES"`
+	LoginLockoutDuration          time.Duration `mapstructure:"LOGIN_LOCKOUT_DURATION"`
+	TotpIssuer                    string        `mapstructure:"TOTP_ISSUER"`
+	TotpEncryptionKey             string        `mapstructure:"TOTP_ENCRYPTION_KEY"`
+	MfaChallengeDuration          time.Duration `mapstructure:"MFA_CHALLENGE_DURATION"`
+	WebauthnRPID                  string        `mapstructure:"WEBAUTHN_RP_ID"`
+	WebauthnRPDisplayName         string        `mapstructure:"WEBAUTHN_RP_DISPLAY_NAME"`
+	WebauthnRPOrigins             []string      `mapstructure:"WEBAUTHN_RP_ORIGINS"`
+	WebauthnChallengeDuration     time.Duration `mapstructure:"WEBAUTHN_CHALLENGE_DURATION"`
+	OidcProvidersPath             string        `mapstructure:"OIDC_PROVIDERS_PATH"`
+	OidcLoginDuration             time.Duration `mapstructure:"OIDC_LOGIN_DURATION"`
+	OidcIssuerURL                 string        `mapstructure:"OIDC_ISSUER_URL"`
+	OidcAuthorizationCodeDuration time.Duration `mapstructure:"OIDC_AUTHORIZATION_CODE_DURATION"`
+	AccountDeletionGracePeriod    time.Duration `mapstructure:"ACCOUNT_DELETION_GRACE_PERIOD"`
+	NewDeviceAlertEnabled         bool          `mapstructure:"NEW_DEVICE_ALERT_ENABLED"`
+	RedisAddress                  string        `mapstructure:"REDIS_ADDRESS"`
+	EmailSenderName               string        `mapstructure:"EMAIL_SENDER_NAME"`
+	EmailSenderAddress            string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
+	EmailSenderUsername           string        `mapstructure:"EMAIL_SENDER_USERNAME"`
+	EmailSenderPassword           string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
 }
 
 func LoadConfig(path string) (Config, error) {
@@ -35,5 +79,32 @@ func LoadConfig(path string) (Config, error) {
 	}
 
 	err = viper.Unmarshal(&config)
-	return config, err
+	if err != nil {
+		return config, err
+	}
+
+	switch config.UnverifiedEmailPolicy {
+	case "", UnverifiedEmailAllow, UnverifiedEmailClaim, UnverifiedEmailDeny:
+	default:
+		return config, fmt.Errorf("invalid UNVERIFIED_EMAIL_POLICY %q", config.UnverifiedEmailPolicy)
+	}
+
+	err = ValidateTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		return config, fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+
+	if config.VerifyEmailResendInterval < 0 {
+		return config, fmt.Errorf("invalid VERIFY_EMAIL_RESEND_INTERVAL %s", config.VerifyEmailResendInterval)
+	}
+
+	if config.VerifyEmailResendInterval == 0 {
+		config.VerifyEmailResendInterval = DefaultVerifyEmailResendInterval
+	}
+
+	if config.TokenServiceAudience != "" && !slices.Contains(config.TokenAudience, config.TokenServiceAudience) {
+		return config, fmt.Errorf("TOKEN_SERVICE_AUDIENCE %q must be one of TOKEN_AUDIENCE", config.TokenServiceAudience)
+	}
+
+	return config, nil
 }
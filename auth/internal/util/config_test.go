@@ -0,0 +1,18 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfigDefaultResendInterval(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.env"), []byte("ENVIRONMENT=test\n"), 0o600))
+
+	config, err := LoadConfig(dir)
+	require.NoError(t, err)
+	require.Equal(t, DefaultVerifyEmailResendInterval, config.VerifyEmailResendInterval)
+}
@@ -2,6 +2,9 @@ package util
 
 import (
 	"context"
+	"net"
+	"net/netip"
+	"strings"
 
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
@@ -18,24 +21,89 @@ type Metadata struct {
 	ClientIP  string
 }
 
-func ExtractMetadata(ctx context.Context) *Metadata {
+// ExtractMetadata reads the user agent and client IP of the request. The
+// client IP is the peer address, unless the peer is one of trustedProxies,
+// such as the gateway. Then it is the rightmost x-forwarded-for address that is
+// not a trusted proxy: each proxy appends the address it received the request
+// from, so anything left of it was sent by the client and can not be trusted.
+func ExtractMetadata(ctx context.Context, trustedProxies []string) *Metadata {
 	mtdt := &Metadata{}
 
-	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		if userAgents := md.Get(grpcGatewayUserAgentHeader); len(userAgents) > 0 {
-			mtdt.UserAgent = userAgents[0]
-		} else if userAgents := md.Get(userAgentHeader); len(userAgents) > 0 {
-			mtdt.UserAgent = userAgents[0]
+	md, _ := metadata.FromIncomingContext(ctx)
+	if userAgents := md.Get(grpcGatewayUserAgentHeader); len(userAgents) > 0 {
+		mtdt.UserAgent = userAgents[0]
+	} else if userAgents := md.Get(userAgentHeader); len(userAgents) > 0 {
+		mtdt.UserAgent = userAgents[0]
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return mtdt
+	}
+
+	mtdt.ClientIP = p.Addr.String()
+	if !isTrustedProxy(mtdt.ClientIP, trustedProxies) {
+		return mtdt
+	}
+
+	forwarded := strings.Split(strings.Join(md.Get(xFowardForHeader), ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		address := strings.TrimSpace(forwarded[i])
+		if address == "" {
+			continue
 		}
 
-		if clientsIPs := md.Get(xFowardForHeader); len(clientsIPs) > 0 {
-			mtdt.ClientIP = clientsIPs[0]
+		mtdt.ClientIP = address
+		if !isTrustedProxy(address, trustedProxies) {
+			break
 		}
 	}
 
-	if p, ok := peer.FromContext(ctx); ok {
-		mtdt.ClientIP = p.Addr.String()
+	return mtdt
+}
+
+// ValidateTrustedProxies checks that every trusted proxy is an IP address or
+// a CIDR prefix.
+func ValidateTrustedProxies(trustedProxies []string) error {
+	for _, proxy := range trustedProxies {
+		if _, err := parseTrustedProxy(proxy); err != nil {
+			return err
+		}
 	}
 
-	return mtdt
+	return nil
+}
+
+func isTrustedProxy(address string, trustedProxies []string) bool {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+
+	ip, err := netip.ParseAddr(address)
+	if err != nil {
+		return false
+	}
+
+	for _, proxy := range trustedProxies {
+		prefix, err := parseTrustedProxy(proxy)
+		if err == nil && prefix.Contains(ip.Unmap()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseTrustedProxy(proxy string) (netip.Prefix, error) {
+	proxy = strings.TrimSpace(proxy)
+	if strings.Contains(proxy, "/") {
+		return netip.ParsePrefix(proxy)
+	}
+
+	ip, err := netip.ParseAddr(proxy)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	return netip.PrefixFrom(ip, ip.BitLen()), nil
 }
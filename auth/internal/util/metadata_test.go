@@ -0,0 +1,79 @@
+package util
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestExtractMetadataClientIP(t *testing.T) {
+	trustedProxies := []string{"10.0.0.2", "172.16.0.0/12"}
+
+	testCases := []struct {
+		name      string
+		peerIP    string
+		forwarded []string
+		clientIP  string
+	}{
+		{
+			name:      "NoPeer",
+			forwarded: []string{"203.0.113.7"},
+			clientIP:  "",
+		},
+		{
+			name:      "UntrustedPeer",
+			peerIP:    "198.51.100.4",
+			forwarded: []string{"203.0.113.7"},
+			clientIP:  "198.51.100.4:50051",
+		},
+		{
+			name:      "TrustedPeer",
+			peerIP:    "10.0.0.2",
+			forwarded: []string{"203.0.113.7"},
+			clientIP:  "203.0.113.7",
+		},
+		{
+			name:      "TrustedPeerSpoofedForwardedFor",
+			peerIP:    "10.0.0.2",
+			forwarded: []string{"198.51.100.99, 203.0.113.7"},
+			clientIP:  "203.0.113.7",
+		},
+		{
+			name:      "TrustedProxyChain",
+			peerIP:    "10.0.0.2",
+			forwarded: []string{"198.51.100.99, 203.0.113.7", "172.20.0.5"},
+			clientIP:  "203.0.113.7",
+		},
+		{
+			name:     "TrustedPeerWithoutForwardedFor",
+			peerIP:   "10.0.0.2",
+			clientIP: "10.0.0.2:50051",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			md := metadata.MD{}
+			for _, forwarded := range tc.forwarded {
+				md.Append(xFowardForHeader, forwarded)
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			if tc.peerIP != "" {
+				ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(tc.peerIP), Port: 50051}})
+			}
+
+			require.Equal(t, tc.clientIP, ExtractMetadata(ctx, trustedProxies).ClientIP)
+		})
+	}
+}
+
+func TestValidateTrustedProxies(t *testing.T) {
+	require.NoError(t, ValidateTrustedProxies([]string{"127.0.0.1", "::1", "10.0.0.0/8"}))
+	require.Error(t, ValidateTrustedProxies([]string{"gateway"}))
+	require.Error(t, ValidateTrustedProxies([]string{"10.0.0.0/33"}))
+}
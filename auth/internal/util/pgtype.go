@@ -41,3 +41,15 @@ func BoolToBool(b *bool) pgtype.Bool {
 
 	return bool
 }
+
+func Int64ToInt8(i *int64) pgtype.Int8 {
+	int8 := pgtype.Int8{
+		Valid: i != nil,
+	}
+
+	if int8.Valid {
+		int8.Int64 = *i
+	}
+
+	return int8
+}
@@ -0,0 +1,81 @@
+package util
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidSecretKey = errors.New("secret key must have exactly 32 characters")
+
+// EncryptSecret seals plaintext with AES-256-GCM, prefixing the random nonce
+// to the returned ciphertext.
+func EncryptSecret(key string, plaintext []byte) ([]byte, error) {
+	gcm, err := newSecretCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// DecryptSecret opens a ciphertext produced by EncryptSecret with the same key.
+func DecryptSecret(key string, ciphertext []byte) ([]byte, error) {
+	gcm, err := newSecretCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+func newSecretCipher(key string) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, ErrInvalidSecretKey
+	}
+
+	block, err := aes.NewCipher([]byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// RandomSecretCode returns a URL safe code read from crypto/rand, for links
+// that are sent by email.
+func RandomSecretCode() (string, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate secret code: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(random), nil
+}
+
+// HashSecretCode hashes a code from RandomSecretCode before it is stored, so
+// a leaked table does not hold codes that can still be used.
+func HashSecretCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
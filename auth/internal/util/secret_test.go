@@ -0,0 +1,53 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecret(t *testing.T) {
+	key := RandomString(32)
+	plaintext := []byte(RandomString(16))
+
+	ciphertext1, err := EncryptSecret(key, plaintext)
+	require.NoError(t, err)
+	require.NotContains(t, string(ciphertext1), string(plaintext))
+
+	decrypted, err := DecryptSecret(key, ciphertext1)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, decrypted)
+
+	ciphertext2, err := EncryptSecret(key, plaintext)
+	require.NoError(t, err)
+	require.NotEqual(t, ciphertext1, ciphertext2)
+
+	_, err = DecryptSecret(RandomString(32), ciphertext1)
+	require.Error(t, err)
+
+	ciphertext1[len(ciphertext1)-1] ^= 0xff
+	_, err = DecryptSecret(key, ciphertext1)
+	require.Error(t, err)
+}
+
+func TestSecretInvalidKey(t *testing.T) {
+	_, err := EncryptSecret(RandomString(16), []byte("secret"))
+	require.ErrorIs(t, err, ErrInvalidSecretKey)
+
+	_, err = DecryptSecret(RandomString(16), []byte("secret"))
+	require.ErrorIs(t, err, ErrInvalidSecretKey)
+}
+
+func TestRandomSecretCode(t *testing.T) {
+	code1, err := RandomSecretCode()
+	require.NoError(t, err)
+	require.Len(t, code1, 43)
+
+	code2, err := RandomSecretCode()
+	require.NoError(t, err)
+	require.NotEqual(t, code1, code2)
+
+	require.Equal(t, HashSecretCode(code1), HashSecretCode(code1))
+	require.NotEqual(t, HashSecretCode(code1), HashSecretCode(code2))
+	require.NotContains(t, HashSecretCode(code1), code1)
+}
@@ -23,14 +23,24 @@ type VerifyEmailCreator interface {
 	CreateVerifyEmail(ctx context.Context, arg infra.CreateVerifyEmail) (*domain.VerifyEmail, error)
 }
 
+type ResetPasswordCreator interface {
+	CreateResetPassword(ctx context.Context, arg infra.CreateResetPassword) (*domain.ResetPassword, error)
+}
+
+type UserPurger interface {
+	PurgeUserTx(ctx context.Context, username string) (infra.PurgeUserTxResult, error)
+}
+
 type RedisTaskProcessor struct {
-	server                *asynq.Server
-	userRepository        UserReader
-	verifyEmailRepository VerifyEmailCreator
-	mailer                mail.EmailSender
+	server                  *asynq.Server
+	userRepository          UserReader
+	verifyEmailRepository   VerifyEmailCreator
+	resetPasswordRepository ResetPasswordCreator
+	userPurger              UserPurger
+	mailer                  mail.EmailSender
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, userRepository UserReader, verifyEmailRepository VerifyEmailCreator, mailer mail.EmailSender) *RedisTaskProcessor {
+func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, userRepository UserReader, verifyEmailRepository VerifyEmailCreator, resetPasswordRepository ResetPasswordCreator, userPurger UserPurger, mailer mail.EmailSender) *RedisTaskProcessor {
 	server := asynq.NewServer(
 		redisOpt,
 		asynq.Config{
@@ -50,10 +60,12 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, userRepository UserRea
 	)
 
 	return &RedisTaskProcessor{
-		server:                server,
-		userRepository:        userRepository,
-		verifyEmailRepository: verifyEmailRepository,
-		mailer:                mailer,
+		server:                  server,
+		userRepository:          userRepository,
+		verifyEmailRepository:   verifyEmailRepository,
+		resetPasswordRepository: resetPasswordRepository,
+		userPurger:              userPurger,
+		mailer:                  mailer,
 	}
 }
 
@@ -61,6 +73,9 @@ func (r *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
 
 	mux.HandleFunc(TaskSendVerifyEmail, r.ProcessTaskSendVerifyEmail)
+	mux.HandleFunc(TaskSendResetPassword, r.ProcessTaskSendResetPassword)
+	mux.HandleFunc(TaskPurgeAccount, r.ProcessTaskPurgeAccount)
+	mux.HandleFunc(TaskSendNewDeviceLogin, r.ProcessTaskSendNewDeviceLogin)
 
 	return r.server.Start(mux)
 }
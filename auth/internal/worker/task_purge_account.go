@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskPurgeAccount = "task:purge_account"
+
+type PayloadPurgeAccount struct {
+	Username string
+}
+
+func (r *RedisTaskDistributor) DistributeTaskPurgeAccount(ctx context.Context, payload *PayloadPurgeAccount, opts ...asynq.Option) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskPurgeAccount, jsonPayload, opts...)
+	info, err := r.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Time("process_at", info.NextProcessAt).
+		Msg("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskPurgeAccount purges a deleted account once its grace period is
+// over. An account that is no longer deleted, or already purged, is skipped.
+func (r *RedisTaskProcessor) ProcessTaskPurgeAccount(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadPurgeAccount
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	result, err := r.userPurger.PurgeUserTx(ctx, payload.Username)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			log.Info().
+				Str("type", task.Type()).
+				Bytes("payload", task.Payload()).
+				Msg("skipped task, account is not deleted")
+			return nil
+		}
+		return fmt.Errorf("failed to purge account: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Bool("anonymized", result.Anonymized).
+		Msg("proccessed task")
+
+	return nil
+}
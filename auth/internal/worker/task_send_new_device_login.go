@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendNewDeviceLogin = "task:send_new_device_login"
+
+// PayloadSendNewDeviceLogin describes a login from a user agent or client IP
+// the user never logged in from before.
+type PayloadSendNewDeviceLogin struct {
+	Username    string
+	SessionID   uuid.UUID
+	UserAgent   string
+	ClientIP    string
+	LoggedInAt  time.Time
+	RevokeToken string
+}
+
+func (r *RedisTaskDistributor) DistributeTaskSendNewDeviceLogin(ctx context.Context, payload *PayloadSendNewDeviceLogin, opts ...asynq.Option) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendNewDeviceLogin, jsonPayload, opts...)
+	info, err := r.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+
+	return nil
+}
+
+func (r *RedisTaskProcessor) ProcessTaskSendNewDeviceLogin(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendNewDeviceLogin
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	user, err := r.userRepository.GetUser(ctx, payload.Username)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	subject := "New login to your Go Bank account"
+	// the page asks the user to confirm before it posts the token, so that
+	// mail scanners following the link do not revoke the session
+	revokeUrl := fmt.Sprintf("http://localhost:8080/revoke-session?token=%s", url.QueryEscape(payload.RevokeToken))
+	content := fmt.Sprintf(`Hello %s,<br/>
+		Your account was just logged in to from a new device.<br/>
+		Device: %s<br/>
+		IP address: %s<br/>
+		Time: %s<br/>
+		If it was you, you can ignore this email.
+		Otherwise, please <a href="%s">click here</a> to log this device out, then change your password.<br/>`,
+		user.FullName, html.EscapeString(payload.UserAgent), html.EscapeString(payload.ClientIP), payload.LoggedInAt.UTC().Format(time.RFC1123), revokeUrl)
+	to := []string{user.Email}
+	err = r.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send new device login email: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("email", user.Email).
+		Msg("proccessed task")
+
+	return nil
+}
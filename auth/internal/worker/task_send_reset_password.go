@@ -0,0 +1,86 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/infra"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendResetPassword = "task:send_reset_password"
+
+type PayloadSendResetPassword struct {
+	Username string
+}
+
+func (r *RedisTaskDistributor) DistributeTaskSendResetPassword(ctx context.Context, payload *PayloadSendResetPassword, opts ...asynq.Option) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendResetPassword, jsonPayload, opts...)
+	info, err := r.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+
+	return nil
+}
+
+func (r *RedisTaskProcessor) ProcessTaskSendResetPassword(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendResetPassword
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	user, err := r.userRepository.GetUser(ctx, payload.Username)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	secretCode, err := util.RandomSecretCode()
+	if err != nil {
+		return err
+	}
+
+	resetPassword, err := r.resetPasswordRepository.CreateResetPassword(ctx, infra.CreateResetPassword{
+		Username:       user.Username,
+		Email:          user.Email,
+		SecretCodeHash: util.HashSecretCode(secretCode),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create reset password: %w", err)
+	}
+
+	subject := "Reset your Go Bank password"
+	resetUrl := fmt.Sprintf("http://localhost:8080/reset-password?reset_id=%d&secret_code=%s", resetPassword.ID, secretCode)
+	content := fmt.Sprintf(`Hello %s,<br/>
+		We received a request to reset your password.<br/>
+		Please <a href="%s">click here</a> to choose a new one. The link expires in 15 minutes.<br/>
+		If you did not ask for it, you can ignore this email.<br/>`, user.FullName, resetUrl)
+	to := []string{user.Email}
+	err = r.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send reset password email: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("email", user.Email).
+		Msg("proccessed task")
+
+	return nil
+}
@@ -0,0 +1,34 @@
+// Package denylist records access tokens that must be rejected before they
+// expire. The auth service writes to it when sessions are revoked and the
+// gateway checks every authenticated request against it.
+//
+// Entries only have to outlive the tokens they reject, so their TTL is the
+// remaining lifetime of the token, or the access token duration when the
+// individual tokens are not known.
+package denylist
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+)
+
+type Denylist interface {
+	// RevokeToken rejects the token with the given ID until it expires.
+	RevokeToken(ctx context.Context, tokenID uuid.UUID, expiresAt time.Time) error
+	// RevokeSession rejects every token bound to the session, see token.WithSessionID.
+	RevokeSession(ctx context.Context, sessionID uuid.UUID, ttl time.Duration) error
+	// BlockUser rejects every token of the user issued up to now.
+	BlockUser(ctx context.Context, username string, ttl time.Duration) error
+	// IsRevoked reports whether any of the entries above rejects the token.
+	IsRevoked(ctx context.Context, payload *token.Payload) (bool, error)
+}
+
+// issuedBeforeBlock compares at second precision because JWT timestamps are
+// truncated to seconds. Tokens minted in the same second as the block are
+// kept, so a user logging in right after being blocked is not locked out.
+func issuedBeforeBlock(payload *token.Payload, blockedAt time.Time) bool {
+	return payload.IssuedAt.Unix() < blockedAt.Unix()
+}
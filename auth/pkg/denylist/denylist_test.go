@@ -0,0 +1,103 @@
+package denylist
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func randomPayload(t *testing.T, opts ...token.PayloadOption) *token.Payload {
+	payload, err := token.NewPayload(util.RandomUsername(), domain.UserRole, time.Minute, opts...)
+	require.NoError(t, err)
+	return payload
+}
+
+func testDenylist(t *testing.T, denylist Denylist) {
+	ctx := context.Background()
+
+	t.Run("NotRevoked", func(t *testing.T) {
+		revoked, err := denylist.IsRevoked(ctx, randomPayload(t, token.WithSessionID(uuid.New())))
+		require.NoError(t, err)
+		require.False(t, revoked)
+	})
+
+	t.Run("RevokeToken", func(t *testing.T) {
+		payload := randomPayload(t)
+		require.NoError(t, denylist.RevokeToken(ctx, payload.ID, payload.ExpiredAt))
+
+		revoked, err := denylist.IsRevoked(ctx, payload)
+		require.NoError(t, err)
+		require.True(t, revoked)
+	})
+
+	t.Run("RevokeSession", func(t *testing.T) {
+		sessionID := uuid.New()
+		payload := randomPayload(t, token.WithSessionID(sessionID))
+		other := randomPayload(t, token.WithSessionID(uuid.New()))
+		require.NoError(t, denylist.RevokeSession(ctx, sessionID, time.Minute))
+
+		revoked, err := denylist.IsRevoked(ctx, payload)
+		require.NoError(t, err)
+		require.True(t, revoked)
+
+		revoked, err = denylist.IsRevoked(ctx, other)
+		require.NoError(t, err)
+		require.False(t, revoked)
+	})
+
+	t.Run("BlockUser", func(t *testing.T) {
+		payload := randomPayload(t)
+		payload.IssuedAt = time.Now().Add(-time.Minute)
+		require.NoError(t, denylist.BlockUser(ctx, payload.Username, time.Minute))
+
+		revoked, err := denylist.IsRevoked(ctx, payload)
+		require.NoError(t, err)
+		require.True(t, revoked)
+
+		// tokens minted after the block, like a new login, are accepted
+		payload.IssuedAt = time.Now().Add(time.Second)
+		revoked, err = denylist.IsRevoked(ctx, payload)
+		require.NoError(t, err)
+		require.False(t, revoked)
+	})
+}
+
+func TestMemoryDenylist(t *testing.T) {
+	testDenylist(t, NewMemoryDenylist())
+}
+
+func TestMemoryDenylistExpiredEntries(t *testing.T) {
+	ctx := context.Background()
+	denylist := NewMemoryDenylist()
+
+	payload := randomPayload(t, token.WithSessionID(uuid.New()))
+	require.NoError(t, denylist.RevokeToken(ctx, payload.ID, time.Now().Add(-time.Second)))
+	require.NoError(t, denylist.RevokeSession(ctx, payload.SessionID, -time.Second))
+
+	revoked, err := denylist.IsRevoked(ctx, payload)
+	require.NoError(t, err)
+	require.False(t, revoked)
+	require.Empty(t, denylist.tokens)
+	require.Empty(t, denylist.sessions)
+}
+
+func TestRedisDenylist(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	config, err := util.LoadConfig("../../")
+	require.NoError(t, err)
+
+	client := redis.NewClient(&redis.Options{Addr: config.RedisAddress})
+	require.NoError(t, client.Ping(context.Background()).Err())
+
+	testDenylist(t, NewRedisDenylist(client))
+}
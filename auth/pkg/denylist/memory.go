@@ -0,0 +1,103 @@
+package denylist
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+)
+
+type memoryEntry struct {
+	blockedAt time.Time
+	expiresAt time.Time
+}
+
+// MemoryDenylist keeps the entries in the process memory. It is the fallback
+// when Redis is not configured, so revocations are only seen by the process
+// that made them.
+type MemoryDenylist struct {
+	mu       sync.Mutex
+	tokens   map[uuid.UUID]memoryEntry
+	sessions map[uuid.UUID]memoryEntry
+	users    map[string]memoryEntry
+}
+
+func NewMemoryDenylist() *MemoryDenylist {
+	return &MemoryDenylist{
+		tokens:   make(map[uuid.UUID]memoryEntry),
+		sessions: make(map[uuid.UUID]memoryEntry),
+		users:    make(map[string]memoryEntry),
+	}
+}
+
+func (m *MemoryDenylist) RevokeToken(_ context.Context, tokenID uuid.UUID, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tokens[tokenID] = memoryEntry{blockedAt: time.Now(), expiresAt: expiresAt}
+	return nil
+}
+
+func (m *MemoryDenylist) RevokeSession(_ context.Context, sessionID uuid.UUID, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.sessions[sessionID] = memoryEntry{blockedAt: now, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+func (m *MemoryDenylist) BlockUser(_ context.Context, username string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.users[username] = memoryEntry{blockedAt: now, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+func (m *MemoryDenylist) IsRevoked(_ context.Context, payload *token.Payload) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.removeExpired(now)
+
+	if _, ok := m.tokens[payload.ID]; ok {
+		return true, nil
+	}
+
+	if payload.SessionID != uuid.Nil {
+		if _, ok := m.sessions[payload.SessionID]; ok {
+			return true, nil
+		}
+	}
+
+	if entry, ok := m.users[payload.Username]; ok && issuedBeforeBlock(payload, entry.blockedAt) {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (m *MemoryDenylist) removeExpired(now time.Time) {
+	for id, entry := range m.tokens {
+		if now.After(entry.expiresAt) {
+			delete(m.tokens, id)
+		}
+	}
+
+	for id, entry := range m.sessions {
+		if now.After(entry.expiresAt) {
+			delete(m.sessions, id)
+		}
+	}
+
+	for username, entry := range m.users {
+		if now.After(entry.expiresAt) {
+			delete(m.users, username)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package denylist
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	tokenKeyPrefix   = "denylist:token:"
+	sessionKeyPrefix = "denylist:session:"
+	userKeyPrefix    = "denylist:user:"
+)
+
+// RedisDenylist stores the entries as keys expiring with the tokens they
+// reject, so it needs no cleanup and is shared by every service instance.
+type RedisDenylist struct {
+	client redis.UniversalClient
+}
+
+func NewRedisDenylist(client redis.UniversalClient) *RedisDenylist {
+	return &RedisDenylist{client: client}
+}
+
+// New returns a RedisDenylist on redisAddress, the Redis used by asynq, or a
+// MemoryDenylist when no address is configured.
+func New(redisAddress string) Denylist {
+	if redisAddress == "" {
+		return NewMemoryDenylist()
+	}
+
+	return NewRedisDenylist(redis.NewClient(&redis.Options{Addr: redisAddress}))
+}
+
+func (r *RedisDenylist) RevokeToken(ctx context.Context, tokenID uuid.UUID, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return r.client.Set(ctx, tokenKeyPrefix+tokenID.String(), 1, ttl).Err()
+}
+
+func (r *RedisDenylist) RevokeSession(ctx context.Context, sessionID uuid.UUID, ttl time.Duration) error {
+	return r.client.Set(ctx, sessionKeyPrefix+sessionID.String(), 1, ttl).Err()
+}
+
+func (r *RedisDenylist) BlockUser(ctx context.Context, username string, ttl time.Duration) error {
+	return r.client.Set(ctx, userKeyPrefix+username, time.Now().Unix(), ttl).Err()
+}
+
+func (r *RedisDenylist) IsRevoked(ctx context.Context, payload *token.Payload) (bool, error) {
+	keys := []string{tokenKeyPrefix + payload.ID.String(), userKeyPrefix + payload.Username}
+	if payload.SessionID != uuid.Nil {
+		keys = append(keys, sessionKeyPrefix+payload.SessionID.String())
+	}
+
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return false, err
+	}
+
+	if values[0] != nil {
+		return true, nil
+	}
+
+	if len(values) > 2 && values[2] != nil {
+		return true, nil
+	}
+
+	if blockedAt, ok := values[1].(string); ok {
+		seconds, err := strconv.ParseInt(blockedAt, 10, 64)
+		if err != nil {
+			return false, err
+		}
+
+		return issuedBeforeBlock(payload, time.Unix(seconds, 0)), nil
+	}
+
+	return false, nil
+}
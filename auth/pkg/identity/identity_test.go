@@ -0,0 +1,97 @@
+package identity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSigner(t *testing.T, ttl time.Duration) *Signer {
+	signer, err := NewSigner(util.RandomString(32), ttl)
+	require.NoError(t, err)
+	return signer
+}
+
+func TestNewSignerShortKey(t *testing.T) {
+	signer, err := NewSigner(util.RandomString(31), time.Minute)
+	require.Error(t, err)
+	require.Nil(t, signer)
+}
+
+func TestSignAndVerify(t *testing.T) {
+	signer := newTestSigner(t, time.Minute)
+
+	payload, err := token.NewPayload(util.RandomUsername(), domain.AdminRole, time.Minute, token.WithSessionID(uuid.New()))
+	require.NoError(t, err)
+
+	forwarded, err := signer.Verify(signer.Sign(payload))
+	require.NoError(t, err)
+	require.Equal(t, payload.Username, forwarded.Username)
+	require.Equal(t, payload.Role, forwarded.Role)
+	require.Equal(t, payload.ID, forwarded.ID)
+	require.Equal(t, payload.SessionID, forwarded.SessionID)
+}
+
+func TestVerifyMissingIdentity(t *testing.T) {
+	signer := newTestSigner(t, time.Minute)
+
+	payload, err := signer.Verify(nil)
+	require.ErrorIs(t, err, ErrMissingIdentity)
+	require.Nil(t, payload)
+}
+
+func TestVerifyTamperedIdentity(t *testing.T) {
+	signer := newTestSigner(t, time.Minute)
+
+	payload, err := token.NewPayload(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	md := signer.Sign(payload)
+	md.Set(RoleMetadata, domain.AdminRole)
+
+	forwarded, err := signer.Verify(md)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+	require.Nil(t, forwarded)
+}
+
+func TestVerifyUnsignedIdentity(t *testing.T) {
+	signer := newTestSigner(t, time.Minute)
+
+	payload, err := token.NewPayload(util.RandomUsername(), domain.AdminRole, time.Minute)
+	require.NoError(t, err)
+
+	md := signer.Sign(payload)
+	md.Delete(SignatureMetadata)
+
+	forwarded, err := signer.Verify(md)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+	require.Nil(t, forwarded)
+}
+
+func TestVerifyOtherKey(t *testing.T) {
+	payload, err := token.NewPayload(util.RandomUsername(), domain.AdminRole, time.Minute)
+	require.NoError(t, err)
+
+	md := newTestSigner(t, time.Minute).Sign(payload)
+
+	forwarded, err := newTestSigner(t, time.Minute).Verify(md)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+	require.Nil(t, forwarded)
+}
+
+func TestVerifyExpiredIdentity(t *testing.T) {
+	signer := newTestSigner(t, time.Minute)
+	signer.ttl = -time.Minute
+
+	payload, err := token.NewPayload(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	forwarded, err := signer.Verify(signer.Sign(payload))
+	require.ErrorIs(t, err, ErrExpiredIdentity)
+	require.Nil(t, forwarded)
+}
@@ -0,0 +1,21 @@
+package token
+
+import "crypto"
+
+// NewAsymmetricJwtToken creates a maker that signs with a single RSA (RS256)
+// or Ed25519 (EdDSA) private key.
+func NewAsymmetricJwtToken(kid string, privateKey crypto.Signer) (*KeyringJwtToken, error) {
+	keyring := NewKeyring()
+
+	err := keyring.AddPrivateKey(kid, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	err = keyring.SetActive(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeyringJwtToken(keyring), nil
+}
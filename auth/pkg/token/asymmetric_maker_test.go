@@ -0,0 +1,206 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomRSAKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func randomEd25519Key(t *testing.T) ed25519.PrivateKey {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func TestAsymmetricJWTMaker(t *testing.T) {
+	testCases := []struct {
+		name       string
+		privateKey func(t *testing.T) crypto.Signer
+		alg        string
+	}{
+		{
+			name:       "RS256",
+			privateKey: func(t *testing.T) crypto.Signer { return randomRSAKey(t) },
+			alg:        jwt.SigningMethodRS256.Alg(),
+		},
+		{
+			name:       "EdDSA",
+			privateKey: func(t *testing.T) crypto.Signer { return randomEd25519Key(t) },
+			alg:        jwt.SigningMethodEdDSA.Alg(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kid := util.RandomString(8)
+			maker, err := NewAsymmetricJwtToken(kid, tc.privateKey(t))
+			require.NoError(t, err)
+
+			username := util.RandomUsername()
+			duration := time.Minute
+			issuedAt := time.Now()
+			expiredAt := issuedAt.Add(duration)
+
+			token, payload, err := maker.CreateToken(username, domain.UserRole, duration)
+			require.NoError(t, err)
+			require.NotEmpty(t, token)
+			require.NotEmpty(t, payload)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &Payload{})
+			require.NoError(t, err)
+			require.Equal(t, kid, parsed.Header["kid"])
+			require.Equal(t, tc.alg, parsed.Header["alg"])
+
+			payload, err = maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.NotZero(t, payload.ID)
+			require.Equal(t, username, payload.Username)
+			require.Equal(t, domain.UserRole, payload.Role)
+			require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+			require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+
+			keySet, err := maker.KeySet()
+			require.NoError(t, err)
+			require.Len(t, keySet.Keys, 1)
+			require.Equal(t, kid, keySet.Keys[0].Kid)
+			require.Equal(t, tc.alg, keySet.Keys[0].Alg)
+		})
+	}
+}
+
+func TestExpiredAsymmetricJWTToken(t *testing.T) {
+	maker, err := NewAsymmetricJwtToken(util.RandomString(8), randomEd25519Key(t))
+	require.NoError(t, err)
+
+	token, payload, err := maker.CreateToken(util.RandomUsername(), domain.UserRole, -time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	payload, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestAsymmetricJWTTokenUnknownKid(t *testing.T) {
+	privateKey := randomRSAKey(t)
+
+	issuer, err := NewAsymmetricJwtToken("old", privateKey)
+	require.NoError(t, err)
+
+	verifier, err := NewAsymmetricJwtToken("new", privateKey)
+	require.NoError(t, err)
+
+	token, _, err := issuer.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := verifier.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestAsymmetricJWTTokenAlgorithmConfusion(t *testing.T) {
+	privateKey := randomRSAKey(t)
+	kid := util.RandomString(8)
+
+	maker, err := NewAsymmetricJwtToken(kid, privateKey)
+	require.NoError(t, err)
+
+	payload, err := NewPayload(util.RandomUsername(), domain.AdminRole, time.Minute)
+	require.NoError(t, err)
+
+	// sign with HS256 using the public key as the HMAC secret
+	publicKey, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	require.NoError(t, err)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+	jwtToken.Header["kid"] = kid
+	token, err := jwtToken.SignedString(publicKey)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestInvalidAsymmetricJWTTokenAlgNone(t *testing.T) {
+	kid := util.RandomString(8)
+	maker, err := NewAsymmetricJwtToken(kid, randomEd25519Key(t))
+	require.NoError(t, err)
+
+	payload, err := NewPayload(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodNone, payload)
+	jwtToken.Header["kid"] = kid
+	token, err := jwtToken.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestParsePrivateKeyPEM(t *testing.T) {
+	rsaKey := randomRSAKey(t)
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+
+	parsed, err := ParsePrivateKeyPEM(pkcs1)
+	require.NoError(t, err)
+	require.True(t, rsaKey.Equal(parsed))
+
+	edKey := randomEd25519Key(t)
+	der, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+	pkcs8 := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	parsed, err = ParsePrivateKeyPEM(pkcs8)
+	require.NoError(t, err)
+	require.True(t, edKey.Equal(parsed))
+
+	_, err = ParsePrivateKeyPEM([]byte("not a key"))
+	require.Error(t, err)
+}
+
+func TestKeyringJWTTokenSignClaims(t *testing.T) {
+	privateKey := randomRSAKey(t)
+	kid := util.RandomString(8)
+
+	maker, err := NewAsymmetricJwtToken(kid, privateKey)
+	require.NoError(t, err)
+
+	signed, err := maker.SignClaims(jwt.MapClaims{"sub": "subject", "nonce": "nonce"})
+	require.NoError(t, err)
+
+	claims := jwt.MapClaims{}
+	parsed, err := jwt.ParseWithClaims(signed, claims, func(token *jwt.Token) (any, error) {
+		return privateKey.Public(), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}))
+	require.NoError(t, err)
+	require.Equal(t, kid, parsed.Header["kid"])
+	require.Equal(t, "subject", claims["sub"])
+	require.Equal(t, "nonce", claims["nonce"])
+
+	keyring := NewKeyring()
+	require.NoError(t, keyring.AddSecret(kid, util.RandomString(32)))
+	require.NoError(t, keyring.SetActive(kid))
+
+	_, err = NewKeyringJwtToken(keyring).SignClaims(jwt.MapClaims{"sub": "subject"})
+	require.ErrorIs(t, err, ErrSymmetricKey)
+}
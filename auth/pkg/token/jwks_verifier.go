@@ -0,0 +1,109 @@
+package token
+
+import (
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// minJwksRefreshInterval bounds how often an unknown kid may trigger a fetch,
+// so tokens with made up key ids cannot hammer the JWKS endpoint.
+const minJwksRefreshInterval = 30 * time.Second
+
+// JwksVerifier verifies tokens issued by an AsymmetricJwtToken using the
+// public keys fetched from a JWKS endpoint. Keys are cached for cacheTTL and
+// refreshed early when a token references a kid that is not cached yet.
+type JwksVerifier struct {
+	url        string
+	cacheTTL   time.Duration
+	httpClient *http.Client
+
+	mu        sync.RWMutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+func NewJwksVerifier(url string, cacheTTL time.Duration) *JwksVerifier {
+	return &JwksVerifier{
+		url:        url,
+		cacheTTL:   cacheTTL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (v *JwksVerifier) VerifyToken(token string, opts ...VerifyOption) (*Payload, error) {
+	return parseToken(token, publicKeyFunc(v.publicKey), opts)
+}
+
+func (v *JwksVerifier) publicKey(kid string) (crypto.PublicKey, bool) {
+	v.mu.RLock()
+	key, ok := v.keys[kid]
+	fresh := time.Since(v.fetchedAt) < v.cacheTTL
+	v.mu.RUnlock()
+
+	if ok && fresh {
+		return key, true
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	// another request may have refreshed the keys while waiting for the lock
+	if key, ok := v.keys[kid]; ok && time.Since(v.fetchedAt) < v.cacheTTL {
+		return key, true
+	}
+
+	if ok || time.Since(v.fetchedAt) >= minJwksRefreshInterval {
+		if err := v.refresh(); err != nil {
+			log.Error().Err(err).Str("url", v.url).Msg("failed to refresh JWKS")
+		}
+	}
+
+	key, ok = v.keys[kid]
+	return key, ok
+}
+
+// refresh must be called with mu held for writing. On failure the previously
+// cached keys are kept.
+func (v *JwksVerifier) refresh() error {
+	v.fetchedAt = time.Now()
+
+	resp, err := v.httpClient.Get(v.url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var keySet JSONWebKeySet
+	err = json.NewDecoder(resp.Body).Decode(&keySet)
+	if err != nil {
+		return fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(keySet.Keys))
+	for _, jwk := range keySet.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		publicKey, err := jwk.PublicKey()
+		if err != nil {
+			log.Warn().Err(err).Str("kid", jwk.Kid).Msg("skipping JWKS key")
+			continue
+		}
+
+		keys[jwk.Kid] = publicKey
+	}
+
+	v.keys = keys
+	return nil
+}
@@ -0,0 +1,96 @@
+package token
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newJwksServer(t *testing.T, maker *KeyringJwtToken, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		keySet, err := maker.KeySet()
+		require.NoError(t, err)
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(keySet))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestJwksVerifier(t *testing.T) {
+	maker, err := NewAsymmetricJwtToken(util.RandomString(8), randomRSAKey(t))
+	require.NoError(t, err)
+
+	var requests atomic.Int32
+	server := newJwksServer(t, maker, &requests)
+
+	verifier := NewJwksVerifier(server.URL, time.Minute)
+
+	username := util.RandomUsername()
+	token, _, err := maker.CreateToken(username, domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	for range 3 {
+		payload, err := verifier.VerifyToken(token)
+		require.NoError(t, err)
+		require.Equal(t, username, payload.Username)
+	}
+
+	require.Equal(t, int32(1), requests.Load())
+}
+
+func TestJwksVerifierUnknownKid(t *testing.T) {
+	maker, err := NewAsymmetricJwtToken(util.RandomString(8), randomEd25519Key(t))
+	require.NoError(t, err)
+
+	var requests atomic.Int32
+	server := newJwksServer(t, maker, &requests)
+
+	verifier := NewJwksVerifier(server.URL, time.Minute)
+
+	other, err := NewAsymmetricJwtToken(util.RandomString(8), randomEd25519Key(t))
+	require.NoError(t, err)
+
+	token, _, err := other.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	for range 3 {
+		payload, err := verifier.VerifyToken(token)
+		require.EqualError(t, err, ErrInvalidToken.Error())
+		require.Nil(t, payload)
+	}
+
+	require.Equal(t, int32(1), requests.Load())
+}
+
+func TestJwksVerifierUnavailable(t *testing.T) {
+	maker, err := NewAsymmetricJwtToken(util.RandomString(8), randomEd25519Key(t))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	verifier := NewJwksVerifier(server.URL, time.Minute)
+
+	token, _, err := maker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := verifier.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
@@ -23,8 +23,8 @@ func NewJwtToken(secretKey string) (*JwtToken, error) {
 	return &JwtToken{secretKey: secretKey}, nil
 }
 
-func (j *JwtToken) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(username, role, duration)
+func (j *JwtToken) CreateToken(username string, role string, duration time.Duration, opts ...PayloadOption) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, duration, opts...)
 	if err != nil {
 		log.Error().Err(err).Msg("failed to create payload")
 		return "", nil, err
@@ -35,7 +35,7 @@ func (j *JwtToken) CreateToken(username string, role string, duration time.Durat
 	return token, payload, err
 }
 
-func (j *JwtToken) VerifyToken(token string) (*Payload, error) {
+func (j *JwtToken) VerifyToken(token string, opts ...VerifyOption) (*Payload, error) {
 	keyFun := func(token *jwt.Token) (any, error) {
 		_, ok := token.Method.(*jwt.SigningMethodHMAC)
 		if !ok {
@@ -45,7 +45,12 @@ func (j *JwtToken) VerifyToken(token string) (*Payload, error) {
 		return []byte(j.secretKey), nil
 	}
 
-	jwtToken, err := jwt.ParseWithClaims(token, &Payload{}, keyFun)
+	return parseToken(token, keyFun, opts)
+}
+
+func parseToken(token string, keyFunc jwt.Keyfunc, opts []VerifyOption) (*Payload, error) {
+	config := newVerifyConfig(opts)
+	jwtToken, err := jwt.ParseWithClaims(token, &Payload{}, keyFunc, config.parserOptions()...)
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
 			log.Error().Err(err).Msg("failed to parse token")
@@ -59,5 +64,10 @@ func (j *JwtToken) VerifyToken(token string) (*Payload, error) {
 		return nil, ErrInvalidToken
 	}
 
+	err = config.checkPurpose(payload)
+	if err != nil {
+		return nil, err
+	}
+
 	return payload, nil
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
 	"github.com/stretchr/testify/require"
@@ -68,3 +69,151 @@ func TestInvalidJWTTokenAlgNone(t *testing.T) {
 	require.EqualError(t, err, ErrInvalidToken.Error())
 	require.Nil(t, payload)
 }
+
+func TestJWTMakerRegisteredClaims(t *testing.T) {
+	maker, err := NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	username := util.RandomUsername()
+	token, _, err := maker.CreateToken(username, domain.UserRole, time.Minute, WithIssuer("auth-service"), WithAudience("gateway", "product"))
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token, RequireIssuer("auth-service"), RequireAudience("product"))
+	require.NoError(t, err)
+	require.Equal(t, "auth-service", payload.Issuer)
+	require.Equal(t, username, payload.Subject)
+	require.Equal(t, []string{"gateway", "product"}, payload.Audience)
+	require.Equal(t, payload.IssuedAt, payload.NotBefore)
+	require.NotZero(t, payload.ID)
+
+	claims := jwt.MapClaims{}
+	_, _, err = jwt.NewParser().ParseUnverified(token, claims)
+	require.NoError(t, err)
+	require.Equal(t, payload.ID.String(), claims["jti"])
+	require.Equal(t, username, claims["sub"])
+	require.Equal(t, "auth-service", claims["iss"])
+	require.Contains(t, claims, "nbf")
+	require.Contains(t, claims, "exp")
+
+	payload, err = maker.VerifyToken(token, RequireIssuer("other-service"))
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+
+	payload, err = maker.VerifyToken(token, RequireAudience("payment"))
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+
+	bound := WithVerifyOptions(maker, RequireAudience("payment"))
+	payload, err = bound.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestJWTTokenNotYetValid(t *testing.T) {
+	secretKey := util.RandomString(32)
+	maker, err := NewJwtToken(secretKey)
+	require.NoError(t, err)
+
+	payload, err := NewPayload(util.RandomUsername(), domain.UserRole, time.Hour)
+	require.NoError(t, err)
+	payload.NotBefore = time.Now().Add(time.Minute)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, payload).SignedString([]byte(secretKey))
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestJWTTokenMissingExpiration(t *testing.T) {
+	secretKey := util.RandomString(32)
+	maker, err := NewJwtToken(secretKey)
+	require.NoError(t, err)
+
+	claims := jwt.MapClaims{"jti": uuid.NewString(), "username": util.RandomUsername(), "role": domain.AdminRole}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secretKey))
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestJWTMakerEmailVerifiedClaim(t *testing.T) {
+	maker, err := NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute, WithEmailVerified(false))
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.NotNil(t, payload.EmailVerified)
+	require.False(t, *payload.EmailVerified)
+	require.False(t, payload.HasVerifiedEmail())
+
+	token, _, err = maker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Nil(t, payload.EmailVerified)
+	require.False(t, payload.HasVerifiedEmail())
+}
+
+func TestJWTMakerPermissionsClaim(t *testing.T) {
+	maker, err := NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute, WithPermissions("product:read", "product:write"))
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, []string{"product:read", "product:write"}, payload.Permissions)
+	require.True(t, payload.HasPermission("product:write"))
+	require.False(t, payload.HasPermission("user:admin"))
+}
+
+func TestJWTMakerScopesClaim(t *testing.T) {
+	maker, err := NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute, WithScopes("openid", "email"))
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, []string{"openid", "email"}, payload.Scopes)
+
+	token, _, err = maker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Nil(t, payload.Scopes)
+}
+
+func TestJWTMakerPurpose(t *testing.T) {
+	maker, err := NewJwtToken(util.RandomString(32))
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute, WithPurpose(PurposeMFAChallenge))
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+
+	payload, err = maker.VerifyToken(token, RequirePurpose(PurposeMFAChallenge))
+	require.NoError(t, err)
+	require.Equal(t, PurposeMFAChallenge, payload.Purpose)
+
+	token, _, err = maker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token, RequirePurpose(PurposeMFAChallenge))
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
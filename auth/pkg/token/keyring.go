@@ -0,0 +1,305 @@
+package token
+
+import (
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrNoActiveKey  = errors.New("keyring has no active signing key")
+	ErrKeyNotFound  = errors.New("key not found in keyring")
+	ErrDuplicateKey = errors.New("key id already present in keyring")
+)
+
+type keyringKey struct {
+	kid         string
+	method      jwt.SigningMethod
+	signingKey  any
+	verifyKey   any
+	publicKey   crypto.PublicKey
+	verifyUntil time.Time
+}
+
+// Keyring holds one active signing key and any number of verification keys,
+// selected by the kid header of the token. Retired keys keep verifying tokens
+// until their verifyUntil deadline, which lets tokens signed before a rotation
+// live out their lifetime. A Keyring is not safe for modification once it is
+// in use; reload it instead.
+type Keyring struct {
+	activeKid string
+	keys      map[string]*keyringKey
+}
+
+func NewKeyring() *Keyring {
+	return &Keyring{keys: make(map[string]*keyringKey)}
+}
+
+// AddSecret adds an HS256 key. Secrets can not be published in a JWKS, so
+// every verifier must be configured with the same keyring.
+func (k *Keyring) AddSecret(kid string, secret string) error {
+	if len(secret) < minSecretKeySize {
+		return fmt.Errorf("invalid key size: must be at least %d characters", minSecretKeySize)
+	}
+
+	return k.add(&keyringKey{
+		kid:        kid,
+		method:     jwt.SigningMethodHS256,
+		signingKey: []byte(secret),
+		verifyKey:  []byte(secret),
+	})
+}
+
+// AddPrivateKey adds an RSA (RS256) or Ed25519 (EdDSA) key that can sign and verify.
+func (k *Keyring) AddPrivateKey(kid string, privateKey crypto.Signer) error {
+	method, err := signingMethodFor(privateKey.Public())
+	if err != nil {
+		return err
+	}
+
+	return k.add(&keyringKey{
+		kid:        kid,
+		method:     method,
+		signingKey: privateKey,
+		verifyKey:  privateKey.Public(),
+		publicKey:  privateKey.Public(),
+	})
+}
+
+// AddPublicKey adds a verification only key, typically a retired key whose
+// private half was already destroyed.
+func (k *Keyring) AddPublicKey(kid string, publicKey crypto.PublicKey) error {
+	method, err := signingMethodFor(publicKey)
+	if err != nil {
+		return err
+	}
+
+	return k.add(&keyringKey{
+		kid:       kid,
+		method:    method,
+		verifyKey: publicKey,
+		publicKey: publicKey,
+	})
+}
+
+func (k *Keyring) add(key *keyringKey) error {
+	if key.kid == "" {
+		return errors.New("key id must not be empty")
+	}
+
+	if _, ok := k.keys[key.kid]; ok {
+		return ErrDuplicateKey
+	}
+
+	k.keys[key.kid] = key
+	return nil
+}
+
+// SetActive selects the key used to sign new tokens.
+func (k *Keyring) SetActive(kid string) error {
+	key, ok := k.keys[kid]
+	if !ok {
+		return ErrKeyNotFound
+	}
+
+	if key.signingKey == nil {
+		return fmt.Errorf("key %s can not sign tokens", kid)
+	}
+
+	if !key.verifyUntil.IsZero() {
+		return fmt.Errorf("key %s is retired", kid)
+	}
+
+	k.activeKid = kid
+	return nil
+}
+
+// Retire stops accepting tokens signed by kid after verifyUntil. It should be
+// at least the longest token lifetime after the key stopped being active.
+func (k *Keyring) Retire(kid string, verifyUntil time.Time) error {
+	key, ok := k.keys[kid]
+	if !ok {
+		return ErrKeyNotFound
+	}
+
+	if kid == k.activeKid {
+		return fmt.Errorf("key %s is active", kid)
+	}
+
+	key.verifyUntil = verifyUntil
+	return nil
+}
+
+func (k *Keyring) activeKey() (*keyringKey, error) {
+	key, ok := k.keys[k.activeKid]
+	if !ok {
+		return nil, ErrNoActiveKey
+	}
+
+	return key, nil
+}
+
+func (k *Keyring) verificationKey(kid string) (*keyringKey, bool) {
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, false
+	}
+
+	if !key.verifyUntil.IsZero() && time.Now().After(key.verifyUntil) {
+		return nil, false
+	}
+
+	return key, true
+}
+
+func (k *Keyring) keyFunc(token *jwt.Token) (any, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok || kid == "" {
+		return nil, ErrInvalidToken
+	}
+
+	key, ok := k.verificationKey(kid)
+	if !ok || token.Method.Alg() != key.method.Alg() {
+		return nil, ErrInvalidToken
+	}
+
+	return key.verifyKey, nil
+}
+
+// KeySet returns the public keys still accepted for verification.
+func (k *Keyring) KeySet() (JSONWebKeySet, error) {
+	keySet := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for kid := range k.keys {
+		key, ok := k.verificationKey(kid)
+		if !ok || key.publicKey == nil {
+			continue
+		}
+
+		jwk, err := NewJSONWebKey(kid, key.publicKey)
+		if err != nil {
+			return JSONWebKeySet{}, err
+		}
+
+		keySet.Keys = append(keySet.Keys, jwk)
+	}
+
+	return keySet, nil
+}
+
+type keyringConfig struct {
+	ActiveKid string `json:"active_kid"`
+	Keys      []struct {
+		Kid            string     `json:"kid"`
+		Secret         string     `json:"secret"`
+		PrivateKeyPath string     `json:"private_key_path"`
+		PublicKeyPath  string     `json:"public_key_path"`
+		VerifyUntil    *time.Time `json:"verify_until"`
+	} `json:"keys"`
+}
+
+// LoadKeyring reads the keyring file of a signer. Key paths are relative to
+// the file. The active_kid is required, so a keyring that can not sign is
+// rejected at startup instead of on the first token.
+//
+//	{
+//	  "active_kid": "2025-02",
+//	  "keys": [
+//	    {"kid": "2025-02", "private_key_path": "keys/2025-02.pem"},
+//	    {"kid": "2025-01", "public_key_path": "keys/2025-01.pub.pem", "verify_until": "2025-02-02T00:00:00Z"}
+//	  ]
+//	}
+func LoadKeyring(path string) (*Keyring, error) {
+	keyring, err := LoadVerificationKeyring(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyring.activeKid == "" {
+		return nil, ErrNoActiveKey
+	}
+
+	return keyring, nil
+}
+
+// LoadVerificationKeyring reads a keyring file like LoadKeyring, but lets the
+// active_kid be empty for keyrings that only verify tokens, like the gateway's.
+func LoadVerificationKeyring(path string) (*Keyring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keyring: %w", err)
+	}
+
+	var config keyringConfig
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal keyring: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	resolve := func(keyPath string) string {
+		if filepath.IsAbs(keyPath) {
+			return keyPath
+		}
+		return filepath.Join(dir, keyPath)
+	}
+
+	keyring := NewKeyring()
+	for _, key := range config.Keys {
+		switch {
+		case key.Secret != "":
+			err = keyring.AddSecret(key.Kid, key.Secret)
+		case key.PrivateKeyPath != "":
+			err = addPEMKey(key.PrivateKeyPath, resolve, func(data []byte) error {
+				privateKey, err := ParsePrivateKeyPEM(data)
+				if err != nil {
+					return err
+				}
+				return keyring.AddPrivateKey(key.Kid, privateKey)
+			})
+		case key.PublicKeyPath != "":
+			err = addPEMKey(key.PublicKeyPath, resolve, func(data []byte) error {
+				publicKey, err := ParsePublicKeyPEM(data)
+				if err != nil {
+					return err
+				}
+				return keyring.AddPublicKey(key.Kid, publicKey)
+			})
+		default:
+			err = errors.New("one of secret, private_key_path or public_key_path is required")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q: %w", key.Kid, err)
+		}
+
+		if key.VerifyUntil != nil {
+			err = keyring.Retire(key.Kid, *key.VerifyUntil)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key %q: %w", key.Kid, err)
+			}
+		}
+	}
+
+	if config.ActiveKid != "" {
+		err = keyring.SetActive(config.ActiveKid)
+		if err != nil {
+			return nil, fmt.Errorf("invalid active key %q: %w", config.ActiveKid, err)
+		}
+	}
+
+	return keyring, nil
+}
+
+func addPEMKey(path string, resolve func(string) string, add func(data []byte) error) error {
+	data, err := os.ReadFile(resolve(path))
+	if err != nil {
+		return err
+	}
+
+	return add(data)
+}
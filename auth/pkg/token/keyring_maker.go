@@ -0,0 +1,70 @@
+package token
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrSymmetricKey is returned when claims that must be verifiable by third
+// parties are signed while the active key is an HS256 secret.
+var ErrSymmetricKey = errors.New("active key is a secret and can not be published")
+
+// KeyringJwtToken signs tokens with the active key of a Keyring and stamps its
+// id in the kid header. Verification picks the key by kid, so tokens signed by
+// a previous key stay valid while that key is still in the keyring.
+type KeyringJwtToken struct {
+	keyring *Keyring
+}
+
+func NewKeyringJwtToken(keyring *Keyring) *KeyringJwtToken {
+	return &KeyringJwtToken{keyring: keyring}
+}
+
+func (k *KeyringJwtToken) CreateToken(username string, role string, duration time.Duration, opts ...PayloadOption) (string, *Payload, error) {
+	key, err := k.keyring.activeKey()
+	if err != nil {
+		return "", nil, err
+	}
+
+	payload, err := NewPayload(username, role, duration, opts...)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create payload")
+		return "", nil, err
+	}
+
+	jwtToken := jwt.NewWithClaims(key.method, payload)
+	jwtToken.Header["kid"] = key.kid
+
+	token, err := jwtToken.SignedString(key.signingKey)
+	return token, payload, err
+}
+
+func (k *KeyringJwtToken) VerifyToken(token string, opts ...VerifyOption) (*Payload, error) {
+	return parseToken(token, k.keyring.keyFunc, opts)
+}
+
+func (k *KeyringJwtToken) KeySet() (JSONWebKeySet, error) {
+	return k.keyring.KeySet()
+}
+
+// SignClaims signs claims other than a Payload, such as OpenID Connect id
+// tokens, with the active key. Those tokens are verified by other parties
+// through the JWKS, so the active key must be asymmetric.
+func (k *KeyringJwtToken) SignClaims(claims jwt.Claims) (string, error) {
+	key, err := k.keyring.activeKey()
+	if err != nil {
+		return "", err
+	}
+
+	if key.publicKey == nil {
+		return "", ErrSymmetricKey
+	}
+
+	jwtToken := jwt.NewWithClaims(key.method, claims)
+	jwtToken.Header["kid"] = key.kid
+
+	return jwtToken.SignedString(key.signingKey)
+}
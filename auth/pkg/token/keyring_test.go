@@ -0,0 +1,209 @@
+package token
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyringRotation(t *testing.T) {
+	oldKey := randomEd25519Key(t)
+	newKey := randomRSAKey(t)
+
+	oldKeyring := NewKeyring()
+	require.NoError(t, oldKeyring.AddPrivateKey("old", oldKey))
+	require.NoError(t, oldKeyring.SetActive("old"))
+
+	oldToken, _, err := NewKeyringJwtToken(oldKeyring).CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	rotated := NewKeyring()
+	require.NoError(t, rotated.AddPrivateKey("new", newKey))
+	require.NoError(t, rotated.AddPublicKey("old", oldKey.Public()))
+	require.NoError(t, rotated.Retire("old", time.Now().Add(time.Hour)))
+	require.NoError(t, rotated.SetActive("new"))
+
+	maker := NewKeyringJwtToken(rotated)
+
+	payload, err := maker.VerifyToken(oldToken)
+	require.NoError(t, err)
+	require.NotNil(t, payload)
+
+	newToken, _, err := maker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &Payload{})
+	require.NoError(t, err)
+	require.Equal(t, "new", parsed.Header["kid"])
+
+	keySet, err := maker.KeySet()
+	require.NoError(t, err)
+	require.Len(t, keySet.Keys, 2)
+}
+
+func TestKeyringRetiredKeyExpired(t *testing.T) {
+	oldKey := randomEd25519Key(t)
+
+	keyring := NewKeyring()
+	require.NoError(t, keyring.AddPrivateKey("old", oldKey))
+	require.NoError(t, keyring.SetActive("old"))
+
+	maker := NewKeyringJwtToken(keyring)
+	token, _, err := maker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	rotated := NewKeyring()
+	require.NoError(t, rotated.AddPrivateKey("new", randomEd25519Key(t)))
+	require.NoError(t, rotated.AddPublicKey("old", oldKey.Public()))
+	require.NoError(t, rotated.Retire("old", time.Now().Add(-time.Second)))
+	require.NoError(t, rotated.SetActive("new"))
+
+	payload, err := NewKeyringJwtToken(rotated).VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+
+	keySet, err := rotated.KeySet()
+	require.NoError(t, err)
+	require.Len(t, keySet.Keys, 1)
+	require.Equal(t, "new", keySet.Keys[0].Kid)
+}
+
+func TestKeyringSecrets(t *testing.T) {
+	keyring := NewKeyring()
+	require.NoError(t, keyring.AddSecret("one", util.RandomString(32)))
+	require.NoError(t, keyring.AddSecret("two", util.RandomString(32)))
+	require.NoError(t, keyring.SetActive("one"))
+
+	maker := NewKeyringJwtToken(keyring)
+	token, _, err := maker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	require.NoError(t, keyring.SetActive("two"))
+
+	payload, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.NotNil(t, payload)
+
+	keySet, err := maker.KeySet()
+	require.NoError(t, err)
+	require.Empty(t, keySet.Keys)
+
+	require.Error(t, keyring.AddSecret("short", util.RandomString(8)))
+	require.ErrorIs(t, keyring.AddSecret("one", util.RandomString(32)), ErrDuplicateKey)
+}
+
+func TestKeyringInvalidActiveKey(t *testing.T) {
+	keyring := NewKeyring()
+
+	_, _, err := NewKeyringJwtToken(keyring).CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.ErrorIs(t, err, ErrNoActiveKey)
+
+	require.ErrorIs(t, keyring.SetActive("missing"), ErrKeyNotFound)
+
+	require.NoError(t, keyring.AddPublicKey("public", randomEd25519Key(t).Public()))
+	require.Error(t, keyring.SetActive("public"))
+
+	require.NoError(t, keyring.AddPrivateKey("retired", randomEd25519Key(t)))
+	require.NoError(t, keyring.Retire("retired", time.Now().Add(time.Hour)))
+	require.Error(t, keyring.SetActive("retired"))
+}
+
+func TestLoadKeyring(t *testing.T) {
+	dir := t.TempDir()
+
+	newKey := randomEd25519Key(t)
+	der, err := x509.MarshalPKCS8PrivateKey(newKey)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "new.pem"), "PRIVATE KEY", der)
+
+	oldKey := randomRSAKey(t)
+	der, err = x509.MarshalPKIXPublicKey(oldKey.Public())
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "old.pub.pem"), "PUBLIC KEY", der)
+
+	secret := util.RandomString(32)
+	verifyUntil := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	config := fmt.Sprintf(`{
+		"active_kid": "new",
+		"keys": [
+			{"kid": "new", "private_key_path": "new.pem"},
+			{"kid": "old", "public_key_path": "old.pub.pem", "verify_until": %q},
+			{"kid": "legacy", "secret": %q, "verify_until": %q}
+		]
+	}`, verifyUntil, secret, verifyUntil)
+	path := filepath.Join(dir, "keyring.json")
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+
+	keyring, err := LoadKeyring(path)
+	require.NoError(t, err)
+
+	oldMaker, err := NewAsymmetricJwtToken("old", oldKey)
+	require.NoError(t, err)
+	oldToken, _, err := oldMaker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	legacy := NewKeyring()
+	require.NoError(t, legacy.AddSecret("legacy", secret))
+	require.NoError(t, legacy.SetActive("legacy"))
+	legacyToken, _, err := NewKeyringJwtToken(legacy).CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	maker := NewKeyringJwtToken(keyring)
+	for _, token := range []string{oldToken, legacyToken} {
+		payload, err := maker.VerifyToken(token)
+		require.NoError(t, err)
+		require.NotNil(t, payload)
+	}
+
+	token, _, err := maker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &Payload{})
+	require.NoError(t, err)
+	require.Equal(t, "new", parsed.Header["kid"])
+}
+
+func TestLoadKeyringActiveRetired(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	config := fmt.Sprintf(`{
+		"active_kid": "one",
+		"keys": [{"kid": "one", "secret": %q, "verify_until": "2020-01-01T00:00:00Z"}]
+	}`, util.RandomString(32))
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+
+	keyring, err := LoadKeyring(path)
+	require.Error(t, err)
+	require.Nil(t, keyring)
+}
+
+func TestLoadKeyringWithoutActiveKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	config := fmt.Sprintf(`{
+		"keys": [{"kid": "one", "secret": %q}]
+	}`, util.RandomString(32))
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+
+	keyring, err := LoadKeyring(path)
+	require.ErrorIs(t, err, ErrNoActiveKey)
+	require.Nil(t, keyring)
+
+	keyring, err = LoadVerificationKeyring(path)
+	require.NoError(t, err)
+	require.NotNil(t, keyring)
+}
+
+func writePEM(t *testing.T, path string, blockType string, der []byte) {
+	t.Helper()
+
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
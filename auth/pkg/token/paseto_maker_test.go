@@ -0,0 +1,230 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/domain"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newPasetoMakers(t *testing.T) map[string]*PasetoMaker {
+	local, err := NewPasetoLocalMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	public, err := NewPasetoPublicMaker(randomEd25519Key(t))
+	require.NoError(t, err)
+
+	return map[string]*PasetoMaker{"Local": local, "Public": public}
+}
+
+func TestPasetoMaker(t *testing.T) {
+	for name, maker := range newPasetoMakers(t) {
+		t.Run(name, func(t *testing.T) {
+			username := util.RandomUsername()
+			role := domain.UserRole
+			duration := time.Minute
+
+			issuedAt := time.Now()
+			expiredAt := issuedAt.Add(duration)
+
+			token, payload, err := maker.CreateToken(username, role, duration)
+			require.NoError(t, err)
+			require.NotEmpty(t, token)
+			require.NotEmpty(t, payload)
+
+			payload, err = maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.NotEmpty(t, payload)
+
+			require.NotZero(t, payload.ID)
+			require.Equal(t, username, payload.Username)
+			require.Equal(t, role, payload.Role)
+			require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+			require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+		})
+	}
+}
+
+func TestExpiredPasetoToken(t *testing.T) {
+	for name, maker := range newPasetoMakers(t) {
+		t.Run(name, func(t *testing.T) {
+			token, payload, err := maker.CreateToken(util.RandomUsername(), domain.UserRole, -time.Minute)
+			require.NoError(t, err)
+			require.NotEmpty(t, token)
+			require.NotEmpty(t, payload)
+
+			payload, err = maker.VerifyToken(token)
+			require.Error(t, err)
+			require.EqualError(t, err, ErrExpiredToken.Error())
+			require.Nil(t, payload)
+		})
+	}
+}
+
+func TestInvalidPasetoTokenAlgNone(t *testing.T) {
+	payload, err := NewPayload(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodNone, payload)
+	token, err := jwtToken.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	for name, maker := range newPasetoMakers(t) {
+		t.Run(name, func(t *testing.T) {
+			payload, err := maker.VerifyToken(token)
+			require.Error(t, err)
+			require.EqualError(t, err, ErrInvalidToken.Error())
+			require.Nil(t, payload)
+		})
+	}
+}
+
+func TestInvalidPasetoTokenWrongPurpose(t *testing.T) {
+	makers := newPasetoMakers(t)
+
+	localToken, _, err := makers["Local"].CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	publicToken, _, err := makers["Public"].CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := makers["Public"].VerifyToken(localToken)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+
+	payload, err = makers["Local"].VerifyToken(publicToken)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestPasetoMakerRegisteredClaims(t *testing.T) {
+	for name, maker := range newPasetoMakers(t) {
+		t.Run(name, func(t *testing.T) {
+			username := util.RandomUsername()
+			token, _, err := maker.CreateToken(username, domain.UserRole, time.Minute, WithIssuer("auth-service"), WithAudience("gateway", "product"), WithSessionID(uuid.New()))
+			require.NoError(t, err)
+
+			payload, err := maker.VerifyToken(token, RequireIssuer("auth-service"), RequireAudience("product"))
+			require.NoError(t, err)
+			require.Equal(t, "auth-service", payload.Issuer)
+			require.Equal(t, username, payload.Subject)
+			require.Equal(t, []string{"gateway", "product"}, payload.Audience)
+			require.NotZero(t, payload.SessionID)
+
+			payload, err = maker.VerifyToken(token, RequireIssuer("other-service"))
+			require.EqualError(t, err, ErrInvalidToken.Error())
+			require.Nil(t, payload)
+
+			payload, err = maker.VerifyToken(token, RequireAudience("payment"))
+			require.EqualError(t, err, ErrInvalidToken.Error())
+			require.Nil(t, payload)
+		})
+	}
+}
+
+func TestPasetoTokenMissingExpiration(t *testing.T) {
+	secretKey := util.RandomString(32)
+	maker, err := NewPasetoLocalMaker(secretKey)
+	require.NoError(t, err)
+
+	key, err := paseto.V4SymmetricKeyFromBytes([]byte(secretKey))
+	require.NoError(t, err)
+
+	pasetoToken := paseto.NewToken()
+	pasetoToken.SetJti(uuid.NewString())
+	pasetoToken.SetString("username", util.RandomUsername())
+	pasetoToken.SetString("role", domain.AdminRole)
+	token := pasetoToken.V4Encrypt(key, nil)
+
+	payload, err := maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestPasetoPublicVerifier(t *testing.T) {
+	privateKey := randomEd25519Key(t)
+
+	maker, err := NewPasetoPublicMaker(privateKey)
+	require.NoError(t, err)
+
+	verifier, err := NewPasetoPublicVerifier(privateKey.Public().(ed25519.PublicKey))
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := verifier.VerifyToken(token)
+	require.NoError(t, err)
+	require.NotNil(t, payload)
+
+	_, _, err = verifier.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute)
+	require.ErrorIs(t, err, ErrVerifyOnly)
+}
+
+func TestInvalidPasetoLocalKeySize(t *testing.T) {
+	maker, err := NewPasetoLocalMaker(util.RandomString(31))
+	require.Error(t, err)
+	require.Nil(t, maker)
+}
+
+func TestPasetoMakerEmailVerifiedClaim(t *testing.T) {
+	for name, maker := range newPasetoMakers(t) {
+		t.Run(name, func(t *testing.T) {
+			token, _, err := maker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute, WithEmailVerified(true))
+			require.NoError(t, err)
+
+			payload, err := maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.True(t, payload.HasVerifiedEmail())
+		})
+	}
+}
+
+func TestPasetoMakerPermissionsClaim(t *testing.T) {
+	for name, maker := range newPasetoMakers(t) {
+		t.Run(name, func(t *testing.T) {
+			token, _, err := maker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute, WithPermissions("product:read"))
+			require.NoError(t, err)
+
+			payload, err := maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.True(t, payload.HasPermission("product:read"))
+		})
+	}
+}
+
+func TestPasetoMakerScopesClaim(t *testing.T) {
+	for name, maker := range newPasetoMakers(t) {
+		t.Run(name, func(t *testing.T) {
+			token, _, err := maker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute, WithScopes("openid", "profile"))
+			require.NoError(t, err)
+
+			payload, err := maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.Equal(t, []string{"openid", "profile"}, payload.Scopes)
+		})
+	}
+}
+
+func TestPasetoMakerPurpose(t *testing.T) {
+	for name, maker := range newPasetoMakers(t) {
+		t.Run(name, func(t *testing.T) {
+			token, _, err := maker.CreateToken(util.RandomUsername(), domain.UserRole, time.Minute, WithPurpose(PurposeMFAChallenge))
+			require.NoError(t, err)
+
+			payload, err := maker.VerifyToken(token)
+			require.EqualError(t, err, ErrInvalidToken.Error())
+			require.Nil(t, payload)
+
+			payload, err = maker.VerifyToken(token, RequirePurpose(PurposeMFAChallenge))
+			require.NoError(t, err)
+			require.Equal(t, PurposeMFAChallenge, payload.Purpose)
+		})
+	}
+}
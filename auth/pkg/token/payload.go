@@ -1,7 +1,10 @@
 package token
 
 import (
+	"encoding/json"
 	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -15,29 +18,224 @@ var (
 
 type Payload struct {
 	ID        uuid.UUID
+	Issuer    string
+	Subject   string
+	Audience  []string
 	Username  string
 	Role      string
+	SessionID uuid.UUID
+	// EmailVerified is only set when the issuer reports the email status,
+	// see HasVerifiedEmail.
+	EmailVerified *bool
+	// Purpose is empty for access tokens. Tokens with a purpose, such as
+	// PurposeRefresh, only pass VerifyToken with RequirePurpose.
+	Purpose string
+	// Permissions are the effective permissions of the user when the access
+	// token was issued, granted through their roles.
+	Permissions []string
+	// Scopes are the OAuth scopes granted to the OpenID Connect client the
+	// token was issued to, written as the space separated scope claim.
+	Scopes    []string
 	IssuedAt  time.Time
+	NotBefore time.Time
 	ExpiredAt time.Time
 }
 
-func NewPayload(username string, role string, durantion time.Duration) (*Payload, error) {
+// PurposeRefresh marks first party refresh tokens, so they are not accepted
+// as bearer access tokens.
+const PurposeRefresh = "refresh"
+
+// PurposeMFAChallenge marks the token returned by a login that still needs a
+// second factor.
+const PurposeMFAChallenge = "mfa_challenge"
+
+// PurposeOidcAccess and PurposeOidcRefresh mark the tokens issued to OpenID
+// Connect clients, so they are not accepted in place of first party tokens.
+const (
+	PurposeOidcAccess  = "oidc_access"
+	PurposeOidcRefresh = "oidc_refresh"
+)
+
+// PurposeRevokeSession marks the token of the link that revokes a session
+// from the new device login email.
+const PurposeRevokeSession = "revoke_session"
+
+type PayloadOption func(payload *Payload)
+
+// WithSessionID binds the token to the login session it was issued for.
+func WithSessionID(sessionID uuid.UUID) PayloadOption {
+	return func(payload *Payload) {
+		payload.SessionID = sessionID
+	}
+}
+
+// WithIssuer names the service that minted the token.
+func WithIssuer(issuer string) PayloadOption {
+	return func(payload *Payload) {
+		payload.Issuer = issuer
+	}
+}
+
+// WithAudience names the services the token is meant for.
+func WithAudience(audience ...string) PayloadOption {
+	return func(payload *Payload) {
+		payload.Audience = audience
+	}
+}
+
+// WithEmailVerified records whether the user has verified their email address.
+func WithEmailVerified(verified bool) PayloadOption {
+	return func(payload *Payload) {
+		payload.EmailVerified = &verified
+	}
+}
+
+// WithPermissions records the effective permissions of the user.
+func WithPermissions(permissions ...string) PayloadOption {
+	return func(payload *Payload) {
+		payload.Permissions = permissions
+	}
+}
+
+// WithScopes records the scopes granted to the client the token is issued to.
+func WithScopes(scopes ...string) PayloadOption {
+	return func(payload *Payload) {
+		payload.Scopes = scopes
+	}
+}
+
+// WithPurpose restricts the token to a single use, see Payload.Purpose.
+func WithPurpose(purpose string) PayloadOption {
+	return func(payload *Payload) {
+		payload.Purpose = purpose
+	}
+}
+
+func NewPayload(username string, role string, durantion time.Duration, opts ...PayloadOption) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
 	}
 
+	now := time.Now()
 	payload := &Payload{
 		ID:        tokenID,
+		Subject:   username,
 		Username:  username,
 		Role:      role,
-		IssuedAt:  time.Now(),
-		ExpiredAt: time.Now().Add(durantion),
+		IssuedAt:  now,
+		NotBefore: now,
+		ExpiredAt: now.Add(durantion),
+	}
+
+	for _, opt := range opts {
+		opt(payload)
 	}
 
 	return payload, nil
 }
 
+// jsonPayload is the wire format of Payload, using the registered claim names
+// of RFC 7519 and NumericDate timestamps.
+type jsonPayload struct {
+	ID            uuid.UUID        `json:"jti"`
+	Issuer        string           `json:"iss,omitempty"`
+	Subject       string           `json:"sub,omitempty"`
+	Audience      jwt.ClaimStrings `json:"aud,omitempty"`
+	Username      string           `json:"username"`
+	Role          string           `json:"role"`
+	SessionID     uuid.UUID        `json:"sid,omitzero"`
+	EmailVerified *bool            `json:"email_verified,omitempty"`
+	Purpose       string           `json:"purpose,omitempty"`
+	Permissions   []string         `json:"permissions,omitempty"`
+	Scope         string           `json:"scope,omitempty"`
+	IssuedAt      *jwt.NumericDate `json:"iat,omitempty"`
+	NotBefore     *jwt.NumericDate `json:"nbf,omitempty"`
+	ExpiredAt     *jwt.NumericDate `json:"exp,omitempty"`
+}
+
+func (payload *Payload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonPayload{
+		ID:            payload.ID,
+		Issuer:        payload.Issuer,
+		Subject:       payload.Subject,
+		Audience:      payload.Audience,
+		Username:      payload.Username,
+		Role:          payload.Role,
+		SessionID:     payload.SessionID,
+		EmailVerified: payload.EmailVerified,
+		Purpose:       payload.Purpose,
+		Permissions:   payload.Permissions,
+		Scope:         strings.Join(payload.Scopes, " "),
+		IssuedAt:      numericDate(payload.IssuedAt),
+		NotBefore:     numericDate(payload.NotBefore),
+		ExpiredAt:     numericDate(payload.ExpiredAt),
+	})
+}
+
+func (payload *Payload) UnmarshalJSON(data []byte) error {
+	var claims jsonPayload
+	err := json.Unmarshal(data, &claims)
+	if err != nil {
+		return err
+	}
+
+	*payload = Payload{
+		ID:            claims.ID,
+		Issuer:        claims.Issuer,
+		Subject:       claims.Subject,
+		Audience:      claims.Audience,
+		Username:      claims.Username,
+		Role:          claims.Role,
+		SessionID:     claims.SessionID,
+		EmailVerified: claims.EmailVerified,
+		Purpose:       claims.Purpose,
+		Permissions:   claims.Permissions,
+		Scopes:        scopesOf(claims.Scope),
+		IssuedAt:      timeOf(claims.IssuedAt),
+		NotBefore:     timeOf(claims.NotBefore),
+		ExpiredAt:     timeOf(claims.ExpiredAt),
+	}
+
+	return nil
+}
+
+func numericDate(t time.Time) *jwt.NumericDate {
+	if t.IsZero() {
+		return nil
+	}
+
+	return jwt.NewNumericDate(t)
+}
+
+func timeOf(date *jwt.NumericDate) time.Time {
+	if date == nil {
+		return time.Time{}
+	}
+
+	return date.Time
+}
+
+// scopesOf splits a scope claim, which is nil when there is no claim.
+func scopesOf(scope string) []string {
+	if scope == "" {
+		return nil
+	}
+
+	return strings.Fields(scope)
+}
+
+// HasVerifiedEmail reports whether the token states that the email is
+// verified. Tokens without the claim are treated as unverified.
+func (payload *Payload) HasVerifiedEmail() bool {
+	return payload.EmailVerified != nil && *payload.EmailVerified
+}
+
+// HasPermission reports whether the token grants permission.
+func (payload *Payload) HasPermission(permission string) bool {
+	return slices.Contains(payload.Permissions, permission)
+}
+
 func (payload *Payload) Valid() error {
 	if time.Now().After(payload.ExpiredAt) {
 		return ErrExpiredToken
@@ -47,31 +245,135 @@ func (payload *Payload) Valid() error {
 }
 
 func (payload *Payload) GetExpirationTime() (*jwt.NumericDate, error) {
-	return &jwt.NumericDate{
-		Time: payload.ExpiredAt,
-	}, nil
+	return numericDate(payload.ExpiredAt), nil
 }
 
 func (payload *Payload) GetIssuedAt() (*jwt.NumericDate, error) {
-	return &jwt.NumericDate{
-		Time: payload.IssuedAt,
-	}, nil
+	return numericDate(payload.IssuedAt), nil
 }
 
 func (payload *Payload) GetNotBefore() (*jwt.NumericDate, error) {
-	return &jwt.NumericDate{
-		Time: payload.IssuedAt,
-	}, nil
+	return numericDate(payload.NotBefore), nil
 }
 
 func (payload *Payload) GetIssuer() (string, error) {
-	return "", nil
+	return payload.Issuer, nil
 }
 
 func (payload *Payload) GetSubject() (string, error) {
-	return "", nil
+	return payload.Subject, nil
 }
 
 func (payload *Payload) GetAudience() (jwt.ClaimStrings, error) {
-	return jwt.ClaimStrings{}, nil
+	return payload.Audience, nil
+}
+
+type verifyConfig struct {
+	issuer   string
+	audience string
+	purpose  string
+}
+
+// VerifyOption adds a check to VerifyToken on top of the signature and the
+// expiration time.
+type VerifyOption func(config *verifyConfig)
+
+// RequireIssuer rejects tokens not minted by issuer.
+func RequireIssuer(issuer string) VerifyOption {
+	return func(config *verifyConfig) {
+		config.issuer = issuer
+	}
+}
+
+// RequireAudience rejects tokens whose audience does not include audience.
+func RequireAudience(audience string) VerifyOption {
+	return func(config *verifyConfig) {
+		config.audience = audience
+	}
+}
+
+// RequirePurpose only accepts tokens minted for purpose. Without it, tokens
+// with any purpose are rejected.
+func RequirePurpose(purpose string) VerifyOption {
+	return func(config *verifyConfig) {
+		config.purpose = purpose
+	}
+}
+
+func newVerifyConfig(opts []VerifyOption) *verifyConfig {
+	config := &verifyConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return config
+}
+
+func (config *verifyConfig) parserOptions() []jwt.ParserOption {
+	parserOptions := []jwt.ParserOption{jwt.WithExpirationRequired()}
+	if config.issuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(config.issuer))
+	}
+
+	if config.audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(config.audience))
+	}
+
+	return parserOptions
+}
+
+// validate applies the checks done by the jwt parser to payloads decoded by
+// makers that do not go through it.
+func (config *verifyConfig) validate(payload *Payload) error {
+	now := time.Now()
+
+	if payload.ExpiredAt.IsZero() {
+		return ErrInvalidToken
+	}
+
+	if now.After(payload.ExpiredAt) {
+		return ErrExpiredToken
+	}
+
+	if now.Before(payload.NotBefore) {
+		return ErrInvalidToken
+	}
+
+	if config.issuer != "" && payload.Issuer != config.issuer {
+		return ErrInvalidToken
+	}
+
+	if config.audience != "" && !slices.Contains(payload.Audience, config.audience) {
+		return ErrInvalidToken
+	}
+
+	return config.checkPurpose(payload)
+}
+
+func (config *verifyConfig) checkPurpose(payload *Payload) error {
+	if payload.Purpose != config.purpose {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
+// Verifier is implemented by every token maker and by JwksVerifier.
+type Verifier interface {
+	VerifyToken(token string, opts ...VerifyOption) (*Payload, error)
+}
+
+type boundVerifier struct {
+	verifier Verifier
+	opts     []VerifyOption
+}
+
+// WithVerifyOptions returns a Verifier that applies opts to every call, so
+// servers can bind the issuer and audience they accept once at startup.
+func WithVerifyOptions(verifier Verifier, opts ...VerifyOption) Verifier {
+	return &boundVerifier{verifier: verifier, opts: opts}
+}
+
+func (b *boundVerifier) VerifyToken(token string, opts ...VerifyOption) (*Payload, error) {
+	return b.verifier.VerifyToken(token, append(b.opts[:len(b.opts):len(b.opts)], opts...)...)
 }
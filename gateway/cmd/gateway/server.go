@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/ed25519"
 	"errors"
 	"io/fs"
 	"net/http"
@@ -10,6 +11,8 @@ import (
 	"syscall"
 
 	"github.com/go-chi/chi"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/denylist"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/identity"
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
 	"github.com/lucasHSantiago/go-ecommerce-ms/gateway/api"
 	"github.com/lucasHSantiago/go-ecommerce-ms/gateway/internal/gateway"
@@ -66,7 +69,7 @@ func (s *Server) startMetrics() {
 }
 
 func (s *Server) startApi(ctx context.Context, waitGroup *errgroup.Group) {
-	gw, settings, err := gateway.NewGateway(context.Background())
+	gw, settings, err := gateway.NewGateway(context.Background(), s.newIdentitySigner())
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create gateway")
 	}
@@ -107,13 +110,139 @@ func (s *Server) startApi(ctx context.Context, waitGroup *errgroup.Group) {
 	})
 }
 
-func (s *Server) setupRoutes(gw http.Handler, settings *gateway.GatewaySettings) *chi.Mux {
+// newTokenVerifier verifies tokens against the auth service JWKS when JWKS_URL
+// is set, so the gateway holds no signing material. A TOKEN_KEYRING_PATH keyring
+// is needed to verify rotated HS256 secrets. Otherwise it falls back to the
+// HS256 secret shared with the auth service.
+func (s *Server) newTokenVerifier() middleware.TokenVerifier {
+	return token.WithVerifyOptions(s.newBaseTokenVerifier(), s.verifyOptions()...)
+}
+
+func (s *Server) newBaseTokenVerifier() token.Verifier {
+	if s.config.TokenFormat == "paseto" {
+		return s.newPasetoVerifier()
+	}
+
+	if s.config.JwksUrl != "" {
+		return token.NewJwksVerifier(s.config.JwksUrl, s.config.JwksCacheTTL)
+	}
+
+	if s.config.TokenKeyringPath != "" {
+		keyring, err := token.LoadVerificationKeyring(s.config.TokenKeyringPath)
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot load token keyring")
+		}
+
+		return token.NewKeyringJwtToken(keyring)
+	}
+
 	jwtToken, err := token.NewJwtToken(s.config.TokenSecret)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot initiate jwt token")
 	}
 
-	middleware := middleware.NewMiddleware(s.config, settings, jwtToken)
+	return jwtToken
+}
+
+// newPasetoVerifier verifies v4.public tokens with the Ed25519 key at
+// TOKEN_PUBLIC_KEY_PATH when set, otherwise v4.local tokens with TOKEN_SECRET_KEY.
+func (s *Server) newPasetoVerifier() token.Verifier {
+	if s.config.TokenPublicKey == "" {
+		pasetoMaker, err := token.NewPasetoLocalMaker(s.config.TokenSecret)
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot initiate paseto token")
+		}
+
+		return pasetoMaker
+	}
+
+	pemBytes, err := os.ReadFile(s.config.TokenPublicKey)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot read token public key")
+	}
+
+	publicKey, err := token.ParsePublicKeyPEM(pemBytes)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot parse token public key")
+	}
+
+	ed25519Key, ok := publicKey.(ed25519.PublicKey)
+	if !ok {
+		log.Fatal().Msg("PASETO public tokens need an Ed25519 public key")
+	}
+
+	pasetoVerifier, err := token.NewPasetoPublicVerifier(ed25519Key)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot initiate paseto token")
+	}
+
+	return pasetoVerifier
+}
+
+// verifyOptions rejects tokens that were not minted by TOKEN_ISSUER for TOKEN_AUDIENCE.
+func (s *Server) verifyOptions() []token.VerifyOption {
+	var opts []token.VerifyOption
+	if s.config.TokenIssuer != "" {
+		opts = append(opts, token.RequireIssuer(s.config.TokenIssuer))
+	}
+
+	if s.config.TokenAudience != "" {
+		opts = append(opts, token.RequireAudience(s.config.TokenAudience))
+	}
+
+	return opts
+}
+
+// newDenylist reads the tokens revoked by the auth service from the Redis at
+// REDIS_ADDRESS, which must be the one the auth service writes to.
+func (s *Server) newDenylist() middleware.Denylist {
+	if s.config.RedisAddress == "" {
+		log.Warn().Msg("REDIS_ADDRESS is not set, revoked tokens are accepted until they expire")
+	}
+
+	return denylist.New(s.config.RedisAddress)
+}
+
+// newApiKeyVerifier verifies X-API-Key headers with the auth gRPC server at
+// AUTH_GRPC_ADDRESS and caches the keys that verified for API_KEY_CACHE_TTL.
+// Without it API keys are rejected.
+func (s *Server) newApiKeyVerifier() middleware.ApiKeyVerifier {
+	if s.config.AuthGrpcAddress == "" {
+		log.Warn().Msg("AUTH_GRPC_ADDRESS is not set, api keys are rejected")
+		return nil
+	}
+
+	apiKeyVerifier, err := gateway.NewAuthApiKeyVerifier(s.config.AuthGrpcAddress)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot initiate api key verifier")
+	}
+
+	if s.config.ApiKeyCacheTTL <= 0 {
+		return apiKeyVerifier
+	}
+
+	return gateway.NewCachedApiKeyVerifier(apiKeyVerifier, s.config.ApiKeyCacheTTL)
+}
+
+// newIdentitySigner signs the identity forwarded to the backend services with
+// IDENTITY_SIGNING_KEY, which the services verify it with. Without it no
+// identity is forwarded.
+func (s *Server) newIdentitySigner() *identity.Signer {
+	if s.config.IdentityKey == "" {
+		log.Warn().Msg("IDENTITY_SIGNING_KEY is not set, caller identities are not forwarded")
+		return nil
+	}
+
+	signer, err := identity.NewSigner(s.config.IdentityKey, identity.DefaultTTL)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot initiate identity signer")
+	}
+
+	return signer
+}
+
+func (s *Server) setupRoutes(gw http.Handler, settings *gateway.GatewaySettings) *chi.Mux {
+	middleware := middleware.NewMiddleware(s.config, settings, s.newTokenVerifier(), s.newDenylist(), s.newApiKeyVerifier())
 
 	routes := chi.NewRouter()
 
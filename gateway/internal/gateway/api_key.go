@@ -0,0 +1,129 @@
+package gateway
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/lucasHSantiago/go-ecommerce-ms/gateway/proto/auth/gen"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+var ErrInvalidApiKey = errors.New("invalid api key")
+
+// ApiKeyIdentity is the service account behind an API key. Payload is
+// forwarded to the backend services like the payload of an access token.
+type ApiKeyIdentity struct {
+	Payload *token.Payload
+	Scopes  []string
+}
+
+// AuthApiKeyVerifier verifies API keys with the auth service, which keeps
+// their hashes and records when they are used.
+type AuthApiKeyVerifier struct {
+	client gen.AuthServiceClient
+}
+
+func NewAuthApiKeyVerifier(authGrpcAddress string) (*AuthApiKeyVerifier, error) {
+	conn, err := grpc.NewClient(authGrpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to auth service: %w", err)
+	}
+
+	return &AuthApiKeyVerifier{client: gen.NewAuthServiceClient(conn)}, nil
+}
+
+// VerifyApiKey returns ErrInvalidApiKey for unknown, revoked and expired keys.
+func (v *AuthApiKeyVerifier) VerifyApiKey(ctx context.Context, key string) (*ApiKeyIdentity, error) {
+	res, err := v.client.VerifyApiKey(ctx, &gen.VerifyApiKeyRequest{ApiKey: key})
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			return nil, ErrInvalidApiKey
+		}
+		return nil, fmt.Errorf("failed to verify api key: %w", err)
+	}
+
+	keyID, err := uuid.Parse(res.GetKeyId())
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify api key: %w", err)
+	}
+
+	return &ApiKeyIdentity{
+		Payload: &token.Payload{
+			ID:       keyID,
+			Subject:  res.GetUsername(),
+			Username: res.GetUsername(),
+			Role:     res.GetRole(),
+		},
+		Scopes: res.GetScopes(),
+	}, nil
+}
+
+// apiKeyVerifier is the verifier a CachedApiKeyVerifier asks on a cache miss.
+type apiKeyVerifier interface {
+	VerifyApiKey(ctx context.Context, key string) (*ApiKeyIdentity, error)
+}
+
+type cachedApiKey struct {
+	identity  *ApiKeyIdentity
+	expiresAt time.Time
+}
+
+// CachedApiKeyVerifier keeps the identity of the keys that verified for
+// cacheTTL, so that a service account does not cost a call to the auth service
+// on every request. Invalid keys are not cached. A key revoked while it is
+// cached keeps working, and its last use is not recorded, until it expires from
+// the cache.
+type CachedApiKeyVerifier struct {
+	verifier apiKeyVerifier
+	cacheTTL time.Duration
+
+	mu   sync.Mutex
+	keys map[[sha256.Size]byte]cachedApiKey
+}
+
+func NewCachedApiKeyVerifier(verifier apiKeyVerifier, cacheTTL time.Duration) *CachedApiKeyVerifier {
+	return &CachedApiKeyVerifier{
+		verifier: verifier,
+		cacheTTL: cacheTTL,
+		keys:     make(map[[sha256.Size]byte]cachedApiKey),
+	}
+}
+
+func (v *CachedApiKeyVerifier) VerifyApiKey(ctx context.Context, key string) (*ApiKeyIdentity, error) {
+	// Only the hash of the key is kept in memory.
+	hash := sha256.Sum256([]byte(key))
+	now := time.Now()
+
+	v.mu.Lock()
+	cached, ok := v.keys[hash]
+	v.mu.Unlock()
+
+	if ok && now.Before(cached.expiresAt) {
+		return cached.identity, nil
+	}
+
+	identity, err := v.verifier.VerifyApiKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	for h, c := range v.keys {
+		if !now.Before(c.expiresAt) {
+			delete(v.keys, h)
+		}
+	}
+	v.keys[hash] = cachedApiKey{identity: identity, expiresAt: now.Add(v.cacheTTL)}
+	v.mu.Unlock()
+
+	return identity, nil
+}
@@ -0,0 +1,88 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/stretchr/testify/require"
+)
+
+type stubApiKeyVerifier struct {
+	identity *ApiKeyIdentity
+	err      error
+	calls    int
+}
+
+func (v *stubApiKeyVerifier) VerifyApiKey(ctx context.Context, key string) (*ApiKeyIdentity, error) {
+	v.calls++
+	return v.identity, v.err
+}
+
+func TestCachedApiKeyVerifier(t *testing.T) {
+	identity := &ApiKeyIdentity{
+		Payload: &token.Payload{Username: "service:billing", Role: "service"},
+		Scopes:  []string{"product:write"},
+	}
+
+	testCases := []struct {
+		name     string
+		verifier *stubApiKeyVerifier
+		cacheTTL time.Duration
+		check    func(t *testing.T, verifier *stubApiKeyVerifier, cached *CachedApiKeyVerifier)
+	}{
+		{
+			name:     "CachesValidKey",
+			verifier: &stubApiKeyVerifier{identity: identity},
+			cacheTTL: time.Minute,
+			check: func(t *testing.T, verifier *stubApiKeyVerifier, cached *CachedApiKeyVerifier) {
+				for range 3 {
+					got, err := cached.VerifyApiKey(context.Background(), "ak_key")
+					require.NoError(t, err)
+					require.Equal(t, identity, got)
+				}
+				require.Equal(t, 1, verifier.calls)
+
+				_, err := cached.VerifyApiKey(context.Background(), "ak_other")
+				require.NoError(t, err)
+				require.Equal(t, 2, verifier.calls)
+			},
+		},
+		{
+			name:     "DoesNotCacheInvalidKey",
+			verifier: &stubApiKeyVerifier{err: ErrInvalidApiKey},
+			cacheTTL: time.Minute,
+			check: func(t *testing.T, verifier *stubApiKeyVerifier, cached *CachedApiKeyVerifier) {
+				for range 2 {
+					_, err := cached.VerifyApiKey(context.Background(), "ak_key")
+					require.ErrorIs(t, err, ErrInvalidApiKey)
+				}
+				require.Equal(t, 2, verifier.calls)
+			},
+		},
+		{
+			name:     "ExpiredEntry",
+			verifier: &stubApiKeyVerifier{identity: identity},
+			cacheTTL: time.Millisecond,
+			check: func(t *testing.T, verifier *stubApiKeyVerifier, cached *CachedApiKeyVerifier) {
+				_, err := cached.VerifyApiKey(context.Background(), "ak_key")
+				require.NoError(t, err)
+
+				time.Sleep(5 * time.Millisecond)
+
+				_, err = cached.VerifyApiKey(context.Background(), "ak_key")
+				require.NoError(t, err)
+				require.Equal(t, 2, verifier.calls)
+				require.Len(t, cached.keys, 1)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cached := NewCachedApiKeyVerifier(tc.verifier, tc.cacheTTL)
+			tc.check(t, tc.verifier, cached)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/identity"
 	"github.com/lucasHSantiago/go-ecommerce-ms/gateway/proto/auth/gen"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
@@ -12,7 +13,7 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
-func NewGateway(ctx context.Context) (http.Handler, *GatewaySettings, error) {
+func NewGateway(ctx context.Context, identitySigner *identity.Signer) (http.Handler, *GatewaySettings, error) {
 	gatewaySettings, err := readSetting()
 	if err != nil {
 		return nil, nil, err
@@ -20,7 +21,7 @@ func NewGateway(ctx context.Context) (http.Handler, *GatewaySettings, error) {
 
 	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 
-	mux := gwruntime.NewServeMux(jsonOption())
+	mux := gwruntime.NewServeMux(jsonOption(), gwruntime.WithMetadata(forwardIdentity(identitySigner)))
 	for _, service := range gatewaySettings.Services {
 		log.Info().Str("name", service.Name).Msg("registering service")
 		err := gen.RegisterAuthServiceHandlerFromEndpoint(ctx, mux, service.Url, dialOpts)
@@ -0,0 +1,50 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	gwruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/identity"
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"google.golang.org/grpc/metadata"
+)
+
+type identityContextKey struct{}
+
+func ContextWithIdentity(ctx context.Context, payload *token.Payload) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, payload)
+}
+
+func IdentityFromContext(ctx context.Context) (*token.Payload, bool) {
+	payload, ok := ctx.Value(identityContextKey{}).(*token.Payload)
+	return payload, ok && payload != nil
+}
+
+// StripIdentityHeaders removes identity metadata sent by the client, so only the
+// identity verified by the gateway is forwarded to the backend services.
+func StripIdentityHeaders(header http.Header) {
+	for key := range header {
+		lowerKey := strings.ToLower(key)
+		for _, md := range identity.Metadata {
+			if lowerKey == md || lowerKey == strings.ToLower(gwruntime.MetadataHeaderPrefix)+md {
+				header.Del(key)
+			}
+		}
+	}
+}
+
+// forwardIdentity signs the verified caller for the backend services, which
+// reject forwarded identities they can not verify. Without a signer nothing is
+// forwarded.
+func forwardIdentity(signer *identity.Signer) func(context.Context, *http.Request) metadata.MD {
+	return func(_ context.Context, r *http.Request) metadata.MD {
+		payload, ok := IdentityFromContext(r.Context())
+		if !ok || signer == nil {
+			return nil
+		}
+
+		return signer.Sign(payload)
+	}
+}
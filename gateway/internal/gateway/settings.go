@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"regexp"
 	"slices"
 	"strings"
 )
@@ -15,9 +16,11 @@ type GatewaySettings struct {
 		Name string `json:"name"`
 		Url  string `json:"url"`
 		Acl  []struct {
-			Http  string   `json:"http"`
-			Route string   `json:"route"`
-			Roles []string `json:"roles"`
+			Http          string   `json:"http"`
+			Route         string   `json:"route"`
+			Roles         []string `json:"roles"`
+			VerifiedEmail bool     `json:"verified_email"`
+			Permissions   []string `json:"permissions"`
 		} `json:"acl"`
 	} `json:"services"`
 }
@@ -25,7 +28,7 @@ type GatewaySettings struct {
 func (gs *GatewaySettings) NeedAuth(r *http.Request) bool {
 	for _, service := range gs.Services {
 		for _, authRoute := range service.Acl {
-			if authRoute.Route != "" && strings.Contains(strings.ToLower(r.URL.Path), strings.ToLower(authRoute.Route)) && r.Method == strings.ToUpper(authRoute.Http) {
+			if matchRoute(r, authRoute.Http, authRoute.Route) {
 				return true
 			}
 		}
@@ -33,24 +36,91 @@ func (gs *GatewaySettings) NeedAuth(r *http.Request) bool {
 	return false
 }
 
-func (gs *GatewaySettings) HasPermission(userRole string) bool {
+// RequiresVerifiedEmail tells whether the request hits a route that only users
+// with a verified email can access. Access tokens only report the email as
+// verified when the auth service runs with UNVERIFIED_EMAIL_POLICY claim or deny,
+// so under allow these routes reject every user. Service accounts calling with
+// an API key are not users and are exempt.
+func (gs *GatewaySettings) RequiresVerifiedEmail(r *http.Request) bool {
 	for _, service := range gs.Services {
-		if service.Acl == nil || len(service.Acl) == 0 {
-			return true
+		for _, authRoute := range service.Acl {
+			if authRoute.VerifiedEmail && matchRoute(r, authRoute.Http, authRoute.Route) {
+				return true
+			}
 		}
+	}
+	return false
+}
 
-		for _, acl := range service.Acl {
-			if acl.Roles == nil || len(acl.Roles) == 0 {
+// AcceptsApiKeys tells whether service accounts may call the route of the
+// request. API keys are only accepted on routes whose ACL lists permissions.
+func (gs *GatewaySettings) AcceptsApiKeys(r *http.Request) bool {
+	for _, service := range gs.Services {
+		for _, authRoute := range service.Acl {
+			if len(authRoute.Permissions) > 0 && matchRoute(r, authRoute.Http, authRoute.Route) {
 				return true
 			}
+		}
+	}
+	return false
+}
+
+// HasPermissions tells whether the given permissions cover every permission
+// the ACL requires for the route of the request. Users get theirs from the
+// access token, service accounts from the scopes of their API key.
+func (gs *GatewaySettings) HasPermissions(r *http.Request, permissions []string) bool {
+	for _, service := range gs.Services {
+		for _, authRoute := range service.Acl {
+			if !matchRoute(r, authRoute.Http, authRoute.Route) {
+				continue
+			}
 
-			if slices.Contains(acl.Roles, userRole) {
-				return true
+			for _, permission := range authRoute.Permissions {
+				if !slices.Contains(permissions, permission) {
+					return false
+				}
 			}
 		}
 	}
+	return true
+}
 
-	return false
+var apiVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// matchRoute tells whether the request path, without its API version, starts
+// with the segments of the route, so that "user" matches /v1/user and
+// /v1/user/export but not /v1/admin/users.
+func matchRoute(r *http.Request, method, route string) bool {
+	if route == "" || r.Method != strings.ToUpper(method) {
+		return false
+	}
+
+	pathSegments := strings.Split(strings.Trim(strings.ToLower(r.URL.Path), "/"), "/")
+	if apiVersion.MatchString(pathSegments[0]) {
+		pathSegments = pathSegments[1:]
+	}
+
+	routeSegments := strings.Split(strings.Trim(strings.ToLower(route), "/"), "/")
+
+	return len(pathSegments) >= len(routeSegments) && slices.Equal(pathSegments[:len(routeSegments)], routeSegments)
+}
+
+// HasRole tells whether the role may call the route of the request. Every ACL
+// entry of the route with roles must list it.
+func (gs *GatewaySettings) HasRole(r *http.Request, role string) bool {
+	for _, service := range gs.Services {
+		for _, authRoute := range service.Acl {
+			if len(authRoute.Roles) == 0 || !matchRoute(r, authRoute.Http, authRoute.Route) {
+				continue
+			}
+
+			if !slices.Contains(authRoute.Roles, role) {
+				return false
+			}
+		}
+	}
+
+	return true
 }
 
 func readSetting() (*GatewaySettings, error) {
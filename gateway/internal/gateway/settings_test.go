@@ -0,0 +1,136 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testSettings = `{
+	"services": [
+		{
+			"name": "Auth",
+			"acl": [
+				{"http": "DELETE", "route": "user", "roles": []},
+				{"http": "GET", "route": "user/export", "roles": [], "verified_email": true},
+				{"http": "DELETE", "route": "admin/users", "roles": ["admin"]}
+			]
+		},
+		{
+			"name": "Product",
+			"acl": [
+				{"http": "POST", "route": "products", "roles": ["admin", "service"], "permissions": ["product:write"]}
+			]
+		}
+	]
+}`
+
+func newTestSettings(t *testing.T) *GatewaySettings {
+	settings := &GatewaySettings{}
+	require.NoError(t, json.Unmarshal([]byte(testSettings), settings))
+	return settings
+}
+
+func TestMatchRoute(t *testing.T) {
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		http   string
+		route  string
+		match  bool
+	}{
+		{name: "Exact", method: http.MethodDelete, path: "/v1/user", http: "DELETE", route: "user", match: true},
+		{name: "SubPath", method: http.MethodGet, path: "/v1/user/export", http: "GET", route: "user", match: true},
+		{name: "NestedRoute", method: http.MethodGet, path: "/v1/user/export", http: "GET", route: "user/export", match: true},
+		{name: "CaseInsensitive", method: http.MethodGet, path: "/v1/User/Export", http: "get", route: "user/export", match: true},
+		{name: "WithoutVersion", method: http.MethodGet, path: "/user/export", http: "GET", route: "user/export", match: true},
+		{name: "SegmentPrefix", method: http.MethodDelete, path: "/v1/admin/users/bob", http: "DELETE", route: "user", match: false},
+		{name: "NotAtStart", method: http.MethodDelete, path: "/v1/admin/user", http: "DELETE", route: "user", match: false},
+		{name: "RouteLongerThanPath", method: http.MethodGet, path: "/v1/user", http: "GET", route: "user/export", match: false},
+		{name: "OtherMethod", method: http.MethodPost, path: "/v1/user", http: "DELETE", route: "user", match: false},
+		{name: "EmptyRoute", method: http.MethodGet, path: "/v1/user", http: "GET", route: "", match: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, tc.path, nil)
+			require.Equal(t, tc.match, matchRoute(r, tc.http, tc.route))
+		})
+	}
+}
+
+func TestGatewaySettingsAcl(t *testing.T) {
+	settings := newTestSettings(t)
+
+	testCases := []struct {
+		name          string
+		method        string
+		path          string
+		needAuth      bool
+		verifiedEmail bool
+		apiKeys       bool
+		roles         map[string]bool
+		permissions   map[string]bool
+	}{
+		{
+			name:     "Public",
+			method:   http.MethodPost,
+			path:     "/v1/user/login",
+			needAuth: false,
+			roles:    map[string]bool{"user": true, "admin": true},
+		},
+		{
+			name:     "AnyRole",
+			method:   http.MethodDelete,
+			path:     "/v1/user",
+			needAuth: true,
+			roles:    map[string]bool{"user": true, "admin": true},
+		},
+		{
+			name:          "VerifiedEmail",
+			method:        http.MethodGet,
+			path:          "/v1/user/export",
+			needAuth:      true,
+			verifiedEmail: true,
+			roles:         map[string]bool{"user": true},
+		},
+		{
+			name:     "AdminOnly",
+			method:   http.MethodDelete,
+			path:     "/v1/admin/users/bob",
+			needAuth: true,
+			roles:    map[string]bool{"user": false, "admin": true},
+		},
+		{
+			name:        "Permissions",
+			method:      http.MethodPost,
+			path:        "/v1/products",
+			needAuth:    true,
+			apiKeys:     true,
+			roles:       map[string]bool{"user": false, "admin": true, "service": true},
+			permissions: map[string]bool{"": false, "product:write": true, "product:read": false},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, tc.path, nil)
+
+			require.Equal(t, tc.needAuth, settings.NeedAuth(r))
+			require.Equal(t, tc.verifiedEmail, settings.RequiresVerifiedEmail(r))
+			require.Equal(t, tc.apiKeys, settings.AcceptsApiKeys(r))
+
+			for role, allowed := range tc.roles {
+				require.Equal(t, allowed, settings.HasRole(r, role), role)
+			}
+
+			for permission, allowed := range tc.permissions {
+				require.Equal(t, allowed, settings.HasPermissions(r, []string{permission}), permission)
+			}
+		})
+	}
+}
@@ -1,28 +1,44 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
+	"github.com/lucasHSantiago/go-ecommerce-ms/gateway/internal/gateway"
 	"github.com/lucasHSantiago/go-ecommerce-ms/gateway/internal/util"
 )
 
 const (
 	authorizationHeader = "authorization"
 	authorizationBearer = "bearer"
+	apiKeyHeader        = "X-API-Key"
 )
 
 func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gateway.StripIdentityHeaders(r.Header)
+
 		if !m.gatewaySettings.NeedAuth(r) {
 			next.ServeHTTP(w, r)
 			return
 		}
 
 		w.Header().Add("Vary", "Authorization")
+		w.Header().Add("Vary", apiKeyHeader)
 
 		authorozationHeader := r.Header.Get("Authorization")
 
+		if apiKey := r.Header.Get(apiKeyHeader); apiKey != "" {
+			if authorozationHeader != "" {
+				util.InvalidAuthenticationTokenResponse(w, r)
+				return
+			}
+
+			m.authenticateApiKey(w, r, next, apiKey)
+			return
+		}
+
 		if authorozationHeader == "" {
 			util.InvalidAuthenticationTokenResponse(w, r)
 			return
@@ -41,11 +57,66 @@ func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 			return
 		}
 
-		if !m.gatewaySettings.HasPermission(payload.Role) {
+		revoked, err := m.denylist.IsRevoked(r.Context(), payload)
+		if err != nil {
+			util.ServerErrorResponse(w, r, err)
+			return
+		}
+
+		if revoked {
+			util.InvalidAuthenticationTokenResponse(w, r)
+			return
+		}
+
+		if !m.gatewaySettings.HasRole(r, payload.Role) {
 			util.UnauthorizedResponse(w, r)
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		if !m.gatewaySettings.HasPermissions(r, payload.Permissions) {
+			util.InsufficientPermissionResponse(w, r)
+			return
+		}
+
+		if m.gatewaySettings.RequiresVerifiedEmail(r) && !payload.HasVerifiedEmail() {
+			util.UnverifiedEmailResponse(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(gateway.ContextWithIdentity(r.Context(), payload)))
 	})
 }
+
+// authenticateApiKey lets service accounts call the routes whose ACL lists
+// permissions, allows their role and only requires permissions among the
+// scopes of the key. Service accounts have no email, so verified_email does not
+// apply to them.
+func (m *Middleware) authenticateApiKey(w http.ResponseWriter, r *http.Request, next http.Handler, apiKey string) {
+	if m.apiKeyVerifier == nil {
+		util.InvalidApiKeyResponse(w, r)
+		return
+	}
+
+	identity, err := m.apiKeyVerifier.VerifyApiKey(r.Context(), apiKey)
+	if err != nil {
+		if errors.Is(err, gateway.ErrInvalidApiKey) {
+			util.InvalidApiKeyResponse(w, r)
+			return
+		}
+
+		util.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if !m.gatewaySettings.HasRole(r, identity.Payload.Role) {
+		util.UnauthorizedResponse(w, r)
+		return
+	}
+
+	if !m.gatewaySettings.AcceptsApiKeys(r) || !m.gatewaySettings.HasPermissions(r, identity.Scopes) {
+		util.InsufficientPermissionResponse(w, r)
+		return
+	}
+
+	next.ServeHTTP(w, r.WithContext(gateway.ContextWithIdentity(r.Context(), identity.Payload)))
+}
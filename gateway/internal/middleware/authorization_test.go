@@ -0,0 +1,402 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
+	"github.com/lucasHSantiago/go-ecommerce-ms/gateway/internal/gateway"
+	"github.com/lucasHSantiago/go-ecommerce-ms/gateway/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+const testSettings = `{
+	"services": [
+		{
+			"name": "Auth",
+			"acl": [
+				{"http": "DELETE", "route": "user", "roles": []},
+				{"http": "GET", "route": "user/export", "roles": [], "verified_email": true},
+				{"http": "DELETE", "route": "admin/users", "roles": ["admin"]}
+			]
+		},
+		{
+			"name": "Product",
+			"acl": [
+				{"http": "POST", "route": "products", "roles": ["admin", "service"], "permissions": ["product:write"]}
+			]
+		}
+	]
+}`
+
+const (
+	testToken  = "valid-token"
+	testApiKey = "ak_valid"
+)
+
+type stubTokenVerifier struct {
+	payload *token.Payload
+}
+
+func (v stubTokenVerifier) VerifyToken(tokenString string, opts ...token.VerifyOption) (*token.Payload, error) {
+	if tokenString != testToken {
+		return nil, token.ErrInvalidToken
+	}
+	return v.payload, nil
+}
+
+type stubDenylist struct {
+	revoked bool
+	err     error
+}
+
+func (d stubDenylist) IsRevoked(ctx context.Context, payload *token.Payload) (bool, error) {
+	return d.revoked, d.err
+}
+
+type stubApiKeyVerifier struct {
+	identity *gateway.ApiKeyIdentity
+	err      error
+}
+
+func (v stubApiKeyVerifier) VerifyApiKey(ctx context.Context, key string) (*gateway.ApiKeyIdentity, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	if key != testApiKey {
+		return nil, gateway.ErrInvalidApiKey
+	}
+	return v.identity, nil
+}
+
+func newTestPayload(role string, opts ...token.PayloadOption) *token.Payload {
+	payload := &token.Payload{Username: "alice", Role: role}
+	for _, opt := range opts {
+		opt(payload)
+	}
+	return payload
+}
+
+func newTestApiKeyIdentity(role string, scopes ...string) *gateway.ApiKeyIdentity {
+	return &gateway.ApiKeyIdentity{
+		Payload: &token.Payload{Username: "service:billing", Role: role},
+		Scopes:  scopes,
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		header         http.Header
+		tokenVerifier  TokenVerifier
+		denylist       Denylist
+		apiKeyVerifier ApiKeyVerifier
+		checkResponse  func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool)
+	}{
+		{
+			name:   "PublicRoute",
+			method: http.MethodPost,
+			path:   "/v1/user/login",
+			header: http.Header{"X-Auth-Username": {"admin"}, "Grpc-Metadata-X-Auth-Role": {"admin"}},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.True(t, called)
+				require.Nil(t, identity)
+			},
+		},
+		{
+			name:          "ForwardsIdentity",
+			method:        http.MethodDelete,
+			path:          "/v1/user",
+			header:        http.Header{"Authorization": {"Bearer " + testToken}, "X-Auth-Username": {"admin"}},
+			tokenVerifier: stubTokenVerifier{payload: newTestPayload("user")},
+			denylist:      stubDenylist{},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.True(t, called)
+				require.NotNil(t, identity)
+				require.Equal(t, "alice", identity.Username)
+			},
+		},
+		{
+			name:   "NoAuthorization",
+			method: http.MethodDelete,
+			path:   "/v1/user",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.False(t, called)
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:          "InvalidToken",
+			method:        http.MethodDelete,
+			path:          "/v1/user",
+			header:        http.Header{"Authorization": {"Bearer other-token"}},
+			tokenVerifier: stubTokenVerifier{payload: newTestPayload("user")},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.False(t, called)
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:          "RevokedToken",
+			method:        http.MethodDelete,
+			path:          "/v1/user",
+			header:        http.Header{"Authorization": {"Bearer " + testToken}},
+			tokenVerifier: stubTokenVerifier{payload: newTestPayload("user")},
+			denylist:      stubDenylist{revoked: true},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.False(t, called)
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:          "DenylistError",
+			method:        http.MethodDelete,
+			path:          "/v1/user",
+			header:        http.Header{"Authorization": {"Bearer " + testToken}},
+			tokenVerifier: stubTokenVerifier{payload: newTestPayload("user")},
+			denylist:      stubDenylist{err: errors.New("redis down")},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.False(t, called)
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:          "WrongRole",
+			method:        http.MethodDelete,
+			path:          "/v1/admin/users/bob",
+			header:        http.Header{"Authorization": {"Bearer " + testToken}},
+			tokenVerifier: stubTokenVerifier{payload: newTestPayload("user")},
+			denylist:      stubDenylist{},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.False(t, called)
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:          "AdminRole",
+			method:        http.MethodDelete,
+			path:          "/v1/admin/users/bob",
+			header:        http.Header{"Authorization": {"Bearer " + testToken}},
+			tokenVerifier: stubTokenVerifier{payload: newTestPayload("admin")},
+			denylist:      stubDenylist{},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.True(t, called)
+				require.Equal(t, "admin", identity.Role)
+			},
+		},
+		{
+			name:          "MissingPermission",
+			method:        http.MethodPost,
+			path:          "/v1/products",
+			header:        http.Header{"Authorization": {"Bearer " + testToken}},
+			tokenVerifier: stubTokenVerifier{payload: newTestPayload("admin", token.WithPermissions("product:read"))},
+			denylist:      stubDenylist{},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.False(t, called)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:          "Permission",
+			method:        http.MethodPost,
+			path:          "/v1/products",
+			header:        http.Header{"Authorization": {"Bearer " + testToken}},
+			tokenVerifier: stubTokenVerifier{payload: newTestPayload("admin", token.WithPermissions("product:write"))},
+			denylist:      stubDenylist{},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.True(t, called)
+			},
+		},
+		{
+			name:          "UnverifiedEmail",
+			method:        http.MethodGet,
+			path:          "/v1/user/export",
+			header:        http.Header{"Authorization": {"Bearer " + testToken}},
+			tokenVerifier: stubTokenVerifier{payload: newTestPayload("user", token.WithEmailVerified(false))},
+			denylist:      stubDenylist{},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.False(t, called)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:          "VerifiedEmail",
+			method:        http.MethodGet,
+			path:          "/v1/user/export",
+			header:        http.Header{"Authorization": {"Bearer " + testToken}},
+			tokenVerifier: stubTokenVerifier{payload: newTestPayload("user", token.WithEmailVerified(true))},
+			denylist:      stubDenylist{},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.True(t, called)
+			},
+		},
+		{
+			name:           "ApiKey",
+			method:         http.MethodPost,
+			path:           "/v1/products",
+			header:         http.Header{"X-Api-Key": {testApiKey}},
+			apiKeyVerifier: stubApiKeyVerifier{identity: newTestApiKeyIdentity("service", "product:write")},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.True(t, called)
+				require.Equal(t, "service:billing", identity.Username)
+			},
+		},
+		{
+			name:           "ApiKeyAndAuthorization",
+			method:         http.MethodPost,
+			path:           "/v1/products",
+			header:         http.Header{"X-Api-Key": {testApiKey}, "Authorization": {"Bearer " + testToken}},
+			apiKeyVerifier: stubApiKeyVerifier{identity: newTestApiKeyIdentity("service", "product:write")},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.False(t, called)
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:   "ApiKeyWithoutVerifier",
+			method: http.MethodPost,
+			path:   "/v1/products",
+			header: http.Header{"X-Api-Key": {testApiKey}},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.False(t, called)
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:           "InvalidApiKey",
+			method:         http.MethodPost,
+			path:           "/v1/products",
+			header:         http.Header{"X-Api-Key": {"ak_other"}},
+			apiKeyVerifier: stubApiKeyVerifier{identity: newTestApiKeyIdentity("service", "product:write")},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.False(t, called)
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:           "ApiKeyVerifierError",
+			method:         http.MethodPost,
+			path:           "/v1/products",
+			header:         http.Header{"X-Api-Key": {testApiKey}},
+			apiKeyVerifier: stubApiKeyVerifier{err: errors.New("auth service down")},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.False(t, called)
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:           "ApiKeyMissingScope",
+			method:         http.MethodPost,
+			path:           "/v1/products",
+			header:         http.Header{"X-Api-Key": {testApiKey}},
+			apiKeyVerifier: stubApiKeyVerifier{identity: newTestApiKeyIdentity("service", "product:read")},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.False(t, called)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:           "ApiKeyWrongRole",
+			method:         http.MethodPost,
+			path:           "/v1/products",
+			header:         http.Header{"X-Api-Key": {testApiKey}},
+			apiKeyVerifier: stubApiKeyVerifier{identity: newTestApiKeyIdentity("reporting", "product:write")},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.False(t, called)
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:           "ApiKeyRouteWithoutPermissions",
+			method:         http.MethodDelete,
+			path:           "/v1/user",
+			header:         http.Header{"X-Api-Key": {testApiKey}},
+			apiKeyVerifier: stubApiKeyVerifier{identity: newTestApiKeyIdentity("service", "product:write")},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, identity *token.Payload, called bool) {
+				require.False(t, called)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			settings := &gateway.GatewaySettings{}
+			require.NoError(t, json.Unmarshal([]byte(testSettings), settings))
+
+			middleware := NewMiddleware(util.Config{}, settings, tc.tokenVerifier, tc.denylist, tc.apiKeyVerifier)
+
+			var identity *token.Payload
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				identity, _ = gateway.IdentityFromContext(r.Context())
+
+				for key := range r.Header {
+					require.NotContains(t, key, "X-Auth-")
+				}
+			})
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tc.method, tc.path, nil)
+			for key, values := range tc.header {
+				for _, value := range values {
+					request.Header.Add(key, value)
+				}
+			}
+
+			middleware.Authenticate(next).ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder, identity, called)
+		})
+	}
+}
+
+func TestAuthenticateRefreshToken(t *testing.T) {
+	tokenMaker, err := token.NewJwtToken("01234567890123456789012345678901")
+	require.NoError(t, err)
+
+	accessToken, _, err := tokenMaker.CreateToken("alice", "user", time.Minute)
+	require.NoError(t, err)
+
+	refreshToken, _, err := tokenMaker.CreateToken("alice", "user", time.Minute, token.WithPurpose(token.PurposeRefresh))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		token      string
+		wantCalled bool
+		wantCode   int
+	}{
+		{name: "AccessToken", token: accessToken, wantCalled: true, wantCode: http.StatusOK},
+		{name: "RefreshToken", token: refreshToken, wantCalled: false, wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			settings := &gateway.GatewaySettings{}
+			require.NoError(t, json.Unmarshal([]byte(testSettings), settings))
+
+			middleware := NewMiddleware(util.Config{}, settings, tokenMaker, stubDenylist{}, nil)
+
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			})
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodDelete, "/v1/user", nil)
+			request.Header.Set("Authorization", "Bearer "+tc.token)
+
+			middleware.Authenticate(next).ServeHTTP(recorder, request)
+			require.Equal(t, tc.wantCalled, called)
+			require.Equal(t, tc.wantCode, recorder.Code)
+		})
+	}
+}
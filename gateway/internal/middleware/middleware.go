@@ -1,21 +1,37 @@
 package middleware
 
 import (
+	"context"
+
 	"github.com/lucasHSantiago/go-ecommerce-ms/auth/pkg/token"
 	"github.com/lucasHSantiago/go-ecommerce-ms/gateway/internal/gateway"
 	"github.com/lucasHSantiago/go-ecommerce-ms/gateway/internal/util"
 )
 
 type TokenVerifier interface {
-	VerifyToken(token string) (*token.Payload, error)
+	VerifyToken(token string, opts ...token.VerifyOption) (*token.Payload, error)
+}
+
+// Denylist tells whether a token that verified was revoked by the auth service
+// before it expired.
+type Denylist interface {
+	IsRevoked(ctx context.Context, payload *token.Payload) (bool, error)
+}
+
+// ApiKeyVerifier resolves the key of the X-API-Key header to the service
+// account it belongs to.
+type ApiKeyVerifier interface {
+	VerifyApiKey(ctx context.Context, key string) (*gateway.ApiKeyIdentity, error)
 }
 
 type Middleware struct {
 	config          util.Config
 	gatewaySettings *gateway.GatewaySettings
 	tokenVerifier   TokenVerifier
+	denylist        Denylist
+	apiKeyVerifier  ApiKeyVerifier
 }
 
-func NewMiddleware(config util.Config, gatewaySettings *gateway.GatewaySettings, tokenVerifier TokenVerifier) *Middleware {
-	return &Middleware{config, gatewaySettings, tokenVerifier}
+func NewMiddleware(config util.Config, gatewaySettings *gateway.GatewaySettings, tokenVerifier TokenVerifier, denylist Denylist, apiKeyVerifier ApiKeyVerifier) *Middleware {
+	return &Middleware{config, gatewaySettings, tokenVerifier, denylist, apiKeyVerifier}
 }
@@ -1,16 +1,29 @@
 package util
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Environment     string `mapstructure:"ENVIRONMENT"`
-	ServerAddress   string `mapstructure:"SERVER_ADDRESS"`
-	RateLimitEnable bool   `mapstructure:"RATE_LIMIT_ENABLE"`
-	RateLimitRps    int    `mapstructure:"RATE_LIMIT_RPS"`
-	RateLimitBurst  int    `mapstructure:"RATE_LIMIT_BURST"`
-	TokenSecret     string `mapstructure:"TOKEN_SECRET_KEY"`
+	Environment      string        `mapstructure:"ENVIRONMENT"`
+	ServerAddress    string        `mapstructure:"SERVER_ADDRESS"`
+	RateLimitEnable  bool          `mapstructure:"RATE_LIMIT_ENABLE"`
+	RateLimitRps     int           `mapstructure:"RATE_LIMIT_RPS"`
+	RateLimitBurst   int           `mapstructure:"RATE_LIMIT_BURST"`
+	TokenFormat      string        `mapstructure:"TOKEN_FORMAT"`
+	TokenSecret      string        `mapstructure:"TOKEN_SECRET_KEY"`
+	TokenPublicKey   string        `mapstructure:"TOKEN_PUBLIC_KEY_PATH"`
+	TokenKeyringPath string        `mapstructure:"TOKEN_KEYRING_PATH"`
+	TokenIssuer      string        `mapstructure:"TOKEN_ISSUER"`
+	TokenAudience    string        `mapstructure:"TOKEN_AUDIENCE"`
+	JwksUrl          string        `mapstructure:"JWKS_URL"`
+	JwksCacheTTL     time.Duration `mapstructure:"JWKS_CACHE_TTL"`
+	RedisAddress     string        `mapstructure:"REDIS_ADDRESS"`
+	AuthGrpcAddress  string        `mapstructure:"AUTH_GRPC_ADDRESS"`
+	ApiKeyCacheTTL   time.Duration `mapstructure:"API_KEY_CACHE_TTL"`
+	IdentityKey      string        `mapstructure:"IDENTITY_SIGNING_KEY"`
 }
 
 func LoadConfig(path string) (Config, error) {
@@ -58,9 +58,24 @@ func InvalidAuthenticationTokenResponse(w http.ResponseWriter, r *http.Request)
 	ErrorResponse(w, r, http.StatusUnauthorized, message)
 }
 
+func InvalidApiKeyResponse(w http.ResponseWriter, r *http.Request) {
+	message := "invalid, revoked or expired api key"
+	ErrorResponse(w, r, http.StatusUnauthorized, message)
+}
+
+func InsufficientPermissionResponse(w http.ResponseWriter, r *http.Request) {
+	message := "you do not have the permissions required to access this resource"
+	ErrorResponse(w, r, http.StatusForbidden, message)
+}
+
 func UnauthorizedResponse(w http.ResponseWriter, r *http.Request) {
 	log.Error().Msg("rate limit exceeded")
 
 	message := "unauthorized request"
 	ErrorResponse(w, r, http.StatusUnauthorized, message)
 }
+
+func UnverifiedEmailResponse(w http.ResponseWriter, r *http.Request) {
+	message := "your email address must be verified to access this resource"
+	ErrorResponse(w, r, http.StatusForbidden, message)
+}